@@ -1,35 +1,50 @@
 package cmd
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/spf13/cobra"
 
 	init_pkg "github.com/davidlee/vice/internal/init"
+	"github.com/davidlee/vice/internal/repository"
 	"github.com/davidlee/vice/internal/ui"
 )
 
-// menuFlag indicates whether to launch the interactive menu interface
-var menuFlag bool
+var (
+	// menuFlag indicates whether to launch the interactive menu interface
+	menuFlag bool
+
+	// entryDate is the day to record (YYYY-MM-DD, "today" or "yesterday")
+	entryDate string
+)
 
 // entryCmd represents the entry command
 // AIDEV-NOTE: T018/5.3-help-update; comprehensive help text reflects full feature set (simple/elastic/informational/checklist habits)
 var entryCmd = &cobra.Command{
 	Use:   "entry",
-	Short: "Record today's habit completion",
+	Short: "Record habit completion for today or a past day",
 	Long: `Record today's habit data through interactive collection forms. Supports all habit types:
 simple boolean tracking, elastic habits with achievement tiers, informational data collection,
 and checklist completion. Features automatic success evaluation based on configured criteria.
 Your entries are stored in entries.yml for progress tracking and analysis.
 
+Use --date to backfill or correct a past day. In the menu, [ and ] move to the
+previous and next day.
+
 Examples:
-  vice entry                    # Record today's habits (sequential form)
-  vice entry --menu             # Launch interactive menu interface (recommended)
-  vice --config-dir /tmp entry  # Use custom config directory`,
+  vice entry                        # Record today's habits (sequential form)
+  vice entry --menu                 # Launch interactive menu interface (recommended)
+  vice entry --date yesterday       # Record yesterday's habits
+  vice entry --menu --date 2025-07-14
+  vice --config-dir /tmp entry      # Use custom config directory`,
 	RunE: runEntry,
 }
 
 func init() {
 	rootCmd.AddCommand(entryCmd)
 	entryCmd.Flags().BoolVar(&menuFlag, "menu", false, "Launch interactive menu interface")
+	entryCmd.Flags().StringVar(&entryDate, "date", "today", "Day to record (YYYY-MM-DD, today, yesterday)")
 }
 
 func runEntry(_ *cobra.Command, _ []string) error {
@@ -42,11 +57,39 @@ func runEntry(_ *cobra.Command, _ []string) error {
 		return err
 	}
 
+	date, err := parseEntryDate(entryDate, time.Now())
+	if err != nil {
+		return err
+	}
+
 	if menuFlag {
-		return runEntryMenu(env)
+		return runEntryMenu(env, date)
 	}
 
 	// Create entry collector and run interactive UI
 	collector := ui.NewEntryCollector(env.GetChecklistsFile())
-	return collector.CollectTodayEntries(env.GetHabitsFile(), env.GetEntriesFile())
+	collector.SetDate(date.Format("2006-01-02"))
+	collector.SetHistory(repository.NewFileRepository(env))
+	return collector.CollectEntries(env.GetHabitsFile(), env.GetEntriesFile())
+}
+
+// parseEntryDate resolves a --date value relative to now. Future dates are rejected.
+func parseEntryDate(value string, now time.Time) (time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch value {
+	case "", "today":
+		return today, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", value, now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q (use YYYY-MM-DD, today or yesterday)", value)
+	}
+	if date.After(today) {
+		return time.Time{}, fmt.Errorf("cannot record entries for a future date: %s", value)
+	}
+	return date, nil
 }
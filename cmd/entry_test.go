@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEntryDate(t *testing.T) {
+	now := time.Date(2025, 7, 16, 21, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		value    string
+		expected string
+		err      string
+	}{
+		{value: "today", expected: "2025-07-16"},
+		{value: "", expected: "2025-07-16"},
+		{value: "yesterday", expected: "2025-07-15"},
+		{value: "2025-07-01", expected: "2025-07-01"},
+		{value: "2025-07-17", err: "future"},
+		{value: "07/01/2025", err: "invalid date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			date, err := parseEntryDate(tt.value, now)
+			if tt.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, date.Format("2006-01-02"))
+		})
+	}
+}
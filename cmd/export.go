@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/davidlee/vice/internal/export"
+	"github.com/davidlee/vice/internal/parser"
+	"github.com/davidlee/vice/internal/storage"
+)
+
+var (
+	exportFormat string   // output format (json, csv, ndjson)
+	exportFrom   string   // first date to include (YYYY-MM-DD)
+	exportTo     string   // last date to include (YYYY-MM-DD)
+	exportHabits []string // habit IDs to include
+	exportOutput string   // output file (default: stdout)
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export entries as JSON, CSV or NDJSON",
+	Long: `Export habit entries with one row per habit per day, for analysis in tools
+like pandas or DuckDB, or for moving data to another machine with 'vice import'.
+
+Each row has the date, habit (ID, title, type, field type), status, achievement
+level, typed value and notes, plus creation/update timestamps. In CSV, list values
+(checklist items) are JSON arrays and value_type records how to read the value.
+
+Examples:
+  vice export                                  # All entries as JSON
+  vice export --format csv > entries.csv
+  vice export --format ndjson --from 2025-07-01 --to 2025-07-31
+  vice export --habit meditation --habit exercise -o habits.json`,
+	Args: cobra.NoArgs,
+	RunE: runExport,
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", export.FormatJSON, "Output format (json, csv, ndjson)")
+	exportCmd.Flags().StringVar(&exportFrom, "from", "", "First date to include (YYYY-MM-DD)")
+	exportCmd.Flags().StringVar(&exportTo, "to", "", "Last date to include (YYYY-MM-DD)")
+	exportCmd.Flags().StringSliceVar(&exportHabits, "habit", nil, "Habit ID to include (repeatable)")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Write to a file instead of stdout")
+}
+
+func runExport(cmd *cobra.Command, _ []string) error {
+	env := GetViceEnv()
+
+	filter := export.Filter{From: exportFrom, To: exportTo, HabitIDs: exportHabits}
+	if err := filter.Validate(); err != nil {
+		return err
+	}
+
+	schema, err := parser.NewHabitParser().LoadFromFile(env.GetHabitsFile())
+	if err != nil {
+		return fmt.Errorf("failed to load habits: %w", err)
+	}
+
+	entryLog, err := storage.NewEntryStorage().LoadFromFile(env.GetEntriesFile())
+	if err != nil {
+		return fmt.Errorf("failed to load entries: %w", err)
+	}
+
+	rows := export.Flatten(entryLog, schema.Habits, filter)
+
+	if exportOutput == "" {
+		return export.Write(cmd.OutOrStdout(), rows, exportFormat)
+	}
+
+	// #nosec G304 -- output path is provided by the user
+	file, err := os.Create(exportOutput)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", exportOutput, err)
+	}
+	if err := export.Write(file, rows, exportFormat); err != nil {
+		_ = file.Close() //nolint:errcheck // Error already being returned
+		return err
+	}
+	// A failed close can mean a truncated export, so it's an error too
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", exportOutput, err)
+	}
+	return nil
+}
@@ -1,7 +1,14 @@
 package cmd
 
 import (
+	"fmt"
+	"io"
+
 	"github.com/spf13/cobra"
+
+	"github.com/davidlee/vice/internal/config"
+	"github.com/davidlee/vice/internal/flotsam"
+	"github.com/davidlee/vice/internal/srs"
 )
 
 // flotsamCmd represents the flotsam command
@@ -20,9 +27,49 @@ All notes with vice:type:* tags participate in spaced repetition learning.
 Examples:
   vice flotsam list     # List all vice-typed notes with SRS status
   vice flotsam due      # Show notes due for review
+  vice flotsam review   # Review due notes interactively
+  vice flotsam links    # Show a note's links and backlinks
   vice flotsam edit     # Edit notes via zk integration`,
 }
 
 func init() {
 	rootCmd.AddCommand(flotsamCmd)
 }
+
+// openSRSDatabase opens the context's SRS database and brings existing rows in
+// line with the configured algorithm: under FSRS, reviewed notes that only have
+// SM-2 state are seeded with FSRS state.
+// AIDEV-NOTE: srs-open; every flotsam command opens the database through here so FSRS users see seeded state everywhere
+func openSRSDatabase(env *config.ViceEnv) (*srs.Database, error) {
+	srsDB, err := srs.NewDatabase(env.ContextData, env.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open SRS database: %w", err)
+	}
+
+	if env.GetSRSAlgorithm() == flotsam.AlgorithmFSRS {
+		if _, err := srsDB.SeedFSRSState(env.Context, flotsam.SeedFSRSFromSM2); err != nil {
+			_ = srsDB.Close() //nolint:errcheck // Error already being returned
+			return nil, fmt.Errorf("failed to migrate SM-2 history to FSRS: %w", err)
+		}
+	}
+
+	return srsDB, nil
+}
+
+// syncFlotsamNotes reconciles the SRS database with notes created, renamed or
+// deleted outside vice, and tells the user what changed on w. It always refreshes:
+// the flotsam directory's mtime misses in-place edits (such as adding a vice:type
+// tag) and changes in subdirectories, and UpdateLinkIndex only reads changed files.
+func syncFlotsamNotes(w io.Writer, srsDB *srs.Database, env *config.ViceEnv) error {
+	report, err := flotsam.NewCacheManager(srsDB, env.ContextData, env.GetSRSAlgorithm()).RefreshCache()
+	if err != nil {
+		return fmt.Errorf("failed to sync flotsam notes: %w", err)
+	}
+	if !report.Changed() {
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(w, "Synced flotsam notes: %d new, %d renamed, %d removed, %d restored\n",
+		len(report.Added), len(report.Renamed), len(report.Removed), len(report.Restored))
+	return nil
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
@@ -11,14 +12,13 @@ import (
 
 	"github.com/davidlee/vice/internal/config"
 	"github.com/davidlee/vice/internal/flotsam"
-	"github.com/davidlee/vice/internal/srs"
 	"github.com/davidlee/vice/internal/zk"
 )
 
 // Add command flags
 var (
 	addType     string // note type: flashcard, idea, script, log
-	addTemplate string // template for note content
+	addTemplate string // template name in the context's templates directory
 	addEdit     bool   // open editor after creation
 )
 
@@ -33,14 +33,28 @@ var flotsamAddCmd = &cobra.Command{
 Creates a markdown file with YAML frontmatter including vice:type:* tags and
 automatically adds the note to the SRS database for spaced repetition learning.
 
-Auto-initializes flotsam environment (directory + ZK notebook) if needed when
-ZK tool is available.
+Notes are created with zk when it's installed, auto-initializing the flotsam
+environment (directory + ZK notebook) if needed. Without zk, vice creates the
+note itself with a zk-compatible ID and frontmatter, and --edit opens $EDITOR.
 
 Examples:
   vice flotsam add "What is X?" --type flashcard    # Create flashcard note
   vice flotsam add "Random idea" --type idea        # Create idea note  
   vice flotsam add --type script --edit             # Create script note and edit
-  vice flotsam add "Daily log" --type log           # Create log entry`,
+  vice flotsam add "Daily log" --type log           # Create log entry
+  vice flotsam add "Kanji" --type flashcard --template kanji
+
+Templates:
+  Note bodies come from templates in the context's templates directory
+  ($VICE_DATA/<context>/templates/). <type>.md (e.g. flashcard.md) replaces the
+  built-in default for that type, and --template <name> picks <name>.md.
+  Templates are Go templates with these variables:
+
+    {{.Title}}  {{.ID}}  {{.Date}}  {{.Context}}  {{.Type}}  {{.Created}}
+
+  Handlebars-style {{title}}, {{id}}, {{date}}, {{context}} and {{type}} work
+  too. Flashcard templates must have "## Question" and "## Answer" sections,
+  which reviews split into prompt and answer.`,
 	RunE: runFlotsamAdd,
 }
 
@@ -49,7 +63,7 @@ func init() {
 
 	// Note type and creation options
 	flotsamAddCmd.Flags().StringVar(&addType, "type", "idea", "note type (flashcard, idea, script, log)")
-	flotsamAddCmd.Flags().StringVar(&addTemplate, "template", "", "template for note content")
+	flotsamAddCmd.Flags().StringVar(&addTemplate, "template", "", "template from the context's templates directory (default: the type's template)")
 	flotsamAddCmd.Flags().BoolVar(&addEdit, "edit", false, "open editor after creating note")
 }
 
@@ -64,9 +78,8 @@ func runFlotsamAdd(_ *cobra.Command, args []string) error {
 	}
 
 	// Validate note type
-	validTypes := []string{"flashcard", "idea", "script", "log"}
-	if !contains(validTypes, addType) {
-		return fmt.Errorf("invalid note type: %s (valid: %s)", addType, strings.Join(validTypes, ", "))
+	if !contains(flotsam.NoteTypes, addType) {
+		return fmt.Errorf("invalid note type: %s (valid: %s)", addType, strings.Join(flotsam.NoteTypes, ", "))
 	}
 
 	// Get title from args or use default
@@ -77,22 +90,38 @@ func runFlotsamAdd(_ *cobra.Command, args []string) error {
 		title = fmt.Sprintf("New %s note", addType)
 	}
 
-	// Get ZK notebook instance
-	zkNotebook := env.GetFlotsamZK()
-	if !zkNotebook.Available() {
-		return fmt.Errorf("zk not available - install from https://github.com/zk-org/zk for note creation")
+	// Templates are validated up front so a broken one doesn't leave a half-made note
+	noteTemplate, err := loadNoteTemplate(env, addType, addTemplate)
+	if err != nil {
+		return err
 	}
 
-	// Use ZK to create the note and get the path/ID
-	notePath, noteID, err := createNoteWithZK(zkNotebook, title, addType, addTemplate)
-	if err != nil {
-		return fmt.Errorf("failed to create note via ZK: %w", err)
+	// Prefer ZK when available, otherwise create the note natively
+	var notePath, noteID string
+	zkNotebook := env.GetFlotsamZK()
+	if zkNotebook.Available() {
+		var err error
+		notePath, noteID, err = createNoteWithZK(zkNotebook, title, addType, env.Context, noteTemplate)
+		if err != nil {
+			return fmt.Errorf("failed to create note via ZK: %w", err)
+		}
+	} else {
+		note, err := flotsam.CreateNote(env.GetFlotsamDir(), flotsam.NewNote{
+			Title:    title,
+			Type:     addType,
+			Context:  env.Context,
+			Template: noteTemplate,
+		}, flotsam.NewFlotsamIDGenerator())
+		if err != nil {
+			return fmt.Errorf("failed to create note: %w", err)
+		}
+		notePath, noteID = note.FilePath, note.ID
 	}
 
 	fmt.Printf("Created note: %s (ID: %s)\n", filepath.Base(notePath), noteID)
 
 	// Add to SRS database
-	if err := addToSRSDatabase(notePath, env); err != nil {
+	if err := addToSRSDatabase(notePath, noteID, env); err != nil {
 		fmt.Printf("Warning: failed to add note to SRS database: %v\n", err)
 		// Don't fail the command - note was created successfully
 	} else {
@@ -101,7 +130,13 @@ func runFlotsamAdd(_ *cobra.Command, args []string) error {
 
 	// Open editor if requested
 	if addEdit {
-		if err := zkNotebook.Edit(notePath); err != nil {
+		var err error
+		if zkNotebook.Available() {
+			err = zkNotebook.Edit(notePath)
+		} else {
+			err = openInEditor(notePath)
+		}
+		if err != nil {
 			fmt.Printf("Warning: failed to open editor: %v\n", err)
 		}
 	}
@@ -113,7 +148,7 @@ func runFlotsamAdd(_ *cobra.Command, args []string) error {
 // AIDEV-NOTE: T041/6.1c-zk-delegation; delegates note creation to ZK for proper ID generation
 // AIDEV-NOTE: T041/6.1c-completed; ZK delegation working with unique ID generation via zk new --working-dir
 // AIDEV-NOTE: ID uniqueness achieved - ZK generates unique filenames and IDs per note
-func createNoteWithZK(zkNotebook *zk.ZKNotebook, title, noteType, template string) (string, string, error) {
+func createNoteWithZK(zkNotebook *zk.ZKNotebook, title, noteType, context string, noteTemplate *flotsam.NoteTemplate) (string, string, error) {
 	// Use ZK's new command to create the note with proper working directory
 	// ZK needs --working-dir to avoid path validation errors
 	result, err := zkNotebook.Execute("new",
@@ -152,7 +187,10 @@ func createNoteWithZK(zkNotebook *zk.ZKNotebook, title, noteType, template strin
 	}
 
 	// Update the file with our vice-specific content while preserving ZK's structure
-	updatedContent := createNoteContent(noteID, title, noteType, template)
+	updatedContent, err := createNoteContent(noteID, title, noteType, context, noteTemplate)
+	if err != nil {
+		return "", "", err
+	}
 	if err := os.WriteFile(notePath, []byte(updatedContent), 0o600); err != nil { //nolint:gosec // Standard file permissions
 		return "", "", fmt.Errorf("failed to update note with vice content: %w", err)
 	}
@@ -189,12 +227,12 @@ func extractIDFromContent(content string) string {
 
 // createNoteContent generates markdown content with YAML frontmatter
 // AIDEV-NOTE: T041/6.1b-content-generation; follows ZK-compatible frontmatter with vice tags
-func createNoteContent(noteID, title, noteType, template string) string {
+func createNoteContent(noteID, title, noteType, context string, noteTemplate *flotsam.NoteTemplate) (string, error) {
 	now := time.Now()
 
 	// Build tags array
 	tags := []string{
-		fmt.Sprintf("vice:type:%s", noteType),
+		flotsam.TypeTag(noteType),
 	}
 
 	// Format tags for YAML - use quoted format for ZK compatibility
@@ -222,34 +260,49 @@ created-at: "%s"
 
 `, noteID, title, now.Format(time.RFC3339), tagsYAML)
 
-	// Add content based on type and template
-	var body string
-	if template != "" {
-		body = template
-	} else {
-		switch noteType {
-		case "flashcard":
-			body = fmt.Sprintf("# %s\n\n## Question\n\n%s\n\n## Answer\n\n<!-- Add your answer here -->\n", title, title)
-		case "idea":
-			body = fmt.Sprintf("# %s\n\n<!-- Develop your idea here -->\n", title)
-		case "script":
-			body = fmt.Sprintf("# %s\n\n```bash\n#!/bin/bash\n# %s\n\n# Add your script here\n```\n", title, title)
-		case "log":
-			body = fmt.Sprintf("# %s - %s\n\n<!-- Daily log entry -->\n", title, now.Format("2006-01-02"))
-		default:
-			body = fmt.Sprintf("# %s\n\n<!-- Add content here -->\n", title)
-		}
+	// Add content from the note's template
+	body, err := noteTemplate.Render(flotsam.TemplateData{
+		Title:   title,
+		ID:      noteID,
+		Date:    now.Format("2006-01-02"),
+		Context: context,
+		Type:    noteType,
+		Created: now,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return frontmatter + body, nil
+}
+
+// loadNoteTemplate loads the context's templates and picks the named one, or the
+// note type's default when name is empty.
+func loadNoteTemplate(env *config.ViceEnv, noteType, name string) (*flotsam.NoteTemplate, error) {
+	templates, err := flotsam.LoadTemplates(env.GetTemplatesDir())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load note templates: %w", err)
+	}
+	if name == "" {
+		return templates.ForType(noteType), nil
 	}
 
-	return frontmatter + body
+	noteTemplate, err := templates.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := noteTemplate.ValidateFor(noteType); err != nil {
+		return nil, err
+	}
+	return noteTemplate, nil
 }
 
 // addToSRSDatabase adds the new note to SRS scheduling
 // AIDEV-NOTE: T041/6.1b-srs-integration; immediate SRS scheduling for new notes
-func addToSRSDatabase(notePath string, env *config.ViceEnv) error {
-	srsDB, err := srs.NewDatabase(env.ContextData, env.Context)
+func addToSRSDatabase(notePath, noteID string, env *config.ViceEnv) error {
+	srsDB, err := openSRSDatabase(env)
 	if err != nil {
-		return fmt.Errorf("failed to open SRS database: %w", err)
+		return err
 	}
 	defer func() {
 		if err := srsDB.Close(); err != nil {
@@ -257,29 +310,32 @@ func addToSRSDatabase(notePath string, env *config.ViceEnv) error {
 		}
 	}()
 
-	// Create SRS entry with default values
-	// Note will be due immediately for first review
-	initialSRSData := &srs.SRSData{
-		Easiness:           2.5,               // Default SM-2 easiness
-		ConsecutiveCorrect: 0,                 // New note
-		Due:                time.Now().Unix(), // Due immediately for first review
-		TotalReviews:       0,                 // New note
+	// Due immediately for first review, in the configured algorithm's initial state
+	initialSRSData, err := flotsam.InitialSRSData(env.GetSRSAlgorithm(), time.Now())
+	if err != nil {
+		return err
 	}
 
-	return srsDB.CreateSRSNote(notePath, extractNoteIDFromPath(notePath), env.Context, initialSRSData)
+	return srsDB.CreateSRSNote(notePath, noteID, env.Context, initialSRSData)
 }
 
-// extractNoteIDFromPath extracts note ID from file path
-func extractNoteIDFromPath(notePath string) string {
-	filename := filepath.Base(notePath)
-	filename = strings.TrimSuffix(filename, ".md")
-
-	// Extract first 4 characters as ID (ZK-style)
-	if len(filename) >= 4 {
-		return filename[:4]
+// openInEditor opens a note in $EDITOR (vi if unset), for when zk isn't there to do it.
+// EDITOR may include arguments, e.g. "code --wait".
+func openInEditor(notePath string) error {
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{"vi"}
 	}
 
-	return filename
+	// #nosec G204 -- the editor is the user's own $EDITOR
+	editCmd := exec.Command(editor[0], append(editor[1:], notePath)...)
+	editCmd.Stdin = os.Stdin
+	editCmd.Stdout = os.Stdout
+	editCmd.Stderr = os.Stderr
+	if err := editCmd.Run(); err != nil {
+		return fmt.Errorf("failed to run %s: %w", editor[0], err)
+	}
+	return nil
 }
 
 // contains checks if slice contains string
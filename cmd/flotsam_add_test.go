@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidlee/vice/internal/config"
+	"github.com/davidlee/vice/internal/flotsam"
+	"github.com/davidlee/vice/internal/srs"
+)
+
+func TestRunFlotsamAdd_WithoutZK(t *testing.T) {
+	saved, savedType, savedTemplate, savedEdit := viceEnv, addType, addTemplate, addEdit
+	defer func() { viceEnv, addType, addTemplate, addEdit = saved, savedType, savedTemplate, savedEdit }()
+
+	// No ZK tool configured, as on machines without zk installed
+	viceEnv = &config.ViceEnv{Context: "personal", ContextData: t.TempDir()}
+	addType, addTemplate, addEdit = "flashcard", "", true
+
+	// The editor appends a line to the note it's given
+	editor := filepath.Join(t.TempDir(), "editor.sh")
+	require.NoError(t, os.WriteFile(editor, []byte("#!/bin/sh\necho 'Edited answer' >> \"$1\"\n"), 0o700)) //nolint:gosec // test script must be executable
+	t.Setenv("EDITOR", editor)
+
+	require.NoError(t, runFlotsamAdd(nil, []string{"What", "is", "X?"}))
+
+	paths, err := filepath.Glob(filepath.Join(viceEnv.GetFlotsamDir(), "*.md"))
+	require.NoError(t, err)
+	require.Len(t, paths, 1)
+
+	note, err := flotsam.ParseFlotsamFile(paths[0])
+	require.NoError(t, err)
+	assert.Equal(t, "What is X?", note.Title)
+	assert.Equal(t, []string{"vice:type:flashcard"}, note.Tags)
+	assert.Equal(t, note.ID+".md", filepath.Base(paths[0]))
+	assert.Contains(t, note.Body, "## Answer")
+	assert.Contains(t, note.Body, "Edited answer")
+
+	// Registered for review under its ID
+	db, err := srs.NewDatabase(viceEnv.ContextData, viceEnv.Context)
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	due, err := db.GetDueNotes(viceEnv.Context)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, note.ID, due[0].NoteID)
+	assert.Equal(t, paths[0], due[0].NotePath)
+
+	t.Run("named template", func(t *testing.T) {
+		require.NoError(t, os.MkdirAll(viceEnv.GetTemplatesDir(), 0o750))
+		require.NoError(t, os.WriteFile(filepath.Join(viceEnv.GetTemplatesDir(), "standup.md"),
+			[]byte("# {{title}}\n\nContext: {{.Context}}, note {{.ID}}\n"), 0o600))
+		addType, addTemplate, addEdit = "log", "standup", false
+		require.NoError(t, runFlotsamAdd(nil, []string{"Monday"}))
+
+		paths, err := filepath.Glob(filepath.Join(viceEnv.GetFlotsamDir(), "*.md"))
+		require.NoError(t, err)
+		require.Len(t, paths, 2)
+		found := false
+		for _, path := range paths {
+			note, err := flotsam.ParseFlotsamFile(path)
+			require.NoError(t, err)
+			if note.Title == "Monday" {
+				found = true
+				assert.Equal(t, "\n# Monday\n\nContext: personal, note "+note.ID+"\n", note.Body)
+			}
+		}
+		assert.True(t, found)
+	})
+
+	t.Run("template errors stop creation", func(t *testing.T) {
+		addType, addTemplate = "flashcard", "standup"
+		err := runFlotsamAdd(nil, []string{"No sections"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "## Question")
+
+		addTemplate = "missing"
+		err = runFlotsamAdd(nil, []string{"Missing"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "template not found: missing (available: standup)")
+
+		paths, err := filepath.Glob(filepath.Join(viceEnv.GetFlotsamDir(), "*.md"))
+		require.NoError(t, err)
+		assert.Len(t, paths, 2)
+	})
+	t.Run("configured algorithm", func(t *testing.T) {
+		viceEnv.Flotsam.Algorithm = flotsam.AlgorithmFSRS
+		defer func() { viceEnv.Flotsam.Algorithm = "" }()
+
+		// An SM-2 note reviewed before the switch is seeded when the database is opened
+		db, err := srs.NewDatabase(viceEnv.ContextData, viceEnv.Context)
+		require.NoError(t, err)
+		require.NoError(t, db.CreateSRSNote("/n/old.md", "old", viceEnv.Context,
+			&srs.SRSData{Easiness: 2.5, ConsecutiveCorrect: 1, Due: time.Now().Unix(), TotalReviews: 1}))
+		require.NoError(t, db.Close())
+
+		before, err := filepath.Glob(filepath.Join(viceEnv.GetFlotsamDir(), "*.md"))
+		require.NoError(t, err)
+		addType, addTemplate, addEdit = "idea", "", false
+		require.NoError(t, runFlotsamAdd(nil, []string{"Graphs"}))
+		after, err := filepath.Glob(filepath.Join(viceEnv.GetFlotsamDir(), "*.md"))
+		require.NoError(t, err)
+		require.Len(t, after, len(before)+1)
+		var added string
+		for _, path := range after {
+			if !slices.Contains(before, path) {
+				added = path
+			}
+		}
+
+		db, err = srs.NewDatabase(viceEnv.ContextData, viceEnv.Context)
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+		for _, path := range []string{"/n/old.md", added} {
+			data, err := db.GetSRSData(path)
+			require.NoError(t, err)
+			assert.Positive(t, data.Stability, path)
+			assert.Positive(t, data.Difficulty, path)
+		}
+	})
+}
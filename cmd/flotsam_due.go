@@ -81,9 +81,9 @@ func runFlotsamDue(_ *cobra.Command, _ []string) error {
 	}
 
 	// Step 2: Open SRS database for enrichment
-	srsDB, err := srs.NewDatabase(env.ContextData, env.Context)
+	srsDB, err := openSRSDatabase(env)
 	if err != nil {
-		return fmt.Errorf("failed to open SRS database: %w", err)
+		return err
 	}
 	defer func() {
 		if err := srsDB.Close(); err != nil {
@@ -91,6 +91,11 @@ func runFlotsamDue(_ *cobra.Command, _ []string) error {
 		}
 	}()
 
+	// Schedule notes added outside vice and drop ones that were deleted
+	if err := syncFlotsamNotes(os.Stderr, srsDB, env); err != nil {
+		return err
+	}
+
 	// Step 3: Enrich with SRS data and filter for due/overdue notes
 	dueNotes, err := getDueNotes(notes, srsDB)
 	if err != nil {
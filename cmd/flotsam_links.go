@@ -0,0 +1,271 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/davidlee/vice/internal/flotsam"
+	"github.com/davidlee/vice/internal/srs"
+)
+
+var (
+	// Links command flags
+	linksFormat  string // output format: table, json, paths
+	linksOrphans bool   // list notes nothing links to
+	linksBroken  bool   // list links to missing notes
+)
+
+// flotsamLinksCmd represents the flotsam links command
+// AIDEV-NOTE: links-cmd; reads the link index in the SRS database (updated incrementally on each run), not zk
+var flotsamLinksCmd = &cobra.Command{
+	Use:   "links [note-id]",
+	Short: "Show a note's links and backlinks",
+	Long: `Show the notes a flotsam note links to and the notes that link back to it.
+
+Links are read from an index in the flotsam cache database, which is brought
+up to date with changed notes before each query, so zk isn't needed. Wiki links
+([[abc1]]), markdown links ([text](abc1.md)) and URLs are all indexed; internal
+links resolve by note ID, then by title.
+
+Use --orphans or --broken instead of a note ID to check the whole notebook.
+
+Examples:
+  vice flotsam links abc1              # Links from and to note abc1
+  vice flotsam links abc1 --format paths  # Linked note paths, for scripting
+  vice flotsam links --orphans         # Notes nothing links to
+  vice flotsam links --broken          # Links to notes that don't exist`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runFlotsamLinks,
+}
+
+func init() {
+	flotsamCmd.AddCommand(flotsamLinksCmd)
+
+	flotsamLinksCmd.Flags().StringVar(&linksFormat, "format", "table", "output format (table, json, paths)")
+	flotsamLinksCmd.Flags().BoolVar(&linksOrphans, "orphans", false, "list notes no other note links to")
+	flotsamLinksCmd.Flags().BoolVar(&linksBroken, "broken", false, "list links that don't resolve to a note")
+}
+
+// noteLinks is a note with its outbound links and backlinks
+type noteLinks struct {
+	Note      srs.IndexedFile   `json:"note"`
+	Outlinks  []srs.IndexedLink `json:"outlinks"`
+	Backlinks []srs.IndexedLink `json:"backlinks"`
+}
+
+// runFlotsamLinks executes the flotsam links command
+func runFlotsamLinks(cmd *cobra.Command, args []string) error {
+	switch {
+	case linksOrphans && linksBroken:
+		return fmt.Errorf("--orphans and --broken can't be combined")
+	case (linksOrphans || linksBroken) && len(args) > 0:
+		return fmt.Errorf("--orphans and --broken list the whole notebook and don't take a note ID")
+	case !linksOrphans && !linksBroken && len(args) == 0:
+		return fmt.Errorf("a note ID is required (or use --orphans or --broken)")
+	}
+	if linksFormat != "table" && linksFormat != "json" && linksFormat != "paths" {
+		return fmt.Errorf("invalid format: %s (valid: table, json, paths)", linksFormat)
+	}
+
+	env := GetViceEnv()
+
+	srsDB, err := openSRSDatabase(env)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := srsDB.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to close SRS database: %v\n", err)
+		}
+	}()
+
+	if _, err := flotsam.UpdateLinkIndex(srsDB, env.GetFlotsamDir()); err != nil {
+		return fmt.Errorf("failed to update link index: %w", err)
+	}
+
+	w := cmd.OutOrStdout()
+	switch {
+	case linksOrphans:
+		orphans, err := srsDB.GetOrphanNotes()
+		if err != nil {
+			return err
+		}
+		return outputOrphanNotes(w, orphans, linksFormat)
+	case linksBroken:
+		broken, err := srsDB.GetBrokenLinks()
+		if err != nil {
+			return err
+		}
+		return outputBrokenLinks(w, broken, linksFormat)
+	}
+
+	links, err := loadNoteLinks(srsDB, args[0])
+	if err != nil {
+		return err
+	}
+	return outputNoteLinks(w, links, linksFormat)
+}
+
+// loadNoteLinks looks up a note by ID (or filename) and loads its links
+func loadNoteLinks(srsDB *srs.Database, noteRef string) (*noteLinks, error) {
+	noteID := strings.TrimSuffix(filepath.Base(noteRef), ".md")
+	note, err := srsDB.FindIndexedNote(noteID)
+	if err != nil {
+		return nil, err
+	}
+
+	outlinks, err := srsDB.GetOutlinks(note.Path)
+	if err != nil {
+		return nil, err
+	}
+	backlinks, err := srsDB.GetBacklinks(note.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &noteLinks{Note: *note, Outlinks: nonNilLinks(outlinks), Backlinks: nonNilLinks(backlinks)}, nil
+}
+
+// nonNilLinks returns an empty slice for nil so JSON output has [] rather than null
+func nonNilLinks(links []srs.IndexedLink) []srs.IndexedLink {
+	if links == nil {
+		return []srs.IndexedLink{}
+	}
+	return links
+}
+
+// outputNoteLinks writes a note's links in the specified format
+func outputNoteLinks(w io.Writer, links *noteLinks, format string) error {
+	switch format {
+	case "paths":
+		// Every linked note, once, for piping into other tools
+		seen := make(map[string]bool)
+		for _, path := range linkedNotePaths(links) {
+			if !seen[path] {
+				seen[path] = true
+				_, _ = fmt.Fprintln(w, path)
+			}
+		}
+	case "json":
+		return writeJSON(w, links)
+	case "table":
+		writeNoteLinksTable(w, links)
+	default:
+		return fmt.Errorf("invalid format: %s (valid: table, json, paths)", format)
+	}
+	return nil
+}
+
+// linkedNotePaths returns the paths of resolved outlink targets, then backlink sources
+func linkedNotePaths(links *noteLinks) []string {
+	var paths []string
+	for _, link := range links.Outlinks {
+		if link.TargetPath != "" {
+			paths = append(paths, link.TargetPath)
+		}
+	}
+	for _, link := range links.Backlinks {
+		paths = append(paths, link.SourcePath)
+	}
+	return paths
+}
+
+// writeNoteLinksTable writes a note's outbound links and backlinks as plain text
+func writeNoteLinksTable(w io.Writer, links *noteLinks) {
+	_, _ = fmt.Fprintf(w, "%s  %s\n%s\n\n", links.Note.NoteID, links.Note.Title, links.Note.Path)
+
+	_, _ = fmt.Fprintf(w, "Links (%d):\n", len(links.Outlinks))
+	for _, link := range links.Outlinks {
+		_, _ = fmt.Fprintf(w, "  %-40s %-10s %-6s %s\n",
+			truncate(link.Target, 40), link.Type, strings.Join(link.Rels, ","), linkStatus(link))
+	}
+
+	_, _ = fmt.Fprintf(w, "\nBacklinks (%d):\n", len(links.Backlinks))
+	for _, link := range links.Backlinks {
+		_, _ = fmt.Fprintf(w, "  %-8s %s\n", link.SourceID, link.SourcePath)
+	}
+}
+
+// linkStatus describes where a link leads: a note ID, "external" or "broken"
+func linkStatus(link srs.IndexedLink) string {
+	switch {
+	case link.External:
+		return "external"
+	case link.TargetPath == "":
+		return "broken"
+	default:
+		return "-> " + strings.TrimSuffix(filepath.Base(link.TargetPath), ".md")
+	}
+}
+
+// outputOrphanNotes writes notes without backlinks in the specified format
+func outputOrphanNotes(w io.Writer, orphans []srs.IndexedFile, format string) error {
+	switch format {
+	case "paths":
+		for _, note := range orphans {
+			_, _ = fmt.Fprintln(w, note.Path)
+		}
+	case "json":
+		if orphans == nil {
+			orphans = []srs.IndexedFile{}
+		}
+		return writeJSON(w, orphans)
+	case "table":
+		if len(orphans) == 0 {
+			_, _ = fmt.Fprintln(w, "No orphan notes found")
+			return nil
+		}
+		_, _ = fmt.Fprintf(w, "Found %d orphan note(s):\n\n", len(orphans))
+		for _, note := range orphans {
+			_, _ = fmt.Fprintf(w, "  %-8s %-40s %s\n", note.NoteID, truncate(note.Title, 40), note.Path)
+		}
+	default:
+		return fmt.Errorf("invalid format: %s (valid: table, json, paths)", format)
+	}
+	return nil
+}
+
+// outputBrokenLinks writes links to missing notes in the specified format
+func outputBrokenLinks(w io.Writer, broken []srs.IndexedLink, format string) error {
+	switch format {
+	case "paths":
+		// Notes containing broken links, once each
+		seen := make(map[string]bool)
+		for _, link := range broken {
+			if !seen[link.SourcePath] {
+				seen[link.SourcePath] = true
+				_, _ = fmt.Fprintln(w, link.SourcePath)
+			}
+		}
+	case "json":
+		return writeJSON(w, nonNilLinks(broken))
+	case "table":
+		if len(broken) == 0 {
+			_, _ = fmt.Fprintln(w, "No broken links found")
+			return nil
+		}
+		_, _ = fmt.Fprintf(w, "Found %d broken link(s):\n\n", len(broken))
+		for _, link := range broken {
+			_, _ = fmt.Fprintf(w, "  %-8s -> %s\n", link.SourceID, link.Target)
+		}
+	default:
+		return fmt.Errorf("invalid format: %s (valid: table, json, paths)", format)
+	}
+	return nil
+}
+
+// writeJSON writes v as indented JSON
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidlee/vice/internal/config"
+)
+
+func TestRunFlotsamLinks(t *testing.T) {
+	saved, savedFormat, savedOrphans, savedBroken := viceEnv, linksFormat, linksOrphans, linksBroken
+	defer func() {
+		viceEnv, linksFormat, linksOrphans, linksBroken = saved, savedFormat, savedOrphans, savedBroken
+	}()
+
+	viceEnv = &config.ViceEnv{Context: "personal", ContextData: t.TempDir()}
+	flotsamDir := viceEnv.GetFlotsamDir()
+	require.NoError(t, os.MkdirAll(flotsamDir, 0o750))
+	notes := map[string]string{
+		"abc1.md": "---\nid: abc1\ntitle: Graphs\n---\n\nSee [[def2]], [[missing]] and https://example.com\n",
+		"def2.md": "---\nid: def2\ntitle: Trees\n---\n\nBack to [[abc1]].\n",
+		"ghi3.md": "---\nid: ghi3\ntitle: Lonely\n---\n\nNo links.\n",
+	}
+	for name, content := range notes {
+		require.NoError(t, os.WriteFile(filepath.Join(flotsamDir, name), []byte(content), 0o600))
+	}
+	abc1, def2, ghi3 := filepath.Join(flotsamDir, "abc1.md"), filepath.Join(flotsamDir, "def2.md"), filepath.Join(flotsamDir, "ghi3.md")
+
+	run := func(format string, orphans, broken bool, args ...string) (string, error) {
+		linksFormat, linksOrphans, linksBroken = format, orphans, broken
+		var buf bytes.Buffer
+		cmd := &cobra.Command{}
+		cmd.SetOut(&buf)
+		err := runFlotsamLinks(cmd, args)
+		return buf.String(), err
+	}
+
+	t.Run("table", func(t *testing.T) {
+		output, err := run("table", false, false, "abc1")
+		require.NoError(t, err)
+		assert.Contains(t, output, "abc1  Graphs")
+		assert.Contains(t, output, "Links (3):")
+		assert.Contains(t, output, "-> def2")
+		assert.Contains(t, output, "broken")
+		assert.Contains(t, output, "external")
+		assert.Contains(t, output, "Backlinks (1):")
+		assert.Contains(t, output, def2)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		output, err := run("json", false, false, "def2.md")
+		require.NoError(t, err)
+
+		var decoded noteLinks
+		require.NoError(t, json.Unmarshal([]byte(output), &decoded))
+		assert.Equal(t, "Trees", decoded.Note.Title)
+		require.Len(t, decoded.Outlinks, 1)
+		assert.Equal(t, abc1, decoded.Outlinks[0].TargetPath)
+		require.Len(t, decoded.Backlinks, 1)
+		assert.Equal(t, "abc1", decoded.Backlinks[0].SourceID)
+	})
+
+	t.Run("paths", func(t *testing.T) {
+		output, err := run("paths", false, false, "abc1")
+		require.NoError(t, err)
+		assert.Equal(t, def2+"\n", output, "resolved link and backlink are the same note")
+	})
+
+	t.Run("orphans", func(t *testing.T) {
+		output, err := run("paths", true, false)
+		require.NoError(t, err)
+		assert.Equal(t, ghi3+"\n", output)
+	})
+
+	t.Run("broken", func(t *testing.T) {
+		output, err := run("table", false, true)
+		require.NoError(t, err)
+		assert.Contains(t, output, "abc1     -> missing")
+	})
+
+	t.Run("index follows edits", func(t *testing.T) {
+		require.NoError(t, os.Remove(def2))
+		output, err := run("paths", false, true)
+		require.NoError(t, err)
+		assert.Equal(t, abc1+"\n", output)
+
+		output, err = run("table", false, true)
+		require.NoError(t, err)
+		assert.Equal(t, 2, strings.Count(output, "abc1     ->"))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := run("table", false, false)
+		assert.ErrorContains(t, err, "note ID is required")
+		_, err = run("table", true, false, "abc1")
+		assert.ErrorContains(t, err, "don't take a note ID")
+		_, err = run("table", true, true)
+		assert.ErrorContains(t, err, "can't be combined")
+		_, err = run("xml", false, false, "abc1")
+		assert.ErrorContains(t, err, "invalid format")
+		_, err = run("table", false, false, "zzzz")
+		assert.ErrorContains(t, err, "note not found")
+	})
+}
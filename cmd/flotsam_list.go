@@ -88,9 +88,9 @@ func runFlotsamList(_ *cobra.Command, _ []string) error {
 	}
 
 	// Query SRS data for enriched output
-	srsDB, err := srs.NewDatabase(env.ContextData, env.Context)
+	srsDB, err := openSRSDatabase(env)
 	if err != nil {
-		return fmt.Errorf("failed to open SRS database: %w", err)
+		return err
 	}
 	defer func() {
 		if err := srsDB.Close(); err != nil {
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+
+	"github.com/davidlee/vice/internal/flotsam"
+	"github.com/davidlee/vice/internal/ui/review"
+)
+
+var (
+	// Review command flags
+	reviewLimit  int    // maximum number of cards in the session
+	reviewResume string // paused session ID to resume
+)
+
+// flotsamReviewCmd represents the flotsam review command
+// AIDEV-NOTE: review-cmd; interactive SRS session over srs.Database due queue via flotsam.SessionManager
+var flotsamReviewCmd = &cobra.Command{
+	Use:   "review",
+	Short: "Review flotsam notes that are due",
+	Long: `Run an interactive spaced repetition review of notes that are due.
+
+Each due note is shown as a prompt (the Question section for flashcards, the
+title otherwise). Reveal the answer, then grade your recall from 0 to 6:
+
+  0  no review        3  incorrect, seemed easy   6  correct, perfect recall
+  1  total blackout   4  correct, hard
+  2  familiar         5  correct, some hesitation
+
+Grades of 4 and above count as correct. The next review date is calculated
+with SM-2 (or FSRS, see below) and saved to the SRS database after every card.
+Quitting part way through pauses the session so it can be resumed later.
+
+The scheduler is chosen per context in config.toml:
+
+  [flotsam]
+  algorithm = "fsrs"        # default for all contexts ("sm2" if unset)
+
+  [flotsam.contexts.work]
+  algorithm = "sm2"         # per-context override
+
+When a context switches to FSRS, existing SM-2 history is used to seed each
+note's FSRS stability and difficulty before the first FSRS review.
+
+Examples:
+  vice flotsam review                          # Review all due notes
+  vice flotsam review --limit 20               # Review at most 20 notes
+  vice flotsam review --resume 20250718-093000 # Resume a paused session`,
+	RunE: runFlotsamReview,
+}
+
+func init() {
+	flotsamCmd.AddCommand(flotsamReviewCmd)
+
+	flotsamReviewCmd.Flags().IntVar(&reviewLimit, "limit", flotsam.DefaultSRSConfig().MaxCardsPerSession, "maximum number of cards to review (0 = no limit)")
+	flotsamReviewCmd.Flags().StringVar(&reviewResume, "resume", "", "resume a paused session by ID")
+}
+
+// runFlotsamReview starts or resumes a review session and runs the review UI
+func runFlotsamReview(_ *cobra.Command, _ []string) error {
+	env := GetViceEnv()
+
+	// Auto-initialize flotsam environment if needed
+	if err := flotsam.EnsureFlotsamEnvironment(env); err != nil {
+		return fmt.Errorf("failed to initialize flotsam environment: %w", err)
+	}
+
+	srsDB, err := openSRSDatabase(env)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := srsDB.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to close SRS database: %v\n", err)
+		}
+	}()
+
+	// Schedule notes added outside vice and drop ones that were deleted
+	if err := syncFlotsamNotes(os.Stderr, srsDB, env); err != nil {
+		return err
+	}
+
+	manager := flotsam.NewSessionManager(srsDB, reviewSessionDir(env.GetFlotsamDir()))
+
+	algorithm := env.GetSRSAlgorithm()
+	if err := manager.UseAlgorithm(algorithm); err != nil {
+		return err
+	}
+
+	var session *flotsam.ReviewSession
+	if reviewResume != "" {
+		session, err = manager.ResumeSession(reviewResume)
+		if err != nil {
+			return fmt.Errorf("failed to resume session %s: %w", reviewResume, err)
+		}
+	} else {
+		session, err = manager.StartSession(env.Context, reviewLimit)
+		if err != nil {
+			return fmt.Errorf("failed to start review session: %w", err)
+		}
+	}
+
+	if session.TotalCards == 0 {
+		fmt.Println("No notes due for review")
+		return nil
+	}
+
+	model := review.NewModel(manager, session)
+	if _, err := tea.NewProgram(model).Run(); err != nil {
+		return fmt.Errorf("review session failed: %w", err)
+	}
+
+	if err := model.Err(); err != nil {
+		return err
+	}
+
+	if model.Paused() {
+		fmt.Printf("Session paused. Resume with: vice flotsam review --resume %s\n", session.SessionID)
+	}
+
+	return nil
+}
+
+// reviewSessionDir returns where paused review sessions are stored
+func reviewSessionDir(flotsamDir string) string {
+	return filepath.Join(flotsamDir, ".vice", "sessions")
+}
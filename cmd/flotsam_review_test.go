@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlotsamReviewCommand(t *testing.T) {
+	cmd := flotsamReviewCmd
+	require.NotNil(t, cmd)
+	assert.Equal(t, "review", cmd.Use)
+	assert.Contains(t, cmd.Short, "Review")
+
+	limitFlag := cmd.Flags().Lookup("limit")
+	require.NotNil(t, limitFlag)
+	assert.Equal(t, "50", limitFlag.DefValue)
+
+	resumeFlag := cmd.Flags().Lookup("resume")
+	require.NotNil(t, resumeFlag)
+	assert.Equal(t, "", resumeFlag.DefValue)
+}
+
+func TestReviewSessionDir(t *testing.T) {
+	flotsamDir := filepath.Join("data", "personal", "flotsam")
+	assert.Equal(t, filepath.Join(flotsamDir, ".vice", "sessions"), reviewSessionDir(flotsamDir))
+}
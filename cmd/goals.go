@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/davidlee/vice/internal/config"
+	"github.com/davidlee/vice/internal/parser"
+	"github.com/davidlee/vice/internal/stats"
+	"github.com/davidlee/vice/internal/storage"
+)
+
+var goalsFormat string // output format: table, json
+
+// goalsCmd represents the goals command
+// AIDEV-NOTE: goals-cmd; goals live in goals.yml next to habits.yml; progress semantics are in stats.GoalProgress
+var goalsCmd = &cobra.Command{
+	Use:   "goals [goal-id]",
+	Short: "Show progress toward habit goals",
+	Long: `Show progress toward the goals in goals.yml, next to habits.yml in the
+current context.
+
+Each goal counts a habit's completions or totals its values until a due date,
+or over the current week, month, quarter or year. The projected date assumes
+the pace of the last 14 days continues; a goal is on track if that's no later
+than its due date.
+
+Example goals.yml:
+  version: "1.0.0"
+  goals:
+    - title: "Meditate 100 sessions"
+      habit_id: meditation
+      target: 100
+      due_date: "2027-01-01"
+    - title: "Read 20 hours this quarter"
+      habit_id: reading
+      measure: total
+      target: 1200        # minutes, as in duration criteria
+      period: quarter
+
+Examples:
+  vice goals                   # Progress for all goals
+  vice goals meditate_100      # Progress for one goal
+  vice goals --format json     # Machine-readable output`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runGoals,
+}
+
+func init() {
+	rootCmd.AddCommand(goalsCmd)
+	goalsCmd.Flags().StringVar(&goalsFormat, "format", "table", "output format (table, json)")
+}
+
+func runGoals(_ *cobra.Command, args []string) error {
+	env := GetViceEnv()
+
+	progress, err := loadGoalProgress(env, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 1 {
+		var selected []stats.GoalProgress
+		for _, goal := range progress {
+			if goal.GoalID == args[0] {
+				selected = append(selected, goal)
+			}
+		}
+		if selected == nil {
+			return fmt.Errorf("goal not found: %s", args[0])
+		}
+		progress = selected
+	}
+
+	return outputGoals(os.Stdout, progress, goalsFormat)
+}
+
+// loadGoalProgress loads the context's goals and measures them against its entries.
+func loadGoalProgress(env *config.ViceEnv, now time.Time) ([]stats.GoalProgress, error) {
+	schema, err := parser.NewHabitParser().LoadFromFile(env.GetHabitsFile())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load habits: %w", err)
+	}
+
+	goals, err := parser.NewGoalParser().LoadFromFile(env.GetGoalsFile(), schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load goals: %w", err)
+	}
+	if len(goals.Goals) == 0 {
+		return nil, nil
+	}
+
+	entryLog, err := storage.NewEntryStorage().LoadFromFile(env.GetEntriesFile())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load entries: %w", err)
+	}
+
+	progress, err := stats.NewCalculator(now).Goals(goals.Goals, schema.Habits, entryLog)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute goal progress: %w", err)
+	}
+	return progress, nil
+}
+
+// outputGoals writes goal progress in the specified format
+func outputGoals(w io.Writer, progress []stats.GoalProgress, format string) error {
+	switch format {
+	case "json":
+		if progress == nil {
+			progress = []stats.GoalProgress{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(progress); err != nil {
+			return fmt.Errorf("failed to encode goals: %w", err)
+		}
+	case "table":
+		writeGoalsTable(w, progress)
+	default:
+		return fmt.Errorf("invalid format: %s (valid: table, json)", format)
+	}
+	return nil
+}
+
+// writeGoalsTable writes a plain text table of goal progress
+func writeGoalsTable(w io.Writer, progress []stats.GoalProgress) {
+	if len(progress) == 0 {
+		_, _ = fmt.Fprintln(w, "No goals configured (add them to goals.yml)")
+		return
+	}
+
+	header := fmt.Sprintf("%-30s %-20s %-14s %6s  %-10s %-10s %s", "Goal", "Habit", "Progress", "", "Due", "Projected", "Status")
+	_, _ = fmt.Fprintln(w, header)
+	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(header)))
+
+	for _, goal := range progress {
+		projected := goal.Projected
+		if projected == "" {
+			projected = "-"
+		}
+		_, _ = fmt.Fprintf(w, "%-30s %-20s %-14s %5.0f%%  %-10s %-10s %s\n",
+			truncate(goal.Title, 30), truncate(goal.HabitID, 20), goal.Amounts(), goal.Percent,
+			goal.Due, projected, goal.Status.Label())
+	}
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidlee/vice/internal/config"
+	"github.com/davidlee/vice/internal/stats"
+)
+
+func TestLoadGoalProgress(t *testing.T) {
+	env := &config.ViceEnv{ContextData: t.TempDir()}
+	require.NoError(t, os.WriteFile(env.GetHabitsFile(), []byte(`version: "1.0.0"
+habits:
+  - title: Meditation
+    habit_type: simple
+    field_type:
+      type: boolean
+    scoring_type: manual
+`), 0o600))
+	require.NoError(t, os.WriteFile(env.GetEntriesFile(), []byte(`version: "1.0.0"
+entries:
+  - date: "2025-07-19"
+    habits:
+      - habit_id: meditation
+        value: true
+        status: completed
+        created_at: 2025-07-19T08:00:00Z
+`), 0o600))
+	now := time.Date(2025, 7, 20, 9, 0, 0, 0, time.UTC)
+
+	t.Run("no goals file", func(t *testing.T) {
+		progress, err := loadGoalProgress(env, now)
+		require.NoError(t, err)
+		assert.Empty(t, progress)
+	})
+
+	t.Run("goals", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(env.GetGoalsFile(), []byte(`version: "1.0.0"
+goals:
+  - title: Meditate 100 sessions
+    habit_id: meditation
+    target: 100
+    due_date: "2027-01-01"
+`), 0o600))
+		progress, err := loadGoalProgress(env, now)
+		require.NoError(t, err)
+		require.Len(t, progress, 1)
+		assert.Equal(t, "meditate_100_sessions", progress[0].GoalID)
+		assert.Equal(t, float64(1), progress[0].Current)
+	})
+
+	t.Run("goal for an unknown habit", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(env.GetGoalsFile(), []byte(`version: "1.0.0"
+goals:
+  - title: Swim
+    habit_id: swim
+    target: 10
+    period: month
+`), 0o600))
+		_, err := loadGoalProgress(env, now)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown habit swim")
+	})
+}
+
+func TestOutputGoals(t *testing.T) {
+	progress := []stats.GoalProgress{
+		{GoalID: "meditate", Title: "Meditate 100 sessions", HabitID: "meditation", Measure: "completions",
+			Current: 42, Target: 100, Percent: 42, Due: "2027-01-01", Projected: "2026-11-20", Status: stats.GoalOnTrack},
+		{GoalID: "read", Title: "Read 20 hours", HabitID: "reading", Measure: "total", FieldType: "duration",
+			Current: 260, Target: 1200, Percent: 21.7, Due: "2025-09-30", Status: stats.GoalBehind},
+	}
+
+	t.Run("table", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, outputGoals(&buf, progress, "table"))
+
+		output := buf.String()
+		assert.Contains(t, output, "Meditate 100 sessions")
+		assert.Contains(t, output, "42/100")
+		assert.Contains(t, output, "2026-11-20")
+		assert.Contains(t, output, "on track")
+		assert.Contains(t, output, "4h20m/20h")
+		assert.Contains(t, output, "behind")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, outputGoals(&buf, progress, "json"))
+
+		var decoded []stats.GoalProgress
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		assert.Equal(t, progress, decoded)
+	})
+
+	t.Run("no goals", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, outputGoals(&buf, nil, "table"))
+		assert.Contains(t, buf.String(), "No goals configured")
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		err := outputGoals(&bytes.Buffer{}, progress, "xml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid format")
+	})
+}
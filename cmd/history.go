@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/davidlee/vice/internal/models"
+	"github.com/davidlee/vice/internal/parser"
+	"github.com/davidlee/vice/internal/storage"
+	"github.com/davidlee/vice/internal/ui/heatmap"
+)
+
+var historyWeeks int // number of weeks to show
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:   "history [habit-id]",
+	Short: "Show a calendar heatmap of habit history",
+	Long: `Show a GitHub-style calendar heatmap of past entries.
+
+With a habit ID, each day is shaded by that habit's outcome; elastic habits are
+shaded by achievement level (none, mini, midi, maxi). Without one, each day is
+shaded by the share of habits completed.
+
+  ■ Completed (darker = lower level / fewer habits)
+  ~ Skipped
+  ✗ Failed
+  □ Missed (due, no entry)
+  · Nothing expected, or before tracking started
+
+Examples:
+  vice history                 # All habits, last 26 weeks
+  vice history meditation      # One habit
+  vice history --weeks 52      # A full year`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runHistory,
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	historyCmd.Flags().IntVar(&historyWeeks, "weeks", heatmap.DefaultWeeks, "number of weeks to show")
+}
+
+func runHistory(_ *cobra.Command, args []string) error {
+	env := GetViceEnv()
+
+	schema, err := parser.NewHabitParser().LoadFromFile(env.GetHabitsFile())
+	if err != nil {
+		return fmt.Errorf("failed to load habits: %w", err)
+	}
+
+	entryLog, err := storage.NewEntryStorage().LoadFromFile(env.GetEntriesFile())
+	if err != nil {
+		return fmt.Errorf("failed to load entries: %w", err)
+	}
+
+	output, err := renderHistory(schema.Habits, entryLog, args, time.Now(), historyWeeks)
+	if err != nil {
+		return err
+	}
+	fmt.Print(output)
+	return nil
+}
+
+// renderHistory renders the heatmap for the habit named in args, or all habits
+func renderHistory(habits []models.Habit, entryLog *models.EntryLog, args []string, now time.Time, weeks int) (string, error) {
+	hm := heatmap.New(now, weeks)
+	if len(args) == 0 {
+		return hm.RenderAll(habits, entryLog)
+	}
+
+	for i := range habits {
+		if habits[i].ID == args[0] {
+			return hm.RenderHabit(&habits[i], entryLog)
+		}
+	}
+	return "", fmt.Errorf("habit not found: %s", args[0])
+}
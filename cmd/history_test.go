@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidlee/vice/internal/models"
+)
+
+func TestRenderHistory(t *testing.T) {
+	habits := []models.Habit{{ID: "walk", Title: "Walk"}, {ID: "read", Title: "Read"}}
+	entryLog := &models.EntryLog{
+		Version: "1.0.0",
+		Entries: []models.DayEntry{
+			{Date: "2025-07-14", Habits: []models.HabitEntry{{HabitID: "walk", Status: models.EntryCompleted}}},
+		},
+	}
+	now := time.Date(2025, 7, 16, 0, 0, 0, 0, time.UTC)
+
+	output, err := renderHistory(habits, entryLog, nil, now, 4)
+	require.NoError(t, err)
+	assert.Contains(t, output, "All habits - last 4 weeks")
+
+	output, err = renderHistory(habits, entryLog, []string{"walk"}, now, 4)
+	require.NoError(t, err)
+	assert.Contains(t, output, "Walk - last 4 weeks")
+
+	_, err = renderHistory(habits, entryLog, []string{"missing"}, now, 4)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "habit not found")
+}
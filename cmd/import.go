@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/davidlee/vice/internal/export"
+	"github.com/davidlee/vice/internal/importer"
+	"github.com/davidlee/vice/internal/models"
+	"github.com/davidlee/vice/internal/parser"
+	"github.com/davidlee/vice/internal/safefile"
+	"github.com/davidlee/vice/internal/scoring"
+	"github.com/davidlee/vice/internal/storage"
+)
+
+var (
+	importFormat     string // input format (json, csv, ndjson); inferred from the extension if empty
+	importDryRun     bool   // report changes without saving
+	importSource     string // foreign tracker to import from (loop, habitica, csv)
+	importOnConflict string // conflict policy for foreign imports (skip, overwrite, fail)
+)
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import entries from 'vice export' or another habit tracker",
+	Long: `Import habit entries from a JSON, CSV or NDJSON file written by 'vice export'.
+
+The file is validated before anything is written. Entries are merged by date and
+habit: an imported entry replaces the existing entry for the same habit and day,
+and all other existing entries are kept. A backup of entries.yml is made first.
+
+With --source, history is imported from another habit tracker instead:
+  loop       Loop Habit Tracker export (zip, directory or Checkmarks.csv)
+  habitica   Habitica user data export (JSON): dailies and habits
+  csv        Generic CSV with date, habit and optional value, status, level, notes
+
+Foreign habits are added to habits.yml unless a habit with the same ID exists.
+When an imported day already has a different entry for a habit, --on-conflict
+decides: skip keeps the existing entry, overwrite replaces it, fail aborts.
+Use --dry-run to review the diff first.
+
+Examples:
+  vice import entries.json
+  vice import entries.csv --dry-run
+  vice import - --format ndjson < entries.ndjson
+  vice import --source loop ~/Downloads/Loop\ Habits\ CSV.zip --dry-run
+  vice import --source habitica user-data.json --on-conflict overwrite`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImport,
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.Flags().StringVarP(&importFormat, "format", "f", "", "Input format (json, csv, ndjson); inferred from the file extension by default")
+	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Show what would change without saving")
+	importCmd.Flags().StringVar(&importSource, "source", "", "Import from another tracker (loop, habitica, csv)")
+	importCmd.Flags().StringVar(&importOnConflict, "on-conflict", string(importer.ConflictSkip), "With --source: skip, overwrite or fail on conflicting entries")
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	env := GetViceEnv()
+
+	if importSource != "" {
+		return importFromTracker(cmd.OutOrStdout(), env.GetHabitsFile(), env.GetEntriesFile(), importSource, args[0], importOnConflict, importDryRun)
+	}
+
+	format := importFormat
+	if format == "" {
+		var err error
+		if format, err = export.FormatFromPath(args[0]); err != nil {
+			return err
+		}
+	}
+
+	var r io.Reader = cmd.InOrStdin()
+	if args[0] != "-" {
+		// #nosec G304 -- input path is provided by the user
+		file, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", args[0], err)
+		}
+		defer func() { _ = file.Close() }()
+		r = file
+	}
+
+	result, err := importEntries(r, format, env.GetHabitsFile(), env.GetEntriesFile(), importDryRun)
+	if err != nil {
+		return err
+	}
+
+	verb := "Imported"
+	if importDryRun {
+		verb = "Would import"
+	}
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s %d entries: %d added, %d updated, %d unchanged\n",
+		verb, result.Added+result.Updated+result.Unchanged, result.Added, result.Updated, result.Unchanged)
+	return nil
+}
+
+// importEntries reads, validates and merges entries into the entries file,
+// recomputing the habits derived from them.
+func importEntries(r io.Reader, format, habitsFile, entriesFile string, dryRun bool) (export.MergeResult, error) {
+	rows, err := export.Read(r, format)
+	if err != nil {
+		return export.MergeResult{}, err
+	}
+
+	incoming, err := export.ToEntryLog(rows, time.Now())
+	if err != nil {
+		return export.MergeResult{}, err
+	}
+
+	entryStorage := storage.NewEntryStorage()
+	if err := recomputeDerivedOnSave(entryStorage, habitsFile); err != nil {
+		return export.MergeResult{}, err
+	}
+
+	// Merge under the entries lock so a concurrent `vice log` isn't overwritten
+	var result export.MergeResult
+	_, err = entryStorage.ModifyWithBackup(entriesFile, storage.DefaultBackupConfig(), func(entryLog *models.EntryLog) error {
+		var err error
+		if result, err = export.Merge(entryLog, incoming); err != nil {
+			return err
+		}
+		if dryRun || result.Added+result.Updated == 0 {
+			return storage.ErrNoChanges
+		}
+		return nil
+	})
+	if err != nil {
+		return result, fmt.Errorf("failed to import entries: %w", err)
+	}
+	return result, nil
+}
+
+// recomputeDerivedOnSave makes entryStorage recompute derived habits, as defined
+// in habitsFile, before every save. Without a habits file there are none.
+func recomputeDerivedOnSave(entryStorage *storage.EntryStorage, habitsFile string) error {
+	if _, err := os.Stat(habitsFile); err != nil {
+		return nil
+	}
+	schema, err := parser.NewHabitParser().LoadFromFile(habitsFile)
+	if err != nil {
+		return fmt.Errorf("failed to load habits: %w", err)
+	}
+	entryStorage.SetBeforeSave(scoring.NewEngine().RecomputeHook(schema))
+	return nil
+}
+
+// importFromTracker imports another tracker's export, printing the diff.
+// Habits are saved before entries so imported entries never reference unknown habits.
+func importFromTracker(w io.Writer, habitsFile, entriesFile, sourceName, path, onConflict string, dryRun bool) error {
+	source, err := importer.GetSource(sourceName)
+	if err != nil {
+		return err
+	}
+	policy, err := importer.ParseConflictPolicy(onConflict)
+	if err != nil {
+		return err
+	}
+
+	dataset, err := source.Load(path)
+	if err != nil {
+		return err
+	}
+
+	habitParser := parser.NewHabitParser()
+	schema := &models.Schema{Version: "1.0.0", CreatedDate: time.Now().Format("2006-01-02")}
+	var habitsVersion safefile.Version
+	if _, err := os.Stat(habitsFile); err == nil {
+		if schema, habitsVersion, err = habitParser.LoadFromFileWithVersion(habitsFile); err != nil {
+			return fmt.Errorf("failed to load habits: %w", err)
+		}
+	}
+
+	// The plan is built and applied under the entries lock; habits are saved with a
+	// version check, so a concurrent habit edit fails the import instead of being lost
+	entryStorage := storage.NewEntryStorage()
+	entryStorage.SetBeforeSave(scoring.NewEngine().RecomputeHook(schema))
+	_, err = entryStorage.ModifyWithBackup(entriesFile, storage.DefaultBackupConfig(), func(entryLog *models.EntryLog) error {
+		plan, err := importer.NewPlan(dataset, schema, entryLog, policy)
+		if plan != nil {
+			plan.Diff(w)
+		}
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			return storage.ErrNoChanges
+		}
+
+		if err := plan.Apply(schema, entryLog); err != nil {
+			return err
+		}
+		if len(plan.NewHabits) > 0 {
+			if _, err := habitParser.SaveToFileIfUnchanged(schema, habitsFile, habitsVersion); err != nil {
+				return fmt.Errorf("failed to save habits: %w", err)
+			}
+		}
+		return nil
+	})
+	return err
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidlee/vice/internal/export"
+	"github.com/davidlee/vice/internal/models"
+	"github.com/davidlee/vice/internal/parser"
+	"github.com/davidlee/vice/internal/storage"
+)
+
+func TestImportEntries(t *testing.T) {
+	dir := t.TempDir()
+	habitsFile, entriesFile := filepath.Join(dir, "habits.yml"), filepath.Join(dir, "entries.yml")
+	csvData := `date,habit_id,status,achievement_level,value,value_type,notes,created_at
+2025-07-14,walk,completed,,true,boolean,,2025-07-14T08:00:00Z
+2025-07-14,morning,completed,maxi,"[""stretch"",""water""]",list,,2025-07-14T08:00:00Z
+2025-07-15,walk,skipped,,,,"rain",2025-07-15T08:00:00Z
+`
+
+	t.Run("dry run does not write", func(t *testing.T) {
+		result, err := importEntries(strings.NewReader(csvData), export.FormatCSV, habitsFile, entriesFile, true)
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Added)
+		assert.NoFileExists(t, entriesFile)
+	})
+
+	result, err := importEntries(strings.NewReader(csvData), export.FormatCSV, habitsFile, entriesFile, false)
+	require.NoError(t, err)
+	assert.Equal(t, export.MergeResult{Added: 3}, result)
+
+	entryLog, err := storage.NewEntryStorage().LoadFromFile(entriesFile)
+	require.NoError(t, err)
+	require.Len(t, entryLog.Entries, 2)
+	day, found := entryLog.GetDayEntry("2025-07-14")
+	require.True(t, found)
+	morning, found := day.GetHabitEntry("morning")
+	require.True(t, found)
+	assert.Equal(t, []interface{}{"stretch", "water"}, morning.Value)
+
+	t.Run("importing again changes nothing", func(t *testing.T) {
+		result, err := importEntries(strings.NewReader(csvData), export.FormatCSV, habitsFile, entriesFile, false)
+		require.NoError(t, err)
+		assert.Equal(t, export.MergeResult{Unchanged: 3}, result)
+	})
+
+	t.Run("invalid rows are rejected", func(t *testing.T) {
+		invalid := "date,habit_id,status\n2025-07-16,walk,completed\n"
+		_, err := importEntries(strings.NewReader(invalid), export.FormatCSV, habitsFile, entriesFile, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid import")
+	})
+}
+
+func TestImportEntries_RecomputesDerivedHabits(t *testing.T) {
+	dir := t.TempDir()
+	habitsFile, entriesFile := filepath.Join(dir, "habits.yml"), filepath.Join(dir, "entries.yml")
+	schema := &models.Schema{Version: "1.0.0", Habits: []models.Habit{
+		{Title: "Focus AM", HabitType: models.InformationalHabit, FieldType: models.FieldType{Type: models.DurationFieldType}},
+		{Title: "Focus PM", HabitType: models.InformationalHabit, FieldType: models.FieldType{Type: models.DurationFieldType}},
+		{
+			Title:      "Deep Work",
+			HabitType:  models.DerivedHabit,
+			FieldType:  models.FieldType{Type: models.DurationFieldType},
+			Derivation: &models.Derivation{Aggregate: models.AggregateSum, Sources: []string{"focus_am", "focus_pm"}},
+		},
+	}}
+	require.NoError(t, parser.NewHabitParser().SaveToFile(schema, habitsFile))
+
+	csvData := `date,habit_id,status,achievement_level,value,value_type,notes,created_at
+2025-07-14,focus_am,completed,,1h,text,,2025-07-14T08:00:00Z
+2025-07-14,focus_pm,completed,,45m,text,,2025-07-14T16:00:00Z
+`
+	_, err := importEntries(strings.NewReader(csvData), export.FormatCSV, habitsFile, entriesFile, false)
+	require.NoError(t, err)
+
+	entryLog, err := storage.NewEntryStorage().LoadFromFile(entriesFile)
+	require.NoError(t, err)
+	day, found := entryLog.GetDayEntry("2025-07-14")
+	require.True(t, found)
+	deepWork, found := day.GetHabitEntry("deep_work")
+	require.True(t, found, "derived habit recomputed from the imported entries")
+	assert.Equal(t, "1h45m", deepWork.Value)
+}
+
+func TestImportFromTracker(t *testing.T) {
+	dir := t.TempDir()
+	habitsFile := filepath.Join(dir, "habits.yml")
+	entriesFile := filepath.Join(dir, "entries.yml")
+	csvFile := filepath.Join(dir, "history.csv")
+	require.NoError(t, os.WriteFile(csvFile, []byte("date,habit,value\n2025-07-14,Walk,yes\n2025-07-15,Walk,no\n"), 0o600))
+
+	var out bytes.Buffer
+	require.NoError(t, importFromTracker(&out, habitsFile, entriesFile, "csv", csvFile, "skip", true))
+	assert.Contains(t, out.String(), "1 new habits, 2 new entries")
+	assert.NoFileExists(t, habitsFile)
+
+	out.Reset()
+	require.NoError(t, importFromTracker(&out, habitsFile, entriesFile, "csv", csvFile, "skip", false))
+	schema, err := parser.NewHabitParser().LoadFromFile(habitsFile)
+	require.NoError(t, err)
+	require.Len(t, schema.Habits, 1)
+	assert.Equal(t, "walk", schema.Habits[0].ID)
+
+	entryLog, err := storage.NewEntryStorage().LoadFromFile(entriesFile)
+	require.NoError(t, err)
+	assert.Len(t, entryLog.Entries, 2)
+
+	t.Run("conflicts fail when asked", func(t *testing.T) {
+		changed := filepath.Join(dir, "changed.csv")
+		require.NoError(t, os.WriteFile(changed, []byte("date,habit,value\n2025-07-14,Walk,no\n"), 0o600))
+
+		out.Reset()
+		err := importFromTracker(&out, habitsFile, entriesFile, "csv", changed, "fail", false)
+		require.Error(t, err)
+		assert.Contains(t, out.String(), "! 2025-07-14 walk existing completed true, imported failed false")
+	})
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/davidlee/vice/internal/models"
 	"github.com/davidlee/vice/internal/parser"
+	"github.com/davidlee/vice/internal/safefile"
 	"github.com/davidlee/vice/internal/ui/checklist"
 )
 
@@ -54,12 +55,14 @@ func runListAdd(_ *cobra.Command, args []string) error {
 	// Initialize checklist parser
 	checklistParser := parser.NewChecklistParser()
 
-	// Load existing checklists or create empty schema
+	// Load existing checklists or create empty schema; the version guards the save
+	// below against changes made by another vice process while the editor is open
 	var schema *models.ChecklistSchema
+	var version safefile.Version
 	var err error
 
 	if _, statErr := os.Stat(env.GetChecklistsFile()); os.IsNotExist(statErr) {
-		// Create new schema if file doesn't exist
+		// Create new schema if file doesn't exist (the zero version requires it still doesn't)
 		schema = &models.ChecklistSchema{
 			Version:     "1.0.0",
 			CreatedDate: time.Now().Format("2006-01-02"),
@@ -67,7 +70,7 @@ func runListAdd(_ *cobra.Command, args []string) error {
 		}
 	} else {
 		// Load existing schema
-		schema, err = checklistParser.LoadFromFile(env.GetChecklistsFile())
+		schema, version, err = checklistParser.LoadFromFileWithVersion(env.GetChecklistsFile())
 		if err != nil {
 			return fmt.Errorf("failed to load checklists: %w", err)
 		}
@@ -98,8 +101,8 @@ func runListAdd(_ *cobra.Command, args []string) error {
 	}
 
 	// Save the updated schema
-	if err := checklistParser.SaveToFile(schema, env.GetChecklistsFile()); err != nil {
-		return fmt.Errorf("failed to save checklists: %w", err)
+	if _, err := checklistParser.SaveToFileIfUnchanged(schema, env.GetChecklistsFile(), version); err != nil {
+		return checklistSaveError(err)
 	}
 
 	fmt.Printf("✓ Created checklist '%s' with %d items\n", newChecklist.ID, len(newChecklist.Items))
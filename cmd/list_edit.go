@@ -1,12 +1,14 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/davidlee/vice/internal/parser"
+	"github.com/davidlee/vice/internal/safefile"
 	"github.com/davidlee/vice/internal/ui/checklist"
 )
 
@@ -43,8 +45,9 @@ func runListEdit(_ *cobra.Command, args []string) error {
 	// Initialize checklist parser
 	checklistParser := parser.NewChecklistParser()
 
-	// Load existing checklists
-	schema, err := checklistParser.LoadFromFile(env.GetChecklistsFile())
+	// Load existing checklists; the version guards the save below against
+	// changes made by another vice process while the editor is open
+	schema, version, err := checklistParser.LoadFromFileWithVersion(env.GetChecklistsFile())
 	if err != nil {
 		return fmt.Errorf("failed to load checklists: %w", err)
 	}
@@ -81,10 +84,19 @@ func runListEdit(_ *cobra.Command, args []string) error {
 	}
 
 	// Save the updated schema
-	if err := checklistParser.SaveToFile(schema, env.GetChecklistsFile()); err != nil {
-		return fmt.Errorf("failed to save checklists: %w", err)
+	if _, err := checklistParser.SaveToFileIfUnchanged(schema, env.GetChecklistsFile(), version); err != nil {
+		return checklistSaveError(err)
 	}
 
 	fmt.Printf("✓ Updated checklist '%s' with %d items\n", checklistID, len(updatedChecklist.Items))
 	return nil
 }
+
+// checklistSaveError wraps a failure to save checklists.yml, explaining a conflict
+// with a concurrent edit.
+func checklistSaveError(err error) error {
+	if errors.Is(err, safefile.ErrConflict) {
+		return fmt.Errorf("checklists changed on disk while editing; nothing was saved, run the command again: %w", err)
+	}
+	return fmt.Errorf("failed to save checklists: %w", err)
+}
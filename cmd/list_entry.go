@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -8,6 +9,7 @@ import (
 
 	"github.com/davidlee/vice/internal/models"
 	"github.com/davidlee/vice/internal/parser"
+	"github.com/davidlee/vice/internal/safefile"
 	"github.com/davidlee/vice/internal/ui/checklist"
 )
 
@@ -68,7 +70,7 @@ func runListEntry(_ *cobra.Command, args []string) error {
 	}
 
 	// Load or create checklist entries schema
-	entriesSchema, err := entriesParser.EnsureSchemaExists(env.GetChecklistEntriesFile())
+	entriesSchema, version, err := entriesParser.LoadFromFileWithVersion(env.GetChecklistEntriesFile())
 	if err != nil {
 		return fmt.Errorf("failed to load checklist entries: %w", err)
 	}
@@ -118,12 +120,8 @@ func runListEntry(_ *cobra.Command, args []string) error {
 		PartialComplete: completion.PartialComplete,
 	}
 
-	if err := entriesParser.SaveChecklistEntryForDate(entriesSchema, today, targetChecklist.ID, entry); err != nil {
-		return fmt.Errorf("failed to save checklist entry: %w", err)
-	}
-
-	if err := entriesParser.SaveToFile(entriesSchema, env.GetChecklistEntriesFile()); err != nil {
-		return fmt.Errorf("failed to save checklist entries file: %w", err)
+	if err := saveChecklistEntry(entriesParser, env.GetChecklistEntriesFile(), entriesSchema, version, today, targetChecklist.ID, entry); err != nil {
+		return err
 	}
 
 	// Display completion summary
@@ -151,3 +149,29 @@ func runListEntry(_ *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// saveChecklistEntry records the checklist's entry for date and saves the entries file, provided it's
+// unchanged since version. If another vice process saved it meanwhile (e.g. a
+// different checklist completed in another terminal), the file is reloaded and the
+// entry applied again, since it only replaces this checklist's state for the day.
+// AIDEV-NOTE: checklist-entry-save; version-checked so concurrent completions aren't lost
+func saveChecklistEntry(entriesParser *parser.ChecklistEntriesParser, path string, schema *models.ChecklistEntriesSchema, version safefile.Version, date, checklistID string, entry models.ChecklistEntry) error {
+	for attempt := 0; ; attempt++ {
+		if err := entriesParser.SaveChecklistEntryForDate(schema, date, checklistID, entry); err != nil {
+			return fmt.Errorf("failed to save checklist entry: %w", err)
+		}
+
+		_, err := entriesParser.SaveToFileIfUnchanged(schema, path, version)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, safefile.ErrConflict) || attempt > 0 {
+			return fmt.Errorf("failed to save checklist entries file: %w", err)
+		}
+
+		schema, version, err = entriesParser.LoadFromFileWithVersion(path)
+		if err != nil {
+			return fmt.Errorf("failed to reload checklist entries: %w", err)
+		}
+	}
+}
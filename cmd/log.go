@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/davidlee/vice/internal/models"
+	"github.com/davidlee/vice/internal/parser"
+	"github.com/davidlee/vice/internal/quicklog"
+	"github.com/davidlee/vice/internal/scoring"
+	"github.com/davidlee/vice/internal/storage"
+)
+
+var (
+	logNotes string // notes to attach to the entry
+	logSkip  bool   // record the habit as skipped
+	logLevel string // achievement level for manually scored habits
+	logDate  string // day to record (YYYY-MM-DD, "today" or "yesterday")
+)
+
+// logCmd represents the log command
+// AIDEV-NOTE: quick-log; non-interactive entry for hotkeys/cron, same scoring rules as the entry flows via internal/quicklog
+var logCmd = &cobra.Command{
+	Use:   "log <habit-id> [value]",
+	Short: "Record a single habit without the interactive form",
+	Long: `Record one habit entry from the command line, for scripts, cron jobs,
+shell aliases and window-manager hotkeys.
+
+The value is parsed according to the habit's field type:
+  boolean           true/false, yes/no (defaults to true when omitted)
+  unsigned_int      42
+  decimal           3.5
+  duration          1h30m, 45m, or a number of minutes
+  time              07:30
+  checklist         comma-separated item names, or "all"
+  text              any text (quote it)
+
+Automatic habits are scored against their criteria. Manually scored elastic and
+checklist habits need --level. Exits non-zero if the value is invalid.
+
+Examples:
+  vice log meditation                     # Boolean habit done
+  vice log exercise 45m                   # Duration, scored automatically
+  vice log wake_up 06:45 --notes "early"
+  vice log morning_routine "stretch, water"
+  vice log reading 30 --level midi        # Manually scored elastic habit
+  vice log gym --skip --notes "travelling"
+  vice log water 8 --date yesterday`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runLog,
+}
+
+func init() {
+	rootCmd.AddCommand(logCmd)
+	logCmd.Flags().StringVar(&logNotes, "notes", "", "Notes to attach to the entry")
+	logCmd.Flags().BoolVar(&logSkip, "skip", false, "Record the habit as skipped")
+	logCmd.Flags().StringVar(&logLevel, "level", "", "Achievement level for manually scored habits (none, mini, midi, maxi)")
+	logCmd.Flags().StringVar(&logDate, "date", "today", "Day to record (YYYY-MM-DD, today, yesterday)")
+}
+
+func runLog(cmd *cobra.Command, args []string) error {
+	env := GetViceEnv()
+
+	date, err := parseEntryDate(logDate, time.Now())
+	if err != nil {
+		return err
+	}
+
+	schema, err := parser.NewHabitParser().LoadFromFile(env.GetHabitsFile())
+	if err != nil {
+		return fmt.Errorf("failed to load habits: %w", err)
+	}
+
+	habit, err := findHabit(schema.Habits, args[0])
+	if err != nil {
+		return err
+	}
+
+	req := quicklog.Request{
+		Notes: logNotes,
+		Skip:  logSkip,
+		Level: logLevel,
+	}
+	if len(args) > 1 {
+		req.Value = args[1]
+		req.HasValue = true
+	}
+
+	if habit.FieldType.Type == models.ChecklistFieldType && !logSkip {
+		checklistParser := parser.NewChecklistParser()
+		checklists, err := checklistParser.LoadFromFile(env.GetChecklistsFile())
+		if err != nil {
+			return fmt.Errorf("failed to load checklists: %w", err)
+		}
+		req.Checklist, err = checklistParser.GetChecklistByID(checklists, habit.FieldType.ChecklistID)
+		if err != nil {
+			return err
+		}
+	}
+
+	entry, err := logHabit(schema, habit, req, env.GetEntriesFile(), date)
+	if err != nil {
+		return err
+	}
+
+	printLogged(cmd.OutOrStdout(), habit, entry, date)
+	return nil
+}
+
+// logHabit builds the entry and writes it for the given local day (as returned by
+// parseEntryDate), replacing any existing entry
+// while keeping its creation time (and notes, unless new ones are given). Habits
+// derived from it are recomputed on save. Window criteria look back over the
+// entries already in the file.
+func logHabit(schema *models.Schema, habit *models.Habit, req quicklog.Request, entriesFile string, day time.Time) (*models.HabitEntry, error) {
+	date := day.Format("2006-01-02")
+
+	entryStorage := storage.NewEntryStorage()
+	entryStorage.SetBeforeSave(scoring.NewEngine().RecomputeHook(schema))
+	entryLog, err := entryStorage.LoadFromFile(entriesFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load entries: %w", err)
+	}
+
+	builder := quicklog.NewBuilder()
+	builder.SetHistory(scoring.EntryHistoryFunc(func(time.Time) (*models.EntryLog, error) {
+		return entryLog, nil
+	}), day)
+	entry, err := builder.Build(habit, req)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.MarkCreated()
+	if dayEntry, found := entryLog.GetDayEntry(date); found {
+		if existing, found := dayEntry.GetHabitEntry(habit.ID); found {
+			entry.CreatedAt = existing.CreatedAt
+			if entry.Notes == "" {
+				entry.Notes = existing.Notes
+			}
+			entry.MarkUpdated()
+		}
+	}
+
+	if err := entry.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid entry for %s: %w", habit.ID, err)
+	}
+
+	if err := entryStorage.UpdateHabitEntry(entriesFile, date, *entry); err != nil {
+		return nil, fmt.Errorf("failed to save entry: %w", err)
+	}
+	return entry, nil
+}
+
+// findHabit looks up a habit by ID.
+func findHabit(habits []models.Habit, id string) (*models.Habit, error) {
+	for i := range habits {
+		if habits[i].ID == id {
+			return &habits[i], nil
+		}
+	}
+	return nil, fmt.Errorf("habit not found: %s", id)
+}
+
+// printLogged prints a one-line confirmation.
+func printLogged(w io.Writer, habit *models.Habit, entry *models.HabitEntry, date time.Time) {
+	title := habit.Title
+	if title == "" {
+		title = habit.ID
+	}
+	line := fmt.Sprintf("%s: %s", title, entry.Status)
+	if entry.Value != nil {
+		line += fmt.Sprintf(" (%v)", entry.Value)
+	}
+	if entry.AchievementLevel != nil && habit.IsElastic() {
+		line += fmt.Sprintf(" [%s]", *entry.AchievementLevel)
+	}
+	line += " on " + date.Format("2006-01-02")
+	_, _ = fmt.Fprintln(w, line)
+}
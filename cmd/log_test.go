@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidlee/vice/internal/models"
+	"github.com/davidlee/vice/internal/quicklog"
+	"github.com/davidlee/vice/internal/storage"
+)
+
+func TestLogHabit(t *testing.T) {
+	entriesFile := filepath.Join(t.TempDir(), "entries.yml")
+	habit := &models.Habit{
+		ID:          "meditate",
+		Title:       "Meditate",
+		HabitType:   models.SimpleHabit,
+		FieldType:   models.FieldType{Type: models.BooleanFieldType},
+		ScoringType: models.ManualScoring,
+	}
+	schema := &models.Schema{Version: "1.0.0", Habits: []models.Habit{*habit}}
+
+	first, err := logHabit(schema, habit, quicklog.Request{Notes: "calm"}, entriesFile, localDay(t, "2025-07-14"))
+	require.NoError(t, err)
+	assert.Equal(t, models.EntryCompleted, first.Status)
+
+	// Logging again replaces the entry, keeping its creation time and notes
+	second, err := logHabit(schema, habit, quicklog.Request{Value: "no", HasValue: true}, entriesFile, localDay(t, "2025-07-14"))
+	require.NoError(t, err)
+	assert.Equal(t, models.EntryFailed, second.Status)
+
+	entryLog, err := storage.NewEntryStorage().LoadFromFile(entriesFile)
+	require.NoError(t, err)
+	dayEntry, found := entryLog.GetDayEntry("2025-07-14")
+	require.True(t, found)
+	require.Len(t, dayEntry.Habits, 1)
+
+	stored := dayEntry.Habits[0]
+	assert.Equal(t, models.EntryFailed, stored.Status)
+	assert.Equal(t, "calm", stored.Notes)
+	assert.WithinDuration(t, first.CreatedAt, stored.CreatedAt, time.Second)
+	assert.NotNil(t, stored.UpdatedAt)
+
+	t.Run("invalid value is not written", func(t *testing.T) {
+		_, err := logHabit(schema, habit, quicklog.Request{Value: "maybe", HasValue: true}, entriesFile, localDay(t, "2025-07-15"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid value for meditate")
+
+		entryLog, err := storage.NewEntryStorage().LoadFromFile(entriesFile)
+		require.NoError(t, err)
+		_, found := entryLog.GetDayEntry("2025-07-15")
+		assert.False(t, found)
+	})
+}
+
+func TestLogHabit_RecomputesDerivedHabits(t *testing.T) {
+	entriesFile := filepath.Join(t.TempDir(), "entries.yml")
+	atLeast := 90.0
+	schema := &models.Schema{Version: "1.0.0", Habits: []models.Habit{
+		{Title: "Focus AM", HabitType: models.InformationalHabit, FieldType: models.FieldType{Type: models.DurationFieldType}},
+		{Title: "Focus PM", HabitType: models.InformationalHabit, FieldType: models.FieldType{Type: models.DurationFieldType}},
+		{
+			Title:       "Deep Work",
+			HabitType:   models.DerivedHabit,
+			FieldType:   models.FieldType{Type: models.DurationFieldType},
+			ScoringType: models.AutomaticScoring,
+			Derivation:  &models.Derivation{Aggregate: models.AggregateSum, Sources: []string{"focus_am", "focus_pm"}},
+			Criteria:    &models.Criteria{Condition: &models.Condition{GreaterThanOrEqual: &atLeast}},
+		},
+	}}
+	require.NoError(t, schema.Validate())
+
+	_, err := logHabit(schema, &schema.Habits[0], quicklog.Request{Value: "1h", HasValue: true}, entriesFile, localDay(t, "2025-07-14"))
+	require.NoError(t, err)
+	_, err = logHabit(schema, &schema.Habits[1], quicklog.Request{Value: "45m", HasValue: true}, entriesFile, localDay(t, "2025-07-14"))
+	require.NoError(t, err)
+
+	entryLog, err := storage.NewEntryStorage().LoadFromFile(entriesFile)
+	require.NoError(t, err)
+	dayEntry, found := entryLog.GetDayEntry("2025-07-14")
+	require.True(t, found)
+	deepWork, found := dayEntry.GetHabitEntry("deep_work")
+	require.True(t, found)
+	assert.Equal(t, "1h45m", deepWork.Value)
+	assert.Equal(t, models.EntryCompleted, deepWork.Status)
+
+	_, err = logHabit(schema, &schema.Habits[2], quicklog.Request{Value: "2h", HasValue: true}, entriesFile, localDay(t, "2025-07-14"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "derived")
+}
+
+func TestLogHabit_WindowCriteria(t *testing.T) {
+	entriesFile := filepath.Join(t.TempDir(), "entries.yml")
+	zero, daily, weekly := 0.0, 60.0, 90.0
+	schema := &models.Schema{Version: "1.0.0", Habits: []models.Habit{{
+		Title:        "Running",
+		HabitType:    models.ElasticHabit,
+		FieldType:    models.FieldType{Type: models.DurationFieldType},
+		ScoringType:  models.AutomaticScoring,
+		MiniCriteria: &models.Criteria{Condition: &models.Condition{GreaterThan: &zero}},
+		MidiCriteria: &models.Criteria{Condition: &models.Condition{GreaterThanOrEqual: &daily}},
+		MaxiCriteria: &models.Criteria{Condition: &models.Condition{
+			Window:             &models.WindowCondition{Aggregate: models.AggregateSum, Period: models.WeekPeriod},
+			GreaterThanOrEqual: &weekly,
+		}},
+	}}}
+	require.NoError(t, schema.Validate())
+	habit := &schema.Habits[0]
+
+	monday, err := logHabit(schema, habit, quicklog.Request{Value: "50m", HasValue: true}, entriesFile, localDay(t, "2025-07-14"))
+	require.NoError(t, err)
+	assert.Equal(t, models.AchievementMini, *monday.AchievementLevel)
+
+	// The week's total reaches 90 minutes on Tuesday
+	tuesday, err := logHabit(schema, habit, quicklog.Request{Value: "40m", HasValue: true}, entriesFile, localDay(t, "2025-07-15"))
+	require.NoError(t, err)
+	assert.Equal(t, models.AchievementMaxi, *tuesday.AchievementLevel)
+}
+
+func TestPrintLogged(t *testing.T) {
+	level := models.AchievementMidi
+	habit := &models.Habit{ID: "exercise", HabitType: models.ElasticHabit}
+	entry := &models.HabitEntry{HabitID: "exercise", Value: "45m", Status: models.EntryCompleted, AchievementLevel: &level}
+
+	var buf bytes.Buffer
+	printLogged(&buf, habit, entry, time.Date(2025, 7, 14, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, "exercise: completed (45m) [midi] on 2025-07-14\n", buf.String())
+}
+
+// localDay parses a YYYY-MM-DD date as parseEntryDate does: midnight, local time.
+func localDay(t *testing.T, date string) time.Time {
+	t.Helper()
+	day, err := parseEntryDate(date, time.Now())
+	require.NoError(t, err)
+	return day
+}
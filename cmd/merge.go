@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/davidlee/vice/internal/models"
+	"github.com/davidlee/vice/internal/safefile"
+	"github.com/davidlee/vice/internal/storage"
+	"github.com/davidlee/vice/internal/ui"
+)
+
+var (
+	mergeInteractive bool // choose a side for each conflict
+	mergeDryRun      bool // report without saving
+)
+
+// mergeCmd represents the merge command
+// AIDEV-NOTE: merge-cmd; two-way merge via storage.MergeEntries, the same merge the git driver (vice sync) uses
+var mergeCmd = &cobra.Command{
+	Use:   "merge <theirs.yml>",
+	Short: "Merge another copy of entries.yml into this context's entries",
+	Long: `Merge another copy of entries.yml, such as one from another machine, into the
+current context's entries.
+
+Days are combined by date and each day's entries by habit: entries only the
+other copy has are added, and nothing of ours is dropped. When both copies have
+a different entry for the same habit and day, the most recently edited one
+(updated_at, else created_at) is kept, or with --interactive you choose.
+
+The merged entries are validated, then saved with a backup of entries.yml.
+Use --dry-run to see the report without saving.
+
+Examples:
+  vice merge ~/sync/laptop/entries.yml --dry-run
+  vice merge ~/sync/laptop/entries.yml
+  vice merge laptop-entries.yml --interactive`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMerge,
+}
+
+func init() {
+	rootCmd.AddCommand(mergeCmd)
+	mergeCmd.Flags().BoolVarP(&mergeInteractive, "interactive", "i", false, "Choose which side to keep for each conflict")
+	mergeCmd.Flags().BoolVar(&mergeDryRun, "dry-run", false, "Show what would change without saving")
+}
+
+func runMerge(cmd *cobra.Command, args []string) error {
+	env := GetViceEnv()
+
+	resolve := storage.KeepLatest
+	if mergeInteractive {
+		resolve = ui.ResolveMergeConflict
+	}
+	return mergeEntriesFile(cmd.OutOrStdout(), env.GetHabitsFile(), env.GetEntriesFile(), args[0], resolve, mergeDryRun)
+}
+
+// mergeEntriesFile merges theirsFile into entriesFile, printing the report.
+// Conflicts are resolved before the entries file is locked, since an
+// interactive resolver waits on the user; the merge is saved only if the file
+// is still the version it was merged against, so a concurrent vice write is
+// never lost.
+func mergeEntriesFile(w io.Writer, habitsFile, entriesFile, theirsFile string, resolve storage.ConflictResolver, dryRun bool) error {
+	entryStorage := storage.NewEntryStorage()
+
+	if _, err := os.Stat(theirsFile); err != nil {
+		return fmt.Errorf("failed to read %s: %w", theirsFile, err)
+	}
+	theirs, err := entryStorage.LoadFromFile(theirsFile)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", theirsFile, err)
+	}
+
+	data, version, err := safefile.ReadFile(entriesFile)
+	if err != nil {
+		return err
+	}
+	ours := models.CreateEmptyEntryLog()
+	if version.Exists {
+		if ours, err = entryStorage.ParseYAML(data); err != nil {
+			return fmt.Errorf("failed to load existing entries: %w", err)
+		}
+	}
+
+	merged, report, err := storage.MergeEntries(ours, theirs, resolve)
+	if err != nil {
+		return fmt.Errorf("failed to merge entries: %w", err)
+	}
+	if err := merged.Validate(); err != nil {
+		return fmt.Errorf("merged entries are invalid, nothing saved: %w", err)
+	}
+	if dryRun || !mergeChangesOurs(report) {
+		writeMergeReport(w, report)
+		return nil
+	}
+
+	// Derived habits are recomputed from the merged entries
+	if err := recomputeDerivedOnSave(entryStorage, habitsFile); err != nil {
+		return err
+	}
+	_, err = entryStorage.ModifyWithBackup(entriesFile, storage.DefaultBackupConfig(), func(current *models.EntryLog) error {
+		unchanged, err := safefile.Unchanged(entriesFile, version)
+		if err != nil {
+			return err
+		}
+		if !unchanged {
+			return fmt.Errorf("entries changed on disk during the merge; nothing was saved, run the command again: %w",
+				&safefile.ConflictError{Path: entriesFile})
+		}
+		*current = *merged
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to merge entries: %w", err)
+	}
+
+	writeMergeReport(w, report)
+	return nil
+}
+
+// mergeChangesOurs reports whether the merge changed anything of ours.
+func mergeChangesOurs(report *storage.MergeReport) bool {
+	if len(report.FromTheirs) > 0 {
+		return true
+	}
+	for _, conflict := range report.Conflicts {
+		if conflict.Kept == storage.KeepTheirs {
+			return true
+		}
+	}
+	return false
+}
+
+// writeMergeReport lists what a merge took from theirs and how conflicts were
+// settled, followed by a summary line.
+func writeMergeReport(w io.Writer, report *storage.MergeReport) {
+	for _, change := range report.FromTheirs {
+		_, _ = fmt.Fprintf(w, "+ %s %s %s\n", change.Date, change.HabitID, ui.DescribeMergeEntry(change.Entry))
+	}
+
+	keptTheirs := 0
+	for _, conflict := range report.Conflicts {
+		if conflict.Kept == storage.KeepTheirs {
+			keptTheirs++
+		}
+		_, _ = fmt.Fprintf(w, "! %s %s ours %s; theirs %s; kept %s\n", conflict.Date, conflict.HabitID,
+			ui.DescribeMergeEntry(conflict.Ours), ui.DescribeMergeEntry(conflict.Theirs), conflict.Kept)
+	}
+
+	_, _ = fmt.Fprintf(w, "%d added, %d conflicts (%d resolved to theirs), %d unchanged\n",
+		len(report.FromTheirs), len(report.Conflicts), keptTheirs, report.Kept)
+}
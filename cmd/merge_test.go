@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidlee/vice/internal/safefile"
+	"github.com/davidlee/vice/internal/storage"
+)
+
+func TestMergeEntriesFile(t *testing.T) {
+	dir := t.TempDir()
+	habitsFile := filepath.Join(dir, "habits.yml")
+	entriesFile := filepath.Join(dir, "entries.yml")
+	theirsFile := filepath.Join(dir, "laptop.yml")
+
+	ours := `version: "1.0.0"
+entries:
+  - date: "2025-07-16"
+    habits:
+      - habit_id: meditation
+        value: true
+        status: completed
+        created_at: 2025-07-16T08:00:00Z
+      - habit_id: reading
+        value: 20m
+        status: completed
+        created_at: 2025-07-16T08:00:00Z
+`
+	theirs := `version: "1.0.0"
+entries:
+  - date: "2025-07-15"
+    habits:
+      - habit_id: walk
+        value: true
+        status: completed
+        created_at: 2025-07-15T18:00:00Z
+  - date: "2025-07-16"
+    habits:
+      - habit_id: reading
+        value: 45m
+        status: completed
+        created_at: 2025-07-16T08:00:00Z
+        updated_at: 2025-07-16T21:00:00Z
+`
+	reset := func() {
+		require.NoError(t, os.WriteFile(entriesFile, []byte(ours), 0o600))
+		require.NoError(t, os.WriteFile(theirsFile, []byte(theirs), 0o600))
+		_ = os.Remove(entriesFile + ".backup")
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		reset()
+		var out bytes.Buffer
+		require.NoError(t, mergeEntriesFile(&out, habitsFile, entriesFile, theirsFile, storage.KeepLatest, true))
+
+		assert.Contains(t, out.String(), "+ 2025-07-15 walk completed true")
+		assert.Contains(t, out.String(), "! 2025-07-16 reading ours completed 20m")
+		assert.Contains(t, out.String(), "kept theirs")
+		assert.Contains(t, out.String(), "1 added, 1 conflicts (1 resolved to theirs), 1 unchanged")
+
+		data, err := os.ReadFile(entriesFile) // #nosec G304 -- test file in temp dir
+		require.NoError(t, err)
+		assert.Equal(t, ours, string(data))
+	})
+
+	t.Run("merge with backup", func(t *testing.T) {
+		reset()
+		require.NoError(t, mergeEntriesFile(&bytes.Buffer{}, habitsFile, entriesFile, theirsFile, storage.KeepLatest, false))
+
+		merged, err := storage.NewEntryStorage().LoadFromFile(entriesFile)
+		require.NoError(t, err)
+		require.Len(t, merged.Entries, 2)
+		assert.Equal(t, "2025-07-15", merged.Entries[0].Date)
+		assert.Equal(t, "45m", merged.Entries[1].Habits[1].Value)
+		assert.FileExists(t, entriesFile+".backup")
+	})
+
+	t.Run("resolver keeps ours", func(t *testing.T) {
+		reset()
+		keepOurs := func(*storage.MergeConflict) (string, error) { return storage.KeepOurs, nil }
+		require.NoError(t, mergeEntriesFile(&bytes.Buffer{}, habitsFile, entriesFile, theirsFile, keepOurs, false))
+
+		merged, err := storage.NewEntryStorage().LoadFromFile(entriesFile)
+		require.NoError(t, err)
+		assert.Equal(t, "20m", merged.Entries[1].Habits[1].Value)
+	})
+
+	t.Run("resolves conflicts without holding the entries lock", func(t *testing.T) {
+		reset()
+		timeout := safefile.LockTimeout
+		safefile.LockTimeout = 50 * time.Millisecond
+		defer func() { safefile.LockTimeout = timeout }()
+
+		// Other vice commands can write while the user picks a side
+		var lockErr error
+		resolve := func(conflict *storage.MergeConflict) (string, error) {
+			if unlock, err := safefile.Lock(entriesFile); err == nil {
+				unlock()
+			} else {
+				lockErr = err
+			}
+			return storage.KeepLatest(conflict)
+		}
+		require.NoError(t, mergeEntriesFile(&bytes.Buffer{}, habitsFile, entriesFile, theirsFile, resolve, false))
+		assert.NoError(t, lockErr)
+	})
+
+	t.Run("concurrent write aborts the merge", func(t *testing.T) {
+		reset()
+		concurrent := strings.Replace(ours, "20m", "30m", 1)
+		resolve := func(conflict *storage.MergeConflict) (string, error) {
+			require.NoError(t, safefile.WriteFile(entriesFile, []byte(concurrent), 0o600))
+			return storage.KeepLatest(conflict)
+		}
+		var out bytes.Buffer
+		err := mergeEntriesFile(&out, habitsFile, entriesFile, theirsFile, resolve, false)
+		require.ErrorIs(t, err, safefile.ErrConflict)
+		assert.ErrorContains(t, err, "nothing was saved")
+		assert.Empty(t, out.String(), "no report for a merge that wasn't saved")
+
+		data, err := os.ReadFile(entriesFile) // #nosec G304 -- test file in temp dir
+		require.NoError(t, err)
+		assert.Equal(t, concurrent, string(data))
+		assert.NoFileExists(t, entriesFile+".backup")
+	})
+
+	t.Run("nothing new", func(t *testing.T) {
+		reset()
+		var out bytes.Buffer
+		require.NoError(t, mergeEntriesFile(&out, habitsFile, entriesFile, entriesFile, storage.KeepLatest, false))
+		assert.Equal(t, "0 added, 0 conflicts (0 resolved to theirs), 2 unchanged\n", out.String())
+		assert.NoFileExists(t, entriesFile+".backup")
+	})
+
+	t.Run("missing or invalid file", func(t *testing.T) {
+		reset()
+		err := mergeEntriesFile(&bytes.Buffer{}, habitsFile, entriesFile, filepath.Join(dir, "nope.yml"), storage.KeepLatest, false)
+		assert.ErrorContains(t, err, "failed to read")
+
+		require.NoError(t, os.WriteFile(theirsFile, []byte("version: 1.0.0\nentries:\n  - date: yesterday\n    habits: []\n"), 0o600))
+		err = mergeEntriesFile(&bytes.Buffer{}, habitsFile, entriesFile, theirsFile, storage.KeepLatest, false)
+		assert.ErrorContains(t, err, "failed to load")
+	})
+}
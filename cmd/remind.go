@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/davidlee/vice/internal/config"
+	"github.com/davidlee/vice/internal/models"
+	"github.com/davidlee/vice/internal/parser"
+	"github.com/davidlee/vice/internal/remind"
+	"github.com/davidlee/vice/internal/storage"
+)
+
+var (
+	remindOnce     bool          // check once and exit instead of running as a daemon
+	remindSince    time.Duration // one-shot: how far back to look (0 = since midnight)
+	remindInterval time.Duration // daemon: time between checks
+	remindNotifier string        // overrides [remind] notifier from config.toml
+)
+
+// remindCmd represents the remind command
+// AIDEV-NOTE: remind-cmd; habits set times under reminders:, delivery under [remind] in config.toml; logic in internal/remind
+var remindCmd = &cobra.Command{
+	Use:   "remind",
+	Short: "Send reminders and nudges for incomplete habits",
+	Long: `Send reminders for habits that are due today and still incomplete.
+
+Habits opt in with a reminders section in habits.yml:
+  reminders:
+    at: ["08:00", "18:00"]   # remind at these times
+    nudge_by: "21:00"        # nudge if still incomplete by then
+    message: "Sit for 10"    # optional notification text
+
+A habit counts as done once it has a completed or skipped entry for the day.
+
+By default vice remind runs until interrupted, checking every --interval and
+re-reading entries each time. With --once it delivers everything due since
+midnight (or within --since) and exits, for use from cron.
+
+Notifications go to stdout unless config.toml selects another notifier:
+  [remind]
+  notifier = "desktop"             # runs: <command> <title> <message>
+  command = "notify-send -u low"   # default: notify-send
+
+  [remind]
+  notifier = "hook"                # runs the hook with sh -c, given
+  hook = "~/bin/on-reminder"       # VICE_HABIT_ID, VICE_HABIT_TITLE, VICE_MESSAGE,
+                                   # VICE_REMINDER_KIND and VICE_REMINDER_TIME
+
+Examples:
+  vice remind                         # Run as a daemon
+  vice remind --once                  # Everything still due today
+  vice remind --once --since 15m      # From cron, every 15 minutes
+  vice remind --notifier desktop      # Override the configured notifier`,
+	Args: cobra.NoArgs,
+	RunE: runRemind,
+}
+
+func init() {
+	rootCmd.AddCommand(remindCmd)
+	remindCmd.Flags().BoolVar(&remindOnce, "once", false, "Check once and exit")
+	remindCmd.Flags().DurationVar(&remindSince, "since", 0, "With --once, how far back to look (default: since midnight)")
+	remindCmd.Flags().DurationVar(&remindInterval, "interval", time.Minute, "Time between checks when running as a daemon")
+	remindCmd.Flags().StringVar(&remindNotifier, "notifier", "", "Notifier to use (stdout, desktop, hook)")
+}
+
+func runRemind(cmd *cobra.Command, _ []string) error {
+	env := GetViceEnv()
+
+	cfg := env.Remind
+	if remindNotifier != "" {
+		cfg.Notifier = remindNotifier
+	}
+	notifier, err := remind.NewNotifier(cfg, cmd.OutOrStdout())
+	if err != nil {
+		return err
+	}
+
+	clock := remind.SystemClock{}
+	reminder := remind.NewReminder(reminderLoader(env), notifier, clock)
+	now := clock.Now()
+
+	if remindOnce {
+		_, err := reminder.Check(remindWindowStart(now, remindSince), now)
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return reminder.Run(ctx, now, remindInterval, cmd.ErrOrStderr())
+}
+
+// reminderLoader reads the context's habits and entries afresh on each call.
+func reminderLoader(env *config.ViceEnv) remind.Loader {
+	return func() (*models.Schema, *models.EntryLog, error) {
+		schema, err := parser.NewHabitParser().LoadFromFile(env.GetHabitsFile())
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load habits: %w", err)
+		}
+		entryLog, err := storage.NewEntryStorage().LoadFromFile(env.GetEntriesFile())
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load entries: %w", err)
+		}
+		return schema, entryLog, nil
+	}
+}
+
+// remindWindowStart is where a one-shot check starts looking: since ago, or midnight.
+func remindWindowStart(now time.Time, since time.Duration) time.Time {
+	if since > 0 {
+		return now.Add(-since)
+	}
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidlee/vice/internal/config"
+	"github.com/davidlee/vice/internal/remind"
+)
+
+func TestRemindWindowStart(t *testing.T) {
+	now := time.Date(2025, 7, 16, 14, 20, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2025, 7, 16, 0, 0, 0, 0, time.UTC), remindWindowStart(now, 0))
+	assert.Equal(t, time.Date(2025, 7, 16, 14, 5, 0, 0, time.UTC), remindWindowStart(now, 15*time.Minute))
+}
+
+func TestReminderLoader(t *testing.T) {
+	env := &config.ViceEnv{ContextData: t.TempDir()}
+	require.NoError(t, os.WriteFile(env.GetHabitsFile(), []byte(`version: "1.0.0"
+habits:
+  - title: Meditation
+    habit_type: simple
+    field_type:
+      type: boolean
+    scoring_type: manual
+    reminders:
+      at: ["08:00"]
+      nudge_by: "21:00"
+`), 0o600))
+	require.NoError(t, os.WriteFile(env.GetEntriesFile(), []byte(`version: "1.0.0"
+entries:
+  - date: "2025-07-17"
+    habits:
+      - habit_id: meditation
+        value: true
+        status: completed
+        created_at: 2025-07-17T08:00:00Z
+`), 0o600))
+
+	var out bytes.Buffer
+	notifier, err := remind.NewNotifier(config.RemindConfig{}, &out)
+	require.NoError(t, err)
+	reminder := remind.NewReminder(reminderLoader(env), notifier, remind.SystemClock{})
+
+	// Incomplete on the 16th, done on the 17th
+	day := time.Date(2025, 7, 16, 0, 0, 0, 0, time.Local)
+	delivered, err := reminder.Check(day, day.AddDate(0, 0, 2))
+	require.NoError(t, err)
+	require.Len(t, delivered, 2)
+	assert.Equal(t, "08:00 Meditation: Time for Meditation\n21:00 Meditation: Meditation is still incomplete today\n", out.String())
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -13,9 +14,11 @@ import (
 
 	"github.com/davidlee/vice/internal/config"
 	"github.com/davidlee/vice/internal/debug"
+	"github.com/davidlee/vice/internal/gitsync"
 	init_pkg "github.com/davidlee/vice/internal/init"
 	"github.com/davidlee/vice/internal/models"
 	"github.com/davidlee/vice/internal/parser"
+	"github.com/davidlee/vice/internal/repository"
 	"github.com/davidlee/vice/internal/storage"
 	"github.com/davidlee/vice/internal/ui"
 	"github.com/davidlee/vice/internal/ui/entrymenu"
@@ -64,8 +67,9 @@ Examples:
   # Context management (persistent):
   vice context list                       # Show all available contexts
   vice context switch work                # Switch to work context (persists)`,
-	PersistentPreRunE: initializeViceEnv, // AIDEV-NOTE: Initializes interactive env - test cmd.Args() not cmd.Execute() to prevent hanging
-	RunE:              runDefaultCommand,
+	PersistentPreRunE:  initializeViceEnv, // AIDEV-NOTE: Initializes interactive env - test cmd.Args() not cmd.Execute() to prevent hanging
+	PersistentPostRunE: commitContextChanges,
+	RunE:               runDefaultCommand,
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -138,6 +142,24 @@ func initializeViceEnv(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
+// commitContextChanges auto-commits whatever a command changed in the context
+// directory, if the context has opted into git versioning (vice sync init).
+// The data is already saved, so a failed commit is only a warning.
+// AIDEV-NOTE: git-autocommit; the only commit point - catches every writer (log, entry menu, habit/list editors, repository saves)
+func commitContextChanges(cmd *cobra.Command, _ []string) error {
+	if viceEnv == nil {
+		return nil
+	}
+	repo := gitsync.NewRepo(viceEnv.ContextData)
+	if !repo.AutoCommitEnabled() {
+		return nil
+	}
+	if _, err := repo.CommitChanges("vice " + strings.Join(os.Args[1:], " ")); err != nil {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to commit changes to %s: %v\n", viceEnv.ContextData, err)
+	}
+	return nil
+}
+
 // GetViceEnv returns the resolved configuration environment.
 // This should be called after cobra command execution has started.
 // AIDEV-NOTE: T028-cmd-integration; replaced GetPaths() with GetViceEnv() for context support
@@ -174,12 +196,11 @@ func runDefaultCommand(_ *cobra.Command, _ []string) error {
 	}
 
 	// Launch entry menu as default behavior
-	return runEntryMenu(env)
+	return runEntryMenu(env, time.Now())
 }
 
-// runEntryMenu launches the interactive entry menu interface.
-// AIDEV-NOTE: entry-menu-integration; T018 command integration for --menu flag
-func runEntryMenu(env *config.ViceEnv) error {
+// runEntryMenu launches the interactive entry menu interface for date.
+func runEntryMenu(env *config.ViceEnv, date time.Time) error {
 	// Load habits
 	habitParser := parser.NewHabitParser()
 	schema, err := habitParser.LoadFromFile(env.GetHabitsFile())
@@ -191,54 +212,30 @@ func runEntryMenu(env *config.ViceEnv) error {
 		return fmt.Errorf("no habits found in %s", env.GetHabitsFile())
 	}
 
-	// Load existing entries for today
+	// Load existing entries; the menu picks out the requested day
 	entryStorage := storage.NewEntryStorage()
-	entries, err := loadTodayEntries(entryStorage, env.GetEntriesFile())
+	entryLog, err := entryStorage.LoadFromFile(env.GetEntriesFile())
 	if err != nil {
 		return fmt.Errorf("failed to load existing entries: %w", err)
 	}
 
 	// AIDEV-NOTE: T018/3.1-menu-launch; EntryCollector setup for menu integration
-	// Create and initialize entry collector for menu usage
+	// Create entry collector for menu usage; SetDate below converts the day's HabitEntry
+	// values to collector format via InitializeForMenu()
 	collector := ui.NewEntryCollector(env.GetChecklistsFile())
-	// CRITICAL: InitializeForMenu() must be called to convert HabitEntry format to collector format
-	collector.InitializeForMenu(schema.Habits, entries)
+	collector.SetSchema(schema)
+	collector.SetHistory(repository.NewFileRepository(env))
 
 	// AIDEV-NOTE: T018/3.2-auto-save; pass entriesFile path for automatic persistence
 	// Create and run entry menu with complete integration: collector + auto-save + return behavior
-	model := entrymenu.NewEntryMenuModel(schema.Habits, entries, collector, env.GetEntriesFile())
+	// Derived habits aren't entered by hand
+	model := entrymenu.NewEntryMenuModel(models.RecordableHabits(schema.Habits), map[string]models.HabitEntry{}, collector, env.GetEntriesFile())
+	if err := model.SetDate(date, entryLog); err != nil {
+		return err
+	}
 
 	program := tea.NewProgram(model, tea.WithAltScreen())
 	_, err = program.Run()
 
 	return err
 }
-
-// loadTodayEntries loads existing entries for today's date.
-func loadTodayEntries(entryStorage *storage.EntryStorage, entriesFile string) (map[string]models.HabitEntry, error) {
-	// Load entry log
-	entryLog, err := entryStorage.LoadFromFile(entriesFile)
-	if err != nil {
-		// If file doesn't exist, return empty entries
-		if os.IsNotExist(err) {
-			return make(map[string]models.HabitEntry), nil
-		}
-		return nil, err
-	}
-
-	// Find today's entries
-	today := time.Now().Format("2006-01-02")
-	for _, dayEntry := range entryLog.Entries {
-		if dayEntry.Date == today {
-			// Convert to map for easy lookup
-			entriesMap := make(map[string]models.HabitEntry)
-			for _, habitEntry := range dayEntry.Habits {
-				entriesMap[habitEntry.HabitID] = habitEntry
-			}
-			return entriesMap, nil
-		}
-	}
-
-	// No entries for today
-	return make(map[string]models.HabitEntry), nil
-}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/davidlee/vice/internal/models"
+	"github.com/davidlee/vice/internal/parser"
+	"github.com/davidlee/vice/internal/stats"
+	"github.com/davidlee/vice/internal/storage"
+)
+
+var statsFormat string // output format: table, json
+
+// statsCmd represents the stats command
+// AIDEV-NOTE: stats-cmd; thin wrapper over stats.Calculator, which owns streak/rate semantics
+var statsCmd = &cobra.Command{
+	Use:   "stats [habit-id]",
+	Short: "Show habit streaks and completion rates",
+	Long: `Show streaks, completion rates and outcome totals for each habit.
+
+For every habit (or just the one given):
+  Streak       Current and longest run of completed days
+  7d/30d/90d   Completed share of days the habit was due
+  Skip/Fail    Skipped and failed entries; Miss counts due days with no entry
+
+Skipped days and days a scheduled habit isn't due neither extend nor break a
+streak. Today doesn't break a streak until an entry is recorded. Elastic
+habits also show how often each achievement level was reached.
+
+Examples:
+  vice stats                   # Stats for all habits
+  vice stats meditation        # Stats for one habit
+  vice stats --format json     # Machine-readable output`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runStats,
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+	statsCmd.Flags().StringVar(&statsFormat, "format", "table", "output format (table, json)")
+}
+
+func runStats(_ *cobra.Command, args []string) error {
+	env := GetViceEnv()
+
+	schema, err := parser.NewHabitParser().LoadFromFile(env.GetHabitsFile())
+	if err != nil {
+		return fmt.Errorf("failed to load habits: %w", err)
+	}
+
+	entryLog, err := storage.NewEntryStorage().LoadFromFile(env.GetEntriesFile())
+	if err != nil {
+		return fmt.Errorf("failed to load entries: %w", err)
+	}
+
+	habits := schema.Habits
+	if len(args) == 1 {
+		habits = nil
+		for _, habit := range schema.Habits {
+			if habit.ID == args[0] {
+				habits = []models.Habit{habit}
+				break
+			}
+		}
+		if habits == nil {
+			return fmt.Errorf("habit not found: %s", args[0])
+		}
+	}
+
+	habitStats, err := stats.NewCalculator(time.Now()).Compute(habits, entryLog)
+	if err != nil {
+		return fmt.Errorf("failed to compute stats: %w", err)
+	}
+
+	return outputStats(os.Stdout, habitStats, statsFormat)
+}
+
+// outputStats writes habit statistics in the specified format
+func outputStats(w io.Writer, habitStats []stats.HabitStats, format string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(habitStats); err != nil {
+			return fmt.Errorf("failed to encode stats: %w", err)
+		}
+	case "table":
+		writeStatsTable(w, habitStats)
+	default:
+		return fmt.Errorf("invalid format: %s (valid: table, json)", format)
+	}
+	return nil
+}
+
+// writeStatsTable writes a plain text table followed by elastic level breakdowns
+func writeStatsTable(w io.Writer, habitStats []stats.HabitStats) {
+	if len(habitStats) == 0 {
+		_, _ = fmt.Fprintln(w, "No habits configured")
+		return
+	}
+
+	header := fmt.Sprintf("%-30s %-9s", "Habit", "Streak")
+	for _, rate := range habitStats[0].Rates {
+		header += fmt.Sprintf(" %6s", fmt.Sprintf("%dd", rate.Days))
+	}
+	header += fmt.Sprintf(" %5s %5s %5s", "Skip", "Fail", "Miss")
+	_, _ = fmt.Fprintln(w, header)
+	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(header)))
+
+	for _, habit := range habitStats {
+		row := fmt.Sprintf("%-30s %-9s", truncate(habitLabel(habit), 30), fmt.Sprintf("%d (%d)", habit.CurrentStreak, habit.LongestStreak))
+		for _, rate := range habit.Rates {
+			row += fmt.Sprintf(" %6s", formatRate(rate))
+		}
+		row += fmt.Sprintf(" %5d %5d %5d", habit.Skipped, habit.Failed, habit.Missed)
+		_, _ = fmt.Fprintln(w, row)
+	}
+
+	_, _ = fmt.Fprintln(w, "\nStreak shows current (longest).")
+
+	for _, habit := range habitStats {
+		if habit.Levels == nil {
+			continue
+		}
+		levels := make([]string, 0, 4)
+		for _, level := range []models.AchievementLevel{models.AchievementNone, models.AchievementMini, models.AchievementMidi, models.AchievementMaxi} {
+			levels = append(levels, fmt.Sprintf("%s %d", level, habit.Levels[level]))
+		}
+		_, _ = fmt.Fprintf(w, "%s levels: %s\n", habitLabel(habit), strings.Join(levels, ", "))
+	}
+}
+
+func habitLabel(habit stats.HabitStats) string {
+	if habit.Title != "" {
+		return habit.Title
+	}
+	return habit.HabitID
+}
+
+// formatRate renders a completion rate, or "-" when nothing was due in the window
+func formatRate(rate stats.CompletionRate) string {
+	if rate.Due == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", rate.Rate)
+}
+
+// truncate shortens s to at most maxLen characters, counting runes so
+// multibyte titles aren't cut mid-character.
+func truncate(s string, maxLen int) string {
+	runes := []rune(s)
+	if len(runes) <= maxLen {
+		return s
+	}
+	return string(runes[:maxLen-3]) + "..."
+}
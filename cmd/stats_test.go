@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidlee/vice/internal/models"
+	"github.com/davidlee/vice/internal/stats"
+)
+
+func TestOutputStats(t *testing.T) {
+	habitStats := []stats.HabitStats{
+		{
+			HabitID:       "walk",
+			Title:         "Morning Walk",
+			CurrentStreak: 2,
+			LongestStreak: 5,
+			Rates:         []stats.CompletionRate{{Days: 7, Completed: 4, Due: 5, Rate: 80}, {Days: 30}},
+			Skipped:       1,
+		},
+		{
+			HabitID:   "run",
+			HabitType: models.ElasticHabit,
+			Rates:     []stats.CompletionRate{{Days: 7}, {Days: 30}},
+			Levels:    map[models.AchievementLevel]int{models.AchievementMidi: 3},
+		},
+	}
+
+	t.Run("table", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, outputStats(&buf, habitStats, "table"))
+
+		output := buf.String()
+		assert.Contains(t, output, "7d")
+		assert.Contains(t, output, "30d")
+		assert.Contains(t, output, "Morning Walk")
+		assert.Contains(t, output, "2 (5)")
+		assert.Contains(t, output, "80%")
+		assert.Contains(t, output, "run levels: none 0, mini 0, midi 3, maxi 0")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, outputStats(&buf, habitStats, "json"))
+
+		var decoded []stats.HabitStats
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		assert.Equal(t, habitStats, decoded)
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		err := outputStats(&bytes.Buffer{}, habitStats, "xml")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid format")
+	})
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "short", truncate("short", 10))
+	assert.Equal(t, "Méditat...", truncate("Méditation matinale", 10))
+	assert.Equal(t, "日本語の...", truncate("日本語の勉強をする", 7))
+}
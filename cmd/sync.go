@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/davidlee/vice/internal/gitsync"
+)
+
+var syncRemote string // remote to pull from and push to
+
+// syncCmd represents the sync command
+// AIDEV-NOTE: git-sync-cmd; opt-in per context via `vice sync init`; git logic in internal/gitsync
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync the current context's data with a git remote",
+	Long: `Commit any pending changes in the current context's data directory, rebase
+onto the remote's copy of the branch and push.
+
+Git versioning is opt-in per context: run 'vice sync init' first. Once enabled,
+every command that changes habits, entries or checklists commits the change.
+entries.yml is merged by date and habit_id, so different habits logged on the
+same day on two machines never conflict; when both changed the same entry the
+most recently modified one is kept.
+
+Examples:
+  vice sync init git@example.com:me/vice-personal.git  # Opt in, set the remote
+  vice sync                                             # Commit, pull --rebase, push
+  vice sync --remote backup                             # Use another remote
+  vice --context work sync                              # Sync the work context`,
+	Args: cobra.NoArgs,
+	RunE: runSync,
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+	syncCmd.Flags().StringVar(&syncRemote, "remote", "origin", "Remote to pull from and push to")
+}
+
+func runSync(cmd *cobra.Command, _ []string) error {
+	env := GetViceEnv()
+
+	result, err := gitsync.NewRepo(env.ContextData).Sync(syncRemote)
+	if err != nil {
+		return fmt.Errorf("failed to sync context %s: %w", env.Context, err)
+	}
+
+	if result.Committed {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Committed local changes")
+	}
+	if result.Pulled {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Rebased onto %s/%s\n", syncRemote, result.Branch)
+	}
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Pushed %s to %s\n", result.Branch, syncRemote)
+	return nil
+}
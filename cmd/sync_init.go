@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/davidlee/vice/internal/gitsync"
+)
+
+// syncInitCmd represents the sync init command
+var syncInitCmd = &cobra.Command{
+	Use:   "init [remote-url]",
+	Short: "Start versioning the current context's data with git",
+	Long: `Make the current context's data directory a git repository, or adopt an
+existing one: ignore vice's temporary and cache files, register the entries.yml
+merge driver, turn on auto-commits and commit the current data. With a URL,
+also set it as the remote (see --remote).
+
+Run it again after moving the vice binary, so git can find the merge driver.
+
+Examples:
+  vice sync init                                       # Local history only
+  vice sync init git@example.com:me/vice-personal.git  # With a remote`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSyncInit,
+}
+
+func init() {
+	syncCmd.AddCommand(syncInitCmd)
+}
+
+func runSyncInit(cmd *cobra.Command, args []string) error {
+	env := GetViceEnv()
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the vice executable: %w", err)
+	}
+
+	repo := gitsync.NewRepo(env.ContextData)
+	if err := repo.Init(executable); err != nil {
+		return fmt.Errorf("failed to initialize git in %s: %w", env.ContextData, err)
+	}
+	if len(args) == 1 {
+		if err := repo.SetRemote(syncRemote, args[0]); err != nil {
+			return fmt.Errorf("failed to set remote %s: %w", syncRemote, err)
+		}
+	}
+
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Versioning context %s in %s\n", env.Context, env.ContextData)
+	return nil
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/davidlee/vice/internal/gitsync"
+	"github.com/davidlee/vice/internal/models"
+	"github.com/davidlee/vice/internal/parser"
+)
+
+// syncMergeDriverCmd is the git merge driver for entries.yml, registered by
+// `vice sync init`. It runs inside git merges and rebases, so it skips the
+// root command's environment setup and auto-commit.
+var syncMergeDriverCmd = &cobra.Command{
+	Use:    "merge-driver <base> <ours> <theirs> [path]",
+	Short:  "Git merge driver for entries.yml",
+	Hidden: true,
+	Args:   cobra.RangeArgs(3, 4),
+	PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
+		return nil
+	},
+	PersistentPostRunE: func(_ *cobra.Command, _ []string) error {
+		return nil
+	},
+	RunE: runSyncMergeDriver,
+}
+
+func init() {
+	syncCmd.AddCommand(syncMergeDriverCmd)
+}
+
+func runSyncMergeDriver(cmd *cobra.Command, args []string) error {
+	// git runs the driver from the top of the worktree and passes the merged
+	// file's path as %P; drivers registered before %P was added omit it
+	habitsFile := "habits.yml"
+	if len(args) == 4 {
+		habitsFile = filepath.Join(filepath.Dir(args[3]), "habits.yml")
+	}
+	// IDs are not persisted: the merge must write nothing but the merged entries
+	var schema *models.Schema
+	if _, err := os.Stat(habitsFile); err == nil {
+		loaded, err := parser.NewHabitParser().LoadFromFileWithIDPersistence(habitsFile, false)
+		if err != nil {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "vice: not recomputing derived habits: %v\n", err)
+		} else {
+			schema = loaded
+		}
+	}
+
+	conflicts, err := gitsync.MergeEntriesFiles(args[0], args[1], args[2], schema)
+	if err != nil {
+		return err
+	}
+
+	for _, conflict := range conflicts {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "vice: %s %s changed on both sides, kept %s\n",
+			conflict.Date, conflict.HabitID, conflict.Kept)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidlee/vice/internal/config"
+	"github.com/davidlee/vice/internal/gitsync"
+)
+
+func TestCommitContextChanges(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "vice")
+	t.Setenv("GIT_AUTHOR_EMAIL", "vice@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "vice")
+	t.Setenv("GIT_COMMITTER_EMAIL", "vice@example.com")
+
+	saved := viceEnv
+	defer func() { viceEnv = saved }()
+	viceEnv = &config.ViceEnv{Context: "personal", ContextData: t.TempDir()}
+	repo := gitsync.NewRepo(viceEnv.ContextData)
+
+	// Not opted in: nothing happens
+	require.NoError(t, os.WriteFile(viceEnv.GetEntriesFile(), []byte("version: 1.0.0\nentries: []\n"), 0o600))
+	require.NoError(t, commitContextChanges(syncCmd, nil))
+	assert.False(t, repo.IsRepo())
+
+	require.NoError(t, repo.Init("vice"))
+	require.NoError(t, os.WriteFile(viceEnv.GetGoalsFile(), []byte("version: 1.0.0\ngoals: []\n"), 0o600))
+
+	var stderr bytes.Buffer
+	syncCmd.SetErr(&stderr)
+	defer syncCmd.SetErr(nil)
+	require.NoError(t, commitContextChanges(syncCmd, nil))
+	assert.Empty(t, stderr.String())
+
+	files, err := repo.ChangedFiles()
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestRunSyncMergeDriver(t *testing.T) {
+	dir := t.TempDir()
+	entry := func(value string, hour string) string {
+		return "version: 1.0.0\nentries:\n  - date: \"2025-07-16\"\n    habits:\n      - habit_id: reading\n        value: " +
+			value + "\n        created_at: 2025-07-16T" + hour + ":00:00Z\n        status: completed\n"
+	}
+	paths := []string{filepath.Join(dir, "base"), filepath.Join(dir, "ours"), filepath.Join(dir, "theirs")}
+	require.NoError(t, os.WriteFile(paths[0], nil, 0o600))
+	require.NoError(t, os.WriteFile(paths[1], []byte(entry("20m", "08")), 0o600))
+	require.NoError(t, os.WriteFile(paths[2], []byte(entry("45m", "20")), 0o600))
+
+	var stderr bytes.Buffer
+	syncMergeDriverCmd.SetErr(&stderr)
+	defer syncMergeDriverCmd.SetErr(nil)
+	require.NoError(t, runSyncMergeDriver(syncMergeDriverCmd, paths))
+
+	assert.Equal(t, "vice: 2025-07-16 reading changed on both sides, kept theirs\n", stderr.String())
+	data, err := os.ReadFile(paths[1])
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "value: 45m")
+}
@@ -16,6 +16,11 @@ Shows each habit with its current status:
   ✓ Completed
   ○ Pending  
   ⤫ Skipped
+  ◎ Target met for this period (scheduled habits)
+  · Not due today (scheduled habits)
+
+Goals from goals.yml, if any, are listed below the table with their progress
+and whether they're on track (see 'vice goals').
 
 Examples:
   vice todo                    # Show today's status table (bubbles)
@@ -26,7 +26,9 @@ type ViceEnv struct {
 	ContextData string // computed path: $DataDir/$Context
 
 	// Configuration settings (loaded from config.toml or defaults)
-	Contexts []string // available contexts from config.toml [core] section
+	Contexts []string      // available contexts from config.toml [core] section
+	Flotsam  FlotsamConfig // flotsam settings from config.toml [flotsam] section
+	Remind   RemindConfig  // reminder delivery from config.toml [remind] section
 
 	// Tool integrations
 	ZK *zk.ZKExecutable // ZK tool integration (nil if unavailable)
@@ -197,6 +199,11 @@ func (env *ViceEnv) GetChecklistEntriesFile() string {
 	return filepath.Join(env.ContextData, "checklist_entries.yml")
 }
 
+// GetGoalsFile returns the context-aware path to goals.yml.
+func (env *ViceEnv) GetGoalsFile() string {
+	return filepath.Join(env.ContextData, "goals.yml")
+}
+
 // GetFlotsamDir returns the context-aware path to the flotsam directory.
 // AIDEV-NOTE: T027/3.2-flotsam-paths; context-aware flotsam directory for markdown note storage
 // AIDEV-NOTE: path-pattern-flotsam; follows same pattern as GetHabitsFile/GetEntriesFile for context isolation
@@ -204,6 +211,11 @@ func (env *ViceEnv) GetFlotsamDir() string {
 	return filepath.Join(env.ContextData, "flotsam")
 }
 
+// GetTemplatesDir returns the context-aware path to the flotsam note templates directory.
+func (env *ViceEnv) GetTemplatesDir() string {
+	return filepath.Join(env.ContextData, "templates")
+}
+
 // GetFlotsamCacheDB returns the context-aware path to the flotsam SQLite cache database.
 // AIDEV-NOTE: T027/3.2-flotsam-cache; ADR-004 SQLite cache strategy for performance
 // AIDEV-NOTE: cache-db-future; will be used for SRS performance cache when Phase 4 (Core Operations) is implemented
@@ -211,6 +223,12 @@ func (env *ViceEnv) GetFlotsamCacheDB() string {
 	return filepath.Join(env.ContextData, "flotsam.db")
 }
 
+// GetSRSAlgorithm returns the SRS algorithm configured for the active context.
+// An empty result selects the default scheduler (SM-2).
+func (env *ViceEnv) GetSRSAlgorithm() string {
+	return env.Flotsam.SRSAlgorithm(env.Context)
+}
+
 // resolveXDGDir resolves an XDG directory with the given priority:
 // 1. VICE_* environment variable (if set)
 // 2. XDG_* environment variable + app name (if set)
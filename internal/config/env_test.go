@@ -240,6 +240,7 @@ func TestViceEnvPaths(t *testing.T) {
 		{env.GetEntriesFile, "/test/data/personal/entries.yml"},
 		{env.GetChecklistsFile, "/test/data/personal/checklists.yml"},
 		{env.GetChecklistEntriesFile, "/test/data/personal/checklist_entries.yml"},
+		{env.GetGoalsFile, "/test/data/personal/goals.yml"},
 	}
 
 	for _, test := range tests {
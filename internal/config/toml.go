@@ -12,7 +12,9 @@ import (
 // AIDEV-NOTE: toml-config-structure; defines app settings (not user data)
 // AIDEV-NOTE: T028-toml-config; separation of concerns - config.toml for app settings, YAML for user data
 type Config struct {
-	Core CoreConfig `toml:"core"`
+	Core    CoreConfig    `toml:"core"`
+	Flotsam FlotsamConfig `toml:"flotsam,omitempty"`
+	Remind  RemindConfig  `toml:"remind,omitempty"`
 }
 
 // CoreConfig represents the [core] section of config.toml.
@@ -20,6 +22,43 @@ type CoreConfig struct {
 	Contexts []string `toml:"contexts"`
 }
 
+// FlotsamConfig represents the [flotsam] section of config.toml.
+// Per-context settings live in [flotsam.contexts.<name>] and override the section defaults.
+type FlotsamConfig struct {
+	Algorithm string                          `toml:"algorithm,omitempty"` // SRS scheduler: "sm2" (default) or "fsrs"
+	Contexts  map[string]FlotsamContextConfig `toml:"contexts,omitempty"`
+}
+
+// FlotsamContextConfig represents a [flotsam.contexts.<name>] section of config.toml.
+type FlotsamContextConfig struct {
+	Algorithm string `toml:"algorithm,omitempty"`
+}
+
+// RemindConfig represents the [remind] section of config.toml: how `vice remind`
+// delivers notifications.
+type RemindConfig struct {
+	Notifier string `toml:"notifier,omitempty"` // "stdout" (default), "desktop" or "hook"
+	Command  string `toml:"command,omitempty"`  // desktop: run as <command> <title> <message> (default notify-send)
+	Hook     string `toml:"hook,omitempty"`     // hook: shell command, given the notification in VICE_* variables
+}
+
+// remindNotifiers lists the notifier names accepted in config.toml.
+// AIDEV-NOTE: keep in sync with remind.NewNotifier
+var remindNotifiers = map[string]bool{"stdout": true, "desktop": true, "hook": true}
+
+// srsAlgorithms lists the scheduler names accepted in config.toml.
+// AIDEV-NOTE: keep in sync with flotsam.NewAlgorithm (config cannot import flotsam)
+var srsAlgorithms = map[string]bool{"sm2": true, "fsrs": true}
+
+// SRSAlgorithm returns the SRS algorithm configured for a context.
+// Returns "" when neither the context nor the [flotsam] section sets one.
+func (c FlotsamConfig) SRSAlgorithm(context string) string {
+	if ctx, ok := c.Contexts[context]; ok && ctx.Algorithm != "" {
+		return ctx.Algorithm
+	}
+	return c.Algorithm
+}
+
 // DefaultConfig returns the default configuration values.
 func DefaultConfig() *Config {
 	return &Config{
@@ -104,6 +143,24 @@ func validateConfig(config *Config) error {
 		seen[context] = true
 	}
 
+	// Validate flotsam SRS algorithm selection
+	if config.Flotsam.Algorithm != "" && !srsAlgorithms[config.Flotsam.Algorithm] {
+		return fmt.Errorf("unknown SRS algorithm in [flotsam]: %s", config.Flotsam.Algorithm)
+	}
+	for name, ctx := range config.Flotsam.Contexts {
+		if ctx.Algorithm != "" && !srsAlgorithms[ctx.Algorithm] {
+			return fmt.Errorf("unknown SRS algorithm in [flotsam.contexts.%s]: %s", name, ctx.Algorithm)
+		}
+	}
+
+	// Validate reminder delivery
+	if config.Remind.Notifier != "" && !remindNotifiers[config.Remind.Notifier] {
+		return fmt.Errorf("unknown notifier in [remind]: %s", config.Remind.Notifier)
+	}
+	if config.Remind.Notifier == "hook" && config.Remind.Hook == "" {
+		return fmt.Errorf("notifier \"hook\" in [remind] requires a hook command")
+	}
+
 	return nil
 }
 
@@ -120,6 +177,8 @@ func LoadViceEnvConfig(env *ViceEnv) error {
 
 	// Update ViceEnv with loaded configuration
 	env.Contexts = config.Core.Contexts
+	env.Flotsam = config.Flotsam
+	env.Remind = config.Remind
 
 	// If current context is not in the loaded contexts, use first context as default
 	contextValid := false
@@ -129,6 +129,52 @@ func TestValidateConfig(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "unknown flotsam algorithm",
+			config: &Config{
+				Core:    CoreConfig{Contexts: []string{"personal"}},
+				Flotsam: FlotsamConfig{Algorithm: "sm18"},
+			},
+			wantErr: true,
+			errMsg:  "unknown SRS algorithm in [flotsam]",
+		},
+		{
+			name: "unknown per-context flotsam algorithm",
+			config: &Config{
+				Core: CoreConfig{Contexts: []string{"personal"}},
+				Flotsam: FlotsamConfig{Contexts: map[string]FlotsamContextConfig{
+					"personal": {Algorithm: "leitner"},
+				}},
+			},
+			wantErr: true,
+			errMsg:  "unknown SRS algorithm in [flotsam.contexts.personal]",
+		},
+		{
+			name: "unknown notifier",
+			config: &Config{
+				Core:   CoreConfig{Contexts: []string{"personal"}},
+				Remind: RemindConfig{Notifier: "pager"},
+			},
+			wantErr: true,
+			errMsg:  "unknown notifier in [remind]: pager",
+		},
+		{
+			name: "hook notifier without hook",
+			config: &Config{
+				Core:   CoreConfig{Contexts: []string{"personal"}},
+				Remind: RemindConfig{Notifier: "hook"},
+			},
+			wantErr: true,
+			errMsg:  "requires a hook command",
+		},
+		{
+			name: "desktop notifier",
+			config: &Config{
+				Core:   CoreConfig{Contexts: []string{"personal"}},
+				Remind: RemindConfig{Notifier: "desktop", Command: "terminal-notifier"},
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -226,6 +272,49 @@ contexts = ["custom1", "custom2", "custom3"]
 	}
 }
 
+func TestLoadConfigFlotsamAlgorithm(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.toml")
+
+	content := `[core]
+contexts = ["personal", "work"]
+
+[flotsam]
+algorithm = "fsrs"
+
+[flotsam.contexts.work]
+algorithm = "sm2"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	config, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig() failed: %v", err)
+	}
+
+	if got := config.Flotsam.SRSAlgorithm("personal"); got != "fsrs" {
+		t.Errorf("SRSAlgorithm(personal) = %q, want %q", got, "fsrs")
+	}
+	if got := config.Flotsam.SRSAlgorithm("work"); got != "sm2" {
+		t.Errorf("SRSAlgorithm(work) = %q, want %q", got, "sm2")
+	}
+
+	env := &ViceEnv{ConfigDir: tempDir, Context: "personal", Contexts: []string{"personal"}}
+	if err := LoadViceEnvConfig(env); err != nil {
+		t.Fatalf("LoadViceEnvConfig() failed: %v", err)
+	}
+	if got := env.GetSRSAlgorithm(); got != "fsrs" {
+		t.Errorf("GetSRSAlgorithm() = %q, want %q", got, "fsrs")
+	}
+
+	// Default config selects no algorithm, leaving the SM-2 default to flotsam
+	if got := DefaultConfig().Flotsam.SRSAlgorithm("personal"); got != "" {
+		t.Errorf("Default SRSAlgorithm = %q, want empty", got)
+	}
+}
+
 func TestEnsureConfigToml(t *testing.T) {
 	tempDir := t.TempDir()
 	configPath := filepath.Join(tempDir, "config.toml")
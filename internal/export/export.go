@@ -0,0 +1,239 @@
+// Package export flattens entry logs into one row per habit per day and reads them back,
+// for analysis tools (pandas, DuckDB) and moving data between machines.
+// AIDEV-NOTE: export-package; rows are the interchange format for `vice export` and `vice import`
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/davidlee/vice/internal/models"
+)
+
+// Supported formats.
+const (
+	FormatJSON   = "json"
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+)
+
+// Value types recorded alongside each value so it can be restored from CSV.
+const (
+	ValueBoolean = "boolean"
+	ValueNumber  = "number"
+	ValueText    = "text"
+	ValueTime    = "time"
+	ValueList    = "list"
+	ValueObject  = "object" // Composite values, keyed by sub-field ID
+)
+
+const dateFormat = "2006-01-02"
+
+// Row is one habit entry on one day.
+type Row struct {
+	Date             string             `json:"date"`
+	HabitID          string             `json:"habit_id"`
+	HabitTitle       string             `json:"habit_title,omitempty"`
+	HabitType        models.HabitType   `json:"habit_type,omitempty"`
+	FieldType        string             `json:"field_type,omitempty"`
+	Status           models.EntryStatus `json:"status"`
+	AchievementLevel string             `json:"achievement_level,omitempty"`
+	Value            interface{}        `json:"value"`
+	ValueType        string             `json:"value_type,omitempty"`
+	Notes            string             `json:"notes,omitempty"`
+	CreatedAt        time.Time          `json:"created_at"`
+	UpdatedAt        *time.Time         `json:"updated_at,omitempty"`
+}
+
+// csvHeader is the column order for CSV output.
+var csvHeader = []string{
+	"date", "habit_id", "habit_title", "habit_type", "field_type", "status",
+	"achievement_level", "value", "value_type", "notes", "created_at", "updated_at",
+}
+
+// Filter limits which entries are exported. Empty fields match everything.
+type Filter struct {
+	From     string   // YYYY-MM-DD, inclusive
+	To       string   // YYYY-MM-DD, inclusive
+	HabitIDs []string // habits to include
+}
+
+// Validate checks the filter's dates.
+func (f Filter) Validate() error {
+	for _, date := range []string{f.From, f.To} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse(dateFormat, date); err != nil {
+			return fmt.Errorf("invalid date %q, expected YYYY-MM-DD", date)
+		}
+	}
+	if f.From != "" && f.To != "" && f.From > f.To {
+		return fmt.Errorf("--from %s is after --to %s", f.From, f.To)
+	}
+	return nil
+}
+
+func (f Filter) matches(date, habitID string) bool {
+	if f.From != "" && date < f.From {
+		return false
+	}
+	if f.To != "" && date > f.To {
+		return false
+	}
+	if len(f.HabitIDs) == 0 {
+		return true
+	}
+	for _, id := range f.HabitIDs {
+		if id == habitID {
+			return true
+		}
+	}
+	return false
+}
+
+// Flatten returns one row per habit per day, oldest first, in entry order within a day.
+// Habit definitions supply titles and types; entries for unknown habits are still exported.
+func Flatten(entryLog *models.EntryLog, habits []models.Habit, filter Filter) []Row {
+	byID := make(map[string]*models.Habit, len(habits))
+	for i := range habits {
+		byID[habits[i].ID] = &habits[i]
+	}
+
+	days := make([]models.DayEntry, len(entryLog.Entries))
+	copy(days, entryLog.Entries)
+	sort.SliceStable(days, func(i, j int) bool { return days[i].Date < days[j].Date })
+
+	rows := []Row{}
+	for _, day := range days {
+		for _, entry := range day.Habits {
+			if !filter.matches(day.Date, entry.HabitID) {
+				continue
+			}
+
+			value, valueType := normalizeValue(entry.Value)
+			row := Row{
+				Date:      day.Date,
+				HabitID:   entry.HabitID,
+				Status:    entry.Status,
+				Value:     value,
+				ValueType: valueType,
+				Notes:     entry.Notes,
+				CreatedAt: entry.CreatedAt,
+				UpdatedAt: entry.UpdatedAt,
+			}
+			if entry.AchievementLevel != nil {
+				row.AchievementLevel = string(*entry.AchievementLevel)
+			}
+			if habit, ok := byID[entry.HabitID]; ok {
+				row.HabitTitle = habit.Title
+				row.HabitType = habit.HabitType
+				row.FieldType = habit.FieldType.Type
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// normalizeValue converts a stored value into a JSON-friendly value and its value type.
+func normalizeValue(value interface{}) (interface{}, string) {
+	normalized := models.NormalizeValue(value)
+	switch value.(type) {
+	case nil:
+		return nil, ""
+	case bool:
+		return normalized, ValueBoolean
+	case int, int64, uint64, float64:
+		return normalized, ValueNumber
+	case time.Time:
+		return normalized, ValueTime
+	case []string, []interface{}:
+		return normalized, ValueList
+	case map[string]interface{}:
+		return normalized, ValueObject
+	default:
+		return normalized, ValueText
+	}
+}
+
+// Write writes rows in the given format.
+func Write(w io.Writer, rows []Row, format string) error {
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(rows); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		return nil
+	case FormatNDJSON:
+		encoder := json.NewEncoder(w)
+		for i := range rows {
+			if err := encoder.Encode(rows[i]); err != nil {
+				return fmt.Errorf("failed to encode row %d: %w", i+1, err)
+			}
+		}
+		return nil
+	case FormatCSV:
+		return writeCSV(w, rows)
+	default:
+		return fmt.Errorf("invalid format: %s (valid: json, csv, ndjson)", format)
+	}
+}
+
+func writeCSV(w io.Writer, rows []Row) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, row := range rows {
+		value, err := formatCSVValue(row.Value, row.ValueType)
+		if err != nil {
+			return fmt.Errorf("failed to format value for %s on %s: %w", row.HabitID, row.Date, err)
+		}
+		updatedAt := ""
+		if row.UpdatedAt != nil {
+			updatedAt = row.UpdatedAt.Format(time.RFC3339)
+		}
+		record := []string{
+			row.Date, row.HabitID, row.HabitTitle, string(row.HabitType), row.FieldType, string(row.Status),
+			row.AchievementLevel, value, row.ValueType, row.Notes, row.CreatedAt.Format(time.RFC3339), updatedAt,
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// formatCSVValue renders a value as a single CSV cell. Lists and composite values are
+// stored as JSON.
+func formatCSVValue(value interface{}, valueType string) (string, error) {
+	switch valueType {
+	case "":
+		return "", nil
+	case ValueList, ValueObject:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	case ValueNumber:
+		if f, ok := value.(float64); ok {
+			return strconv.FormatFloat(f, 'f', -1, 64), nil
+		}
+	}
+	return fmt.Sprintf("%v", value), nil
+}
@@ -0,0 +1,190 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidlee/vice/internal/models"
+)
+
+var created = time.Date(2025, 7, 14, 8, 0, 0, 0, time.UTC)
+
+func level(l models.AchievementLevel) *models.AchievementLevel {
+	return &l
+}
+
+func testEntryLog() *models.EntryLog {
+	updated := created.Add(time.Hour)
+	return &models.EntryLog{
+		Version: "1.0.0",
+		Entries: []models.DayEntry{
+			{Date: "2025-07-15", Habits: []models.HabitEntry{
+				{HabitID: "walk", Status: models.EntrySkipped, Notes: "rain, wind", CreatedAt: created},
+			}},
+			{Date: "2025-07-14", Habits: []models.HabitEntry{
+				{HabitID: "walk", Value: true, Status: models.EntryCompleted, CreatedAt: created},
+				{HabitID: "run", Value: 42.5, AchievementLevel: level(models.AchievementMidi), Status: models.EntryCompleted, CreatedAt: created, UpdatedAt: &updated},
+				{HabitID: "wake", Value: time.Date(0, 1, 1, 6, 45, 0, 0, time.UTC), Status: models.EntryCompleted, CreatedAt: created},
+				{HabitID: "morning", Value: []interface{}{"stretch", "water"}, AchievementLevel: level(models.AchievementMaxi), Status: models.EntryCompleted, CreatedAt: created},
+				{HabitID: "ride", Value: map[string]interface{}{"distance": 21.5, "duration": "1h5m"}, Status: models.EntryCompleted, CreatedAt: created},
+			}},
+		},
+	}
+}
+
+var testHabits = []models.Habit{
+	{ID: "walk", Title: "Walk", HabitType: models.SimpleHabit, FieldType: models.FieldType{Type: models.BooleanFieldType}},
+	{ID: "run", Title: "Run", HabitType: models.ElasticHabit, FieldType: models.FieldType{Type: models.UnsignedDecimalFieldType}},
+}
+
+func TestFlatten(t *testing.T) {
+	rows := Flatten(testEntryLog(), testHabits, Filter{})
+	require.Len(t, rows, 6)
+
+	// Oldest day first
+	assert.Equal(t, "2025-07-14", rows[0].Date)
+	assert.Equal(t, "Walk", rows[0].HabitTitle)
+	assert.Equal(t, models.SimpleHabit, rows[0].HabitType)
+	assert.Equal(t, true, rows[0].Value)
+	assert.Equal(t, ValueBoolean, rows[0].ValueType)
+
+	assert.Equal(t, "midi", rows[1].AchievementLevel)
+	assert.Equal(t, ValueNumber, rows[1].ValueType)
+	assert.Equal(t, "06:45", rows[2].Value)
+	assert.Equal(t, ValueTime, rows[2].ValueType)
+	assert.Equal(t, []string{"stretch", "water"}, rows[3].Value)
+	assert.Empty(t, rows[3].HabitTitle) // unknown habit still exported
+	assert.Equal(t, map[string]interface{}{"distance": 21.5, "duration": "1h5m"}, rows[4].Value)
+	assert.Equal(t, ValueObject, rows[4].ValueType)
+
+	assert.Equal(t, models.EntrySkipped, rows[5].Status)
+	assert.Nil(t, rows[5].Value)
+
+	t.Run("filter", func(t *testing.T) {
+		rows := Flatten(testEntryLog(), testHabits, Filter{From: "2025-07-15", HabitIDs: []string{"walk"}})
+		require.Len(t, rows, 1)
+		assert.Equal(t, "2025-07-15", rows[0].Date)
+
+		rows = Flatten(testEntryLog(), testHabits, Filter{To: "2025-07-14", HabitIDs: []string{"run", "wake"}})
+		assert.Len(t, rows, 2)
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		assert.Error(t, Filter{From: "14/07/2025"}.Validate())
+		assert.Error(t, Filter{From: "2025-07-15", To: "2025-07-14"}.Validate())
+		assert.NoError(t, Filter{From: "2025-07-14", To: "2025-07-14"}.Validate())
+	})
+}
+
+func TestWriteReadRoundTrip(t *testing.T) {
+	rows := Flatten(testEntryLog(), testHabits, Filter{})
+
+	for _, format := range []string{FormatJSON, FormatCSV, FormatNDJSON} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, Write(&buf, rows, format))
+
+			decoded, err := Read(&buf, format)
+			require.NoError(t, err)
+			require.Len(t, decoded, len(rows))
+
+			for i := range rows {
+				assert.Equal(t, rows[i].Date, decoded[i].Date)
+				assert.Equal(t, rows[i].HabitID, decoded[i].HabitID)
+				assert.Equal(t, rows[i].Status, decoded[i].Status)
+				assert.Equal(t, rows[i].AchievementLevel, decoded[i].AchievementLevel)
+				assert.Equal(t, rows[i].Value, decoded[i].Value)
+				assert.Equal(t, rows[i].Notes, decoded[i].Notes)
+				assert.True(t, rows[i].CreatedAt.Equal(decoded[i].CreatedAt))
+			}
+			require.NotNil(t, decoded[1].UpdatedAt)
+		})
+	}
+
+	t.Run("ndjson has one row per line", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, rows, FormatNDJSON))
+		assert.Len(t, strings.Split(strings.TrimSpace(buf.String()), "\n"), len(rows))
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		err := Write(&bytes.Buffer{}, rows, "xml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid format")
+	})
+}
+
+func TestReadCSVErrors(t *testing.T) {
+	_, err := Read(strings.NewReader("date,habit_id\n2025-07-14,walk\n"), FormatCSV)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing the status column")
+
+	_, err = Read(strings.NewReader("date,habit_id,status,value,value_type\n2025-07-14,walk,completed,lots,number\n"), FormatCSV)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+}
+
+func TestToEntryLog(t *testing.T) {
+	now := time.Date(2025, 7, 16, 0, 0, 0, 0, time.UTC)
+
+	t.Run("valid rows", func(t *testing.T) {
+		rows := []Row{
+			{Date: "2025-07-14", HabitID: "walk", Status: models.EntryCompleted, Value: true},
+			{Date: "2025-07-14", HabitID: "run", Status: models.EntryCompleted, Value: 5.0, AchievementLevel: "mini"},
+		}
+		entryLog, err := ToEntryLog(rows, now)
+		require.NoError(t, err)
+		require.Len(t, entryLog.Entries, 1)
+		assert.Len(t, entryLog.Entries[0].Habits, 2)
+		assert.Equal(t, now, entryLog.Entries[0].Habits[0].CreatedAt)
+	})
+
+	t.Run("validation errors", func(t *testing.T) {
+		_, err := ToEntryLog([]Row{{Date: "2025-07-14", HabitID: "walk", Status: models.EntryCompleted}}, now)
+		assert.ErrorContains(t, err, "must have values")
+
+		_, err = ToEntryLog([]Row{
+			{Date: "2025-07-14", HabitID: "walk", Status: models.EntrySkipped},
+			{Date: "2025-07-14", HabitID: "walk", Status: models.EntrySkipped},
+		}, now)
+		assert.ErrorContains(t, err, "duplicate habit ID")
+
+		_, err = ToEntryLog([]Row{{Date: "2025-07-14", HabitID: "run", Status: models.EntryCompleted, Value: 1.0, AchievementLevel: "mega"}}, now)
+		assert.ErrorContains(t, err, "invalid achievement level")
+	})
+}
+
+func TestMerge(t *testing.T) {
+	entryLog := testEntryLog()
+
+	incoming := &models.EntryLog{
+		Version: "1.0.0",
+		Entries: []models.DayEntry{
+			{Date: "2025-07-14", Habits: []models.HabitEntry{
+				{HabitID: "walk", Value: true, Status: models.EntryCompleted, CreatedAt: created},
+				{HabitID: "run", Value: 60.0, AchievementLevel: level(models.AchievementMaxi), Status: models.EntryCompleted, CreatedAt: created},
+			}},
+			{Date: "2025-07-13", Habits: []models.HabitEntry{
+				{HabitID: "walk", Value: false, Status: models.EntryFailed, CreatedAt: created},
+			}},
+		},
+	}
+
+	result, err := Merge(entryLog, incoming)
+	require.NoError(t, err)
+	assert.Equal(t, MergeResult{Added: 1, Updated: 1, Unchanged: 1}, result)
+
+	require.Len(t, entryLog.Entries, 3)
+	assert.Equal(t, "2025-07-13", entryLog.Entries[0].Date)
+
+	day, _ := entryLog.GetDayEntry("2025-07-14")
+	assert.Len(t, day.Habits, 5)
+	run, _ := day.GetHabitEntry("run")
+	assert.Equal(t, 60.0, run.Value)
+	assert.Equal(t, models.AchievementMaxi, *run.AchievementLevel)
+}
@@ -0,0 +1,286 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/davidlee/vice/internal/models"
+)
+
+// MergeResult counts what an import changed.
+type MergeResult struct {
+	Added     int // entries with no existing date/habit match
+	Updated   int // existing entries replaced by different data
+	Unchanged int // identical entries already present
+}
+
+// Read parses rows written by Write.
+func Read(r io.Reader, format string) ([]Row, error) {
+	switch format {
+	case FormatJSON:
+		var rows []Row
+		if err := json.NewDecoder(r).Decode(&rows); err != nil {
+			return nil, fmt.Errorf("failed to decode JSON: %w", err)
+		}
+		return restoreRows(rows)
+	case FormatNDJSON:
+		return readNDJSON(r)
+	case FormatCSV:
+		return readCSV(r)
+	default:
+		return nil, fmt.Errorf("invalid format: %s (valid: json, csv, ndjson)", format)
+	}
+}
+
+// FormatFromPath infers the format from a file extension.
+func FormatFromPath(path string) (string, error) {
+	switch {
+	case strings.HasSuffix(path, ".json"):
+		return FormatJSON, nil
+	case strings.HasSuffix(path, ".ndjson"), strings.HasSuffix(path, ".jsonl"):
+		return FormatNDJSON, nil
+	case strings.HasSuffix(path, ".csv"):
+		return FormatCSV, nil
+	default:
+		return "", fmt.Errorf("cannot infer format from %s; use --format json|csv|ndjson", path)
+	}
+}
+
+func readNDJSON(r io.Reader) ([]Row, error) {
+	var rows []Row
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var row Row
+		if err := json.Unmarshal([]byte(text), &row); err != nil {
+			return nil, fmt.Errorf("line %d: failed to decode JSON: %w", line, err)
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read NDJSON: %w", err)
+	}
+	return restoreRows(rows)
+}
+
+// restoreRows converts JSON-decoded values back to the types entries use.
+func restoreRows(rows []Row) ([]Row, error) {
+	for i := range rows {
+		value, err := restoreValue(rows[i].Value, rows[i].ValueType)
+		if err != nil {
+			return nil, fmt.Errorf("row %d (%s %s): %w", i+1, rows[i].Date, rows[i].HabitID, err)
+		}
+		rows[i].Value = value
+	}
+	return rows, nil
+}
+
+func restoreValue(value interface{}, valueType string) (interface{}, error) {
+	if fields, ok := value.(map[string]interface{}); ok {
+		if valueType != "" && valueType != ValueObject {
+			return nil, fmt.Errorf("object value with value_type %s", valueType)
+		}
+		return fields, nil
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		return value, nil
+	}
+	if valueType != "" && valueType != ValueList {
+		return nil, fmt.Errorf("list value with value_type %s", valueType)
+	}
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		result = append(result, fmt.Sprintf("%v", item))
+	}
+	return result, nil
+}
+
+func readCSV(r io.Reader) ([]Row, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("CSV is empty")
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, required := range []string{"date", "habit_id", "status"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("CSV is missing the %s column", required)
+		}
+	}
+
+	cell := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+
+	rows := make([]Row, 0, len(records)-1)
+	for n, record := range records[1:] {
+		line := n + 2
+		row := Row{
+			Date:             cell(record, "date"),
+			HabitID:          cell(record, "habit_id"),
+			HabitTitle:       cell(record, "habit_title"),
+			HabitType:        models.HabitType(cell(record, "habit_type")),
+			FieldType:        cell(record, "field_type"),
+			Status:           models.EntryStatus(cell(record, "status")),
+			AchievementLevel: cell(record, "achievement_level"),
+			ValueType:        cell(record, "value_type"),
+			Notes:            cell(record, "notes"),
+		}
+
+		row.Value, err = parseCSVValue(cell(record, "value"), row.ValueType)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		if created := cell(record, "created_at"); created != "" {
+			if row.CreatedAt, err = time.Parse(time.RFC3339, created); err != nil {
+				return nil, fmt.Errorf("line %d: invalid created_at %q", line, created)
+			}
+		}
+		if updated := cell(record, "updated_at"); updated != "" {
+			updatedAt, err := time.Parse(time.RFC3339, updated)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid updated_at %q", line, updated)
+			}
+			row.UpdatedAt = &updatedAt
+		}
+
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// parseCSVValue restores a CSV cell using its value type.
+func parseCSVValue(raw, valueType string) (interface{}, error) {
+	if raw == "" && valueType != ValueText {
+		return nil, nil
+	}
+	switch valueType {
+	case ValueBoolean:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid boolean value %q", raw)
+		}
+		return b, nil
+	case ValueNumber:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number value %q", raw)
+		}
+		return f, nil
+	case ValueList:
+		var items []string
+		if err := json.Unmarshal([]byte(raw), &items); err != nil {
+			return nil, fmt.Errorf("invalid list value %q: expected a JSON array", raw)
+		}
+		return items, nil
+	case ValueObject:
+		var fields map[string]interface{}
+		if err := json.Unmarshal([]byte(raw), &fields); err != nil {
+			return nil, fmt.Errorf("invalid object value %q: expected a JSON object", raw)
+		}
+		return fields, nil
+	case ValueText, ValueTime, "":
+		return raw, nil
+	default:
+		return nil, fmt.Errorf("unknown value_type %q", valueType)
+	}
+}
+
+// ToEntryLog groups rows into an entry log and validates it.
+// Rows without a creation time are stamped with now.
+func ToEntryLog(rows []Row, now time.Time) (*models.EntryLog, error) {
+	entryLog := models.CreateEmptyEntryLog()
+	days := make(map[string]int)
+
+	for _, row := range rows {
+		entry := models.HabitEntry{
+			HabitID:   row.HabitID,
+			Value:     row.Value,
+			Notes:     row.Notes,
+			CreatedAt: row.CreatedAt,
+			UpdatedAt: row.UpdatedAt,
+			Status:    row.Status,
+		}
+		if entry.CreatedAt.IsZero() {
+			entry.CreatedAt = now
+		}
+		if row.AchievementLevel != "" {
+			level := models.AchievementLevel(row.AchievementLevel)
+			entry.AchievementLevel = &level
+		}
+
+		i, ok := days[row.Date]
+		if !ok {
+			i = len(entryLog.Entries)
+			days[row.Date] = i
+			entryLog.Entries = append(entryLog.Entries, models.DayEntry{Date: row.Date})
+		}
+		entryLog.Entries[i].Habits = append(entryLog.Entries[i].Habits, entry)
+	}
+
+	if err := entryLog.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid import: %w", err)
+	}
+	return entryLog, nil
+}
+
+// Merge copies incoming entries into the log by date and habit. Incoming entries
+// replace existing ones for the same habit and day; everything else is kept.
+func Merge(entryLog, incoming *models.EntryLog) (MergeResult, error) {
+	var result MergeResult
+
+	for _, incomingDay := range incoming.Entries {
+		day, found := entryLog.GetDayEntry(incomingDay.Date)
+		if !found {
+			entryLog.Entries = append(entryLog.Entries, models.DayEntry{Date: incomingDay.Date, Habits: []models.HabitEntry{}})
+			day = &entryLog.Entries[len(entryLog.Entries)-1]
+		}
+
+		for _, entry := range incomingDay.Habits {
+			existing, found := day.GetHabitEntry(entry.HabitID)
+			switch {
+			case !found:
+				result.Added++
+			case existing.Same(&entry):
+				result.Unchanged++
+				continue
+			default:
+				result.Updated++
+			}
+			if err := day.UpdateHabitEntry(entry); err != nil {
+				return result, fmt.Errorf("failed to merge %s on %s: %w", entry.HabitID, incomingDay.Date, err)
+			}
+		}
+	}
+
+	sort.SliceStable(entryLog.Entries, func(i, j int) bool { return entryLog.Entries[i].Date < entryLog.Entries[j].Date })
+
+	if err := entryLog.Validate(); err != nil {
+		return result, fmt.Errorf("merged entries are invalid: %w", err)
+	}
+	return result, nil
+}
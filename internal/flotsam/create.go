@@ -0,0 +1,127 @@
+// Package flotsam provides native note creation for when zk isn't installed.
+// AIDEV-NOTE: native-create; mirrors `zk new` (4-char alphanum IDs, <id>.md filenames, ZK frontmatter)
+// so notes created without zk are indistinguishable from zk-created ones
+package flotsam
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// maxIDAttempts bounds how many generated IDs are tried before giving up.
+// With 36^4 possible IDs this is only reached in a nearly full notebook.
+const maxIDAttempts = 100
+
+// NoteTypes are the vice note types, tagged vice:type:<type>.
+var NoteTypes = []string{"flashcard", "idea", "script", "log"}
+
+// TypeTag returns the vice:type:* tag for a note type.
+func TypeTag(noteType string) string {
+	return "vice:type:" + noteType
+}
+
+// NewNote describes a note to create with CreateNote.
+type NewNote struct {
+	Title    string
+	Type     string        // One of NoteTypes
+	Context  string        // Vice context, for templates
+	Template *NoteTemplate // Body template; nil for the type's built-in default
+	Now      time.Time     // Creation time; zero for time.Now()
+}
+
+// CreateNote writes a new note with a collision-free ID to flotsamDir and returns it.
+// IDs come from generate (usually NewFlotsamIDGenerator()); an ID whose file already
+// exists is skipped, and the file is claimed atomically so concurrent creators can't
+// overwrite each other.
+func CreateNote(flotsamDir string, spec NewNote, generate IDGenerator) (*FlotsamNote, error) {
+	if spec.Title == "" {
+		return nil, fmt.Errorf("note title cannot be empty")
+	}
+	if spec.Type == "" {
+		return nil, fmt.Errorf("note type cannot be empty")
+	}
+	now := spec.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	noteTemplate := spec.Template
+	if noteTemplate == nil {
+		noteTemplate = DefaultTemplate(spec.Type)
+	}
+
+	if err := os.MkdirAll(flotsamDir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create flotsam directory: %w", err)
+	}
+
+	for attempt := 0; attempt < maxIDAttempts; attempt++ {
+		id := generate()
+		body, err := noteTemplate.Render(TemplateData{
+			Title:   spec.Title,
+			ID:      id,
+			Date:    now.Format("2006-01-02"),
+			Context: spec.Context,
+			Type:    spec.Type,
+			Created: now,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		note := &FlotsamNote{
+			ID:       id,
+			Title:    spec.Title,
+			Tags:     []string{TypeTag(spec.Type)},
+			Created:  now.Truncate(time.Second),
+			Modified: now,
+			Body:     body,
+		}
+		filePath := filepath.Join(flotsamDir, GenerateNoteFilename(note.ID))
+
+		content, err := SerializeFlotsamNote(note)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize note: %w", err)
+		}
+
+		created, err := writeNewFile(filePath, content)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			note.FilePath = filePath
+			return note, nil
+		}
+	}
+
+	return nil, fmt.Errorf("failed to find an unused note ID in %s after %d attempts", flotsamDir, maxIDAttempts)
+}
+
+// writeNewFile writes content to filePath unless it already exists, reporting
+// whether it did. The content goes to a temp file that's hard-linked into place,
+// so the note appears complete or not at all.
+func writeNewFile(filePath string, content []byte) (bool, error) {
+	temp, err := os.CreateTemp(filepath.Dir(filePath), "."+filepath.Base(filePath)+".*.tmp")
+	if err != nil {
+		return false, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tempPath := temp.Name()
+	defer func() { _ = os.Remove(tempPath) }()
+
+	if _, err := temp.Write(content); err != nil {
+		_ = temp.Close()
+		return false, fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := temp.Close(); err != nil {
+		return false, fmt.Errorf("failed to write temp file: %w", err)
+	}
+
+	// Link fails if the note already exists, unlike rename
+	if err := os.Link(tempPath, filePath); err != nil {
+		if os.IsExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to create note file %s: %w", filePath, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,96 @@
+package flotsam
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sequenceIDs returns a generator yielding ids in order.
+func sequenceIDs(ids ...string) IDGenerator {
+	return func() string {
+		id := ids[0]
+		ids = ids[1:]
+		return id
+	}
+}
+
+func TestCreateNote(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "flotsam")
+	now := time.Date(2025, 7, 20, 9, 30, 0, 0, time.UTC)
+
+	note, err := CreateNote(dir, NewNote{Title: "What is X?", Type: "flashcard", Now: now}, sequenceIDs("ab12"))
+	if err != nil {
+		t.Fatalf("CreateNote() error = %v", err)
+	}
+	if note.ID != "ab12" || note.FilePath != filepath.Join(dir, "ab12.md") {
+		t.Errorf("Expected note ab12 at ab12.md, got %s at %s", note.ID, note.FilePath)
+	}
+
+	parsed, err := ParseFlotsamFile(note.FilePath)
+	if err != nil {
+		t.Fatalf("ParseFlotsamFile() error = %v", err)
+	}
+	if parsed.ID != "ab12" || parsed.Title != "What is X?" {
+		t.Errorf("Unexpected frontmatter: id=%q title=%q", parsed.ID, parsed.Title)
+	}
+	if len(parsed.Tags) != 1 || parsed.Tags[0] != "vice:type:flashcard" {
+		t.Errorf("Expected vice:type:flashcard tag, got %v", parsed.Tags)
+	}
+	if !parsed.Created.Equal(now) {
+		t.Errorf("Expected created-at %v, got %v", now, parsed.Created)
+	}
+	if !strings.Contains(parsed.Body, "## Question") || !strings.Contains(parsed.Body, "## Answer") {
+		t.Errorf("Expected the flashcard body, got:\n%s", parsed.Body)
+	}
+
+	// No temp files are left behind
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("Expected only ab12.md, got %d files", len(files))
+	}
+}
+
+func TestCreateNote_SkipsTakenIDs(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "aaaa.md")
+	if err := os.WriteFile(existing, []byte("keep me\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	custom, err := ParseNoteTemplate("custom", "Custom body for {{id}}\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	note, err := CreateNote(dir, NewNote{Title: "Idea", Type: "idea", Template: custom}, sequenceIDs("aaaa", "aaaa", "bbbb"))
+	if err != nil {
+		t.Fatalf("CreateNote() error = %v", err)
+	}
+	if note.ID != "bbbb" {
+		t.Errorf("Expected the first free ID bbbb, got %s", note.ID)
+	}
+	if data, _ := os.ReadFile(existing); string(data) != "keep me\n" {
+		t.Errorf("Existing note was overwritten: %q", data)
+	}
+	if data, _ := os.ReadFile(note.FilePath); !strings.HasSuffix(string(data), "\nCustom body for bbbb\n") {
+		t.Errorf("Expected the custom body, got:\n%s", data)
+	}
+
+	t.Run("gives up when no ID is free", func(t *testing.T) {
+		_, err := CreateNote(dir, NewNote{Title: "Idea", Type: "idea"}, func() string { return "aaaa" })
+		if err == nil || !strings.Contains(err.Error(), "unused note ID") {
+			t.Errorf("Expected an unused ID error, got %v", err)
+		}
+	})
+
+	t.Run("requires a title", func(t *testing.T) {
+		if _, err := CreateNote(dir, NewNote{Type: "idea"}, NewFlotsamIDGenerator()); err == nil {
+			t.Error("Expected an error for a missing title")
+		}
+	})
+}
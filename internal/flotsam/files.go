@@ -33,6 +33,12 @@ func ParseFlotsamFile(filePath string) (*FlotsamNote, error) {
 		return nil, fmt.Errorf("failed to parse frontmatter in %s: %w", filePath, err)
 	}
 
+	// Hashtags and links from the body
+	parsed, err := ParseNoteContent(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse content of %s: %w", filePath, err)
+	}
+
 	// Get file info for modification time
 	fileInfo, err := os.Stat(filePath)
 	if err != nil {
@@ -44,10 +50,11 @@ func ParseFlotsamFile(filePath string) (*FlotsamNote, error) {
 		ID:       frontmatter.ID,
 		Title:    frontmatter.Title,
 		Type:     frontmatter.Type,
-		Tags:     frontmatter.Tags,
+		Tags:     parsed.Tags,
 		Created:  frontmatter.Created,
 		Modified: fileInfo.ModTime(),
 		Body:     body,
+		Links:    NoteLinkTargets(parsed.Links),
 		FilePath: filePath,
 		SRS:      frontmatter.SRS,
 	}
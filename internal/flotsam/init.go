@@ -17,6 +17,10 @@ import (
 // This function is idempotent and safe to call multiple times.
 // AIDEV-NOTE: auto-init strategy - transparent, graceful, user-friendly setup
 func EnsureFlotsamEnvironment(env *config.ViceEnv) error {
+	if env.ZK == nil {
+		// Avoid passing a typed nil pointer as a non-nil interface
+		return EnsureFlotsamEnvironmentWithZK(env, nil)
+	}
 	return EnsureFlotsamEnvironmentWithZK(env, env.ZK)
 }
 
@@ -0,0 +1,181 @@
+// AIDEV-NOTE: link-index; persistent link index for flotsam notes; incremental - files with an unchanged mtime aren't read, and files whose
+// CalculateChecksum is unchanged aren't reparsed; the tables and graph queries live in srs (note_index.go)
+
+package flotsam
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/davidlee/vice/internal/srs"
+)
+
+// LinkIndexUpdate reports what UpdateLinkIndex changed.
+type LinkIndexUpdate struct {
+	Indexed   []string `json:"indexed"` // New or changed note files
+	Removed   []string `json:"removed"` // Note files no longer on disk
+	Unchanged int      `json:"unchanged"`
+}
+
+// UpdateLinkIndex brings the link index in db up to date with the notes in
+// flotsamDir, including subdirectories but not hidden ones such as .zk and .vice.
+func UpdateLinkIndex(db *srs.Database, flotsamDir string) (*LinkIndexUpdate, error) {
+	flotsamDir, err := filepath.Abs(flotsamDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve flotsam directory: %w", err)
+	}
+
+	indexed, err := db.GetIndexedFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	update := &LinkIndexUpdate{Indexed: []string{}, Removed: []string{}}
+	onDisk := make(map[string]bool)
+
+	err = filepath.WalkDir(flotsamDir, func(notePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if notePath == flotsamDir && os.IsNotExist(err) {
+				return fs.SkipAll // No notes yet
+			}
+			return err
+		}
+		if d.IsDir() {
+			if notePath != flotsamDir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(strings.ToLower(d.Name()), ".md") {
+			return nil
+		}
+		onDisk[notePath] = true
+
+		info, err := d.Info()
+		if err != nil {
+			return fmt.Errorf("failed to stat %s: %w", notePath, err)
+		}
+		previous, found := indexed[notePath]
+		if found && info.ModTime().Equal(previous.ModTime) {
+			update.Unchanged++
+			return nil
+		}
+
+		content, err := os.ReadFile(notePath) // #nosec G304 -- path is within the flotsam directory
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", notePath, err)
+		}
+		checksum := CalculateChecksum(content)
+		if found && checksum == previous.Checksum {
+			update.Unchanged++
+			return db.TouchIndexedFile(notePath, info.ModTime())
+		}
+
+		file, links := indexNote(notePath, content)
+		file.ModTime = info.ModTime()
+		file.Checksum = checksum
+		if err := db.IndexFile(file, links); err != nil {
+			return err
+		}
+		update.Indexed = append(update.Indexed, notePath)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan flotsam directory: %w", err)
+	}
+
+	for notePath := range indexed {
+		if onDisk[notePath] {
+			continue
+		}
+		if err := db.RemoveIndexedFile(notePath); err != nil {
+			return nil, err
+		}
+		update.Removed = append(update.Removed, notePath)
+	}
+	sort.Strings(update.Removed)
+
+	return update, nil
+}
+
+// NewCacheManager returns the SRS cache manager for a context, with refreshes
+// indexing note files via UpdateLinkIndex so they can reconcile srs_reviews:
+// new vice-typed notes get scheduled under algorithm (see InitialSRSData) and
+// renamed or deleted notes are followed.
+func NewCacheManager(db *srs.Database, contextDir, algorithm string) *srs.CacheManager {
+	return db.GetCacheManager(contextDir).WithFileSync(
+		func(db *srs.Database, flotsamDir string) error {
+			_, err := UpdateLinkIndex(db, flotsamDir)
+			return err
+		},
+		func(now time.Time) (*srs.SRSData, error) {
+			return InitialSRSData(algorithm, now)
+		},
+	)
+}
+
+// indexNote parses a note into its link index entry. A note that can't be parsed
+// is still indexed, under its filename, so links to it resolve.
+func indexNote(notePath string, content []byte) (srs.IndexedFile, []srs.IndexedLink) {
+	file := srs.IndexedFile{
+		Path:   notePath,
+		NoteID: strings.TrimSuffix(filepath.Base(notePath), filepath.Ext(notePath)),
+	}
+
+	parsed, err := ParseNoteContent(string(content))
+	if err != nil {
+		log.Warn("Indexing note without links", "path", notePath, "error", err)
+		return file, nil
+	}
+
+	if id, ok := parsed.Metadata["id"].(string); ok && id != "" {
+		file.NoteID = id
+	}
+	file.Title = parsed.Title
+	file.Type = noteTypeFromTags(parsed.Tags)
+
+	links := make([]srs.IndexedLink, 0, len(parsed.Links))
+	for _, link := range parsed.Links {
+		if strings.HasPrefix(link.Href, "#") {
+			continue // Anchor within this note
+		}
+
+		rels := make([]string, 0, len(link.Rels))
+		for _, rel := range link.Rels {
+			rels = append(rels, string(rel))
+		}
+
+		indexedLink := srs.IndexedLink{
+			Target:   link.Href,
+			Title:    link.Title,
+			Type:     link.Type.String(),
+			Rels:     rels,
+			External: link.IsExternal,
+			Snippet:  link.Snippet,
+		}
+		if !link.IsExternal {
+			indexedLink.TargetID = linkTargetID(link.Href)
+		}
+		links = append(links, indexedLink)
+	}
+
+	return file, links
+}
+
+// linkTargetID returns the note ID an internal link refers to: the filename
+// without directory, extension or #anchor, so "abc1", "abc1.md" and
+// "../notes/abc1.md#usage" all refer to note abc1.
+func linkTargetID(href string) string {
+	if i := strings.Index(href, "#"); i >= 0 {
+		href = href[:i]
+	}
+	return strings.TrimSuffix(path.Base(filepath.ToSlash(href)), ".md")
+}
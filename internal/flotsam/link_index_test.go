@@ -0,0 +1,225 @@
+package flotsam
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/davidlee/vice/internal/srs"
+)
+
+func TestUpdateLinkIndex(t *testing.T) {
+	contextDir := t.TempDir()
+	flotsamDir := filepath.Join(contextDir, "flotsam")
+	db, err := srs.NewDatabase(contextDir, "test")
+	if err != nil {
+		t.Fatalf("NewDatabase() error = %v", err)
+	}
+	defer func() { _ = db.Close() }() //nolint:errcheck // Test cleanup
+
+	writeNote := func(name, content string, modTime time.Time) string {
+		t.Helper()
+		path := filepath.Join(flotsamDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	update := func() *LinkIndexUpdate {
+		t.Helper()
+		result, err := UpdateLinkIndex(db, flotsamDir)
+		if err != nil {
+			t.Fatalf("UpdateLinkIndex() error = %v", err)
+		}
+		return result
+	}
+
+	base := time.Date(2025, 7, 20, 9, 0, 0, 0, time.UTC)
+	abc1 := writeNote("abc1.md", "---\nid: abc1\ntitle: Graphs\n---\n\nSee [[def2]] and [Trees](sub/def2.md#intro \"down\").\n", base)
+	def2 := writeNote("sub/def2.md", "# Trees\n\nBack to [[abc1]], on to [[nowhere]]. Jump to [top](#top).\n", base)
+	writeNote(".zk/templates/default.md", "[[abc1]]\n", base)
+
+	result := update()
+	if !reflect.DeepEqual(result.Indexed, []string{abc1, def2}) || result.Unchanged != 0 {
+		t.Errorf("Expected both notes indexed, got %+v", result)
+	}
+
+	outlinks, err := db.GetOutlinks(abc1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outlinks) != 2 || outlinks[0].TargetPath != def2 || outlinks[1].TargetPath != def2 ||
+		!reflect.DeepEqual(outlinks[1].Rels, []string{"down"}) || outlinks[1].Type != "markdown" {
+		t.Errorf("Expected two resolved links to def2, got %+v", outlinks)
+	}
+
+	backlinks, err := db.GetBacklinks(abc1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backlinks) != 1 || backlinks[0].SourceID != "def2" {
+		t.Errorf("Expected backlink from def2 only (templates in .zk are skipped), got %+v", backlinks)
+	}
+
+	broken, err := db.GetBrokenLinks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(broken) != 1 || broken[0].Target != "nowhere" {
+		t.Errorf("Expected [[nowhere]] broken and the #top anchor ignored, got %+v", broken)
+	}
+
+	// Same mtime: not read. New mtime, same content: checksum matches, links kept.
+	writeNote("abc1.md", "---\nid: abc1\ntitle: Graphs\n---\n\nSee [[def2]] and [Trees](sub/def2.md#intro \"down\").\n", base.Add(time.Minute))
+	result = update()
+	if len(result.Indexed) != 0 || result.Unchanged != 2 {
+		t.Errorf("Expected nothing reindexed, got %+v", result)
+	}
+	files, err := db.GetIndexedFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !files[abc1].ModTime.Equal(base.Add(time.Minute)) {
+		t.Errorf("Expected mtime updated, got %v", files[abc1].ModTime)
+	}
+
+	// Changed content is reindexed; deleted files are removed.
+	writeNote("abc1.md", "---\nid: abc1\ntitle: Graphs\n---\n\nNo links any more.\n", base.Add(2*time.Minute))
+	if err := os.Remove(def2); err != nil {
+		t.Fatal(err)
+	}
+	result = update()
+	if !reflect.DeepEqual(result.Indexed, []string{abc1}) || !reflect.DeepEqual(result.Removed, []string{def2}) {
+		t.Errorf("Expected abc1 reindexed and def2 removed, got %+v", result)
+	}
+
+	orphans, err := db.GetOrphanNotes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orphans) != 1 || orphans[0].NoteID != "abc1" || orphans[0].Title != "Graphs" {
+		t.Errorf("Expected abc1 orphaned, got %+v", orphans)
+	}
+}
+
+func TestUpdateLinkIndexMissingDirectory(t *testing.T) {
+	contextDir := t.TempDir()
+	db, err := srs.NewDatabase(contextDir, "test")
+	if err != nil {
+		t.Fatalf("NewDatabase() error = %v", err)
+	}
+	defer func() { _ = db.Close() }() //nolint:errcheck // Test cleanup
+
+	result, err := UpdateLinkIndex(db, filepath.Join(contextDir, "missing"))
+	if err != nil {
+		t.Fatalf("UpdateLinkIndex() error = %v", err)
+	}
+	if len(result.Indexed) != 0 || len(result.Removed) != 0 {
+		t.Errorf("Expected empty update, got %+v", result)
+	}
+}
+
+func TestLinkTargetID(t *testing.T) {
+	tests := map[string]string{
+		"abc1":                   "abc1",
+		"abc1.md":                "abc1",
+		"../notes/abc1.md#usage": "abc1",
+		"Some Title":             "Some Title",
+	}
+	for href, expected := range tests {
+		if got := linkTargetID(href); got != expected {
+			t.Errorf("linkTargetID(%q) = %q, expected %q", href, got, expected)
+		}
+	}
+}
+
+func TestNewCacheManagerReconcilesReviews(t *testing.T) {
+	contextDir := t.TempDir()
+	flotsamDir := filepath.Join(contextDir, "flotsam")
+	db, err := srs.NewDatabase(contextDir, "test")
+	if err != nil {
+		t.Fatalf("NewDatabase() error = %v", err)
+	}
+	defer func() { _ = db.Close() }() //nolint:errcheck // Test cleanup
+
+	if err := os.MkdirAll(flotsamDir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	card := filepath.Join(flotsamDir, "abc1.md")
+	plain := filepath.Join(flotsamDir, "def2.md")
+	if err := os.WriteFile(card, []byte("---\nid: abc1\ntags: [vice:type:flashcard]\n---\n\nQ?\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(plain, []byte("---\nid: def2\n---\n\nJust a note.\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cacheManager := NewCacheManager(db, contextDir, AlgorithmFSRS)
+	refresh := func() *srs.RefreshReport {
+		t.Helper()
+		report, err := cacheManager.RefreshCache()
+		if err != nil {
+			t.Fatalf("RefreshCache() error = %v", err)
+		}
+		return report
+	}
+
+	if report := refresh(); !reflect.DeepEqual(report.Added, []string{card}) {
+		t.Errorf("Expected only the flashcard scheduled, got %+v", report)
+	}
+	data, err := db.GetSRSData(card)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.Stability <= 0 || data.Difficulty <= 0 {
+		t.Errorf("Expected the flashcard to start with FSRS state, got %+v", data)
+	}
+	if report := refresh(); report.Changed() {
+		t.Errorf("Expected second refresh to change nothing, got %+v", report)
+	}
+
+	moved := filepath.Join(flotsamDir, "cards", "abc1.md")
+	if err := os.MkdirAll(filepath.Dir(moved), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(card, moved); err != nil {
+		t.Fatal(err)
+	}
+	if report := refresh(); !reflect.DeepEqual(report.Renamed, map[string]string{card: moved}) || len(report.Added) != 0 {
+		t.Errorf("Expected rename by note ID, got %+v", report)
+	}
+
+	if err := os.Remove(moved); err != nil {
+		t.Fatal(err)
+	}
+	if report := refresh(); !reflect.DeepEqual(report.Removed, []string{moved}) {
+		t.Errorf("Expected removed note tombstoned, got %+v", report)
+	}
+	due, err := db.GetDueNotes("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(due) != 0 {
+		t.Errorf("Expected no due notes after removal, got %d", len(due))
+	}
+
+	// Tagging an existing note in place doesn't change the directory's mtime
+	if err := os.WriteFile(plain, []byte("---\nid: def2\ntags: [vice:type:idea]\n---\n\nJust a note.\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(plain, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if report := refresh(); !reflect.DeepEqual(report.Added, []string{plain}) {
+		t.Errorf("Expected newly tagged note scheduled, got %+v", report)
+	}
+}
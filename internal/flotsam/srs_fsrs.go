@@ -0,0 +1,291 @@
+// Package flotsam provides SRS implementation using the FSRS algorithm.
+// The model and default parameters follow FSRS-4.5 as published by the
+// open-spaced-repetition project (https://github.com/open-spaced-repetition).
+// AIDEV-NOTE: FSRS scheduler behind Algorithm; stability/difficulty/retrievability model with SM-2 seeding
+package flotsam
+
+import (
+	"math"
+	"time"
+)
+
+// FSRS constants and configuration
+const (
+	// FSRSDesiredRetention is the target probability of recall when a card comes due
+	FSRSDesiredRetention = 0.9
+	// FSRSMaxInterval caps scheduling at roughly 100 years
+	FSRSMaxInterval = 36500
+
+	// fsrsDecay and fsrsFactor shape the forgetting curve R(t,S) = (1 + factor*t/S)^decay
+	fsrsDecay  = -0.5
+	fsrsFactor = 19.0 / 81.0
+
+	minDifficulty = 1.0
+	maxDifficulty = 10.0
+	minStability  = 0.1
+)
+
+// DefaultFSRSWeights are the FSRS-4.5 default model parameters w0..w16.
+var DefaultFSRSWeights = [17]float64{
+	0.4872, 1.4003, 3.7145, 13.8206, // initial stability for Again/Hard/Good/Easy
+	5.1618, 1.2298, // initial difficulty
+	0.8975, 0.031, // difficulty update and mean reversion
+	1.6474, 0.1367, 1.0461, // stability after recall
+	2.1072, 0.0793, 0.3246, 1.587, // stability after lapse
+	0.2272, 2.8755, // hard penalty, easy bonus
+}
+
+// fsrsRating is the FSRS four-button grade.
+type fsrsRating int
+
+const (
+	fsrsAgain fsrsRating = iota + 1
+	fsrsHard
+	fsrsGood
+	fsrsEasy
+)
+
+// ratingFromQuality maps the 0-6 go-srs quality scale onto FSRS grades.
+// Anything below CorrectHard is a lapse; the three correct grades map to Hard/Good/Easy.
+func ratingFromQuality(q Quality) fsrsRating {
+	switch q {
+	case CorrectHard:
+		return fsrsHard
+	case CorrectEffort:
+		return fsrsGood
+	case CorrectEasy:
+		return fsrsEasy
+	default:
+		return fsrsAgain
+	}
+}
+
+// FSRSCalculator implements the Free Spaced Repetition Scheduler.
+type FSRSCalculator struct {
+	now       time.Time
+	weights   [17]float64
+	retention float64
+}
+
+// NewFSRSCalculator creates a new FSRS calculator with the current time
+func NewFSRSCalculator() *FSRSCalculator {
+	return NewFSRSCalculatorWithTime(time.Now())
+}
+
+// NewFSRSCalculatorWithTime creates a new FSRS calculator with a specific time (for testing)
+func NewFSRSCalculatorWithTime(t time.Time) *FSRSCalculator {
+	return &FSRSCalculator{
+		now:       t,
+		weights:   DefaultFSRSWeights,
+		retention: FSRSDesiredRetention,
+	}
+}
+
+// ProcessReview updates SRS data based on a review session.
+// Cards carrying only SM-2 state are seeded from it before the review is applied.
+func (calc *FSRSCalculator) ProcessReview(oldData *SRSData, quality Quality) (*SRSData, error) {
+	if err := quality.Validate(); err != nil {
+		return nil, err
+	}
+
+	rating := ratingFromQuality(quality)
+	var newData SRSData
+
+	if oldData == nil || oldData.TotalReviews == 0 {
+		newData = SRSData{
+			Easiness:   DefaultEasiness,
+			Stability:  calc.initialStability(rating),
+			Difficulty: calc.initialDifficulty(rating),
+		}
+		if oldData != nil {
+			newData.Easiness = oldData.Easiness
+		}
+	} else {
+		newData = *oldData
+		if newData.Stability <= 0 || newData.Difficulty <= 0 {
+			newData.Stability, newData.Difficulty = SeedFSRSFromSM2(
+				oldData.Easiness, oldData.ConsecutiveCorrect, 0)
+		}
+
+		elapsed := calc.elapsedDays(oldData, newData.Stability)
+		retrievability := calc.retrievability(elapsed, newData.Stability)
+
+		if rating == fsrsAgain {
+			newData.Stability = calc.stabilityAfterLapse(newData.Difficulty, newData.Stability, retrievability)
+		} else {
+			newData.Stability = calc.stabilityAfterRecall(newData.Difficulty, newData.Stability, retrievability, rating)
+		}
+		newData.Difficulty = calc.nextDifficulty(newData.Difficulty, rating)
+	}
+
+	newData.TotalReviews++
+	if quality.IsCorrect() {
+		newData.ConsecutiveCorrect++
+	} else {
+		newData.ConsecutiveCorrect = 0
+	}
+
+	days := 1
+	if rating != fsrsAgain {
+		days = calc.nextInterval(newData.Stability)
+	}
+	newData.Due = calc.now.AddDate(0, 0, days).Unix()
+	newData.LastReview = calc.now.Unix()
+
+	newData.ReviewHistory = append(newData.ReviewHistory, ReviewRecord{
+		Timestamp: calc.now.Unix(),
+		Quality:   quality,
+	})
+
+	return &newData, nil
+}
+
+// elapsedDays returns days since the last review. Without a recorded review
+// time the card is assumed to have been reviewed on schedule, plus any overdue days.
+func (calc *FSRSCalculator) elapsedDays(data *SRSData, stability float64) float64 {
+	day := (24 * time.Hour).Seconds()
+	if data.LastReview > 0 {
+		return math.Max(0, float64(calc.now.Unix()-data.LastReview)/day)
+	}
+
+	overdue := math.Max(0, float64(calc.now.Unix()-data.Due)/day)
+	return stability + overdue
+}
+
+// retrievability is the predicted probability of recall after t days.
+func (calc *FSRSCalculator) retrievability(elapsedDays, stability float64) float64 {
+	return math.Pow(1+fsrsFactor*elapsedDays/stability, fsrsDecay)
+}
+
+// nextInterval returns the whole-day interval at which recall drops to the desired retention.
+func (calc *FSRSCalculator) nextInterval(stability float64) int {
+	interval := stability / fsrsFactor * (math.Pow(calc.retention, 1/fsrsDecay) - 1)
+	days := int(math.Round(interval))
+	if days < 1 {
+		return 1
+	}
+	if days > FSRSMaxInterval {
+		return FSRSMaxInterval
+	}
+	return days
+}
+
+func (calc *FSRSCalculator) initialStability(rating fsrsRating) float64 {
+	return math.Max(calc.weights[rating-1], minStability)
+}
+
+func (calc *FSRSCalculator) initialDifficulty(rating fsrsRating) float64 {
+	w := calc.weights
+	return clampDifficulty(w[4] - w[5]*float64(rating-fsrsGood))
+}
+
+// nextDifficulty applies the grade and reverts towards the initial "Good" difficulty.
+func (calc *FSRSCalculator) nextDifficulty(difficulty float64, rating fsrsRating) float64 {
+	w := calc.weights
+	next := difficulty - w[6]*float64(rating-fsrsGood)
+	return clampDifficulty(w[7]*calc.initialDifficulty(fsrsGood) + (1-w[7])*next)
+}
+
+func (calc *FSRSCalculator) stabilityAfterRecall(difficulty, stability, retrievability float64, rating fsrsRating) float64 {
+	w := calc.weights
+	modifier := 1.0
+	switch rating {
+	case fsrsHard:
+		modifier = w[15]
+	case fsrsEasy:
+		modifier = w[16]
+	}
+
+	growth := math.Exp(w[8]) * (11 - difficulty) * math.Pow(stability, -w[9]) *
+		(math.Exp(w[10]*(1-retrievability)) - 1) * modifier
+	return math.Max(stability*(1+growth), minStability)
+}
+
+func (calc *FSRSCalculator) stabilityAfterLapse(difficulty, stability, retrievability float64) float64 {
+	w := calc.weights
+	next := w[11] * math.Pow(difficulty, -w[12]) * (math.Pow(stability+1, w[13]) - 1) *
+		math.Exp(w[14]*(1-retrievability))
+	return math.Max(math.Min(next, stability), minStability)
+}
+
+// IsDue checks if a card is due for review based on its SRS data
+func (calc *FSRSCalculator) IsDue(data *SRSData) bool {
+	return calc.IsDueAt(data, calc.now)
+}
+
+// IsDueAt checks if a card is due for review at a specific time
+func (calc *FSRSCalculator) IsDueAt(data *SRSData, t time.Time) bool {
+	if data == nil {
+		return true // New cards are always due
+	}
+	return data.Due <= t.Unix()
+}
+
+// GetDueTime returns when the card is next due for review
+func (calc *FSRSCalculator) GetDueTime(data *SRSData) time.Time {
+	if data == nil {
+		return calc.now // New cards are due now
+	}
+	return time.Unix(data.Due, 0)
+}
+
+// NewCard returns the state of a card that hasn't been reviewed yet, due now.
+// Stability and difficulty start at their "Good" values so the card carries
+// FSRS state from the outset; the first review replaces them with the real grade's.
+func (calc *FSRSCalculator) NewCard() *SRSData {
+	return &SRSData{
+		Easiness:   DefaultEasiness,
+		Due:        calc.now.Unix(),
+		Stability:  calc.initialStability(fsrsGood),
+		Difficulty: calc.initialDifficulty(fsrsGood),
+	}
+}
+
+// GetNextInterval returns the number of days until the next review
+func (calc *FSRSCalculator) GetNextInterval(data *SRSData) int {
+	if data == nil {
+		return 0 // New cards have no interval
+	}
+
+	days := int(math.Ceil(time.Unix(data.Due, 0).Sub(calc.now).Hours() / 24))
+	if days < 0 {
+		return 0 // Overdue cards
+	}
+	return days
+}
+
+// SeedFSRSFromSM2 derives FSRS state from SM-2 easiness and interval.
+// With the default 90% retention an FSRS interval equals stability, so the
+// last SM-2 interval is used directly; when it is unknown (0) the interval SM-2
+// would have scheduled for the current streak is used instead. Easiness
+// 1.3 (hardest) to 3.0 maps linearly onto difficulty 10 to 1.
+// AIDEV-NOTE: fsrs-seeding; used by srs.Database.SeedFSRSState migration and lazily in ProcessReview
+func SeedFSRSFromSM2(easiness float64, consecutiveCorrect int, intervalDays float64) (stability, difficulty float64) {
+	if easiness < MinEasiness {
+		easiness = MinEasiness
+	}
+
+	if intervalDays <= 0 {
+		intervalDays = sm2IntervalDays(easiness, consecutiveCorrect)
+	}
+
+	stability = math.Max(intervalDays, minStability)
+	difficulty = clampDifficulty(maxDifficulty - (easiness-MinEasiness)*(maxDifficulty-minDifficulty)/(3.0-MinEasiness))
+	return stability, difficulty
+}
+
+// sm2IntervalDays returns the interval SM-2 schedules after a streak of correct answers.
+func sm2IntervalDays(easiness float64, consecutiveCorrect int) float64 {
+	switch {
+	case consecutiveCorrect <= 1:
+		return 1
+	case consecutiveCorrect == 2:
+		return DueDateStartDays
+	default:
+		return DueDateStartDays * math.Pow(easiness, float64(consecutiveCorrect-2))
+	}
+}
+
+func clampDifficulty(d float64) float64 {
+	return math.Min(math.Max(d, minDifficulty), maxDifficulty)
+}
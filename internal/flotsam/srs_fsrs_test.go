@@ -0,0 +1,191 @@
+package flotsam
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFSRSCalculatorNewCard(t *testing.T) {
+	fixedTime := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	calc := NewFSRSCalculatorWithTime(fixedTime)
+
+	tests := []struct {
+		name               string
+		quality            Quality
+		expectedStability  float64
+		expectedDifficulty float64
+		expectedDays       int
+		expectedCorrect    int
+	}{
+		{"lapse", IncorrectFamiliar, 0.4872, 7.6214, 1, 0},
+		{"hard", CorrectHard, 1.4003, 6.3916, 1, 1},
+		{"good", CorrectEffort, 3.7145, 5.1618, 4, 1},
+		{"easy", CorrectEasy, 13.8206, 3.9320, 14, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.ProcessReview(nil, tt.quality)
+			if err != nil {
+				t.Fatalf("ProcessReview failed: %v", err)
+			}
+
+			if math.Abs(result.Stability-tt.expectedStability) > 0.0001 {
+				t.Errorf("Stability = %.4f, want %.4f", result.Stability, tt.expectedStability)
+			}
+			if math.Abs(result.Difficulty-tt.expectedDifficulty) > 0.0001 {
+				t.Errorf("Difficulty = %.4f, want %.4f", result.Difficulty, tt.expectedDifficulty)
+			}
+			if expectedDue := fixedTime.AddDate(0, 0, tt.expectedDays).Unix(); result.Due != expectedDue {
+				t.Errorf("Due = %d, want %d (%d days)", result.Due, expectedDue, tt.expectedDays)
+			}
+			if result.ConsecutiveCorrect != tt.expectedCorrect || result.TotalReviews != 1 {
+				t.Errorf("Unexpected counters: correct=%d total=%d", result.ConsecutiveCorrect, result.TotalReviews)
+			}
+			if result.LastReview != fixedTime.Unix() {
+				t.Errorf("LastReview = %d, want %d", result.LastReview, fixedTime.Unix())
+			}
+		})
+	}
+}
+
+func TestFSRSCalculatorSubsequentReviews(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	first, err := NewFSRSCalculatorWithTime(start).ProcessReview(nil, CorrectEffort)
+	if err != nil {
+		t.Fatalf("ProcessReview failed: %v", err)
+	}
+
+	// Reviewed on schedule and recalled: stability grows past the last interval
+	onTime := time.Unix(first.Due, 0)
+	recalled, err := NewFSRSCalculatorWithTime(onTime).ProcessReview(first, CorrectEffort)
+	if err != nil {
+		t.Fatalf("ProcessReview failed: %v", err)
+	}
+	if recalled.Stability <= first.Stability {
+		t.Errorf("Expected stability to grow after recall: %.4f -> %.4f", first.Stability, recalled.Stability)
+	}
+	if recalled.Difficulty != first.Difficulty {
+		t.Errorf("Expected Good to keep difficulty at its mean, got %.4f -> %.4f", first.Difficulty, recalled.Difficulty)
+	}
+	if NewFSRSCalculatorWithTime(onTime).GetNextInterval(recalled) <= 4 {
+		t.Errorf("Expected interval beyond the first 4 days")
+	}
+
+	// Forgotten: stability drops, difficulty rises, card due tomorrow
+	lapsed, err := NewFSRSCalculatorWithTime(onTime).ProcessReview(first, IncorrectBlackout)
+	if err != nil {
+		t.Fatalf("ProcessReview failed: %v", err)
+	}
+	if lapsed.Stability >= first.Stability || lapsed.Difficulty <= first.Difficulty {
+		t.Errorf("Expected lapse to reduce stability and raise difficulty: %+v", lapsed)
+	}
+	if lapsed.ConsecutiveCorrect != 0 || lapsed.TotalReviews != 2 {
+		t.Errorf("Unexpected counters after lapse: %+v", lapsed)
+	}
+	if lapsed.Due != onTime.AddDate(0, 0, 1).Unix() {
+		t.Errorf("Expected lapsed card due in 1 day")
+	}
+}
+
+func TestFSRSCalculatorSeedsFromSM2(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	sm2Data := &SRSData{
+		Easiness:           2.5,
+		ConsecutiveCorrect: 3,
+		Due:                now.Unix(),
+		TotalReviews:       3,
+	}
+
+	result, err := NewFSRSCalculatorWithTime(now).ProcessReview(sm2Data, CorrectEffort)
+	if err != nil {
+		t.Fatalf("ProcessReview failed: %v", err)
+	}
+	if result.Stability <= 15 {
+		t.Errorf("Expected stability above the seeded SM-2 interval of 15 days, got %.4f", result.Stability)
+	}
+	if result.Easiness != sm2Data.Easiness || result.ConsecutiveCorrect != 4 {
+		t.Errorf("Expected SM-2 fields carried forward, got %+v", result)
+	}
+}
+
+func TestSeedFSRSFromSM2(t *testing.T) {
+	tests := []struct {
+		name               string
+		easiness           float64
+		consecutive        int
+		intervalDays       float64
+		expectedStability  float64
+		expectedDifficulty float64
+	}{
+		{"known interval", 2.5, 3, 12, 12, 3.6471},
+		{"derived interval", 2.5, 3, 0, 15, 3.6471},
+		{"second repetition", 2.5, 2, 0, 6, 3.6471},
+		{"hardest card", MinEasiness, 0, 0, 1, 10},
+		{"easiest card", 3.2, 5, 0, 6 * 3.2 * 3.2 * 3.2, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stability, difficulty := SeedFSRSFromSM2(tt.easiness, tt.consecutive, tt.intervalDays)
+			if math.Abs(stability-tt.expectedStability) > 0.0001 {
+				t.Errorf("stability = %.4f, want %.4f", stability, tt.expectedStability)
+			}
+			if math.Abs(difficulty-tt.expectedDifficulty) > 0.0001 {
+				t.Errorf("difficulty = %.4f, want %.4f", difficulty, tt.expectedDifficulty)
+			}
+		})
+	}
+}
+
+func TestNewAlgorithm(t *testing.T) {
+	now := time.Now()
+
+	for _, name := range []string{"", AlgorithmSM2} {
+		algorithm, err := NewAlgorithm(name, now)
+		if err != nil {
+			t.Fatalf("NewAlgorithm(%q) failed: %v", name, err)
+		}
+		if _, ok := algorithm.(*SM2Calculator); !ok {
+			t.Errorf("NewAlgorithm(%q) = %T, want *SM2Calculator", name, algorithm)
+		}
+	}
+
+	algorithm, err := NewAlgorithm(AlgorithmFSRS, now)
+	if err != nil {
+		t.Fatalf("NewAlgorithm(fsrs) failed: %v", err)
+	}
+	if _, ok := algorithm.(*FSRSCalculator); !ok {
+		t.Errorf("NewAlgorithm(fsrs) = %T, want *FSRSCalculator", algorithm)
+	}
+
+	if _, err := NewAlgorithm("sm18", now); !errors.Is(err, ErrUnknownAlgorithm) {
+		t.Errorf("Expected ErrUnknownAlgorithm, got %v", err)
+	}
+}
+
+func TestInitialSRSData(t *testing.T) {
+	now := time.Date(2025, 7, 20, 9, 0, 0, 0, time.UTC)
+
+	sm2, err := InitialSRSData(AlgorithmSM2, now)
+	if err != nil {
+		t.Fatalf("InitialSRSData(sm2) failed: %v", err)
+	}
+	if sm2.Easiness != DefaultEasiness || sm2.Due != now.Unix() || sm2.Stability != 0 || sm2.TotalReviews != 0 {
+		t.Errorf("Unexpected SM-2 initial state: %+v", sm2)
+	}
+
+	fsrs, err := InitialSRSData(AlgorithmFSRS, now)
+	if err != nil {
+		t.Fatalf("InitialSRSData(fsrs) failed: %v", err)
+	}
+	if fsrs.Due != now.Unix() || fsrs.Stability != DefaultFSRSWeights[2] || fsrs.Difficulty != DefaultFSRSWeights[4] {
+		t.Errorf("Expected FSRS initial state at the Good grade, got %+v", fsrs)
+	}
+
+	if _, err := InitialSRSData("sm18", now); !errors.Is(err, ErrUnknownAlgorithm) {
+		t.Errorf("Expected ErrUnknownAlgorithm, got %v", err)
+	}
+}
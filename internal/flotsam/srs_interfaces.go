@@ -11,6 +11,7 @@ package flotsam
 
 import (
 	"errors"
+	"fmt"
 	"time"
 )
 
@@ -34,6 +35,29 @@ type Algorithm interface {
 
 	// GetNextInterval returns the number of days until the next review
 	GetNextInterval(data *SRSData) int
+
+	// NewCard returns the state of a card that hasn't been reviewed yet, due now
+	NewCard() *SRSData
+}
+
+// Algorithm names accepted by NewAlgorithm and config.toml
+const (
+	AlgorithmSM2  = "sm2"
+	AlgorithmFSRS = "fsrs"
+)
+
+// NewAlgorithm returns the named scheduling algorithm evaluated at time t.
+// An empty name selects SM-2.
+// AIDEV-NOTE: algorithm-registry; add new schedulers here and to config.validateConfig
+func NewAlgorithm(name string, t time.Time) (Algorithm, error) {
+	switch name {
+	case "", AlgorithmSM2:
+		return NewSM2CalculatorWithTime(t), nil
+	case AlgorithmFSRS:
+		return NewFSRSCalculatorWithTime(t), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownAlgorithm, name)
+	}
 }
 
 // SRS Storage Interface - adapted from go-srs db.Handler for flotsam file-based storage
@@ -105,7 +129,7 @@ type FlotsamNote struct {
 
 	// Content
 	Body      string   `yaml:"-" json:"-"` // Markdown body content
-	Links     []string `yaml:"-" json:"-"` // Extracted [[wikilinks]]
+	Links     []string `yaml:"-" json:"-"` // Targets of links to other notes
 	Backlinks []string `yaml:"-" json:"-"` // Computed reverse links
 	FilePath  string   `yaml:"-" json:"-"` // Absolute file path
 
@@ -162,6 +186,15 @@ var (
 
 	// ErrStorageFailure is returned for file system or storage errors
 	ErrStorageFailure = errors.New("storage operation failed")
+
+	// ErrSessionComplete is returned when a review session has no cards left
+	ErrSessionComplete = errors.New("review session complete")
+
+	// ErrSessionNotFound is returned when a paused session cannot be located
+	ErrSessionNotFound = errors.New("review session not found")
+
+	// ErrUnknownAlgorithm is returned for unsupported SRS algorithm names
+	ErrUnknownAlgorithm = errors.New("unknown SRS algorithm")
 )
 
 // SRS Configuration and Options
@@ -169,7 +202,7 @@ var (
 
 // SRSConfig holds configuration for SRS behavior
 type SRSConfig struct {
-	// Algorithm to use (currently only SM-2 supported)
+	// Algorithm to use: "sm2" (default) or "fsrs"
 	Algorithm string `yaml:"algorithm" json:"algorithm"`
 
 	// Default quality for new cards
@@ -191,7 +224,7 @@ type SRSConfig struct {
 // DefaultSRSConfig returns the default SRS configuration
 func DefaultSRSConfig() *SRSConfig {
 	return &SRSConfig{
-		Algorithm:               "sm2",
+		Algorithm:               AlgorithmSM2,
 		DefaultQuality:          NoReview,
 		MaxCardsPerSession:      50,
 		IncludeHistory:          true,
@@ -204,6 +237,7 @@ func DefaultSRSConfig() *SRSConfig {
 // AIDEV-NOTE: session management for batched reviews
 type ReviewSession struct {
 	// Session metadata
+	SessionID string    `json:"session_id"`
 	StartTime time.Time `json:"start_time"`
 	Context   string    `json:"context"`
 
@@ -217,6 +251,12 @@ type ReviewSession struct {
 	CorrectCount  int `json:"correct_count"`
 	ReviewedCount int `json:"reviewed_count"`
 
+	// Timing for the card currently being shown
+	CardStartedAt time.Time `json:"card_started_at"`
+
+	// Review record accumulated as cards are graded
+	Review *FlotsamReview `json:"review"`
+
 	// Session statistics
 	SessionStats *SessionStats `json:"session_stats"`
 }
@@ -0,0 +1,416 @@
+// Package flotsam provides review session management for SRS-scheduled notes.
+// This file implements ReviewSessionManager on top of the SRS database.
+// AIDEV-NOTE: review-session; walks srs.Database due queue, grades via Algorithm, persists via RecordReview
+package flotsam
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/davidlee/vice/internal/srs"
+)
+
+// SessionManager implements ReviewSessionManager backed by the SRS database.
+// Note content is read from the markdown files referenced by the database rows.
+type SessionManager struct {
+	db         *srs.Database
+	sessionDir string
+
+	// now returns the current time (overridable for testing)
+	now func() time.Time
+	// algorithmAt builds the scheduling algorithm for a review performed at t
+	algorithmAt func(t time.Time) Algorithm
+}
+
+// Compile-time check that SessionManager satisfies ReviewSessionManager.
+var _ ReviewSessionManager = (*SessionManager)(nil)
+
+// NewSessionManager creates a session manager using SM-2 scheduling.
+// sessionDir is where paused sessions are stored (created on demand).
+func NewSessionManager(db *srs.Database, sessionDir string) *SessionManager {
+	return &SessionManager{
+		db:         db,
+		sessionDir: sessionDir,
+		now:        time.Now,
+		algorithmAt: func(t time.Time) Algorithm {
+			return NewSM2CalculatorWithTime(t)
+		},
+	}
+}
+
+// UseAlgorithm selects the scheduling algorithm by name (see NewAlgorithm).
+func (m *SessionManager) UseAlgorithm(name string) error {
+	if _, err := NewAlgorithm(name, m.now()); err != nil {
+		return err
+	}
+
+	m.algorithmAt = func(t time.Time) Algorithm {
+		algorithm, _ := NewAlgorithm(name, t) //nolint:errcheck // name validated above
+		return algorithm
+	}
+	return nil
+}
+
+// StartSession loads the due queue for a context and begins a new session.
+// Notes whose files can no longer be read are skipped. maxCards <= 0 means no limit.
+func (m *SessionManager) StartSession(context string, maxCards int) (*ReviewSession, error) {
+	if context == "" {
+		return nil, ErrInvalidContext
+	}
+
+	dueNotes, err := m.db.GetDueNotes(context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load due notes: %w", err)
+	}
+
+	var cards []*FlotsamNote
+	for _, dueNote := range dueNotes {
+		if maxCards > 0 && len(cards) >= maxCards {
+			break
+		}
+
+		card, err := m.loadCard(dueNote.NotePath)
+		if err != nil {
+			// File deleted or unreadable since it was scheduled - skip it
+			continue
+		}
+		cards = append(cards, card)
+	}
+
+	start := m.now()
+	sessionID := start.Format("20060102-150405")
+	review := CreateFlotsamReview(context, sessionID)
+	review.Timestamp = start
+
+	return &ReviewSession{
+		SessionID:     sessionID,
+		StartTime:     start,
+		Context:       context,
+		DueCards:      cards,
+		ReviewedCards: make([]*FlotsamNote, 0, len(cards)),
+		TotalCards:    len(cards),
+		CardStartedAt: start,
+		Review:        review,
+	}, nil
+}
+
+// GetCurrentCard returns the card awaiting review, or ErrSessionComplete.
+func (m *SessionManager) GetCurrentCard(session *ReviewSession) (*FlotsamNote, error) {
+	if session == nil {
+		return nil, errors.New("session cannot be nil")
+	}
+
+	if session.CurrentIndex >= len(session.DueCards) {
+		return nil, ErrSessionComplete
+	}
+
+	return session.DueCards[session.CurrentIndex], nil
+}
+
+// SubmitReview grades the current card, persists the new schedule and review log entry, and advances.
+func (m *SessionManager) SubmitReview(session *ReviewSession, quality Quality) error {
+	if err := quality.Validate(); err != nil {
+		return err
+	}
+
+	card, err := m.GetCurrentCard(session)
+	if err != nil {
+		return err
+	}
+
+	now := m.now()
+
+	// New cards have no meaningful history; treat them as such for the algorithm
+	var previous *SRSData
+	if card.SRS != nil && card.SRS.TotalReviews > 0 {
+		previous = card.SRS
+	}
+
+	updated, err := m.algorithmAt(now).ProcessReview(previous, quality)
+	if err != nil {
+		return fmt.Errorf("failed to process review for %s: %w", card.ID, err)
+	}
+
+	reviewTime := now.Sub(session.CardStartedAt)
+	if reviewTime < 0 {
+		reviewTime = 0
+	}
+
+	logEntry := &srs.ReviewLogEntry{
+		NotePath:   card.FilePath,
+		SessionID:  session.SessionID,
+		ReviewedAt: now,
+		Quality:    int(quality),
+		Previous:   toDatabaseSRS(previous),
+		Updated:    *toDatabaseSRS(updated),
+		TimeSpent:  reviewTime,
+	}
+	if err := m.db.RecordReview(logEntry); err != nil {
+		return fmt.Errorf("failed to save review for %s: %w", card.ID, err)
+	}
+
+	if session.Review == nil {
+		session.Review = CreateFlotsamReview(session.Context, session.SessionID)
+	}
+	session.Review.AddReviewItem(card.ID, quality, reviewTime, previous, updated)
+	session.Review.Items[len(session.Review.Items)-1].ReviewedAt = now
+
+	card.SRS = updated
+	session.ReviewedCards = append(session.ReviewedCards, card)
+	session.ReviewedCount++
+	if quality.IsCorrect() {
+		session.CorrectCount++
+	}
+	session.CurrentIndex++
+	session.CardStartedAt = now
+
+	return nil
+}
+
+// CompleteSession finalizes the session, discards any paused state and returns statistics.
+func (m *SessionManager) CompleteSession(session *ReviewSession) (*SessionStats, error) {
+	if session == nil {
+		return nil, errors.New("session cannot be nil")
+	}
+
+	if session.Review == nil {
+		session.Review = CreateFlotsamReview(session.Context, session.SessionID)
+	}
+	session.Review.CompleteReview()
+
+	stats := &SessionStats{
+		Duration:         m.now().Sub(session.StartTime),
+		CardsReviewed:    session.Review.GetReviewCount(),
+		CorrectAnswers:   session.Review.GetCorrectCount(),
+		IncorrectAnswers: session.Review.GetIncorrectCount(),
+		SuccessRate:      session.Review.GetSuccessRate(),
+		AverageTime:      session.Review.GetAverageReviewTime(),
+	}
+	session.SessionStats = stats
+
+	// A completed session can no longer be resumed
+	if err := os.Remove(m.sessionPath(session.SessionID)); err != nil && !os.IsNotExist(err) {
+		return stats, fmt.Errorf("failed to remove paused session: %w", err)
+	}
+
+	return stats, nil
+}
+
+// pausedSession is the on-disk form of a paused ReviewSession.
+// Card paths are stored rather than notes so content is re-read on resume.
+type pausedSession struct {
+	SessionID     string         `json:"session_id"`
+	Context       string         `json:"context"`
+	StartTime     time.Time      `json:"start_time"`
+	NotePaths     []string       `json:"note_paths"`
+	CurrentIndex  int            `json:"current_index"`
+	CorrectCount  int            `json:"correct_count"`
+	ReviewedCount int            `json:"reviewed_count"`
+	Review        *FlotsamReview `json:"review"`
+}
+
+// PauseSession writes the session state to the session directory.
+func (m *SessionManager) PauseSession(session *ReviewSession) error {
+	if session == nil {
+		return errors.New("session cannot be nil")
+	}
+
+	if session.SessionID == "" {
+		return errors.New("session ID cannot be empty")
+	}
+
+	paused := pausedSession{
+		SessionID:     session.SessionID,
+		Context:       session.Context,
+		StartTime:     session.StartTime,
+		NotePaths:     make([]string, 0, len(session.DueCards)),
+		CurrentIndex:  session.CurrentIndex,
+		CorrectCount:  session.CorrectCount,
+		ReviewedCount: session.ReviewedCount,
+		Review:        session.Review,
+	}
+	for _, card := range session.DueCards {
+		paused.NotePaths = append(paused.NotePaths, card.FilePath)
+	}
+
+	data, err := json.MarshalIndent(paused, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize session: %w", err)
+	}
+
+	if err := os.MkdirAll(m.sessionDir, 0o750); err != nil {
+		return fmt.Errorf("failed to create session directory: %w", err)
+	}
+
+	if err := os.WriteFile(m.sessionPath(session.SessionID), data, 0o600); err != nil {
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+
+	return nil
+}
+
+// ResumeSession reloads a paused session, re-reading note content and SRS state.
+func (m *SessionManager) ResumeSession(sessionID string) (*ReviewSession, error) {
+	data, err := os.ReadFile(m.sessionPath(sessionID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to read session: %w", err)
+	}
+
+	var paused pausedSession
+	if err := json.Unmarshal(data, &paused); err != nil {
+		return nil, fmt.Errorf("failed to parse session: %w", err)
+	}
+
+	session := &ReviewSession{
+		SessionID:     paused.SessionID,
+		StartTime:     paused.StartTime,
+		Context:       paused.Context,
+		ReviewedCards: make([]*FlotsamNote, 0, len(paused.NotePaths)),
+		CorrectCount:  paused.CorrectCount,
+		ReviewedCount: paused.ReviewedCount,
+		CardStartedAt: m.now(),
+		Review:        paused.Review,
+	}
+
+	for i, notePath := range paused.NotePaths {
+		card, err := m.loadCard(notePath)
+		if err != nil {
+			// Drop cards that vanished while paused
+			continue
+		}
+		session.DueCards = append(session.DueCards, card)
+		if i < paused.CurrentIndex {
+			session.ReviewedCards = append(session.ReviewedCards, card)
+		}
+	}
+
+	// Keep the index aligned with any cards dropped above
+	session.CurrentIndex = len(session.ReviewedCards)
+	session.TotalCards = len(session.DueCards)
+
+	return session, nil
+}
+
+// sessionPath returns the file used to store a paused session.
+func (m *SessionManager) sessionPath(sessionID string) string {
+	return filepath.Join(m.sessionDir, sessionID+".json")
+}
+
+// loadCard reads a note from disk and attaches its SRS state from the database.
+func (m *SessionManager) loadCard(notePath string) (*FlotsamNote, error) {
+	note, err := ParseFlotsamFile(notePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if note.Type == "" {
+		note.Type = noteTypeFromTags(note.Tags)
+	}
+	if note.ID == "" {
+		note.ID = ExtractIDFromFilename(filepath.Base(notePath))
+	}
+
+	data, err := m.db.GetSRSData(notePath)
+	if err != nil {
+		return nil, err
+	}
+	note.SRS = toFlotsamSRS(data)
+
+	return note, nil
+}
+
+// noteTypeFromTags returns the note type from the first vice:type:* tag.
+func noteTypeFromTags(tags []string) string {
+	for _, tag := range tags {
+		if noteType, ok := ParseViceTag(tag); ok {
+			return noteType
+		}
+	}
+	return ""
+}
+
+// InitialSRSData returns the database state for a note entering SRS scheduling
+// at t under the named algorithm (see NewAlgorithm): due immediately.
+func InitialSRSData(algorithm string, t time.Time) (*srs.SRSData, error) {
+	calc, err := NewAlgorithm(algorithm, t)
+	if err != nil {
+		return nil, err
+	}
+	return toDatabaseSRS(calc.NewCard()), nil
+}
+
+// toFlotsamSRS converts database scheduling data to the algorithm representation.
+func toFlotsamSRS(data *srs.SRSData) *SRSData {
+	if data == nil {
+		return nil
+	}
+	return &SRSData{
+		Easiness:           data.Easiness,
+		ConsecutiveCorrect: data.ConsecutiveCorrect,
+		Due:                data.Due,
+		TotalReviews:       data.TotalReviews,
+		LastReview:         data.LastReview,
+		Stability:          data.Stability,
+		Difficulty:         data.Difficulty,
+	}
+}
+
+// toDatabaseSRS converts algorithm scheduling data to the database representation.
+func toDatabaseSRS(data *SRSData) *srs.SRSData {
+	if data == nil {
+		return nil
+	}
+	return &srs.SRSData{
+		Easiness:           data.Easiness,
+		ConsecutiveCorrect: data.ConsecutiveCorrect,
+		Due:                data.Due,
+		TotalReviews:       data.TotalReviews,
+		LastReview:         data.LastReview,
+		Stability:          data.Stability,
+		Difficulty:         data.Difficulty,
+	}
+}
+
+// flashcardSectionPattern matches "## Question" / "## Answer" style headings.
+var flashcardSectionPattern = regexp.MustCompile(`(?im)^#{1,6}\s+(question|answer)\s*$`)
+
+// SplitFlashcard returns the prompt and answer to show when reviewing a note.
+// Flashcards with Question/Answer sections are split on those headings; any
+// other note uses its title as the prompt and its body as the answer.
+func SplitFlashcard(note *FlotsamNote) (question, answer string) {
+	if note == nil {
+		return "", ""
+	}
+
+	matches := flashcardSectionPattern.FindAllStringSubmatchIndex(note.Body, -1)
+	sections := make(map[string]string)
+	for i, match := range matches {
+		name := strings.ToLower(note.Body[match[2]:match[3]])
+		end := len(note.Body)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		sections[name] = strings.TrimSpace(note.Body[match[1]:end])
+	}
+
+	question, hasQuestion := sections["question"]
+	answer, hasAnswer := sections["answer"]
+
+	if !hasQuestion || question == "" {
+		question = note.Title
+	}
+	if !hasAnswer {
+		answer = strings.TrimSpace(note.Body)
+	}
+
+	return question, answer
+}
@@ -0,0 +1,265 @@
+package flotsam
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/davidlee/vice/internal/srs"
+)
+
+// setupSessionTest creates a context with an SRS database and due notes on disk.
+func setupSessionTest(t *testing.T, noteIDs ...string) (*SessionManager, *srs.Database, string) {
+	t.Helper()
+
+	contextDir := t.TempDir()
+	flotsamDir := filepath.Join(contextDir, "flotsam")
+	if err := os.MkdirAll(flotsamDir, 0o750); err != nil {
+		t.Fatalf("Failed to create flotsam dir: %v", err)
+	}
+
+	db, err := srs.NewDatabase(contextDir, "test")
+	if err != nil {
+		t.Fatalf("Failed to open SRS database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	due := time.Now().Add(-time.Hour).Unix()
+	for _, id := range noteIDs {
+		notePath := filepath.Join(flotsamDir, id+".md")
+		content := "---\nid: " + id + "\ntitle: Note " + id + "\ntags: ['vice:type:flashcard']\n---\n\n" +
+			"# Note " + id + "\n\n## Question\n\nWhat is " + id + "?\n\n## Answer\n\n" + id + " is a test.\n"
+		if err := os.WriteFile(notePath, []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write note: %v", err)
+		}
+		if err := db.CreateSRSNote(notePath, id, "test", &srs.SRSData{Easiness: DefaultEasiness, Due: due}); err != nil {
+			t.Fatalf("Failed to create SRS note: %v", err)
+		}
+		due++ // deterministic due ordering
+	}
+
+	manager := NewSessionManager(db, filepath.Join(flotsamDir, ".vice", "sessions"))
+	return manager, db, flotsamDir
+}
+
+func TestSessionManagerReviewFlow(t *testing.T) {
+	manager, db, flotsamDir := setupSessionTest(t, "abc1", "abc2")
+
+	fixedTime := time.Now()
+	manager.now = func() time.Time { return fixedTime }
+
+	session, err := manager.StartSession("test", 0)
+	if err != nil {
+		t.Fatalf("StartSession failed: %v", err)
+	}
+	if session.TotalCards != 2 {
+		t.Fatalf("Expected 2 cards, got %d", session.TotalCards)
+	}
+
+	card, err := manager.GetCurrentCard(session)
+	if err != nil {
+		t.Fatalf("GetCurrentCard failed: %v", err)
+	}
+	if card.ID != "abc1" || card.Type != TypeFlashcard {
+		t.Errorf("Unexpected first card: id=%s type=%s", card.ID, card.Type)
+	}
+
+	fixedTime = fixedTime.Add(10 * time.Second)
+	if err := manager.SubmitReview(session, CorrectEasy); err != nil {
+		t.Fatalf("SubmitReview failed: %v", err)
+	}
+	fixedTime = fixedTime.Add(20 * time.Second)
+	if err := manager.SubmitReview(session, IncorrectBlackout); err != nil {
+		t.Fatalf("SubmitReview failed: %v", err)
+	}
+
+	if _, err := manager.GetCurrentCard(session); !errors.Is(err, ErrSessionComplete) {
+		t.Errorf("Expected ErrSessionComplete, got %v", err)
+	}
+	if err := manager.SubmitReview(session, CorrectEasy); !errors.Is(err, ErrSessionComplete) {
+		t.Errorf("Expected ErrSessionComplete on extra submit, got %v", err)
+	}
+
+	// Schedule persisted to the database
+	data, err := db.GetSRSData(filepath.Join(flotsamDir, "abc1.md"))
+	if err != nil {
+		t.Fatalf("GetSRSData failed: %v", err)
+	}
+	if data.TotalReviews != 1 || data.ConsecutiveCorrect != 1 {
+		t.Errorf("Unexpected persisted SRS data: %+v", data)
+	}
+	if data.Due <= fixedTime.Unix() {
+		t.Errorf("Expected correct card to be scheduled in the future")
+	}
+
+	// Review recorded in the history log
+	history, err := db.GetReviewHistory(filepath.Join(flotsamDir, "abc1.md"))
+	if err != nil {
+		t.Fatalf("GetReviewHistory failed: %v", err)
+	}
+	if len(history) != 1 || history[0].SessionID != session.SessionID || history[0].Quality != int(CorrectEasy) {
+		t.Errorf("Unexpected review history: %+v", history)
+	}
+	if history[0].TimeSpent != 10*time.Second || history[0].Previous != nil {
+		t.Errorf("Expected 10s first review without previous state, got %+v", history[0])
+	}
+
+	stats, err := manager.CompleteSession(session)
+	if err != nil {
+		t.Fatalf("CompleteSession failed: %v", err)
+	}
+	if stats.CardsReviewed != 2 || stats.CorrectAnswers != 1 || stats.IncorrectAnswers != 1 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+	if stats.SuccessRate != 50 {
+		t.Errorf("Expected 50%% success rate, got %.1f", stats.SuccessRate)
+	}
+	if stats.AverageTime != 15*time.Second {
+		t.Errorf("Expected 15s average time, got %v", stats.AverageTime)
+	}
+	if !session.Review.Completed || !session.Review.HasNewCards() {
+		t.Errorf("Expected completed review containing new cards")
+	}
+}
+
+func TestSessionManagerMaxCardsAndMissingFiles(t *testing.T) {
+	manager, _, flotsamDir := setupSessionTest(t, "abc1", "abc2", "abc3")
+
+	if err := os.Remove(filepath.Join(flotsamDir, "abc1.md")); err != nil {
+		t.Fatalf("Failed to remove note: %v", err)
+	}
+
+	session, err := manager.StartSession("test", 1)
+	if err != nil {
+		t.Fatalf("StartSession failed: %v", err)
+	}
+	if session.TotalCards != 1 || session.DueCards[0].ID != "abc2" {
+		t.Errorf("Expected only abc2 in session, got %d cards", session.TotalCards)
+	}
+
+	if _, err := manager.StartSession("", 0); !errors.Is(err, ErrInvalidContext) {
+		t.Errorf("Expected ErrInvalidContext for empty context, got %v", err)
+	}
+}
+
+func TestSessionManagerPauseResume(t *testing.T) {
+	manager, _, _ := setupSessionTest(t, "abc1", "abc2", "abc3")
+
+	session, err := manager.StartSession("test", 0)
+	if err != nil {
+		t.Fatalf("StartSession failed: %v", err)
+	}
+	if err := manager.SubmitReview(session, CorrectEffort); err != nil {
+		t.Fatalf("SubmitReview failed: %v", err)
+	}
+	if err := manager.PauseSession(session); err != nil {
+		t.Fatalf("PauseSession failed: %v", err)
+	}
+
+	resumed, err := manager.ResumeSession(session.SessionID)
+	if err != nil {
+		t.Fatalf("ResumeSession failed: %v", err)
+	}
+	if resumed.CurrentIndex != 1 || resumed.TotalCards != 3 || resumed.CorrectCount != 1 {
+		t.Errorf("Unexpected resumed state: index=%d total=%d correct=%d",
+			resumed.CurrentIndex, resumed.TotalCards, resumed.CorrectCount)
+	}
+	if resumed.DueCards[0].SRS.TotalReviews != 1 {
+		t.Errorf("Expected reviewed card to reload persisted SRS data")
+	}
+
+	card, err := manager.GetCurrentCard(resumed)
+	if err != nil || card.ID != "abc2" {
+		t.Errorf("Expected abc2 as current card, got %v (%v)", card, err)
+	}
+
+	if _, err := manager.CompleteSession(resumed); err != nil {
+		t.Fatalf("CompleteSession failed: %v", err)
+	}
+	if _, err := manager.ResumeSession(session.SessionID); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("Expected completed session to be removed, got %v", err)
+	}
+}
+
+func TestSessionManagerUseAlgorithm(t *testing.T) {
+	manager, db, flotsamDir := setupSessionTest(t, "abc1")
+
+	if err := manager.UseAlgorithm("sm18"); !errors.Is(err, ErrUnknownAlgorithm) {
+		t.Fatalf("Expected ErrUnknownAlgorithm, got %v", err)
+	}
+	if err := manager.UseAlgorithm(AlgorithmFSRS); err != nil {
+		t.Fatalf("UseAlgorithm failed: %v", err)
+	}
+
+	session, err := manager.StartSession("test", 0)
+	if err != nil {
+		t.Fatalf("StartSession failed: %v", err)
+	}
+	if err := manager.SubmitReview(session, CorrectEffort); err != nil {
+		t.Fatalf("SubmitReview failed: %v", err)
+	}
+
+	data, err := db.GetSRSData(filepath.Join(flotsamDir, "abc1.md"))
+	if err != nil {
+		t.Fatalf("GetSRSData failed: %v", err)
+	}
+	if data.Stability == 0 || data.Difficulty == 0 {
+		t.Errorf("Expected FSRS state to be persisted, got %+v", data)
+	}
+
+	history, err := db.GetReviewHistory(filepath.Join(flotsamDir, "abc1.md"))
+	if err != nil || len(history) != 1 || history[0].Updated.Stability != data.Stability {
+		t.Errorf("Expected FSRS state in review log, got %+v (%v)", history, err)
+	}
+}
+
+func TestSplitFlashcard(t *testing.T) {
+	tests := []struct {
+		name           string
+		note           *FlotsamNote
+		expectQuestion string
+		expectAnswer   string
+	}{
+		{
+			name: "question and answer sections",
+			note: &FlotsamNote{
+				Title: "Capital",
+				Body:  "# Capital\n\n## Question\n\nCapital of France?\n\n## Answer\n\nParis\n",
+			},
+			expectQuestion: "Capital of France?",
+			expectAnswer:   "Paris",
+		},
+		{
+			name: "answer section only falls back to title",
+			note: &FlotsamNote{
+				Title: "Capital",
+				Body:  "### answer\nParis",
+			},
+			expectQuestion: "Capital",
+			expectAnswer:   "Paris",
+		},
+		{
+			name: "plain note uses title and body",
+			note: &FlotsamNote{
+				Title: "An idea",
+				Body:  "\nSome thoughts.\n",
+			},
+			expectQuestion: "An idea",
+			expectAnswer:   "Some thoughts.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			question, answer := SplitFlashcard(tt.note)
+			if question != tt.expectQuestion {
+				t.Errorf("question = %q, want %q", question, tt.expectQuestion)
+			}
+			if answer != tt.expectAnswer {
+				t.Errorf("answer = %q, want %q", answer, tt.expectAnswer)
+			}
+		})
+	}
+}
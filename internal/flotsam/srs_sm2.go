@@ -81,6 +81,12 @@ type SRSData struct {
 	Due int64 `yaml:"due" json:"due"`
 	// Total number of reviews performed
 	TotalReviews int `yaml:"total_reviews" json:"total_reviews"`
+	// Unix timestamp of the most recent review (0 if unknown)
+	LastReview int64 `yaml:"last_review,omitempty" json:"last_review,omitempty"`
+	// FSRS memory stability in days (0 if not using FSRS)
+	Stability float64 `yaml:"stability,omitempty" json:"stability,omitempty"`
+	// FSRS difficulty between 1 and 10 (0 if not using FSRS)
+	Difficulty float64 `yaml:"difficulty,omitempty" json:"difficulty,omitempty"`
 	// Optional: Review history for debugging/analysis
 	ReviewHistory []ReviewRecord `yaml:"review_history,omitempty" json:"review_history,omitempty"`
 }
@@ -126,6 +132,8 @@ func (calc *SM2Calculator) ProcessReview(oldData *SRSData, quality Quality) (*SR
 		newData = calc.updateCard(*oldData, quality)
 	}
 
+	newData.LastReview = calc.now.Unix()
+
 	// Add review to history
 	newData.ReviewHistory = append(newData.ReviewHistory, ReviewRecord{
 		Timestamp: calc.now.Unix(),
@@ -238,6 +246,14 @@ func (calc *SM2Calculator) GetDueTime(data *SRSData) time.Time {
 	return time.Unix(data.Due, 0)
 }
 
+// NewCard returns the state of a card that hasn't been reviewed yet, due now
+func (calc *SM2Calculator) NewCard() *SRSData {
+	return &SRSData{
+		Easiness: DefaultEasiness,
+		Due:      calc.now.Unix(),
+	}
+}
+
 // GetNextInterval returns the number of days until the next review
 func (calc *SM2Calculator) GetNextInterval(data *SRSData) int {
 	if data == nil {
@@ -0,0 +1,206 @@
+// Package flotsam provides note templates for `vice flotsam add`.
+// AIDEV-NOTE: note-templates; <context>/templates/<name>.md are Go text/templates; <type>.md overrides the
+// built-in default for that vice:type:*. Both {{.Title}} and handlebars-style {{title}} work.
+package flotsam
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// templateExt is the file extension of note templates.
+const templateExt = ".md"
+
+// TemplateData is what a note template is rendered with.
+type TemplateData struct {
+	Title   string
+	ID      string
+	Date    string // Creation date, YYYY-MM-DD
+	Context string
+	Type    string
+	Created time.Time // For custom formats, e.g. {{.Created.Format "15:04"}}
+}
+
+// templateFuncs exposes TemplateData fields as handlebars-style {{title}} etc.
+func templateFuncs(data TemplateData) template.FuncMap {
+	return template.FuncMap{
+		"title":   func() string { return data.Title },
+		"id":      func() string { return data.ID },
+		"date":    func() string { return data.Date },
+		"context": func() string { return data.Context },
+		"type":    func() string { return data.Type },
+	}
+}
+
+// builtinTemplates are the default note bodies for each note type.
+var builtinTemplates = map[string]string{
+	"flashcard": "# {{.Title}}\n\n## Question\n\n{{.Title}}\n\n## Answer\n\n<!-- Add your answer here -->\n",
+	"idea":      "# {{.Title}}\n\n<!-- Develop your idea here -->\n",
+	"script":    "# {{.Title}}\n\n```bash\n#!/bin/bash\n# {{.Title}}\n\n# Add your script here\n```\n",
+	"log":       "# {{.Title}} - {{.Date}}\n\n<!-- Daily log entry -->\n",
+}
+
+// fallbackTemplate is used for note types without a built-in template.
+const fallbackTemplate = "# {{.Title}}\n\n<!-- Add content here -->\n"
+
+// NoteTemplate is a parsed, validated note template.
+type NoteTemplate struct {
+	Name string
+	Path string // Empty for built-in templates
+	tmpl *template.Template
+}
+
+// ParseNoteTemplate parses and validates a note template. It's rendered once with
+// sample data so unknown variables are reported now rather than when a note is
+// created, and a template named after the flashcard type must have Question and
+// Answer sections.
+func ParseNoteTemplate(name, text string) (*NoteTemplate, error) {
+	tmpl, err := template.New(name).Funcs(templateFuncs(TemplateData{})).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template %s: %w", name, err)
+	}
+
+	noteTemplate := &NoteTemplate{Name: name, tmpl: tmpl}
+	if err := noteTemplate.ValidateFor(name); err != nil {
+		return nil, err
+	}
+	return noteTemplate, nil
+}
+
+// ValidateFor checks the template renders for a note of the given type, using sample data.
+func (t *NoteTemplate) ValidateFor(noteType string) error {
+	now := time.Now()
+	_, err := t.Render(TemplateData{
+		Title:   "Sample",
+		ID:      "abcd",
+		Date:    now.Format("2006-01-02"),
+		Context: "personal",
+		Type:    noteType,
+		Created: now,
+	})
+	return err
+}
+
+// Render renders the template for a note. Flashcards must come out with Question
+// and Answer sections so reviews can show the question before the answer.
+func (t *NoteTemplate) Render(data TemplateData) (string, error) {
+	tmpl, err := t.tmpl.Clone()
+	if err != nil {
+		return "", fmt.Errorf("failed to clone template %s: %w", t.Name, err)
+	}
+
+	var body strings.Builder
+	if err := tmpl.Funcs(templateFuncs(data)).Execute(&body, data); err != nil {
+		return "", fmt.Errorf("invalid template %s: %w", t.Name, err)
+	}
+
+	if data.Type == "flashcard" && !hasFlashcardSections(body.String()) {
+		return "", fmt.Errorf("invalid template %s: flashcards need \"## Question\" and \"## Answer\" sections", t.Name)
+	}
+	return body.String(), nil
+}
+
+// hasFlashcardSections reports whether body has both Question and Answer headings.
+func hasFlashcardSections(body string) bool {
+	found := make(map[string]bool)
+	for _, match := range flashcardSectionPattern.FindAllStringSubmatch(body, -1) {
+		found[strings.ToLower(match[1])] = true
+	}
+	return found["question"] && found["answer"]
+}
+
+// DefaultTemplate returns the built-in template for a note type.
+func DefaultTemplate(noteType string) *NoteTemplate {
+	text, found := builtinTemplates[noteType]
+	if !found {
+		text = fallbackTemplate
+	}
+	return &NoteTemplate{
+		Name: noteType,
+		tmpl: template.Must(template.New(noteType).Funcs(templateFuncs(TemplateData{})).Parse(text)),
+	}
+}
+
+// TemplateSet is the note templates in a context's templates directory.
+type TemplateSet struct {
+	Dir       string
+	templates map[string]*NoteTemplate
+}
+
+// LoadTemplates loads and validates every template in dir. A missing directory
+// gives an empty set; invalid templates are all reported together.
+func LoadTemplates(dir string) (*TemplateSet, error) {
+	set := &TemplateSet{Dir: dir, templates: make(map[string]*NoteTemplate)}
+
+	files, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return set, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read templates directory %s: %w", dir, err)
+	}
+
+	var errs []error
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != templateExt {
+			continue
+		}
+		path := filepath.Join(dir, file.Name())
+		text, err := os.ReadFile(path) // #nosec G304 -- path is in the context's templates directory
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to read template %s: %w", path, err))
+			continue
+		}
+
+		name := strings.TrimSuffix(file.Name(), templateExt)
+		noteTemplate, err := ParseNoteTemplate(name, string(text))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+			continue
+		}
+		noteTemplate.Path = path
+		set.templates[name] = noteTemplate
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return set, nil
+}
+
+// Get returns the template with the given name (with or without the .md extension).
+func (s *TemplateSet) Get(name string) (*NoteTemplate, error) {
+	noteTemplate, found := s.templates[strings.TrimSuffix(name, templateExt)]
+	if !found {
+		available := s.Names()
+		if len(available) == 0 {
+			return nil, fmt.Errorf("template not found: %s (no templates in %s)", name, s.Dir)
+		}
+		return nil, fmt.Errorf("template not found: %s (available: %s)", name, strings.Join(available, ", "))
+	}
+	return noteTemplate, nil
+}
+
+// ForType returns the template for a note type: <type>.md if present, otherwise the built-in default.
+func (s *TemplateSet) ForType(noteType string) *NoteTemplate {
+	if noteTemplate, found := s.templates[noteType]; found {
+		return noteTemplate
+	}
+	return DefaultTemplate(noteType)
+}
+
+// Names returns the names of the loaded templates in sorted order.
+func (s *TemplateSet) Names() []string {
+	names := make([]string, 0, len(s.templates))
+	for name := range s.templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,145 @@
+package flotsam
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNoteTemplate_Render(t *testing.T) {
+	data := TemplateData{
+		Title:   "What is X?",
+		ID:      "ab12",
+		Date:    "2025-07-20",
+		Context: "work",
+		Type:    "idea",
+		Created: time.Date(2025, 7, 20, 9, 30, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"fields", "{{.Title}} {{.ID}} {{.Date}} {{.Context}} {{.Type}}", "What is X? ab12 2025-07-20 work idea"},
+		{"handlebars style", "{{title}} {{id}} {{date}} {{context}} {{type}}", "What is X? ab12 2025-07-20 work idea"},
+		{"created format", `{{.Created.Format "15:04"}}`, "09:30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			noteTemplate, err := ParseNoteTemplate("custom", tt.text)
+			if err != nil {
+				t.Fatalf("ParseNoteTemplate() error = %v", err)
+			}
+			got, err := noteTemplate.Render(data)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseNoteTemplate_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		tmpl    string
+		text    string
+		wantErr string
+	}{
+		{"syntax", "idea", "# {{.Title}\n", "invalid template idea"},
+		{"unknown variable", "idea", "# {{.Author}}\n", "can't evaluate field Author"},
+		{"unknown function", "idea", "# {{author}}\n", `function "author" not defined`},
+		{"flashcard without sections", "flashcard", "# {{.Title}}\n\n## Answer\n", "## Question"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseNoteTemplate(tt.tmpl, tt.text)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseNoteTemplate() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadTemplates(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, text string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(text), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("flashcard.md", "## Question\n\n{{title}}?\n\n## Answer\n\n")
+	write("standup.md", "# Standup {{date}}\n")
+	write("README.txt", "not a template")
+
+	templates, err := LoadTemplates(dir)
+	if err != nil {
+		t.Fatalf("LoadTemplates() error = %v", err)
+	}
+	if got := strings.Join(templates.Names(), ","); got != "flashcard,standup" {
+		t.Errorf("Names() = %s", got)
+	}
+
+	// <type>.md replaces the built-in default for that type only
+	if templates.ForType("flashcard").Path != filepath.Join(dir, "flashcard.md") {
+		t.Error("Expected flashcard.md for flashcards")
+	}
+	body, err := templates.ForType("idea").Render(TemplateData{Title: "Idea", Type: "idea"})
+	if err != nil || body != "# Idea\n\n<!-- Develop your idea here -->\n" {
+		t.Errorf("Expected the built-in idea template, got %q (%v)", body, err)
+	}
+
+	if _, err := templates.Get("standup.md"); err != nil {
+		t.Errorf("Get() error = %v", err)
+	}
+	if _, err := templates.Get("weekly"); err == nil || !strings.Contains(err.Error(), "available: flashcard, standup") {
+		t.Errorf("Expected a not found error listing templates, got %v", err)
+	}
+
+	t.Run("missing directory", func(t *testing.T) {
+		templates, err := LoadTemplates(filepath.Join(dir, "missing"))
+		if err != nil || len(templates.Names()) != 0 {
+			t.Errorf("Expected an empty set, got %v (%v)", templates, err)
+		}
+	})
+
+	t.Run("invalid templates are all reported", func(t *testing.T) {
+		write("broken.md", "{{.Title")
+		write("log.md", "{{.Nope}}")
+		_, err := LoadTemplates(dir)
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		for _, want := range []string{"broken.md", "log.md"} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("Expected %s in error: %v", want, err)
+			}
+		}
+	})
+}
+
+func TestDefaultTemplates(t *testing.T) {
+	for _, noteType := range NoteTypes {
+		if err := DefaultTemplate(noteType).ValidateFor(noteType); err != nil {
+			t.Errorf("built-in %s template: %v", noteType, err)
+		}
+	}
+
+	// Flashcards split into question and answer for review
+	body, err := DefaultTemplate("flashcard").Render(TemplateData{Title: "What is X?", Type: "flashcard"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	question, _ := SplitFlashcard(&FlotsamNote{Title: "What is X?", Body: body})
+	if question != "What is X?" {
+		t.Errorf("Expected the title as the question, got %q", question)
+	}
+}
@@ -35,6 +35,20 @@ const (
 	LinkTypeImplicit                 // Auto-detected URLs
 )
 
+// String returns the link type's name as zk reports it.
+func (t LinkType) String() string {
+	switch t {
+	case LinkTypeMarkdown:
+		return "markdown"
+	case LinkTypeWikiLink:
+		return "wiki-link"
+	case LinkTypeImplicit:
+		return "url"
+	default:
+		return "unknown"
+	}
+}
+
 // LinkRelation defines the relationship between a link's source and target.
 type LinkRelation string
 
@@ -219,15 +233,21 @@ func NewLinkExtractor() *LinkExtractor {
 // ExtractLinks extracts all links from markdown content using AST parsing.
 // This is much more robust than regex-based extraction.
 func (le *LinkExtractor) ExtractLinks(content string) ([]Link, error) {
-	bytes := []byte(content)
+	root, source := le.parse(content)
+	return le.parseLinks(root, source)
+}
+
+// parse parses markdown content into its AST, returning the source it refers to.
+func (le *LinkExtractor) parse(content string) (ast.Node, []byte) {
+	source := []byte(content)
 
 	context := parser.NewContext()
 	root := le.md.Parser().Parse(
-		text.NewReader(bytes),
+		text.NewReader(source),
 		parser.WithContext(context),
 	)
 
-	return le.parseLinks(root, bytes)
+	return root, source
 }
 
 // parseLinks extracts outbound links from the AST.
@@ -381,6 +401,23 @@ func ExtractWikiLinkTargets(content string) []string {
 	return targets
 }
 
+// NoteLinkTargets returns the distinct targets of links to other notes, in order,
+// skipping external URLs and in-page anchors.
+func NoteLinkTargets(links []Link) []string {
+	seen := make(map[string]bool)
+	targets := []string{}
+
+	for _, link := range links {
+		if link.IsExternal || strings.HasPrefix(link.Href, "#") || seen[link.Href] {
+			continue
+		}
+		seen[link.Href] = true
+		targets = append(targets, link.Href)
+	}
+
+	return targets
+}
+
 // BuildBacklinkIndex builds a map of note targets to their source notes.
 // This is used for context-scoped backlink computation.
 func BuildBacklinkIndex(notes map[string]string) map[string][]string {
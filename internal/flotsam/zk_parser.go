@@ -14,10 +14,13 @@ import (
 	"fmt"
 	"net/url"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/relvacode/iso8601"
+	"github.com/yuin/goldmark/ast"
 	"gopkg.in/djherbis/times.v1"
 	"gopkg.in/yaml.v3"
 )
@@ -41,22 +44,130 @@ type NoteContent struct {
 
 // Note: Link and LinkType are defined in zk_links.go
 
-// ParseNoteContent parses a note's raw content into its components.
+// ParseNoteContent parses a note's raw content into its components. Frontmatter
+// becomes Metadata, tags come from the frontmatter and #hashtags in the body, and
+// Links holds every outbound link with its type and relations.
 // Adapted from zk's note parsing logic for flotsam use.
+// AIDEV-NOTE: note-parse-core; extracts what zk would index so search, backlinks and review work without zk
 func ParseNoteContent(content string) (*NoteContent, error) {
-	// For now, this is a simplified implementation
-	// TODO: Implement proper frontmatter parsing and link extraction
+	frontmatterText, body := splitFrontmatter(content)
 
-	result := &NoteContent{
-		Title:    extractTitle(content),
-		Lead:     extractLead(content),
-		Body:     content,
-		Tags:     []string{},
-		Links:    []Link{},
-		Metadata: make(map[string]interface{}),
+	metadata := make(map[string]interface{})
+	if err := yaml.Unmarshal([]byte(frontmatterText), &metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML frontmatter: %w", err)
 	}
+	if metadata == nil {
+		metadata = make(map[string]interface{}) // Frontmatter that is only "null"
+	}
+
+	root, source := globalExtractor.parse(body)
+	links, err := globalExtractor.parseLinks(root, source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract links: %w", err)
+	}
+
+	title := extractTitle(body)
+	if metaTitle, ok := metadata["title"].(string); ok && strings.TrimSpace(metaTitle) != "" {
+		title = strings.TrimSpace(metaTitle)
+	}
+
+	return &NoteContent{
+		Title:    title,
+		Lead:     extractLead(body),
+		Body:     body,
+		Tags:     uniqueTags(append(metadataTags(metadata), extractHashtags(root, source)...)),
+		Links:    links,
+		Metadata: metadata,
+	}, nil
+}
+
+// metadataTags returns the tags listed in frontmatter `tags` or `keywords`, which
+// zk accepts either as a YAML list or as a comma or space separated string.
+func metadataTags(metadata map[string]interface{}) []string {
+	tags := []string{}
+	for _, key := range []string{"tags", "keywords"} {
+		switch value := metadata[key].(type) {
+		case []interface{}:
+			for _, item := range value {
+				if tag, ok := item.(string); ok {
+					tags = append(tags, strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+				}
+			}
+		case string:
+			for _, tag := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+				tags = append(tags, strings.TrimPrefix(tag, "#"))
+			}
+		}
+	}
+	return tags
+}
+
+// hashtagPattern matches #hashtags at the start of a line or after whitespace.
+// Tags may contain ':' and '/' so vice:type:* tags and nested tags work, but can't
+// end with punctuation. A '(' prefix isn't accepted, so anchor links such as
+// [setup](#setup) aren't read as tags.
+var hashtagPattern = regexp.MustCompile(`(?m)(?:^|\s)#([\p{L}\p{N}_\-:/]*[\p{L}\p{N}_\-])`)
+
+// extractHashtags returns the #hashtags in a parsed note, ignoring code spans and
+// code blocks. Purely numeric tags such as issue numbers (#42) are skipped.
+func extractHashtags(root ast.Node, source []byte) []string {
+	text := make([]byte, len(source))
+	copy(text, source)
+	blank := func(start, stop int) {
+		for i := start; i < stop; i++ {
+			if text[i] != '\n' {
+				text[i] = ' '
+			}
+		}
+	}
+
+	_ = ast.Walk(root, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch node := n.(type) {
+		case *ast.FencedCodeBlock, *ast.CodeBlock, *ast.HTMLBlock:
+			lines := node.Lines()
+			for i := 0; i < lines.Len(); i++ {
+				blank(lines.At(i).Start, lines.At(i).Stop)
+			}
+			if fenced, ok := node.(*ast.FencedCodeBlock); ok && fenced.Info != nil {
+				blank(fenced.Info.Segment.Start, fenced.Info.Segment.Stop)
+			}
+		case *ast.CodeSpan:
+			for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+				if textNode, ok := child.(*ast.Text); ok {
+					blank(textNode.Segment.Start, textNode.Segment.Stop)
+				}
+			}
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+
+	tags := []string{}
+	for _, match := range hashtagPattern.FindAllSubmatch(text, -1) {
+		tag := string(match[1])
+		if strings.IndexFunc(tag, func(r rune) bool { return !unicode.IsDigit(r) }) == -1 {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	return tags
+}
 
-	return result, nil
+// uniqueTags removes empty and duplicate tags, keeping the first occurrence.
+func uniqueTags(tags []string) []string {
+	seen := make(map[string]bool)
+	result := []string{}
+	for _, tag := range tags {
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		result = append(result, tag)
+	}
+	return result
 }
 
 // extractTitle extracts the title from markdown content.
@@ -138,6 +249,9 @@ func CreationDateFrom(metadata map[string]interface{}, times times.Timespec) tim
 	// Read the creation date from the YAML frontmatter `date` or `created-at` key.
 	for _, key := range []string{"created-at", "date"} {
 		if dateVal, ok := metadata[key]; ok {
+			if date, ok := dateVal.(time.Time); ok {
+				return date // Unquoted YAML timestamps
+			}
 			if dateStr, ok := dateVal.(string); ok {
 				if time, err := iso8601.ParseString(dateStr); err == nil {
 					return time
@@ -192,40 +306,32 @@ type Frontmatter struct {
 // AIDEV-NOTE: yaml-parsing-core; this is the primary entry point for all flotsam frontmatter parsing
 // AIDEV-NOTE: error-handling-yaml; returns detailed errors for malformed YAML to aid debugging
 func ParseFrontmatter(content []byte) (*Frontmatter, string, error) {
-	contentStr := string(content)
-	lines := strings.Split(contentStr, "\n")
+	frontmatterText, body := splitFrontmatter(string(content))
+
+	var frontmatter Frontmatter
+	if err := yaml.Unmarshal([]byte(frontmatterText), &frontmatter); err != nil {
+		return nil, "", fmt.Errorf("failed to parse YAML frontmatter: %w", err)
+	}
+
+	return &frontmatter, body, nil
+}
+
+// splitFrontmatter splits content into its YAML frontmatter (without the ---
+// delimiters) and body. Content without a complete frontmatter block is all body.
+func splitFrontmatter(content string) (frontmatter, body string) {
+	lines := strings.Split(content, "\n")
 
 	if len(lines) < 2 || lines[0] != "---" {
-		// No frontmatter, return empty frontmatter and full content as body
-		return &Frontmatter{}, contentStr, nil
+		return "", content
 	}
 
 	// Find the closing ---
-	endIndex := -1
 	for i := 1; i < len(lines); i++ {
 		if lines[i] == "---" {
-			endIndex = i
-			break
+			return strings.Join(lines[1:i], "\n"), strings.Join(lines[i+1:], "\n")
 		}
 	}
 
-	if endIndex == -1 {
-		// No closing ---, treat as no frontmatter
-		return &Frontmatter{}, contentStr, nil
-	}
-
-	// Parse YAML frontmatter
-	frontmatterLines := lines[1:endIndex]
-	frontmatterText := strings.Join(frontmatterLines, "\n")
-
-	var frontmatter Frontmatter
-	if err := yaml.Unmarshal([]byte(frontmatterText), &frontmatter); err != nil {
-		return nil, "", fmt.Errorf("failed to parse YAML frontmatter: %w", err)
-	}
-
-	// Get body content
-	bodyLines := lines[endIndex+1:]
-	body := strings.Join(bodyLines, "\n")
-
-	return &frontmatter, body, nil
+	// No closing ---, treat as no frontmatter
+	return "", content
 }
@@ -2,6 +2,8 @@ package flotsam
 
 import (
 	"os"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -34,8 +36,114 @@ This is the body content that follows.
 		t.Errorf("Expected lead paragraph, got '%s'", result.Lead)
 	}
 
-	if result.Body != content {
-		t.Errorf("Expected body to be full content")
+	if strings.Contains(result.Body, "tags:") || !strings.HasPrefix(result.Body, "\n# Test Note") {
+		t.Errorf("Expected body without frontmatter, got %q", result.Body)
+	}
+
+	if result.Metadata["title"] != "Test Note" {
+		t.Errorf("Expected title metadata, got %v", result.Metadata)
+	}
+
+	if !reflect.DeepEqual(result.Tags, []string{"test", "example"}) {
+		t.Errorf("Expected frontmatter tags, got %v", result.Tags)
+	}
+}
+
+func TestParseNoteContentTagsAndLinks(t *testing.T) {
+	content := `---
+title: Graph Theory
+keywords: "maths, #study"
+aliases: [graphs]
+review:
+  deck: cs
+---
+
+# Heading Title
+
+#vice:type:idea about [[abc1]] and #maths, see #[[parent]] and [[child]]#.
+Related: [Trees](def2.md "down") and https://example.com #topics/graphs.
+
+Issue #42 isn't a tag, and neither is ` + "`#code` or the URL fragment https://example.com/#anchor" + `.
+
+` + "```go\n// #notatag\n```" + `
+`
+
+	result, err := ParseNoteContent(content)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if result.Title != "Graph Theory" {
+		t.Errorf("Expected frontmatter title to win, got %q", result.Title)
+	}
+
+	expectedTags := []string{"maths", "study", "vice:type:idea", "topics/graphs"}
+	if !reflect.DeepEqual(result.Tags, expectedTags) {
+		t.Errorf("Expected tags %v, got %v", expectedTags, result.Tags)
+	}
+
+	if !reflect.DeepEqual(result.Metadata["aliases"], []interface{}{"graphs"}) {
+		t.Errorf("Expected aliases metadata, got %v", result.Metadata["aliases"])
+	}
+	if review, ok := result.Metadata["review"].(map[string]interface{}); !ok || review["deck"] != "cs" {
+		t.Errorf("Expected nested review metadata, got %v", result.Metadata["review"])
+	}
+
+	type linkSummary struct {
+		Href     string
+		Type     LinkType
+		Rels     []LinkRelation
+		External bool
+	}
+	var links []linkSummary
+	for _, link := range result.Links {
+		links = append(links, linkSummary{link.Href, link.Type, link.Rels, link.IsExternal})
+	}
+	expectedLinks := []linkSummary{
+		{"abc1", LinkTypeWikiLink, []LinkRelation{}, false},
+		{"parent", LinkTypeWikiLink, []LinkRelation{LinkRelationUp}, false},
+		{"child", LinkTypeWikiLink, []LinkRelation{LinkRelationDown}, false},
+		{"def2.md", LinkTypeMarkdown, []LinkRelation{LinkRelationDown}, false},
+		{"https://example.com", LinkTypeImplicit, []LinkRelation{}, true},
+		{"https://example.com/#anchor", LinkTypeImplicit, []LinkRelation{}, true},
+	}
+	if !reflect.DeepEqual(links, expectedLinks) {
+		t.Errorf("Expected links %+v, got %+v", expectedLinks, links)
+	}
+
+	targets := NoteLinkTargets(result.Links)
+	if !reflect.DeepEqual(targets, []string{"abc1", "parent", "child", "def2.md"}) {
+		t.Errorf("Expected note link targets, got %v", targets)
+	}
+}
+
+func TestParseNoteContentWithoutFrontmatter(t *testing.T) {
+	result, err := ParseNoteContent("# Plain\n\nJust text. #solo\n")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Title != "Plain" || result.Lead != "Just text. #solo" {
+		t.Errorf("Unexpected title/lead %q / %q", result.Title, result.Lead)
+	}
+	if !reflect.DeepEqual(result.Tags, []string{"solo"}) {
+		t.Errorf("Expected hashtag, got %v", result.Tags)
+	}
+	if len(result.Metadata) != 0 || len(result.Links) != 0 {
+		t.Errorf("Expected no metadata or links, got %v / %v", result.Metadata, result.Links)
+	}
+
+	if _, err := ParseNoteContent("---\ntitle: [unclosed\n---\n"); err == nil {
+		t.Error("Expected error for malformed frontmatter")
+	}
+}
+
+func TestParseNoteContentIgnoresAnchorLinks(t *testing.T) {
+	result, err := ParseNoteContent("# Guide\n\nJump to [setup](#setup) or ![diagram](#figure-1), tagged #docs.\n")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(result.Tags, []string{"docs"}) {
+		t.Errorf("Expected link and image destinations not to be tags, got %v", result.Tags)
 	}
 }
 
@@ -0,0 +1,299 @@
+// Package gitsync versions a context's data directory with git: opt-in
+// auto-commits after saves, sync against a remote, and a merge driver that
+// merges entries.yml by date and habit_id.
+// AIDEV-NOTE: gitsync-package; opt-in lives in the repo itself (git config vice.autocommit), so it is per context
+package gitsync
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// AutoCommitKey is the git config key that opts a context into auto-commits.
+const AutoCommitKey = "vice.autocommit"
+
+// MergeDriverName is the merge driver entries.yml is assigned in .gitattributes.
+const MergeDriverName = "vice-entries"
+
+// gitignore keeps vice's temporary, backup, lock and cache files out of the repository.
+var gitignore = []string{
+	"# vice temporary, backup, lock and cache files",
+	"*.tmp",
+	"*.backup",
+	"*.lock",
+	"flotsam.db*",
+}
+
+// gitattributes assigns entries.yml to the vice merge driver.
+var gitattributes = []string{"entries.yml merge=" + MergeDriverName}
+
+// Repo runs git in a context data directory.
+type Repo struct {
+	Dir string
+}
+
+// NewRepo creates a Repo for a context data directory.
+func NewRepo(dir string) *Repo {
+	return &Repo{Dir: dir}
+}
+
+// IsRepo reports whether the directory is the root of a git repository.
+func (r *Repo) IsRepo() bool {
+	_, err := os.Stat(filepath.Join(r.Dir, ".git"))
+	return err == nil
+}
+
+// AutoCommitEnabled reports whether the context has opted into auto-commits.
+func (r *Repo) AutoCommitEnabled() bool {
+	if !r.IsRepo() {
+		return false
+	}
+	value, err := r.git("config", "--bool", "--get", AutoCommitKey)
+	return err == nil && value == "true"
+}
+
+// Init makes the directory a git repository tracking vice data: it adds vice's
+// lines to .gitignore and .gitattributes (keeping whatever is already there),
+// registers the entries.yml merge driver as run by executable, enables
+// auto-commits and commits what is already there. It is safe to run again,
+// e.g. after the vice binary moves.
+func (r *Repo) Init(executable string) error {
+	if !r.IsRepo() {
+		if _, err := r.git("init", "--quiet"); err != nil {
+			return err
+		}
+	}
+
+	for name, lines := range map[string][]string{".gitignore": gitignore, ".gitattributes": gitattributes} {
+		if err := appendMissingLines(filepath.Join(r.Dir, name), lines); err != nil {
+			return err
+		}
+	}
+
+	// git runs the driver through sh, so the path is shell-quoted; %P locates
+	// the habits.yml next to the merged entries.yml
+	driver := shellQuote(executable) + " sync merge-driver %O %A %B %P"
+	for _, setting := range [][]string{
+		{"merge." + MergeDriverName + ".name", "vice entries.yml merge by date and habit_id"},
+		{"merge." + MergeDriverName + ".driver", driver},
+		{AutoCommitKey, "true"},
+	} {
+		if _, err := r.git("config", setting[0], setting[1]); err != nil {
+			return err
+		}
+	}
+
+	_, err := r.CommitChanges("vice sync init")
+	return err
+}
+
+// SetRemote adds the named remote, or changes its URL if it exists.
+func (r *Repo) SetRemote(name, url string) error {
+	if _, err := r.git("remote", "get-url", name); err == nil {
+		_, err = r.git("remote", "set-url", name, url)
+		return err
+	}
+	_, err := r.git("remote", "add", name, url)
+	return err
+}
+
+// SyncResult describes what Sync did.
+type SyncResult struct {
+	Committed bool   // Pending changes were committed first
+	Pulled    bool   // The remote had the branch and we rebased onto it
+	Branch    string // The branch synced
+}
+
+// Sync commits pending changes, rebases onto the remote's copy of the current
+// branch (when it has one) and pushes. A failed rebase is aborted, leaving the
+// local commits as they were.
+func (r *Repo) Sync(remote string) (*SyncResult, error) {
+	if !r.IsRepo() {
+		return nil, fmt.Errorf("%s is not a git repository (run vice sync init)", r.Dir)
+	}
+	if _, err := r.git("remote", "get-url", remote); err != nil {
+		return nil, fmt.Errorf("remote %s is not configured (run vice sync init <url>): %w", remote, err)
+	}
+
+	result := &SyncResult{}
+	committed, err := r.CommitChanges("vice sync")
+	if err != nil {
+		return nil, fmt.Errorf("failed to commit local changes: %w", err)
+	}
+	result.Committed = committed
+
+	result.Branch, err = r.git("rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	heads, err := r.git("ls-remote", "--heads", remote, result.Branch)
+	if err != nil {
+		return nil, err
+	}
+	if heads != "" {
+		if _, err := r.git("pull", "--rebase", "--quiet", remote, result.Branch); err != nil {
+			_, _ = r.git("rebase", "--abort")
+			return nil, fmt.Errorf("failed to rebase onto %s/%s, local commits left unchanged: %w", remote, result.Branch, err)
+		}
+		result.Pulled = true
+	}
+
+	if _, err := r.git("push", "--quiet", "--set-upstream", remote, result.Branch); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ChangedFiles returns the paths, relative to the directory, with uncommitted
+// changes, including untracked files.
+func (r *Repo) ChangedFiles() ([]string, error) {
+	output, err := r.git("status", "--porcelain", "-z", "--untracked-files=all")
+	if err != nil {
+		return nil, err
+	}
+
+	// Records are "XY path", NUL-separated; renames and copies are followed by
+	// their original path
+	var files []string
+	records := strings.Split(output, "\x00")
+	for i := 0; i < len(records); i++ {
+		if len(records[i]) < 4 {
+			continue
+		}
+		files = append(files, records[i][3:])
+		if records[i][0] == 'R' || records[i][0] == 'C' {
+			i++
+		}
+	}
+	return files, nil
+}
+
+// CommitChanges commits every uncommitted change, with a subject naming what
+// changed (e.g. "Update entries, habits") and reason as the body. It returns
+// false when there was nothing to commit, and does nothing while a merge or
+// rebase is in progress.
+func (r *Repo) CommitChanges(reason string) (bool, error) {
+	if r.operationInProgress() {
+		return false, nil
+	}
+
+	files, err := r.ChangedFiles()
+	if err != nil || len(files) == 0 {
+		return false, err
+	}
+
+	if _, err := r.git("add", "--all"); err != nil {
+		return false, err
+	}
+	args := []string{"commit", "--quiet", "-m", "Update " + DescribeChanges(files)}
+	if reason != "" {
+		args = append(args, "-m", reason)
+	}
+	if _, err := r.git(args...); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DescribeChanges names changed files the way vice talks about them, e.g.
+// "entries, checklist entries, flotsam notes".
+func DescribeChanges(files []string) string {
+	labels := map[string]string{
+		"habits.yml":            "habits",
+		"entries.yml":           "entries",
+		"checklists.yml":        "checklists",
+		"checklist_entries.yml": "checklist entries",
+		"goals.yml":             "goals",
+	}
+
+	seen := make(map[string]bool)
+	var names []string
+	for _, file := range files {
+		name, found := labels[file]
+		switch {
+		case found:
+		case strings.HasPrefix(file, "flotsam/"):
+			name = "flotsam notes"
+		default:
+			name = file
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// operationInProgress reports whether git is part-way through a merge or
+// rebase, when committing would interfere (e.g. from inside the merge driver).
+func (r *Repo) operationInProgress() bool {
+	for _, name := range []string{"MERGE_HEAD", "rebase-merge", "rebase-apply", "CHERRY_PICK_HEAD"} {
+		if _, err := os.Stat(filepath.Join(r.Dir, ".git", name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// git runs a git command in the directory and returns its output, without the
+// trailing newline.
+func (r *Repo) git(args ...string) (string, error) {
+	// #nosec G204 -- git is run with arguments built by vice
+	cmd := exec.Command("git", append([]string{"-C", r.Dir}, args...)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = strings.TrimSpace(stdout.String())
+		}
+		return "", fmt.Errorf("git %s failed: %w: %s", args[0], err, message)
+	}
+	return strings.TrimRight(stdout.String(), "\n"), nil
+}
+
+// appendMissingLines adds the lines that path doesn't already contain to its
+// end, creating it if needed. Existing content is left as it is.
+func appendMissingLines(path string, lines []string) error {
+	content, err := os.ReadFile(path) // #nosec G304 -- path is within the context data directory
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	existing := make(map[string]bool)
+	for _, line := range strings.Split(string(content), "\n") {
+		existing[strings.TrimSpace(line)] = true
+	}
+
+	var missing []string
+	for _, line := range lines {
+		if !existing[line] {
+			missing = append(missing, line)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	if len(content) > 0 && !bytes.HasSuffix(content, []byte("\n")) {
+		content = append(content, '\n')
+	}
+	content = append(content, strings.Join(missing, "\n")+"\n"...)
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// shellQuote quotes s for a POSIX shell: it is wrapped in single quotes, and
+// each single quote inside closes the quoting, adds an escaped quote and reopens it.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
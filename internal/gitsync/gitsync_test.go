@@ -0,0 +1,258 @@
+package gitsync
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidlee/vice/internal/models"
+	"github.com/davidlee/vice/internal/storage"
+)
+
+// TestMain lets the test binary stand in for vice as the merge driver, which
+// Init registers as "<executable> sync merge-driver %O %A %B %P".
+func TestMain(m *testing.M) {
+	if len(os.Args) == 7 && os.Args[1] == "sync" && os.Args[2] == "merge-driver" {
+		if _, err := MergeEntriesFiles(os.Args[3], os.Args[4], os.Args[5], nil); err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// setGitIdentity gives commits an author without touching the user's git config.
+func setGitIdentity(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "vice")
+	t.Setenv("GIT_AUTHOR_EMAIL", "vice@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "vice")
+	t.Setenv("GIT_COMMITTER_EMAIL", "vice@example.com")
+	t.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(t.TempDir(), "gitconfig"))
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+}
+
+func readFile(t *testing.T, dir, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, name)) // #nosec G304 -- test file in temp dir
+	require.NoError(t, err)
+	return string(data)
+}
+
+const entriesHeader = "version: 1.0.0\nentries:\n"
+
+func entriesDay(date string, habits ...string) string {
+	day := "  - date: \"" + date + "\"\n    habits:\n"
+	for _, habit := range habits {
+		day += "      - habit_id: " + habit + "\n        value: true\n        created_at: " + date + "T08:00:00Z\n        status: completed\n"
+	}
+	return day
+}
+
+func TestRepo_InitAndCommit(t *testing.T) {
+	setGitIdentity(t)
+	dir := t.TempDir()
+	repo := NewRepo(dir)
+	assert.False(t, repo.IsRepo())
+	assert.False(t, repo.AutoCommitEnabled())
+
+	writeFile(t, dir, "habits.yml", "version: 1.0.0\nhabits: []\n")
+	writeFile(t, dir, "entries.yml.backup", "stale")
+	require.NoError(t, repo.Init("/usr/local/bin/vice"))
+
+	assert.True(t, repo.IsRepo())
+	assert.True(t, repo.AutoCommitEnabled())
+	driver, err := repo.git("config", "merge.vice-entries.driver")
+	require.NoError(t, err)
+	assert.Equal(t, `'/usr/local/bin/vice' sync merge-driver %O %A %B %P`, driver)
+
+	files, err := repo.git("ls-files")
+	require.NoError(t, err)
+	assert.Equal(t, ".gitattributes\n.gitignore\nhabits.yml", files)
+
+	t.Run("commit describes the changes", func(t *testing.T) {
+		writeFile(t, dir, "entries.yml", entriesHeader+entriesDay("2025-07-16", "meditation"))
+		writeFile(t, dir, "checklist_entries.yml", "version: 1.0.0\n")
+		writeFile(t, dir, "flotsam/abcd.md", "# note\n")
+
+		committed, err := repo.CommitChanges("vice log meditation")
+		require.NoError(t, err)
+		assert.True(t, committed)
+
+		message, err := repo.git("log", "-1", "--format=%B")
+		require.NoError(t, err)
+		assert.Equal(t, "Update checklist entries, entries, flotsam notes\n\nvice log meditation", message)
+	})
+
+	t.Run("nothing to commit", func(t *testing.T) {
+		committed, err := repo.CommitChanges("vice todo")
+		require.NoError(t, err)
+		assert.False(t, committed)
+	})
+
+	t.Run("renames", func(t *testing.T) {
+		require.NoError(t, os.Rename(filepath.Join(dir, "flotsam/abcd.md"), filepath.Join(dir, "flotsam/efgh.md")))
+		_, err := repo.git("add", "--all")
+		require.NoError(t, err)
+		files, err := repo.ChangedFiles()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"flotsam/efgh.md"}, files)
+	})
+
+	t.Run("not while rebasing", func(t *testing.T) {
+		require.NoError(t, os.Mkdir(filepath.Join(dir, ".git", "rebase-merge"), 0o750))
+		defer func() { _ = os.Remove(filepath.Join(dir, ".git", "rebase-merge")) }()
+		committed, err := repo.CommitChanges("vice log")
+		require.NoError(t, err)
+		assert.False(t, committed)
+	})
+}
+
+func TestRepo_InitKeepsExistingFiles(t *testing.T) {
+	setGitIdentity(t)
+	dir := t.TempDir()
+	writeFile(t, dir, ".gitignore", "notes/private/\n*.tmp")
+	writeFile(t, dir, ".gitattributes", "*.md text eol=lf\n")
+
+	repo := NewRepo(dir)
+	require.NoError(t, repo.Init("/usr/local/bin/vice"))
+	require.NoError(t, repo.Init("/usr/local/bin/vice"))
+
+	assert.Equal(t, "notes/private/\n*.tmp\n# vice temporary, backup, lock and cache files\n*.backup\n*.lock\nflotsam.db*\n",
+		readFile(t, dir, ".gitignore"), "existing lines kept, vice's added once")
+	assert.Equal(t, "*.md text eol=lf\nentries.yml merge=vice-entries\n", readFile(t, dir, ".gitattributes"))
+}
+
+func TestShellQuote(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	for _, s := range []string{"/usr/local/bin/vice", "/home/o'brien/bin/vice", "/opt/$HOME/`id`/a\\b \"c\"/vice"} {
+		out, err := exec.Command("sh", "-c", "printf %s "+shellQuote(s)).Output() // #nosec G204 -- quoting under test
+		require.NoError(t, err)
+		assert.Equal(t, s, string(out))
+	}
+}
+
+func TestDescribeChanges(t *testing.T) {
+	assert.Equal(t, "entries, habits", DescribeChanges([]string{"entries.yml", "habits.yml", "entries.yml"}))
+	assert.Equal(t, "goals, notes.txt", DescribeChanges([]string{"goals.yml", "notes.txt"}))
+}
+
+func TestRepo_Sync(t *testing.T) {
+	setGitIdentity(t)
+	executable, err := os.Executable()
+	require.NoError(t, err)
+
+	remote := t.TempDir()
+	_, err = NewRepo(remote).git("init", "--quiet", "--bare")
+	require.NoError(t, err)
+
+	// Two machines, starting from the same history
+	laptop := NewRepo(t.TempDir())
+	writeFile(t, laptop.Dir, "entries.yml", entriesHeader+entriesDay("2025-07-15", "meditation"))
+	require.NoError(t, laptop.Init(executable))
+	require.NoError(t, laptop.SetRemote("origin", remote))
+
+	result, err := laptop.Sync("origin")
+	require.NoError(t, err)
+	assert.False(t, result.Pulled)
+
+	desktopDir := filepath.Join(t.TempDir(), "personal")
+	_, err = NewRepo(".").git("clone", "--quiet", remote, desktopDir)
+	require.NoError(t, err)
+	desktop := NewRepo(desktopDir)
+	require.NoError(t, desktop.Init(executable))
+
+	// Each logs a different habit on the same day
+	writeFile(t, laptop.Dir, "entries.yml", entriesHeader+entriesDay("2025-07-15", "meditation")+entriesDay("2025-07-16", "meditation"))
+	writeFile(t, desktop.Dir, "entries.yml", entriesHeader+entriesDay("2025-07-15", "meditation")+entriesDay("2025-07-16", "reading"))
+
+	result, err = laptop.Sync("origin")
+	require.NoError(t, err)
+	assert.True(t, result.Committed)
+	assert.True(t, result.Pulled)
+
+	result, err = desktop.Sync("origin")
+	require.NoError(t, err)
+	assert.True(t, result.Committed)
+	assert.True(t, result.Pulled)
+
+	entryLog, err := storage.NewEntryStorage().LoadFromFile(filepath.Join(desktop.Dir, "entries.yml"))
+	require.NoError(t, err)
+	day, found := entryLog.GetDayEntry("2025-07-16")
+	require.True(t, found)
+	require.Len(t, day.Habits, 2)
+	assert.Equal(t, "meditation", day.Habits[0].HabitID)
+	assert.Equal(t, "reading", day.Habits[1].HabitID)
+
+	t.Run("unknown remote", func(t *testing.T) {
+		_, err := desktop.Sync("backup")
+		assert.ErrorContains(t, err, "remote backup is not configured")
+	})
+
+	t.Run("not a repository", func(t *testing.T) {
+		_, err := NewRepo(t.TempDir()).Sync("origin")
+		assert.ErrorContains(t, err, "not a git repository")
+	})
+}
+
+func TestMergeEntriesFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "base", "")
+	writeFile(t, dir, "ours", entriesHeader+entriesDay("2025-07-16", "meditation"))
+	writeFile(t, dir, "theirs", entriesHeader+entriesDay("2025-07-16", "meditation", "reading"))
+
+	conflicts, err := MergeEntriesFiles(filepath.Join(dir, "base"), filepath.Join(dir, "ours"), filepath.Join(dir, "theirs"), nil)
+	require.NoError(t, err)
+	assert.Empty(t, conflicts)
+	assert.Contains(t, readFile(t, dir, "ours"), "habit_id: reading")
+
+	writeFile(t, dir, "theirs", "not: [valid")
+	_, err = MergeEntriesFiles(filepath.Join(dir, "base"), filepath.Join(dir, "ours"), filepath.Join(dir, "theirs"), nil)
+	assert.Error(t, err)
+}
+
+func TestMergeEntriesFiles_RecomputesDerivedHabits(t *testing.T) {
+	schema := &models.Schema{Version: "1.0.0", Habits: []models.Habit{
+		{Title: "Meditation", HabitType: models.SimpleHabit, ScoringType: models.ManualScoring, FieldType: models.FieldType{Type: models.BooleanFieldType}},
+		{Title: "Reading", HabitType: models.SimpleHabit, ScoringType: models.ManualScoring, FieldType: models.FieldType{Type: models.BooleanFieldType}},
+		{
+			Title:      "Habits Done",
+			HabitType:  models.DerivedHabit,
+			FieldType:  models.FieldType{Type: models.UnsignedIntFieldType},
+			Derivation: &models.Derivation{Aggregate: models.AggregateCount, Sources: []string{"meditation", "reading"}},
+		},
+	}}
+	require.NoError(t, schema.Validate())
+
+	dir := t.TempDir()
+	writeFile(t, dir, "base", "")
+	writeFile(t, dir, "ours", entriesHeader+entriesDay("2025-07-16", "meditation"))
+	writeFile(t, dir, "theirs", entriesHeader+entriesDay("2025-07-16", "reading"))
+
+	_, err := MergeEntriesFiles(filepath.Join(dir, "base"), filepath.Join(dir, "ours"), filepath.Join(dir, "theirs"), schema)
+	require.NoError(t, err)
+
+	merged, err := storage.NewEntryStorage().LoadFromFile(filepath.Join(dir, "ours"))
+	require.NoError(t, err)
+	day, found := merged.GetDayEntry("2025-07-16")
+	require.True(t, found)
+	done, found := day.GetHabitEntry("habits_done")
+	require.True(t, found, "derived habit recomputed from both sides")
+	assert.EqualValues(t, 2, done.Value)
+}
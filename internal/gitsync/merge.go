@@ -0,0 +1,61 @@
+package gitsync
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/davidlee/vice/internal/models"
+	"github.com/davidlee/vice/internal/scoring"
+	"github.com/davidlee/vice/internal/storage"
+)
+
+// MergeEntriesFiles is the entries.yml merge driver: it merges the ancestor
+// (base), current (ours) and other (theirs) versions with
+// storage.MergeEntryLogs, keeping the latest edit on conflicts, and writes the result over ours, as git expects.
+// Conflicts are resolved, not left for the user, and returned for reporting.
+// When schema is non-nil its derived habits are recomputed from the merged
+// entries, as every other entries.yml write does.
+func MergeEntriesFiles(basePath, oursPath, theirsPath string, schema *models.Schema) ([]storage.MergeConflict, error) {
+	entryStorage := storage.NewEntryStorage()
+	if schema != nil {
+		entryStorage.SetBeforeSave(scoring.NewEngine().RecomputeHook(schema))
+	}
+
+	logs := make([]*models.EntryLog, 3)
+	for i, path := range []string{basePath, oursPath, theirsPath} {
+		entryLog, err := loadMergeInput(entryStorage, path)
+		if err != nil {
+			return nil, err
+		}
+		logs[i] = entryLog
+	}
+
+	merged, report, err := storage.MergeEntryLogs(logs[0], logs[1], logs[2], storage.KeepLatest)
+	if err != nil {
+		return nil, err
+	}
+	if err := merged.Validate(); err != nil {
+		return nil, fmt.Errorf("merged entries are invalid: %w", err)
+	}
+	if err := entryStorage.SaveToFile(merged, oursPath); err != nil {
+		return nil, fmt.Errorf("failed to write merged entries: %w", err)
+	}
+	return report.Conflicts, nil
+}
+
+// loadMergeInput loads one version of entries.yml; git passes an empty file as
+// the ancestor when both sides added it.
+func loadMergeInput(entryStorage *storage.EntryStorage, path string) (*models.EntryLog, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if info.Size() == 0 {
+		return models.CreateEmptyEntryLog(), nil
+	}
+	entryLog, err := entryStorage.LoadFromFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", path, err)
+	}
+	return entryLog, nil
+}
@@ -0,0 +1,239 @@
+package importer
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/davidlee/vice/internal/models"
+	"github.com/davidlee/vice/internal/quicklog"
+)
+
+// CSVSource imports a generic long-format CSV: one row per habit per day.
+//
+// Required columns are date and habit (a name or ID); value, status, level and
+// notes are optional. Each habit's field type is inferred from its values, and
+// habits with achievement levels become manually scored elastic habits.
+type CSVSource struct{}
+
+// NewCSVSource creates a generic CSV source.
+func NewCSVSource() *CSVSource {
+	return &CSVSource{}
+}
+
+// Name returns the source identifier.
+func (s *CSVSource) Name() string { return "csv" }
+
+// Description returns a one-line summary.
+func (s *CSVSource) Description() string {
+	return "Generic CSV with date, habit and optional value, status, level, notes columns"
+}
+
+// csvDateLayouts are the accepted date formats.
+var csvDateLayouts = []string{dateFormat, "2006/01/02", "2006-01-02 15:04:05", time.RFC3339}
+
+// csvRow is a parsed row before habits are resolved.
+type csvRow struct {
+	line   int
+	date   string
+	habit  string
+	value  string
+	status string
+	level  string
+	notes  string
+}
+
+// Load reads a generic CSV file.
+func (s *CSVSource) Load(path string) (*Dataset, error) {
+	// #nosec G304 -- path is provided by the user
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+	return parseGenericCSV(data)
+}
+
+func parseGenericCSV(data []byte) (*Dataset, error) {
+	records, err := readCSVRecords(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("CSV is empty")
+	}
+
+	columns := columnIndex(records[0])
+	habitColumn := ""
+	for _, name := range []string{"habit", "habit_id", "name"} {
+		if _, ok := columns[name]; ok {
+			habitColumn = name
+			break
+		}
+	}
+	if _, ok := columns["date"]; !ok || habitColumn == "" {
+		return nil, fmt.Errorf("CSV needs a date column and a habit column")
+	}
+
+	// Collect rows per habit, keeping first-seen habit order
+	var order []string
+	rowsByHabit := make(map[string][]csvRow)
+	for i, record := range records[1:] {
+		cell := func(name string) string { return cellValue(record, columns, name) }
+		row := csvRow{
+			line:   i + 2,
+			habit:  cell(habitColumn),
+			value:  cell("value"),
+			status: strings.ToLower(cell("status")),
+			level:  firstNonEmpty(cell("level"), cell("achievement_level")),
+			notes:  cell("notes"),
+		}
+		if row.habit == "" {
+			return nil, fmt.Errorf("line %d: missing habit", row.line)
+		}
+		if row.date, err = parseCSVDate(cell("date")); err != nil {
+			return nil, fmt.Errorf("line %d: %w", row.line, err)
+		}
+		if _, ok := rowsByHabit[row.habit]; !ok {
+			order = append(order, row.habit)
+		}
+		rowsByHabit[row.habit] = append(rowsByHabit[row.habit], row)
+	}
+
+	dataset := &Dataset{}
+	for _, name := range order {
+		rows := rowsByHabit[name]
+		habit, err := newHabit(inferCSVHabit(name, rows))
+		if err != nil {
+			return nil, err
+		}
+		dataset.Habits = append(dataset.Habits, habit)
+
+		for _, row := range rows {
+			entry, err := csvEntry(&habit, row)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", row.line, err)
+			}
+			dataset.Entries = append(dataset.Entries, DatedEntry{Date: row.date, Entry: entry})
+		}
+	}
+	return dataset, nil
+}
+
+// inferCSVHabit picks a habit and field type that fit every value given for it.
+func inferCSVHabit(name string, rows []csvRow) models.Habit {
+	var values []string
+	hasLevels := false
+	for _, row := range rows {
+		if row.value != "" {
+			values = append(values, row.value)
+		}
+		if row.level != "" {
+			hasLevels = true
+		}
+	}
+
+	fieldType := inferFieldType(values)
+	habit := models.Habit{Title: name, FieldType: models.FieldType{Type: fieldType}}
+	switch {
+	case hasLevels:
+		habit.HabitType = models.ElasticHabit
+	case fieldType == models.BooleanFieldType:
+		habit.HabitType = models.SimpleHabit
+	default:
+		habit.HabitType = models.InformationalHabit
+	}
+	return habit
+}
+
+// inferFieldType returns the narrowest field type that parses every value.
+func inferFieldType(values []string) string {
+	if len(values) == 0 {
+		return models.BooleanFieldType
+	}
+	for _, fieldType := range []string{
+		models.BooleanFieldType,
+		models.UnsignedDecimalFieldType,
+		models.DecimalFieldType,
+		models.TimeFieldType,
+		models.DurationFieldType,
+	} {
+		if allParse(fieldType, values) {
+			return fieldType
+		}
+	}
+	return models.TextFieldType
+}
+
+func allParse(fieldType string, values []string) bool {
+	for _, value := range values {
+		if fieldType == models.DurationFieldType {
+			// Bare numbers were already claimed by the numeric types
+			if _, err := time.ParseDuration(value); err != nil {
+				return false
+			}
+			continue
+		}
+		if _, err := quicklog.ParseValue(models.FieldType{Type: fieldType}, value, nil); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// csvEntry builds an entry from a row. Without a status column, empty values are
+// skipped, false booleans failed and everything else completed.
+func csvEntry(habit *models.Habit, row csvRow) (models.HabitEntry, error) {
+	var value interface{}
+	if row.value != "" {
+		parsed, err := quicklog.ParseValue(habit.FieldType, row.value, nil)
+		if err != nil {
+			return models.HabitEntry{}, fmt.Errorf("invalid value for %s: %w", habit.ID, err)
+		}
+		value = parsed
+	}
+
+	status := models.EntryStatus(row.status)
+	switch status {
+	case "":
+		status = models.EntryCompleted
+		if value == nil {
+			status = models.EntrySkipped
+		} else if done, ok := value.(bool); ok && !done {
+			status = models.EntryFailed
+		}
+	case models.EntryCompleted, models.EntryFailed:
+		if value == nil && habit.FieldType.Type == models.BooleanFieldType {
+			value = status == models.EntryCompleted
+		}
+	case models.EntrySkipped:
+		value = nil
+	default:
+		return models.HabitEntry{}, fmt.Errorf("invalid status %q (valid: completed, failed, skipped)", row.status)
+	}
+
+	entry, err := newEntry(habit.ID, row.date, status, value)
+	if err != nil {
+		return models.HabitEntry{}, err
+	}
+	entry.Notes = row.notes
+
+	if row.level != "" && status != models.EntrySkipped {
+		level, err := quicklog.ParseLevel(row.level)
+		if err != nil {
+			return models.HabitEntry{}, err
+		}
+		entry.AchievementLevel = &level
+	}
+	return entry, nil
+}
+
+// parseCSVDate normalizes a date to YYYY-MM-DD.
+func parseCSVDate(raw string) (string, error) {
+	for _, layout := range csvDateLayouts {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t.Format(dateFormat), nil
+		}
+	}
+	return "", fmt.Errorf("invalid date %q (use YYYY-MM-DD)", raw)
+}
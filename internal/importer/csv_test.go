@@ -0,0 +1,70 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidlee/vice/internal/models"
+)
+
+func TestParseGenericCSV(t *testing.T) {
+	data := `Date,Habit,Value,Level,Notes
+2025-07-14,Meditate,yes,,
+2025-07-15,Meditate,no,,tired
+2025/07/16,Meditate,,,
+2025-07-14,Run,5.2,mini,
+2025-07-15,Run,10,maxi,
+2025-07-14,Wake up,06:45,,
+2025-07-14,Mood,good,,
+2025-07-14,Reading,45m,,
+`
+	dataset, err := parseGenericCSV([]byte(data))
+	require.NoError(t, err)
+	require.Len(t, dataset.Habits, 5)
+
+	types := make(map[string]string)
+	habitTypes := make(map[string]models.HabitType)
+	for _, habit := range dataset.Habits {
+		types[habit.ID] = habit.FieldType.Type
+		habitTypes[habit.ID] = habit.HabitType
+	}
+	assert.Equal(t, models.BooleanFieldType, types["meditate"])
+	assert.Equal(t, models.SimpleHabit, habitTypes["meditate"])
+	assert.Equal(t, models.UnsignedDecimalFieldType, types["run"])
+	assert.Equal(t, models.ElasticHabit, habitTypes["run"])
+	assert.Equal(t, models.TimeFieldType, types["wake_up"])
+	assert.Equal(t, models.TextFieldType, types["mood"])
+	assert.Equal(t, models.DurationFieldType, types["reading"])
+	assert.Equal(t, models.InformationalHabit, habitTypes["reading"])
+
+	require.Len(t, dataset.Entries, 8)
+	assert.Equal(t, models.EntryCompleted, dataset.Entries[0].Entry.Status)
+	assert.Equal(t, models.EntryFailed, dataset.Entries[1].Entry.Status)
+	assert.Equal(t, "tired", dataset.Entries[1].Entry.Notes)
+	assert.Equal(t, "2025-07-16", dataset.Entries[2].Date)
+	assert.Equal(t, models.EntrySkipped, dataset.Entries[2].Entry.Status)
+	assert.Equal(t, models.AchievementMaxi, *dataset.Entries[4].Entry.AchievementLevel)
+}
+
+func TestParseGenericCSV_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{name: "missing columns", data: "when,what\n", err: "needs a date column"},
+		{name: "bad date", data: "date,habit\n14/07/2025,Walk\n", err: "line 2: invalid date"},
+		{name: "bad status", data: "date,habit,status\n2025-07-14,Walk,maybe\n", err: "invalid status"},
+		{name: "bad level", data: "date,habit,value,level\n2025-07-14,Run,5,huge\n", err: "invalid achievement level"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseGenericCSV([]byte(tt.data))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
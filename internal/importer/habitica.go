@@ -0,0 +1,194 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/davidlee/vice/internal/models"
+)
+
+// HabiticaSource imports a Habitica user data export (JSON).
+//
+// Dailies become simple boolean habits: a completed day is completed, a due day
+// left undone is failed. Habits (+/- counters) become informational habits
+// counting positive clicks per day.
+type HabiticaSource struct{}
+
+// NewHabiticaSource creates a Habitica source.
+func NewHabiticaSource() *HabiticaSource {
+	return &HabiticaSource{}
+}
+
+// Name returns the source identifier.
+func (s *HabiticaSource) Name() string { return "habitica" }
+
+// Description returns a one-line summary.
+func (s *HabiticaSource) Description() string {
+	return "Habitica user data export (JSON): dailies and habits"
+}
+
+type habiticaExport struct {
+	Tasks struct {
+		Habits []habiticaTask `json:"habits"`
+		Dailys []habiticaTask `json:"dailys"`
+	} `json:"tasks"`
+}
+
+type habiticaTask struct {
+	Text      string            `json:"text"`
+	Notes     string            `json:"notes"`
+	Frequency string            `json:"frequency"`
+	EveryX    int               `json:"everyX"`
+	Repeat    map[string]bool   `json:"repeat"`
+	History   []habiticaHistory `json:"history"`
+}
+
+type habiticaHistory struct {
+	Date      interface{} `json:"date"` // milliseconds since the epoch, or an ISO timestamp
+	Completed *bool       `json:"completed"`
+	IsDue     *bool       `json:"isDue"`
+	ScoredUp  *int        `json:"scoredUp"`
+}
+
+// habiticaWeekdays maps Habitica repeat keys to schedule weekday names, Monday first.
+var habiticaWeekdays = []struct{ key, day string }{
+	{"m", "mon"}, {"t", "tue"}, {"w", "wed"}, {"th", "thu"}, {"f", "fri"}, {"s", "sat"}, {"su", "sun"},
+}
+
+// Load reads a Habitica export.
+func (s *HabiticaSource) Load(path string) (*Dataset, error) {
+	// #nosec G304 -- path is provided by the user
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Habitica export: %w", err)
+	}
+	return parseHabitica(data, time.Local)
+}
+
+func parseHabitica(data []byte, loc *time.Location) (*Dataset, error) {
+	var habitica habiticaExport
+	if err := json.Unmarshal(data, &habitica); err != nil {
+		return nil, fmt.Errorf("failed to decode Habitica export: %w", err)
+	}
+
+	dataset := &Dataset{}
+
+	for _, task := range habitica.Tasks.Dailys {
+		habit, err := newHabit(models.Habit{
+			Title:       task.Text,
+			Description: task.Notes,
+			HabitType:   models.SimpleHabit,
+			FieldType:   models.FieldType{Type: models.BooleanFieldType},
+			Schedule:    habiticaSchedule(task),
+		})
+		if err != nil {
+			return nil, err
+		}
+		dataset.Habits = append(dataset.Habits, habit)
+
+		// The last record of a day wins (cron may record a day more than once)
+		days := make(map[string]bool)
+		for _, record := range task.History {
+			if record.Completed == nil || (record.IsDue != nil && !*record.IsDue) {
+				continue
+			}
+			date, err := habiticaDate(record.Date, loc)
+			if err != nil {
+				return nil, fmt.Errorf("daily %q: %w", task.Text, err)
+			}
+			days[date] = *record.Completed
+		}
+		for _, date := range slices.Sorted(maps.Keys(days)) {
+			status := models.EntryFailed
+			if days[date] {
+				status = models.EntryCompleted
+			}
+			entry, err := newEntry(habit.ID, date, status, days[date])
+			if err != nil {
+				return nil, err
+			}
+			dataset.Entries = append(dataset.Entries, DatedEntry{Date: date, Entry: entry})
+		}
+	}
+
+	for _, task := range habitica.Tasks.Habits {
+		habit, err := newHabit(models.Habit{
+			Title:       task.Text,
+			Description: task.Notes,
+			HabitType:   models.InformationalHabit,
+			FieldType:   models.FieldType{Type: models.UnsignedIntFieldType, Unit: "times"},
+			Direction:   "higher_better",
+		})
+		if err != nil {
+			return nil, err
+		}
+		dataset.Habits = append(dataset.Habits, habit)
+
+		counts := make(map[string]int)
+		for _, record := range task.History {
+			if record.ScoredUp == nil {
+				continue
+			}
+			date, err := habiticaDate(record.Date, loc)
+			if err != nil {
+				return nil, fmt.Errorf("habit %q: %w", task.Text, err)
+			}
+			counts[date] += *record.ScoredUp
+		}
+		for _, date := range slices.Sorted(maps.Keys(counts)) {
+			if counts[date] == 0 {
+				continue
+			}
+			entry, err := newEntry(habit.ID, date, models.EntryCompleted, float64(counts[date]))
+			if err != nil {
+				return nil, err
+			}
+			dataset.Entries = append(dataset.Entries, DatedEntry{Date: date, Entry: entry})
+		}
+	}
+
+	return dataset, nil
+}
+
+// habiticaSchedule maps a daily's repeat settings. Daily tasks repeating every
+// day (or with settings vice can't express) are left on the default daily schedule.
+func habiticaSchedule(task habiticaTask) *models.Schedule {
+	switch task.Frequency {
+	case "weekly":
+		var weekdays []string
+		for _, weekday := range habiticaWeekdays {
+			if task.Repeat[weekday.key] {
+				weekdays = append(weekdays, weekday.day)
+			}
+		}
+		if len(weekdays) == 0 || len(weekdays) == 7 {
+			return nil
+		}
+		return &models.Schedule{Frequency: models.WeekdaysFrequency, Weekdays: weekdays}
+	case "daily":
+		if task.EveryX > 1 {
+			return &models.Schedule{Frequency: models.EveryNDaysFrequency, Interval: task.EveryX}
+		}
+	}
+	return nil
+}
+
+// habiticaDate converts a history timestamp to a local date.
+func habiticaDate(raw interface{}, loc *time.Location) (string, error) {
+	switch v := raw.(type) {
+	case float64:
+		return time.UnixMilli(int64(v)).In(loc).Format(dateFormat), nil
+	case string:
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return "", fmt.Errorf("invalid history date %q", v)
+		}
+		return t.In(loc).Format(dateFormat), nil
+	default:
+		return "", fmt.Errorf("invalid history date %v", raw)
+	}
+}
@@ -0,0 +1,70 @@
+package importer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidlee/vice/internal/models"
+)
+
+const habiticaJSON = `{
+  "tasks": {
+    "dailys": [
+      {
+        "text": "Floss",
+        "notes": "every evening",
+        "frequency": "weekly",
+        "repeat": {"m": true, "t": false, "w": true, "th": false, "f": true, "s": false, "su": false},
+        "history": [
+          {"date": 1752537600000, "value": 1, "isDue": true, "completed": true},
+          {"date": 1752624000000, "value": 0.5, "isDue": true, "completed": false},
+          {"date": 1752710400000, "value": 0.5, "isDue": false, "completed": false}
+        ]
+      }
+    ],
+    "habits": [
+      {
+        "text": "Drink water",
+        "history": [
+          {"date": 1752537600000, "value": 1, "scoredUp": 2, "scoredDown": 0},
+          {"date": 1752541200000, "value": 2, "scoredUp": 1, "scoredDown": 0},
+          {"date": "2025-07-16T09:00:00Z", "value": 2, "scoredUp": 0, "scoredDown": 1}
+        ]
+      }
+    ]
+  }
+}`
+
+func TestParseHabitica(t *testing.T) {
+	dataset, err := parseHabitica([]byte(habiticaJSON), time.UTC)
+	require.NoError(t, err)
+	require.Len(t, dataset.Habits, 2)
+
+	floss := dataset.Habits[0]
+	assert.Equal(t, "floss", floss.ID)
+	assert.Equal(t, models.SimpleHabit, floss.HabitType)
+	require.NotNil(t, floss.Schedule)
+	assert.Equal(t, []string{"mon", "wed", "fri"}, floss.Schedule.Weekdays)
+
+	water := dataset.Habits[1]
+	assert.Equal(t, "drink_water", water.ID)
+	assert.Equal(t, models.InformationalHabit, water.HabitType)
+
+	require.Len(t, dataset.Entries, 3)
+	assert.Equal(t, "2025-07-15", dataset.Entries[0].Date)
+	assert.Equal(t, models.EntryCompleted, dataset.Entries[0].Entry.Status)
+	assert.Equal(t, "2025-07-16", dataset.Entries[1].Date)
+	assert.Equal(t, models.EntryFailed, dataset.Entries[1].Entry.Status)
+
+	// Two clicks and one click on the same day; a day with only "-" clicks is left out
+	assert.Equal(t, "2025-07-15", dataset.Entries[2].Date)
+	assert.Equal(t, 3.0, dataset.Entries[2].Entry.Value)
+}
+
+func TestParseHabitica_InvalidJSON(t *testing.T) {
+	_, err := parseHabitica([]byte("not json"), time.UTC)
+	assert.ErrorContains(t, err, "failed to decode Habitica export")
+}
@@ -0,0 +1,268 @@
+// Package importer brings history from other habit trackers into vice.
+// Each Source maps a foreign export into vice habits and entries (a Dataset);
+// a Plan then diffs the dataset against the current context and applies it.
+// AIDEV-NOTE: importer-framework; adapters only parse, all conflict handling lives in Plan
+package importer
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/davidlee/vice/internal/models"
+)
+
+const dateFormat = "2006-01-02"
+
+// Source reads a foreign export into a dataset.
+type Source interface {
+	// Name is the identifier used on the command line.
+	Name() string
+	// Description is a one-line summary for help text.
+	Description() string
+	// Load reads the export at path (a file, directory or archive, depending on the source).
+	Load(path string) (*Dataset, error)
+}
+
+// Sources returns every supported source, in display order.
+func Sources() []Source {
+	return []Source{
+		NewLoopSource(),
+		NewHabiticaSource(),
+		NewCSVSource(),
+	}
+}
+
+// GetSource finds a source by name.
+func GetSource(name string) (Source, error) {
+	var names []string
+	for _, source := range Sources() {
+		if source.Name() == name {
+			return source, nil
+		}
+		names = append(names, source.Name())
+	}
+	return nil, fmt.Errorf("unknown import source: %s (valid: %s)", name, strings.Join(names, ", "))
+}
+
+// Dataset is a foreign export mapped into vice habits and dated entries.
+type Dataset struct {
+	Habits  []models.Habit
+	Entries []DatedEntry
+}
+
+// DatedEntry is a habit entry for a single day.
+type DatedEntry struct {
+	Date  string // YYYY-MM-DD
+	Entry models.HabitEntry
+}
+
+// newHabit creates a validated habit, generating its ID from the title.
+func newHabit(habit models.Habit) (models.Habit, error) {
+	if habit.ScoringType == "" && habit.HabitType != models.InformationalHabit {
+		habit.ScoringType = models.ManualScoring
+	}
+	if err := habit.Validate(); err != nil {
+		return models.Habit{}, fmt.Errorf("invalid habit %q: %w", habit.Title, err)
+	}
+	return habit, nil
+}
+
+// newEntry creates an entry stamped with the time of its day, so imported
+// history keeps meaningful creation times.
+func newEntry(habitID, date string, status models.EntryStatus, value interface{}) (models.HabitEntry, error) {
+	day, err := time.ParseInLocation(dateFormat, date, time.Local)
+	if err != nil {
+		return models.HabitEntry{}, fmt.Errorf("invalid date %q: %w", date, err)
+	}
+	return models.HabitEntry{
+		HabitID:   habitID,
+		Value:     value,
+		Status:    status,
+		CreatedAt: day,
+	}, nil
+}
+
+// ConflictPolicy decides what happens when an imported entry differs from an existing one.
+type ConflictPolicy string
+
+// Conflict policies.
+const (
+	ConflictSkip      ConflictPolicy = "skip"      // Keep the existing entry
+	ConflictOverwrite ConflictPolicy = "overwrite" // Replace it with the imported entry
+	ConflictFail      ConflictPolicy = "fail"      // Refuse to import anything
+)
+
+// ParseConflictPolicy parses a policy name.
+func ParseConflictPolicy(name string) (ConflictPolicy, error) {
+	switch policy := ConflictPolicy(name); policy {
+	case ConflictSkip, ConflictOverwrite, ConflictFail:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid conflict policy: %s (valid: skip, overwrite, fail)", name)
+	}
+}
+
+// ChangeKind classifies an imported entry against the existing log.
+type ChangeKind int
+
+// Change kinds.
+const (
+	ChangeAdd       ChangeKind = iota // No entry for that habit and day yet
+	ChangeConflict                    // A different entry already exists
+	ChangeUnchanged                   // The same entry already exists
+)
+
+// Change is one imported entry and what it would do.
+type Change struct {
+	Kind     ChangeKind
+	Date     string
+	Incoming models.HabitEntry
+	Existing *models.HabitEntry // set for conflicts and unchanged entries
+}
+
+// Plan is the diff between a dataset and the current habits and entries.
+type Plan struct {
+	Policy    ConflictPolicy
+	NewHabits []models.Habit // habits whose ID isn't defined yet
+	Changes   []Change       // ordered by date, then habit
+}
+
+// NewPlan diffs a dataset against the existing habits and entries. Imported habits
+// match existing ones by ID; unmatched habits are added. Returns an error if the
+// dataset has duplicate entries, or if there are conflicts and the policy is fail.
+func NewPlan(dataset *Dataset, schema *models.Schema, entryLog *models.EntryLog, policy ConflictPolicy) (*Plan, error) {
+	plan := &Plan{Policy: policy}
+
+	known := make(map[string]bool)
+	for i := range schema.Habits {
+		known[schema.Habits[i].ID] = true
+	}
+	for _, habit := range dataset.Habits {
+		if !known[habit.ID] {
+			known[habit.ID] = true
+			plan.NewHabits = append(plan.NewHabits, habit)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, dated := range dataset.Entries {
+		key := dated.Date + "/" + dated.Entry.HabitID
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate imported entry for %s on %s", dated.Entry.HabitID, dated.Date)
+		}
+		seen[key] = true
+
+		if !known[dated.Entry.HabitID] {
+			return nil, fmt.Errorf("imported entry on %s references unknown habit %s", dated.Date, dated.Entry.HabitID)
+		}
+		if err := dated.Entry.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid imported entry for %s on %s: %w", dated.Entry.HabitID, dated.Date, err)
+		}
+
+		change := Change{Kind: ChangeAdd, Date: dated.Date, Incoming: dated.Entry}
+		if day, found := entryLog.GetDayEntry(dated.Date); found {
+			if existing, found := day.GetHabitEntry(dated.Entry.HabitID); found {
+				change.Existing = existing
+				change.Kind = ChangeConflict
+				if existing.Same(&dated.Entry) {
+					change.Kind = ChangeUnchanged
+				}
+			}
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+
+	sort.SliceStable(plan.Changes, func(i, j int) bool {
+		if plan.Changes[i].Date != plan.Changes[j].Date {
+			return plan.Changes[i].Date < plan.Changes[j].Date
+		}
+		return plan.Changes[i].Incoming.HabitID < plan.Changes[j].Incoming.HabitID
+	})
+
+	if policy == ConflictFail {
+		if conflicts := plan.Count(ChangeConflict); conflicts > 0 {
+			return plan, fmt.Errorf("%d imported entries conflict with existing entries (use --on-conflict skip or overwrite)", conflicts)
+		}
+	}
+	return plan, nil
+}
+
+// Count returns the number of changes of a kind.
+func (p *Plan) Count(kind ChangeKind) int {
+	count := 0
+	for _, change := range p.Changes {
+		if change.Kind == kind {
+			count++
+		}
+	}
+	return count
+}
+
+// Diff writes a human-readable diff: "+" for additions, "!" for conflicts, then a summary.
+// Unchanged entries are only counted.
+func (p *Plan) Diff(w io.Writer) {
+	for _, habit := range p.NewHabits {
+		_, _ = fmt.Fprintf(w, "+ habit %s %q (%s, %s)\n", habit.ID, habit.Title, habit.HabitType, habit.FieldType.Type)
+	}
+
+	resolution := "keep existing"
+	if p.Policy == ConflictOverwrite {
+		resolution = "overwrite"
+	}
+	for _, change := range p.Changes {
+		switch change.Kind {
+		case ChangeAdd:
+			_, _ = fmt.Fprintf(w, "+ %s %s %s\n", change.Date, change.Incoming.HabitID, change.Incoming.Describe())
+		case ChangeConflict:
+			_, _ = fmt.Fprintf(w, "! %s %s existing %s, imported %s (%s)\n", change.Date, change.Incoming.HabitID,
+				change.Existing.Describe(), change.Incoming.Describe(), resolution)
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "%d new habits, %d new entries, %d conflicts (%s), %d unchanged\n",
+		len(p.NewHabits), p.Count(ChangeAdd), p.Count(ChangeConflict), p.Policy, p.Count(ChangeUnchanged))
+}
+
+// Apply adds the new habits to the schema and the entries to the log, resolving
+// conflicts by the plan's policy. Both are validated afterwards.
+func (p *Plan) Apply(schema *models.Schema, entryLog *models.EntryLog) error {
+	if p.Policy == ConflictFail && p.Count(ChangeConflict) > 0 {
+		return fmt.Errorf("refusing to apply an import with conflicts")
+	}
+
+	schema.Habits = append(schema.Habits, p.NewHabits...)
+
+	for _, change := range p.Changes {
+		if change.Kind == ChangeUnchanged || (change.Kind == ChangeConflict && p.Policy != ConflictOverwrite) {
+			continue
+		}
+
+		day, found := entryLog.GetDayEntry(change.Date)
+		if !found {
+			entryLog.Entries = append(entryLog.Entries, models.DayEntry{Date: change.Date, Habits: []models.HabitEntry{}})
+			day = &entryLog.Entries[len(entryLog.Entries)-1]
+		}
+
+		entry := change.Incoming
+		if change.Existing != nil {
+			entry.CreatedAt = change.Existing.CreatedAt
+			entry.MarkUpdated()
+		}
+		if err := day.UpdateHabitEntry(entry); err != nil {
+			return fmt.Errorf("failed to import %s on %s: %w", entry.HabitID, change.Date, err)
+		}
+	}
+
+	sort.SliceStable(entryLog.Entries, func(i, j int) bool { return entryLog.Entries[i].Date < entryLog.Entries[j].Date })
+
+	if err := schema.Validate(); err != nil {
+		return fmt.Errorf("imported habits are invalid: %w", err)
+	}
+	if err := entryLog.Validate(); err != nil {
+		return fmt.Errorf("imported entries are invalid: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package importer
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidlee/vice/internal/models"
+)
+
+func testSchema() *models.Schema {
+	return &models.Schema{
+		Version: "1.0.0",
+		Habits: []models.Habit{
+			{Title: "Walk", ID: "walk", HabitType: models.SimpleHabit, FieldType: models.FieldType{Type: models.BooleanFieldType}, ScoringType: models.ManualScoring},
+		},
+	}
+}
+
+func testEntryLog() *models.EntryLog {
+	created := time.Date(2025, 7, 14, 20, 0, 0, 0, time.UTC)
+	return &models.EntryLog{
+		Version: "1.0.0",
+		Entries: []models.DayEntry{
+			{Date: "2025-07-14", Habits: []models.HabitEntry{
+				{HabitID: "walk", Value: true, Status: models.EntryCompleted, CreatedAt: created},
+			}},
+			{Date: "2025-07-15", Habits: []models.HabitEntry{
+				{HabitID: "walk", Value: true, Status: models.EntryCompleted, CreatedAt: created},
+			}},
+		},
+	}
+}
+
+func testDataset(t *testing.T) *Dataset {
+	t.Helper()
+	read, err := newHabit(models.Habit{Title: "Read", HabitType: models.SimpleHabit, FieldType: models.FieldType{Type: models.BooleanFieldType}})
+	require.NoError(t, err)
+	walk, err := newHabit(models.Habit{Title: "Walk", HabitType: models.SimpleHabit, FieldType: models.FieldType{Type: models.BooleanFieldType}})
+	require.NoError(t, err)
+
+	dataset := &Dataset{Habits: []models.Habit{read, walk}}
+	add := func(habitID, date string, status models.EntryStatus, value interface{}) {
+		entry, err := newEntry(habitID, date, status, value)
+		require.NoError(t, err)
+		dataset.Entries = append(dataset.Entries, DatedEntry{Date: date, Entry: entry})
+	}
+	add("walk", "2025-07-15", models.EntryFailed, false)   // conflict
+	add("walk", "2025-07-14", models.EntryCompleted, true) // unchanged
+	add("read", "2025-07-14", models.EntryCompleted, true) // new
+	add("walk", "2025-07-13", models.EntrySkipped, nil)    // new day
+	return dataset
+}
+
+func TestNewPlan(t *testing.T) {
+	plan, err := NewPlan(testDataset(t), testSchema(), testEntryLog(), ConflictSkip)
+	require.NoError(t, err)
+
+	require.Len(t, plan.NewHabits, 1)
+	assert.Equal(t, "read", plan.NewHabits[0].ID)
+	assert.Equal(t, 2, plan.Count(ChangeAdd))
+	assert.Equal(t, 1, plan.Count(ChangeConflict))
+	assert.Equal(t, 1, plan.Count(ChangeUnchanged))
+	assert.Equal(t, "2025-07-13", plan.Changes[0].Date)
+
+	var diff bytes.Buffer
+	plan.Diff(&diff)
+	assert.Contains(t, diff.String(), `+ habit read "Read" (simple, boolean)`)
+	assert.Contains(t, diff.String(), "+ 2025-07-14 read completed true")
+	assert.Contains(t, diff.String(), "! 2025-07-15 walk existing completed true, imported failed false (keep existing)")
+	assert.Contains(t, diff.String(), "1 new habits, 2 new entries, 1 conflicts (skip), 1 unchanged")
+
+	t.Run("fail policy", func(t *testing.T) {
+		_, err := NewPlan(testDataset(t), testSchema(), testEntryLog(), ConflictFail)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "1 imported entries conflict")
+	})
+
+	t.Run("duplicate entries", func(t *testing.T) {
+		dataset := testDataset(t)
+		dataset.Entries = append(dataset.Entries, dataset.Entries[0])
+		_, err := NewPlan(dataset, testSchema(), testEntryLog(), ConflictSkip)
+		assert.ErrorContains(t, err, "duplicate imported entry")
+	})
+}
+
+func TestPlan_Apply(t *testing.T) {
+	tests := []struct {
+		policy     ConflictPolicy
+		walkStatus models.EntryStatus
+	}{
+		{policy: ConflictSkip, walkStatus: models.EntryCompleted},
+		{policy: ConflictOverwrite, walkStatus: models.EntryFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			schema := testSchema()
+			entryLog := testEntryLog()
+			plan, err := NewPlan(testDataset(t), schema, entryLog, tt.policy)
+			require.NoError(t, err)
+			require.NoError(t, plan.Apply(schema, entryLog))
+
+			assert.Len(t, schema.Habits, 2)
+			require.Len(t, entryLog.Entries, 3)
+			assert.Equal(t, "2025-07-13", entryLog.Entries[0].Date)
+
+			day, _ := entryLog.GetDayEntry("2025-07-15")
+			walk, _ := day.GetHabitEntry("walk")
+			assert.Equal(t, tt.walkStatus, walk.Status)
+			if tt.policy == ConflictOverwrite {
+				assert.NotNil(t, walk.UpdatedAt)
+			}
+
+			day, _ = entryLog.GetDayEntry("2025-07-14")
+			assert.Len(t, day.Habits, 2)
+		})
+	}
+}
+
+func TestGetSource(t *testing.T) {
+	for _, name := range []string{"loop", "habitica", "csv"} {
+		source, err := GetSource(name)
+		require.NoError(t, err)
+		assert.Equal(t, name, source.Name())
+		assert.NotEmpty(t, source.Description())
+	}
+
+	_, err := GetSource("streaks")
+	assert.ErrorContains(t, err, "valid: loop, habitica, csv")
+}
@@ -0,0 +1,338 @@
+package importer
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/davidlee/vice/internal/models"
+)
+
+// Loop Habit Tracker checkmark values for yes/no habits. The others are -1 (no data),
+// 0 (not done - also written for every day without a check-in) and 1 (implied by
+// the habit's frequency), none of which are real check-ins.
+const (
+	loopYesManual = 2 // Checked in
+	loopSkip      = 3 // Skipped
+)
+
+// LoopSource imports Loop Habit Tracker CSV exports.
+//
+// Loop exports a zip with Habits.csv (one row per habit) and Checkmarks.csv
+// (a Date column plus one column per habit name). The zip, the unpacked
+// directory or a lone Checkmarks.csv can be imported.
+type LoopSource struct{}
+
+// NewLoopSource creates a Loop Habit Tracker source.
+func NewLoopSource() *LoopSource {
+	return &LoopSource{}
+}
+
+// Name returns the source identifier.
+func (s *LoopSource) Name() string { return "loop" }
+
+// Description returns a one-line summary.
+func (s *LoopSource) Description() string {
+	return "Loop Habit Tracker export (zip, directory or Checkmarks.csv)"
+}
+
+// loopHabit is a row of Habits.csv.
+type loopHabit struct {
+	name        string
+	description string
+	numerical   bool
+	unit        string
+	targetType  string // AT_LEAST or AT_MOST
+	targetValue float64
+	numerator   int // Frequency: numerator times...
+	denominator int // ...per denominator days
+}
+
+// Load reads a Loop export.
+func (s *LoopSource) Load(path string) (*Dataset, error) {
+	habitsCSV, checkmarksCSV, err := readLoopFiles(path)
+	if err != nil {
+		return nil, err
+	}
+
+	loopHabits := make(map[string]loopHabit)
+	if habitsCSV != nil {
+		if loopHabits, err = parseLoopHabits(habitsCSV); err != nil {
+			return nil, err
+		}
+	}
+	return parseLoopCheckmarks(checkmarksCSV, loopHabits)
+}
+
+// readLoopFiles returns the contents of Habits.csv (nil if absent) and Checkmarks.csv.
+func readLoopFiles(path string) ([]byte, []byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read Loop export: %w", err)
+	}
+
+	switch {
+	case info.IsDir():
+		// #nosec G304 -- path is provided by the user
+		checkmarks, err := os.ReadFile(filepath.Join(path, "Checkmarks.csv"))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read Checkmarks.csv: %w", err)
+		}
+		// #nosec G304 -- path is provided by the user
+		habits, err := os.ReadFile(filepath.Join(path, "Habits.csv"))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, nil, fmt.Errorf("failed to read Habits.csv: %w", err)
+		}
+		return habits, checkmarks, nil
+
+	case strings.EqualFold(filepath.Ext(path), ".zip"):
+		return readLoopZip(path)
+
+	default:
+		// #nosec G304 -- path is provided by the user
+		checkmarks, err := os.ReadFile(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		return nil, checkmarks, nil
+	}
+}
+
+func readLoopZip(path string) ([]byte, []byte, error) {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open Loop export %s: %w", path, err)
+	}
+	defer func() { _ = archive.Close() }()
+
+	var habits, checkmarks []byte
+	for _, file := range archive.File {
+		// Per-habit folders contain their own Checkmarks.csv; only the top-level files are used
+		switch file.Name {
+		case "Habits.csv":
+			habits, err = readZipFile(file)
+		case "Checkmarks.csv":
+			checkmarks, err = readZipFile(file)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read %s from %s: %w", file.Name, path, err)
+		}
+	}
+	if checkmarks == nil {
+		return nil, nil, fmt.Errorf("%s has no Checkmarks.csv", path)
+	}
+	return habits, checkmarks, nil
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = reader.Close() }()
+	return io.ReadAll(reader)
+}
+
+// parseLoopHabits reads Habits.csv. Older exports lack the type and target columns.
+func parseLoopHabits(data []byte) (map[string]loopHabit, error) {
+	records, err := readCSVRecords(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Habits.csv: %w", err)
+	}
+	if len(records) == 0 {
+		return map[string]loopHabit{}, nil
+	}
+
+	columns := columnIndex(records[0])
+	if _, ok := columns["name"]; !ok {
+		return nil, fmt.Errorf("missing Name column in Habits.csv")
+	}
+
+	habits := make(map[string]loopHabit)
+	for _, record := range records[1:] {
+		cell := func(name string) string { return cellValue(record, columns, name) }
+
+		habit := loopHabit{
+			name:        cell("name"),
+			description: firstNonEmpty(cell("question"), cell("description")),
+			numerical:   strings.EqualFold(cell("type"), "NUMERICAL") || cell("unit") != "",
+			unit:        cell("unit"),
+			targetType:  strings.ToUpper(cell("target type")),
+		}
+		habit.targetValue, _ = strconv.ParseFloat(cell("target value"), 64)
+		habit.numerator, _ = strconv.Atoi(firstNonEmpty(cell("frequencynumerator"), cell("numrepetitions")))
+		habit.denominator, _ = strconv.Atoi(firstNonEmpty(cell("frequencydenominator"), cell("interval")))
+		habits[habit.name] = habit
+	}
+	return habits, nil
+}
+
+// parseLoopCheckmarks maps each habit column of Checkmarks.csv to vice entries.
+func parseLoopCheckmarks(data []byte, loopHabits map[string]loopHabit) (*Dataset, error) {
+	records, err := readCSVRecords(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Checkmarks.csv: %w", err)
+	}
+	if len(records) == 0 || len(records[0]) < 2 || !strings.EqualFold(strings.TrimSpace(records[0][0]), "date") {
+		return nil, fmt.Errorf("expected Checkmarks.csv to start with a Date column followed by habit columns")
+	}
+
+	dataset := &Dataset{}
+	header := records[0]
+	habitIDs := make([]string, len(header))
+	numerical := make([]bool, len(header))
+
+	for col := 1; col < len(header); col++ {
+		name := strings.TrimSpace(header[col])
+		if name == "" {
+			continue // trailing comma
+		}
+		loop, ok := loopHabits[name]
+		if !ok {
+			loop = loopHabit{name: name, numerical: !isLoopBooleanColumn(records[1:], col)}
+		}
+		habit, err := newHabit(loop.toHabit())
+		if err != nil {
+			return nil, err
+		}
+		dataset.Habits = append(dataset.Habits, habit)
+		habitIDs[col] = habit.ID
+		numerical[col] = loop.numerical
+	}
+
+	for _, record := range records[1:] {
+		date := strings.TrimSpace(record[0])
+		for col := 1; col < len(record) && col < len(header); col++ {
+			if habitIDs[col] == "" {
+				continue
+			}
+			raw := strings.TrimSpace(record[col])
+			value, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q for %s on %s in Checkmarks.csv", raw, header[col], date)
+			}
+
+			var entry models.HabitEntry
+			switch {
+			case numerical[col] && value > 0:
+				entry, err = newEntry(habitIDs[col], date, models.EntryCompleted, value)
+			case numerical[col]:
+				continue // zero is written for days without a check-in
+			case int(value) == loopYesManual:
+				entry, err = newEntry(habitIDs[col], date, models.EntryCompleted, true)
+			case int(value) == loopSkip:
+				entry, err = newEntry(habitIDs[col], date, models.EntrySkipped, nil)
+			default:
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			dataset.Entries = append(dataset.Entries, DatedEntry{Date: date, Entry: entry})
+		}
+	}
+	return dataset, nil
+}
+
+// toHabit maps a Loop habit: yes/no habits become simple boolean habits, numerical
+// habits with a target become simple habits scored against it, and numerical
+// habits without one become informational.
+func (h loopHabit) toHabit() models.Habit {
+	habit := models.Habit{
+		Title:       h.name,
+		Description: h.description,
+		HabitType:   models.SimpleHabit,
+		FieldType:   models.FieldType{Type: models.BooleanFieldType},
+		Schedule:    loopSchedule(h.numerator, h.denominator),
+	}
+	if !h.numerical {
+		return habit
+	}
+
+	habit.FieldType = models.FieldType{Type: models.UnsignedDecimalFieldType, Unit: h.unit}
+	if h.targetValue <= 0 {
+		habit.HabitType = models.InformationalHabit
+		habit.Direction = "higher_better"
+		return habit
+	}
+
+	target := h.targetValue
+	condition := &models.Condition{GreaterThanOrEqual: &target}
+	if h.targetType == "AT_MOST" {
+		condition = &models.Condition{LessThanOrEqual: &target}
+	}
+	habit.ScoringType = models.AutomaticScoring
+	habit.Criteria = &models.Criteria{
+		Description: fmt.Sprintf("Loop target: %s %g %s", strings.ToLower(strings.ReplaceAll(h.targetType, "_", " ")), target, h.unit),
+		Condition:   condition,
+	}
+	return habit
+}
+
+// loopSchedule converts a Loop frequency (numerator times per denominator days).
+func loopSchedule(numerator, denominator int) *models.Schedule {
+	switch {
+	case numerator <= 0 || denominator <= 0 || numerator >= denominator:
+		return nil // daily
+	case denominator == 7:
+		return &models.Schedule{Frequency: models.TimesPerWeekFrequency, Times: numerator}
+	case numerator == 1:
+		return &models.Schedule{Frequency: models.EveryNDaysFrequency, Interval: denominator}
+	default:
+		return nil
+	}
+}
+
+// isLoopBooleanColumn guesses a habit's type without Habits.csv: yes/no columns
+// only ever hold the checkmark codes.
+func isLoopBooleanColumn(records [][]string, col int) bool {
+	for _, record := range records {
+		if col >= len(record) {
+			continue
+		}
+		switch strings.TrimSpace(record[col]) {
+		case "-1", "0", "1", "2", "3":
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// readCSVRecords parses CSV data, tolerating ragged rows.
+func readCSVRecords(data []byte) ([][]string, error) {
+	reader := csv.NewReader(strings.NewReader(string(data)))
+	reader.FieldsPerRecord = -1
+	return reader.ReadAll()
+}
+
+// columnIndex maps lower-cased, trimmed header names to column positions.
+func columnIndex(header []string) map[string]int {
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	return columns
+}
+
+// cellValue returns the trimmed cell in a named column, or "" if absent.
+func cellValue(record []string, columns map[string]int, name string) string {
+	if i, ok := columns[name]; ok && i < len(record) {
+		return strings.TrimSpace(record[i])
+	}
+	return ""
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,101 @@
+package importer
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidlee/vice/internal/models"
+)
+
+const loopHabitsCSV = `Position,Name,Type,Question,Description,FrequencyNumerator,FrequencyDenominator,Color,Unit,Target Type,Target Value,Archived?
+001,Meditate,YES_NO,Did you meditate today?,,1,1,#FF8F00,,,0,false
+002,Gym,YES_NO,,,3,7,#FF8F00,,,0,false
+003,Pushups,NUMERICAL,How many pushups?,,1,1,#FF8F00,reps,AT_LEAST,20,false
+004,Weight,NUMERICAL,,,1,1,#FF8F00,kg,,0,false
+`
+
+const loopCheckmarksCSV = `Date,Meditate,Gym,Pushups,Weight,
+2025-07-15,2,0,25,72.5,
+2025-07-14,0,3,0,-1,
+2025-07-13,1,2,10,72.8,
+`
+
+func TestLoopSource(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Habits.csv"), []byte(loopHabitsCSV), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Checkmarks.csv"), []byte(loopCheckmarksCSV), 0o600))
+
+	zipPath := filepath.Join(t.TempDir(), "Loop Habits CSV.zip")
+	writeZip(t, zipPath, map[string]string{
+		"Habits.csv":              loopHabitsCSV,
+		"Checkmarks.csv":          loopCheckmarksCSV,
+		"001 Meditate/Scores.csv": "Date,Score\n",
+	})
+
+	for name, path := range map[string]string{"directory": dir, "zip": zipPath} {
+		t.Run(name, func(t *testing.T) {
+			dataset, err := NewLoopSource().Load(path)
+			require.NoError(t, err)
+			require.Len(t, dataset.Habits, 4)
+
+			meditate, gym, pushups, weight := dataset.Habits[0], dataset.Habits[1], dataset.Habits[2], dataset.Habits[3]
+			assert.Equal(t, "meditate", meditate.ID)
+			assert.Equal(t, models.SimpleHabit, meditate.HabitType)
+			assert.Equal(t, models.BooleanFieldType, meditate.FieldType.Type)
+			assert.Nil(t, meditate.Schedule)
+
+			require.NotNil(t, gym.Schedule)
+			assert.Equal(t, models.TimesPerWeekFrequency, gym.Schedule.Frequency)
+			assert.Equal(t, 3, gym.Schedule.Times)
+
+			assert.Equal(t, models.AutomaticScoring, pushups.ScoringType)
+			require.NotNil(t, pushups.Criteria)
+			assert.Equal(t, 20.0, *pushups.Criteria.Condition.GreaterThanOrEqual)
+			assert.Equal(t, "reps", pushups.FieldType.Unit)
+
+			assert.Equal(t, models.InformationalHabit, weight.HabitType)
+
+			entries := make(map[string]models.HabitEntry)
+			for _, dated := range dataset.Entries {
+				entries[dated.Date+" "+dated.Entry.HabitID] = dated.Entry
+			}
+			// Only real check-ins: "0" and "1" (implied) are not imported
+			assert.Len(t, entries, 7)
+			assert.Equal(t, models.EntryCompleted, entries["2025-07-15 meditate"].Status)
+			assert.Equal(t, models.EntrySkipped, entries["2025-07-14 gym"].Status)
+			assert.Equal(t, 25.0, entries["2025-07-15 pushups"].Value)
+			assert.Equal(t, 72.8, entries["2025-07-13 weight"].Value)
+			_, found := entries["2025-07-13 meditate"]
+			assert.False(t, found)
+		})
+	}
+
+	t.Run("checkmarks only", func(t *testing.T) {
+		dataset, err := NewLoopSource().Load(filepath.Join(dir, "Checkmarks.csv"))
+		require.NoError(t, err)
+		require.Len(t, dataset.Habits, 4)
+		assert.Equal(t, models.BooleanFieldType, dataset.Habits[1].FieldType.Type)
+		assert.Equal(t, models.UnsignedDecimalFieldType, dataset.Habits[2].FieldType.Type)
+	})
+}
+
+func writeZip(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+
+	writer := zip.NewWriter(file)
+	for name, content := range files {
+		w, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+}
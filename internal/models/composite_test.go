@@ -0,0 +1,174 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func compositeRunFieldType() FieldType {
+	return FieldType{
+		Type: CompositeFieldType,
+		Fields: []SubField{
+			{ID: "distance", Title: "Distance", FieldType: FieldType{Type: UnsignedDecimalFieldType, Unit: "km"}},
+			{ID: "duration", FieldType: FieldType{Type: DurationFieldType}},
+			{ID: "start", FieldType: FieldType{Type: TimeFieldType}},
+			{ID: "outdoors", FieldType: FieldType{Type: BooleanFieldType}},
+		},
+	}
+}
+
+func TestFieldType_ValidateComposite(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields []SubField
+		err    string
+	}{
+		{name: "valid", fields: compositeRunFieldType().Fields},
+		{name: "no sub-fields", err: "at least one sub-field"},
+		{name: "invalid ID", fields: []SubField{{ID: "Distance km", FieldType: FieldType{Type: DecimalFieldType}}}, err: "invalid sub-field ID"},
+		{name: "duplicate ID", fields: []SubField{
+			{ID: "a", FieldType: FieldType{Type: DecimalFieldType}},
+			{ID: "a", FieldType: FieldType{Type: TextFieldType}},
+		}, err: "duplicate sub-field ID: a"},
+		{name: "nested composite", fields: []SubField{
+			{ID: "a", FieldType: FieldType{Type: CompositeFieldType}},
+		}, err: "cannot be used in a composite field"},
+		{name: "checklist", fields: []SubField{
+			{ID: "a", FieldType: FieldType{Type: ChecklistFieldType, ChecklistID: "x"}},
+		}, err: "cannot be used in a composite field"},
+		{name: "invalid sub-field type", fields: []SubField{
+			{ID: "a", FieldType: FieldType{Type: "colour"}},
+		}, err: "sub-field a: unknown field type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fieldType := FieldType{Type: CompositeFieldType, Fields: tt.fields}
+			err := fieldType.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestHabit_ValidateCompositeCriteria(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name      string
+		fieldType FieldType
+		condition Condition
+		err       string
+	}{
+		{
+			name:      "sub-field comparison",
+			fieldType: compositeRunFieldType(),
+			condition: Condition{Field: "distance", GreaterThanOrEqual: f(5)},
+		},
+		{
+			name:      "logical operators over sub-fields",
+			fieldType: compositeRunFieldType(),
+			condition: Condition{And: []Condition{
+				{Field: "distance", GreaterThan: f(3)},
+				{Field: "start", Before: "07:00"},
+			}},
+		},
+		{
+			name:      "nested conditions on the same field",
+			fieldType: compositeRunFieldType(),
+			condition: Condition{Field: "distance", Or: []Condition{{LessThan: f(1)}, {Field: "distance", GreaterThan: f(5)}}},
+		},
+		{
+			name:      "comparison without field",
+			fieldType: compositeRunFieldType(),
+			condition: Condition{GreaterThan: f(3)},
+			err:       "must name a field",
+		},
+		{
+			name:      "unknown field",
+			fieldType: compositeRunFieldType(),
+			condition: Condition{Field: "pace", GreaterThan: f(3)},
+			err:       `unknown field "pace"`,
+		},
+		{
+			name:      "nested condition switches field",
+			fieldType: compositeRunFieldType(),
+			condition: Condition{Field: "distance", And: []Condition{{Field: "duration", LessThan: f(30)}}},
+			err:       "cannot nest a condition on field duration",
+		},
+		{
+			name:      "unsatisfiable sub-field condition",
+			fieldType: compositeRunFieldType(),
+			condition: Condition{Field: "distance", GreaterThan: f(10), LessThan: f(5)},
+			err:       "field distance: condition can never be satisfied",
+		},
+		{
+			name:      "field on a non-composite habit",
+			fieldType: FieldType{Type: DecimalFieldType},
+			condition: Condition{Field: "distance", GreaterThan: f(3)},
+			err:       "only valid for composite habits",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition := tt.condition
+			habit := Habit{
+				Title:       "Run",
+				HabitType:   SimpleHabit,
+				FieldType:   tt.fieldType,
+				ScoringType: AutomaticScoring,
+				Criteria:    &Criteria{Condition: &condition},
+			}
+			err := habit.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestHabitEntry_CompositeYAMLRoundTrip(t *testing.T) {
+	entry := HabitEntry{
+		HabitID: "run",
+		Value: map[string]interface{}{
+			"distance": 5.0,
+			"duration": "28m",
+			"start":    "06:45",
+			"outdoors": true,
+		},
+		Status: EntryCompleted,
+	}
+
+	data, err := yaml.Marshal(&entry)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `distance: "5.0"`)
+
+	var decoded HabitEntry
+	require.NoError(t, yaml.Unmarshal(data, &decoded))
+
+	value, ok := decoded.Value.(map[string]interface{})
+	require.True(t, ok, "expected a map, got %T", decoded.Value)
+	assert.Equal(t, 5.0, value["distance"])
+	assert.Equal(t, "28m", value["duration"])
+	assert.Equal(t, true, value["outdoors"])
+	start, ok := value["start"].(time.Time)
+	require.True(t, ok, "expected start to be parsed as a time, got %T", value["start"])
+	assert.Equal(t, "06:45", start.Format("15:04"))
+
+	// Re-marshaling gives the same YAML
+	again, err := yaml.Marshal(&decoded)
+	require.NoError(t, err)
+	assert.Equal(t, string(data), string(again))
+}
@@ -0,0 +1,380 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// HasComparisons reports whether the condition sets any comparison operator.
+func (c *Condition) HasComparisons() bool {
+	return c.GreaterThan != nil || c.GreaterThanOrEqual != nil ||
+		c.LessThan != nil || c.LessThanOrEqual != nil ||
+		c.Range != nil || c.Before != "" || c.After != "" ||
+		c.Equals != nil || c.ChecklistCompletion != nil ||
+		c.HasTextOperators()
+}
+
+// HasTextOperators reports whether the condition sets any text operator.
+func (c *Condition) HasTextOperators() bool {
+	return c.Matches != "" || len(c.ContainsAny) > 0 || len(c.ContainsAll) > 0 ||
+		c.MinWords != nil || c.MinLines != nil
+}
+
+// HasLogicalOperators reports whether the condition nests and, or or not conditions.
+func (c *Condition) HasLogicalOperators() bool {
+	return len(c.And) > 0 || len(c.Or) > 0 || c.Not != nil
+}
+
+// validateSatisfiable returns an error if no value of the field type can ever meet
+// the condition, e.g. "greater_than: 10 and less_than: 5", "not equals: true and
+// not equals: false", or a threshold outside the field's min/max.
+// AIDEV-NOTE: condition-satisfiability; the truth of a condition only changes at its
+// thresholds, so testing each threshold, the points between them and one point beyond
+// either end is exhaustive. Keep holdsFor* in step with scoring.Engine.evaluateCondition.
+func (c *Condition) validateSatisfiable(fieldType FieldType) error {
+	switch fieldType.Type {
+	case BooleanFieldType:
+		if !c.holdsForBool(true) && !c.holdsForBool(false) {
+			return fmt.Errorf("condition can never be satisfied")
+		}
+		return nil
+	case TextFieldType:
+		if err := c.validateTextOperators(); err != nil {
+			return err
+		}
+		if c.usesTextOperators() {
+			return nil // Satisfiability of patterns and keywords isn't checked
+		}
+	case UnsignedIntFieldType, UnsignedDecimalFieldType, DecimalFieldType, DurationFieldType, TimeFieldType:
+	default:
+		return nil
+	}
+
+	var thresholds []float64
+	if err := c.collectThresholds(fieldType.Type, &thresholds); err != nil {
+		return err
+	}
+
+	domain := newNumericDomain(fieldType)
+	for _, x := range domain.candidates(thresholds) {
+		if c.holdsForNumber(x, fieldType.Type) {
+			return nil
+		}
+	}
+	return fmt.Errorf("condition can never be satisfied")
+}
+
+// validateFieldReferences checks the field names in a condition tree. On composite
+// habits a condition must name a sub-field before comparing; the condition and its
+// nested conditions are then checked against that sub-field's type. Other habits
+// have no sub-fields to name.
+// AIDEV-NOTE: composite-field-scope; a named field scopes its whole subtree, mirrored
+// by scoring.Engine.evaluateCondition
+func (c *Condition) validateFieldReferences(fieldType FieldType) error {
+	if fieldType.Type != CompositeFieldType {
+		if field := c.firstField(); field != "" {
+			return fmt.Errorf("field %q is only valid for composite habits", field)
+		}
+		return nil
+	}
+
+	if c.Field != "" {
+		subField, found := fieldType.GetSubField(c.Field)
+		if !found {
+			return fmt.Errorf("unknown field %q (valid: %s)", c.Field, strings.Join(fieldType.SubFieldIDs(), ", "))
+		}
+		for _, nested := range c.nested() {
+			if field := nested.firstOtherField(c.Field); field != "" {
+				return fmt.Errorf("condition on field %s cannot nest a condition on field %s", c.Field, field)
+			}
+		}
+		if err := c.validateSatisfiable(subField.FieldType); err != nil {
+			return fmt.Errorf("field %s: %w", c.Field, err)
+		}
+		return nil
+	}
+
+	if c.HasComparisons() || !c.HasLogicalOperators() {
+		return fmt.Errorf("conditions on composite habits must name a field")
+	}
+	for _, nested := range c.nested() {
+		if err := nested.validateFieldReferences(fieldType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// firstField returns the first field named anywhere in the condition tree.
+func (c *Condition) firstField() string {
+	return c.firstOtherField("")
+}
+
+// firstOtherField returns the first field named in the tree other than field.
+func (c *Condition) firstOtherField(field string) string {
+	if c.Field != "" && c.Field != field {
+		return c.Field
+	}
+	for _, nested := range c.nested() {
+		if other := nested.firstOtherField(field); other != "" {
+			return other
+		}
+	}
+	return ""
+}
+
+// validateTextOperators checks the text operators in the condition tree.
+func (c *Condition) validateTextOperators() error {
+	if c.Matches != "" {
+		if _, err := regexp.Compile(c.Matches); err != nil {
+			return fmt.Errorf("invalid matches pattern: %w", err)
+		}
+	}
+	for _, keyword := range append(append([]string{}, c.ContainsAny...), c.ContainsAll...) {
+		if strings.TrimSpace(keyword) == "" {
+			return fmt.Errorf("contains_any and contains_all keywords cannot be empty")
+		}
+	}
+	if c.MinWords != nil && *c.MinWords < 0 {
+		return fmt.Errorf("min_words cannot be negative")
+	}
+	if c.MinLines != nil && *c.MinLines < 0 {
+		return fmt.Errorf("min_lines cannot be negative")
+	}
+
+	for _, nested := range c.nested() {
+		if err := nested.validateTextOperators(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// usesTextOperators reports whether any condition in the tree sets a text operator.
+func (c *Condition) usesTextOperators() bool {
+	if c.HasTextOperators() {
+		return true
+	}
+	for _, nested := range c.nested() {
+		if nested.usesTextOperators() {
+			return true
+		}
+	}
+	return false
+}
+
+// nested returns the conditions under and, or and not.
+func (c *Condition) nested() []*Condition {
+	var conditions []*Condition
+	for i := range c.And {
+		conditions = append(conditions, &c.And[i])
+	}
+	for i := range c.Or {
+		conditions = append(conditions, &c.Or[i])
+	}
+	if c.Not != nil {
+		conditions = append(conditions, c.Not)
+	}
+	return conditions
+}
+
+// collectThresholds gathers every boundary value in the condition tree. Before and
+// after only apply to time fields, converted to minutes since midnight.
+func (c *Condition) collectThresholds(fieldType string, thresholds *[]float64) error {
+	for _, value := range []*float64{c.GreaterThan, c.GreaterThanOrEqual, c.LessThan, c.LessThanOrEqual} {
+		if value != nil {
+			*thresholds = append(*thresholds, *value)
+		}
+	}
+	if c.Range != nil {
+		*thresholds = append(*thresholds, c.Range.Min, c.Range.Max)
+	}
+	for name, value := range map[string]string{"before": c.Before, "after": c.After} {
+		if value == "" || fieldType != TimeFieldType {
+			continue
+		}
+		minutes, err := parseConditionTime(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s time: %w", name, err)
+		}
+		*thresholds = append(*thresholds, minutes)
+	}
+
+	for _, nested := range c.nested() {
+		if err := nested.collectThresholds(fieldType, thresholds); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// holdsForNumber evaluates the condition for a numeric value: the number itself,
+// minutes for durations and times, or the length of text.
+func (c *Condition) holdsForNumber(x float64, fieldType string) bool {
+	if c.HasComparisons() || !c.HasLogicalOperators() {
+		if !c.comparisonsHoldForNumber(x, fieldType) {
+			return false
+		}
+	}
+	return c.logicalOperatorsHold(func(nested *Condition) bool { return nested.holdsForNumber(x, fieldType) })
+}
+
+func (c *Condition) comparisonsHoldForNumber(x float64, fieldType string) bool {
+	if c.GreaterThan != nil && !(x > *c.GreaterThan) {
+		return false
+	}
+	if c.GreaterThanOrEqual != nil && !(x >= *c.GreaterThanOrEqual) {
+		return false
+	}
+	if c.LessThan != nil && !(x < *c.LessThan) {
+		return false
+	}
+	if c.LessThanOrEqual != nil && !(x <= *c.LessThanOrEqual) {
+		return false
+	}
+	if c.Range != nil {
+		if c.Range.MinInclusive == nil || *c.Range.MinInclusive {
+			if x < c.Range.Min {
+				return false
+			}
+		} else if x <= c.Range.Min {
+			return false
+		}
+		if c.Range.MaxInclusive == nil || *c.Range.MaxInclusive {
+			if x > c.Range.Max {
+				return false
+			}
+		} else if x >= c.Range.Max {
+			return false
+		}
+	}
+
+	if fieldType == TimeFieldType {
+		if before, err := parseConditionTime(c.Before); err == nil && !(x < before) {
+			return false
+		}
+		if after, err := parseConditionTime(c.After); err == nil && !(x > after) {
+			return false
+		}
+	}
+
+	// Text without length operators only needs to be non-empty
+	if fieldType == TextFieldType && !c.HasComparisons() {
+		return x > 0
+	}
+	return true
+}
+
+// holdsForBool evaluates the condition for a boolean value.
+func (c *Condition) holdsForBool(value bool) bool {
+	if c.Equals != nil && value != *c.Equals {
+		return false
+	}
+	return c.logicalOperatorsHold(func(nested *Condition) bool { return nested.holdsForBool(value) })
+}
+
+// logicalOperatorsHold applies and, or and not, using holds to evaluate nested conditions.
+func (c *Condition) logicalOperatorsHold(holds func(*Condition) bool) bool {
+	for i := range c.And {
+		if !holds(&c.And[i]) {
+			return false
+		}
+	}
+	if len(c.Or) > 0 {
+		anyHolds := false
+		for i := range c.Or {
+			if holds(&c.Or[i]) {
+				anyHolds = true
+				break
+			}
+		}
+		if !anyHolds {
+			return false
+		}
+	}
+	return c.Not == nil || !holds(c.Not)
+}
+
+// numericDomain is the set of values a field can take, as used by satisfiability checks.
+type numericDomain struct {
+	min, max *float64
+	integer  bool
+}
+
+func newNumericDomain(fieldType FieldType) numericDomain {
+	zero := 0.0
+	domain := numericDomain{min: fieldType.Min, max: fieldType.Max}
+
+	switch fieldType.Type {
+	case UnsignedIntFieldType, UnsignedDecimalFieldType, DurationFieldType:
+		if domain.min == nil || *domain.min < 0 {
+			domain.min = &zero
+		}
+	case TimeFieldType:
+		lastMinute := 23*60 + 59.0
+		domain = numericDomain{min: &zero, max: &lastMinute}
+	case TextFieldType:
+		// Length in characters; a field's min/max don't apply
+		domain = numericDomain{min: &zero}
+	}
+
+	domain.integer = fieldType.Type == UnsignedIntFieldType || fieldType.Type == TimeFieldType || fieldType.Type == TextFieldType
+	return domain
+}
+
+// candidates returns the values to test: each threshold and domain bound, the
+// points between them and either side of them, limited to the domain.
+func (d numericDomain) candidates(thresholds []float64) []float64 {
+	points := append([]float64{}, thresholds...)
+	if d.min != nil {
+		points = append(points, *d.min)
+	}
+	if d.max != nil {
+		points = append(points, *d.max)
+	}
+	if len(points) == 0 {
+		points = append(points, 0)
+	}
+	sort.Float64s(points)
+
+	var candidates []float64
+	for i, point := range points {
+		if d.integer {
+			candidates = append(candidates, math.Floor(point)-1, math.Floor(point), math.Ceil(point), math.Ceil(point)+1)
+			continue
+		}
+		candidates = append(candidates, point)
+		if i > 0 {
+			candidates = append(candidates, (points[i-1]+point)/2)
+		}
+	}
+	if !d.integer {
+		candidates = append(candidates, points[0]-1, points[len(points)-1]+1)
+	}
+
+	inDomain := candidates[:0]
+	for _, x := range candidates {
+		if (d.min == nil || x >= *d.min) && (d.max == nil || x <= *d.max) {
+			inDomain = append(inDomain, x)
+		}
+	}
+	return inDomain
+}
+
+// parseConditionTime parses an "HH:MM" condition time to minutes since midnight.
+func parseConditionTime(value string) (float64, error) {
+	parts := strings.Split(strings.TrimSpace(value), ":")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("expected HH:MM, got %q", value)
+	}
+	hours, err1 := strconv.Atoi(parts[0])
+	minutes, err2 := strconv.Atoi(parts[1])
+	if err1 != nil || err2 != nil || hours < 0 || hours > 23 || minutes < 0 || minutes > 59 {
+		return 0, fmt.Errorf("expected HH:MM, got %q", value)
+	}
+	return float64(hours*60 + minutes), nil
+}
@@ -0,0 +1,159 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCondition_ValidateSatisfiable(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+	b := func(v bool) *bool { return &v }
+	exclusive := false
+
+	tests := []struct {
+		name        string
+		fieldType   FieldType
+		condition   Condition
+		satisfiable bool
+	}{
+		{
+			name:        "simple threshold",
+			fieldType:   FieldType{Type: UnsignedIntFieldType},
+			condition:   Condition{GreaterThanOrEqual: f(10)},
+			satisfiable: true,
+		},
+		{
+			name:        "contradictory and",
+			fieldType:   FieldType{Type: DecimalFieldType},
+			condition:   Condition{And: []Condition{{GreaterThan: f(10)}, {LessThan: f(5)}}},
+			satisfiable: false,
+		},
+		{
+			name:        "contradictory operators on one condition",
+			fieldType:   FieldType{Type: DecimalFieldType},
+			condition:   Condition{GreaterThan: f(10), LessThanOrEqual: f(10)},
+			satisfiable: false,
+		},
+		{
+			name:        "continuous gap between thresholds",
+			fieldType:   FieldType{Type: UnsignedDecimalFieldType},
+			condition:   Condition{GreaterThan: f(1), LessThan: f(2)},
+			satisfiable: true,
+		},
+		{
+			name:        "no integer between thresholds",
+			fieldType:   FieldType{Type: UnsignedIntFieldType},
+			condition:   Condition{GreaterThan: f(1), LessThan: f(2)},
+			satisfiable: false,
+		},
+		{
+			name:        "negative threshold on unsigned field",
+			fieldType:   FieldType{Type: UnsignedDecimalFieldType},
+			condition:   Condition{LessThan: f(0)},
+			satisfiable: false,
+		},
+		{
+			name:        "threshold above field max",
+			fieldType:   FieldType{Type: UnsignedIntFieldType, Max: f(10)},
+			condition:   Condition{GreaterThan: f(10)},
+			satisfiable: false,
+		},
+		{
+			name:        "empty exclusive range",
+			fieldType:   FieldType{Type: DecimalFieldType},
+			condition:   Condition{Range: &RangeCondition{Min: 5, Max: 5, MinInclusive: &exclusive}},
+			satisfiable: false,
+		},
+		{
+			name:        "or with one possible branch",
+			fieldType:   FieldType{Type: DurationFieldType},
+			condition:   Condition{Or: []Condition{{LessThan: f(0)}, {GreaterThan: f(30)}}},
+			satisfiable: true,
+		},
+		{
+			name:        "not of everything",
+			fieldType:   FieldType{Type: DecimalFieldType},
+			condition:   Condition{Not: &Condition{Or: []Condition{{LessThan: f(3)}, {GreaterThanOrEqual: f(3)}}}},
+			satisfiable: false,
+		},
+		{
+			name:        "time window",
+			fieldType:   FieldType{Type: TimeFieldType},
+			condition:   Condition{After: "06:00", Before: "09:00"},
+			satisfiable: true,
+		},
+		{
+			name:        "inverted time window",
+			fieldType:   FieldType{Type: TimeFieldType},
+			condition:   Condition{And: []Condition{{After: "09:00"}, {Before: "06:00"}}},
+			satisfiable: false,
+		},
+		{
+			name:        "before midnight",
+			fieldType:   FieldType{Type: TimeFieldType},
+			condition:   Condition{Before: "00:00"},
+			satisfiable: false,
+		},
+		{
+			name:        "boolean contradiction",
+			fieldType:   FieldType{Type: BooleanFieldType},
+			condition:   Condition{Equals: b(true), Not: &Condition{Equals: b(true)}},
+			satisfiable: false,
+		},
+		{
+			name:        "text shorter than nothing",
+			fieldType:   FieldType{Type: TextFieldType},
+			condition:   Condition{LessThan: f(0)},
+			satisfiable: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.condition.validateSatisfiable(tt.fieldType)
+			if tt.satisfiable {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, "condition can never be satisfied")
+			}
+		})
+	}
+
+	t.Run("text operators", func(t *testing.T) {
+		text := FieldType{Type: TextFieldType}
+		negative := -1
+
+		assert.NoError(t, (&Condition{Not: &Condition{Matches: "^skip"}}).validateSatisfiable(text))
+		assert.ErrorContains(t, (&Condition{Matches: "(unclosed"}).validateSatisfiable(text), "invalid matches pattern")
+		assert.ErrorContains(t, (&Condition{Or: []Condition{{ContainsAny: []string{" "}}}}).validateSatisfiable(text), "keywords cannot be empty")
+		assert.ErrorContains(t, (&Condition{MinWords: &negative}).validateSatisfiable(text), "min_words cannot be negative")
+	})
+
+	t.Run("invalid time", func(t *testing.T) {
+		condition := Condition{Or: []Condition{{Before: "25:00"}}}
+		err := condition.validateSatisfiable(FieldType{Type: TimeFieldType})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid before time")
+	})
+}
+
+func TestHabit_Validate_UnsatisfiableCriteria(t *testing.T) {
+	low, high := 10.0, 5.0
+	habit := Habit{
+		Title:        "Run",
+		HabitType:    ElasticHabit,
+		FieldType:    FieldType{Type: DurationFieldType},
+		ScoringType:  AutomaticScoring,
+		MiniCriteria: &Criteria{Condition: &Condition{GreaterThan: &high}},
+		MidiCriteria: &Criteria{Condition: &Condition{GreaterThan: &low}},
+		MaxiCriteria: &Criteria{Condition: &Condition{
+			And: []Condition{{GreaterThan: &low}, {LessThan: &high}},
+		}},
+	}
+
+	err := habit.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid maxi_criteria: condition can never be satisfied")
+}
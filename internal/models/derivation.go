@@ -0,0 +1,183 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Derivation computes a derived habit's value from other habits' entries.
+type Derivation struct {
+	Aggregate string   `yaml:"aggregate"`             // sum, average, min, max or count
+	Sources   []string `yaml:"sources"`               // IDs of the habits to aggregate
+	Window    int      `yaml:"window_days,omitempty"` // Days aggregated, ending on the entry's day (default 1)
+}
+
+// Derivation aggregates.
+const (
+	AggregateSum     = "sum"     // Total of the source values
+	AggregateAverage = "average" // Mean of the source values
+	AggregateMin     = "min"     // Smallest source value
+	AggregateMax     = "max"     // Largest source value
+	AggregateCount   = "count"   // Number of completed source entries
+)
+
+// maxDerivationWindow bounds window_days to a year.
+const maxDerivationWindow = 366
+
+// WindowDays returns the number of days aggregated, at least 1.
+func (d *Derivation) WindowDays() int {
+	if d.Window < 1 {
+		return 1
+	}
+	return d.Window
+}
+
+// Validate checks the derivation on its own; sources are checked against the
+// schema by Schema.Validate.
+func (d *Derivation) Validate(habitID string, fieldType FieldType) error {
+	switch d.Aggregate {
+	case AggregateSum, AggregateAverage, AggregateMin, AggregateMax:
+		if fieldValueKind(fieldType.Type) == "" {
+			return fmt.Errorf("%s needs a numeric, duration or time field type, got %s", d.Aggregate, fieldType.Type)
+		}
+		if d.Aggregate == AggregateSum && fieldType.Type == TimeFieldType {
+			return fmt.Errorf("times of day cannot be summed")
+		}
+	case AggregateCount:
+		if fieldValueKind(fieldType.Type) != "number" {
+			return fmt.Errorf("count needs a numeric field type, got %s", fieldType.Type)
+		}
+	case "":
+		return fmt.Errorf("aggregate is required")
+	default:
+		return fmt.Errorf("invalid aggregate: %s (valid: sum, average, min, max, count)", d.Aggregate)
+	}
+
+	if len(d.Sources) == 0 {
+		return fmt.Errorf("at least one source habit is required")
+	}
+	seen := make(map[string]bool)
+	for _, source := range d.Sources {
+		if source == habitID {
+			return fmt.Errorf("habit cannot be derived from itself")
+		}
+		if seen[source] {
+			return fmt.Errorf("duplicate source habit: %s", source)
+		}
+		seen[source] = true
+	}
+
+	if d.Window < 0 || d.Window > maxDerivationWindow {
+		return fmt.Errorf("window_days must be between 1 and %d", maxDerivationWindow)
+	}
+	return nil
+}
+
+// fieldValueKind groups field types whose values can be aggregated together:
+// "number", "duration" or "time"; "" if values can't be aggregated.
+func fieldValueKind(fieldType string) string {
+	switch fieldType {
+	case UnsignedIntFieldType, UnsignedDecimalFieldType, DecimalFieldType:
+		return "number"
+	case DurationFieldType:
+		return "duration"
+	case TimeFieldType:
+		return "time"
+	default:
+		return ""
+	}
+}
+
+// validateDerivations checks that derived habits reference existing habits of a
+// compatible type, and that no habit is derived from itself through others.
+func (s *Schema) validateDerivations() error {
+	habits := make(map[string]*Habit, len(s.Habits))
+	for i := range s.Habits {
+		habits[s.Habits[i].ID] = &s.Habits[i]
+	}
+
+	for i := range s.Habits {
+		habit := &s.Habits[i]
+		if habit.Derivation == nil {
+			continue
+		}
+		for _, sourceID := range habit.Derivation.Sources {
+			source, found := habits[sourceID]
+			if !found {
+				return fmt.Errorf("derived habit %s: unknown source habit %s", habit.ID, sourceID)
+			}
+			if habit.Derivation.Aggregate == AggregateCount {
+				continue // Any habit's completions can be counted
+			}
+			if fieldValueKind(source.FieldType.Type) != fieldValueKind(habit.FieldType.Type) {
+				return fmt.Errorf("derived habit %s: cannot %s %s values of %s into a %s field",
+					habit.ID, habit.Derivation.Aggregate, source.FieldType.Type, sourceID, habit.FieldType.Type)
+			}
+		}
+	}
+
+	if _, err := s.DerivedHabitOrder(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DerivedHabitOrder returns the derived habits ordered so each comes after any
+// derived habits it's computed from. Returns an error if derivations form a cycle.
+// AIDEV-NOTE: derived-habit-order; recomputation relies on this order so derived
+// sources are up to date before the habits built on them
+func (s *Schema) DerivedHabitOrder() ([]*Habit, error) {
+	habits := make(map[string]*Habit, len(s.Habits))
+	for i := range s.Habits {
+		habits[s.Habits[i].ID] = &s.Habits[i]
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+	var order []*Habit
+	var path []string
+
+	var visit func(habit *Habit) error
+	visit = func(habit *Habit) error {
+		switch state[habit.ID] {
+		case visited:
+			return nil
+		case visiting:
+			start := 0
+			for i, id := range path {
+				if id == habit.ID {
+					start = i
+				}
+			}
+			cycle := append(append([]string{}, path[start:]...), habit.ID)
+			return fmt.Errorf("derived habits form a cycle: %s", strings.Join(cycle, " -> "))
+		}
+
+		state[habit.ID] = visiting
+		path = append(path, habit.ID)
+		for _, sourceID := range habit.Derivation.Sources {
+			if source, found := habits[sourceID]; found && source.Derivation != nil {
+				if err := visit(source); err != nil {
+					return err
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[habit.ID] = visited
+		order = append(order, habit)
+		return nil
+	}
+
+	for i := range s.Habits {
+		if s.Habits[i].Derivation != nil {
+			if err := visit(&s.Habits[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return order, nil
+}
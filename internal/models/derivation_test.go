@@ -0,0 +1,134 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func derivedHabit(title, aggregate, fieldType string, sources ...string) Habit {
+	return Habit{
+		Title:      title,
+		HabitType:  DerivedHabit,
+		FieldType:  FieldType{Type: fieldType},
+		Derivation: &Derivation{Aggregate: aggregate, Sources: sources},
+	}
+}
+
+func TestHabit_ValidateDerived(t *testing.T) {
+	atLeast := 4.0
+
+	tests := []struct {
+		name   string
+		habit  Habit
+		modify func(*Habit)
+		err    string
+	}{
+		{name: "sum", habit: derivedHabit("Deep Work", AggregateSum, DurationFieldType, "focus_am", "focus_pm")},
+		{name: "count with criteria", habit: derivedHabit("Healthy Day", AggregateCount, UnsignedIntFieldType, "walk", "water"),
+			modify: func(h *Habit) {
+				h.ScoringType = AutomaticScoring
+				h.Criteria = &Criteria{Condition: &Condition{GreaterThanOrEqual: &atLeast}}
+			}},
+		{name: "missing derivation", habit: derivedHabit("X", AggregateSum, DecimalFieldType, "a"),
+			modify: func(h *Habit) { h.Derivation = nil }, err: "derivation is required"},
+		{name: "derivation on other habit types", habit: derivedHabit("X", AggregateSum, DecimalFieldType, "a"),
+			modify: func(h *Habit) { h.HabitType = InformationalHabit }, err: "only valid for derived habits"},
+		{name: "invalid aggregate", habit: derivedHabit("X", "median", DecimalFieldType, "a"), err: "invalid aggregate: median"},
+		{name: "no sources", habit: derivedHabit("X", AggregateSum, DecimalFieldType), err: "at least one source"},
+		{name: "self reference", habit: derivedHabit("X", AggregateSum, DecimalFieldType, "x"), err: "derived from itself"},
+		{name: "duplicate source", habit: derivedHabit("X", AggregateSum, DecimalFieldType, "a", "a"), err: "duplicate source habit: a"},
+		{name: "count into duration", habit: derivedHabit("X", AggregateCount, DurationFieldType, "a"), err: "count needs a numeric field type"},
+		{name: "sum of times", habit: derivedHabit("X", AggregateSum, TimeFieldType, "a"), err: "cannot be summed"},
+		{name: "text field", habit: derivedHabit("X", AggregateMax, TextFieldType, "a"), err: "needs a numeric, duration or time field type"},
+		{name: "negative window", habit: derivedHabit("X", AggregateSum, DecimalFieldType, "a"),
+			modify: func(h *Habit) { h.Derivation.Window = -1 }, err: "window_days"},
+		{name: "window criteria", habit: derivedHabit("X", AggregateSum, DecimalFieldType, "a"),
+			modify: func(h *Habit) {
+				h.ScoringType = AutomaticScoring
+				h.Criteria = &Criteria{Condition: &Condition{Window: &WindowCondition{Aggregate: AggregateSum, Days: 7}, GreaterThan: &atLeast}}
+			}, err: "not window conditions"},
+		{name: "criteria without automatic scoring", habit: derivedHabit("X", AggregateSum, DecimalFieldType, "a"),
+			modify: func(h *Habit) { h.Criteria = &Criteria{Condition: &Condition{GreaterThan: &atLeast}} }, err: "must use automatic scoring"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			habit := tt.habit
+			if tt.modify != nil {
+				tt.modify(&habit)
+			}
+			err := habit.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestSchema_ValidateDerivations(t *testing.T) {
+	source := func(title, fieldType string) Habit {
+		return Habit{Title: title, HabitType: InformationalHabit, FieldType: FieldType{Type: fieldType}}
+	}
+
+	tests := []struct {
+		name   string
+		habits []Habit
+		err    string
+	}{
+		{name: "valid", habits: []Habit{
+			source("Focus AM", DurationFieldType),
+			source("Focus PM", DurationFieldType),
+			derivedHabit("Deep Work", AggregateSum, DurationFieldType, "focus_am", "focus_pm"),
+		}},
+		{name: "derived from derived", habits: []Habit{
+			derivedHabit("Weekly Deep Work", AggregateSum, DurationFieldType, "deep_work"),
+			source("Focus AM", DurationFieldType),
+			derivedHabit("Deep Work", AggregateSum, DurationFieldType, "focus_am"),
+		}},
+		{name: "count of any type", habits: []Habit{
+			source("Walk", BooleanFieldType),
+			source("Journal", TextFieldType),
+			derivedHabit("Healthy Day", AggregateCount, UnsignedIntFieldType, "walk", "journal"),
+		}},
+		{name: "unknown source", habits: []Habit{
+			derivedHabit("Deep Work", AggregateSum, DurationFieldType, "focus_am"),
+		}, err: "unknown source habit focus_am"},
+		{name: "incompatible source", habits: []Habit{
+			source("Pages", UnsignedIntFieldType),
+			derivedHabit("Deep Work", AggregateSum, DurationFieldType, "pages"),
+		}, err: "cannot sum unsigned_int values of pages into a duration field"},
+		{name: "cycle", habits: []Habit{
+			derivedHabit("A", AggregateSum, DecimalFieldType, "b"),
+			derivedHabit("B", AggregateSum, DecimalFieldType, "c"),
+			derivedHabit("C", AggregateSum, DecimalFieldType, "a"),
+		}, err: "derived habits form a cycle: a -> b -> c -> a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := Schema{Version: "1.0.0", Habits: tt.habits}
+			err := schema.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+
+	t.Run("order puts sources first", func(t *testing.T) {
+		schema := Schema{Version: "1.0.0", Habits: tests[1].habits}
+		require.NoError(t, schema.Validate())
+		order, err := schema.DerivedHabitOrder()
+		require.NoError(t, err)
+		require.Len(t, order, 2)
+		assert.Equal(t, "deep_work", order[0].ID)
+		assert.Equal(t, "weekly_deep_work", order[1].ID)
+	})
+}
@@ -161,6 +161,80 @@ func (ge *HabitEntry) GetLastModified() time.Time {
 	return ge.CreatedAt
 }
 
+// Same reports whether two entries (either possibly nil) record the same data.
+// Timestamps and how YAML decoded the value don't count, so the same check-in
+// saved on two machines, or reloaded from disk, still matches.
+// AIDEV-NOTE: entry-equality; shared by import, merge and the entry menu's concurrent-edit check
+func (ge *HabitEntry) Same(other *HabitEntry) bool {
+	if ge == nil || other == nil {
+		return ge == other
+	}
+	if ge.Status != other.Status || ge.Notes != other.Notes {
+		return false
+	}
+	if (ge.AchievementLevel == nil) != (other.AchievementLevel == nil) {
+		return false
+	}
+	if ge.AchievementLevel != nil && *ge.AchievementLevel != *other.AchievementLevel {
+		return false
+	}
+	return fmt.Sprintf("%v", NormalizeValue(ge.Value)) == fmt.Sprintf("%v", NormalizeValue(other.Value))
+}
+
+// Describe summarizes the entry for import and merge reports, e.g.
+// "completed 45m [mini] (notes: tired)". A nil entry is "deleted".
+func (ge *HabitEntry) Describe() string {
+	if ge == nil {
+		return "deleted"
+	}
+
+	description := string(ge.Status)
+	if ge.Value != nil {
+		description += fmt.Sprintf(" %v", ge.Value)
+	}
+	if ge.AchievementLevel != nil {
+		description += fmt.Sprintf(" [%s]", *ge.AchievementLevel)
+	}
+	if ge.Notes != "" {
+		description += fmt.Sprintf(" (notes: %s)", ge.Notes)
+	}
+	return description
+}
+
+// NormalizeValue converts a stored entry value into a plain, JSON-friendly form:
+// numbers become float64, durations strings, times "15:04", lists []string and
+// composite values maps of normalized sub-field values.
+func NormalizeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case time.Duration:
+		return v.String()
+	case time.Time:
+		return v.Format("15:04")
+	case nil, bool, float64, string, []string:
+		return v
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, fmt.Sprintf("%v", item))
+		}
+		return items
+	case map[string]interface{}:
+		fields := make(map[string]interface{}, len(v))
+		for id, fieldValue := range v {
+			fields[id] = NormalizeValue(fieldValue)
+		}
+		return fields
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
 // Validate validates a habit entry for correctness.
 func (ge *HabitEntry) Validate() error {
 	// Habit ID is required
@@ -309,6 +383,17 @@ func (de *DayEntry) UpdateHabitEntry(habitEntry HabitEntry) error {
 	return nil
 }
 
+// RemoveHabitEntry removes the entry for a habit, reporting whether there was one.
+func (de *DayEntry) RemoveHabitEntry(habitID string) bool {
+	for i := range de.Habits {
+		if de.Habits[i].HabitID == habitID {
+			de.Habits = append(de.Habits[:i], de.Habits[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
 // GetBooleanValue safely extracts a boolean value from the habit entry.
 // Returns the boolean value and true if successful, false and false if not a boolean.
 func (ge *HabitEntry) GetBooleanValue() (bool, bool) {
@@ -498,20 +583,7 @@ func (ge *HabitEntry) MarshalYAML() (interface{}, error) {
 	result["habit_id"] = alias.HabitID
 
 	if alias.Value != nil {
-		// Handle time field values specially - format as HH:MM if it's a time
-		if timeVal, ok := alias.Value.(time.Time); ok && isTimeFieldValue(timeVal) {
-			result["value"] = timeVal.Format("15:04")
-		} else if floatVal, ok := alias.Value.(float64); ok {
-			// Preserve float64 type by ensuring decimal notation
-			if floatVal == float64(int64(floatVal)) {
-				// If it's a whole number, add .0 to preserve float type
-				result["value"] = fmt.Sprintf("%.1f", floatVal)
-			} else {
-				result["value"] = floatVal
-			}
-		} else {
-			result["value"] = alias.Value
-		}
+		result["value"] = marshalValue(alias.Value)
 	}
 
 	if alias.AchievementLevel != nil {
@@ -592,6 +664,31 @@ func (ge *HabitEntry) UnmarshalYAML(node *yaml.Node) error {
 	return nil
 }
 
+// marshalValue formats a value for YAML: time-of-day values as HH:MM, whole floats
+// with a decimal point, and composite values field by field.
+func marshalValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case time.Time:
+		// Handle time field values specially - format as HH:MM if it's a time
+		if isTimeFieldValue(v) {
+			return v.Format("15:04")
+		}
+	case float64:
+		// Preserve float64 type by ensuring decimal notation
+		if v == float64(int64(v)) {
+			// If it's a whole number, add .0 to preserve float type
+			return fmt.Sprintf("%.1f", v)
+		}
+	case map[string]interface{}:
+		fields := make(map[string]interface{}, len(v))
+		for id, fieldValue := range v {
+			fields[id] = marshalValue(fieldValue)
+		}
+		return fields
+	}
+	return value
+}
+
 // isTimeFieldValue determines if a time.Time value represents a time-of-day rather than a full timestamp
 func isTimeFieldValue(t time.Time) bool {
 	// Time field values have year 0000 (zero date with time component)
@@ -600,6 +697,14 @@ func isTimeFieldValue(t time.Time) bool {
 
 // parseValueField handles parsing the value field which could be various types including time strings
 func parseValueField(raw interface{}) interface{} {
+	// Composite values are maps of sub-field values
+	if fields, ok := raw.(map[string]interface{}); ok {
+		parsed := make(map[string]interface{}, len(fields))
+		for id, fieldValue := range fields {
+			parsed[id] = parseValueField(fieldValue)
+		}
+		return parsed
+	}
 	if str, ok := raw.(string); ok {
 		// Try parsing as time first (for time field values)
 		if strings.Contains(str, ":") {
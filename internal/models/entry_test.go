@@ -761,6 +761,43 @@ func TestHabitEntry_StatusHelperMethods(t *testing.T) {
 	})
 }
 
+func TestHabitEntry_Same(t *testing.T) {
+	mini := AchievementMini
+	entry := &HabitEntry{HabitID: "reading", Value: 20, Status: EntryCompleted, CreatedAt: time.Now()}
+
+	t.Run("ignores timestamps and decoded value types", func(t *testing.T) {
+		reloaded := &HabitEntry{HabitID: "reading", Value: 20.0, Status: EntryCompleted, CreatedAt: time.Now().Add(time.Hour)}
+		assert.True(t, entry.Same(reloaded))
+
+		list := &HabitEntry{Value: []interface{}{"a", "b"}, Status: EntryCompleted}
+		assert.True(t, list.Same(&HabitEntry{Value: []string{"a", "b"}, Status: EntryCompleted}))
+	})
+
+	t.Run("compares the recorded data", func(t *testing.T) {
+		assert.False(t, entry.Same(&HabitEntry{Value: 30, Status: EntryCompleted}))
+		assert.False(t, entry.Same(&HabitEntry{Value: 20, Status: EntrySkipped}))
+		assert.False(t, entry.Same(&HabitEntry{Value: 20, Status: EntryCompleted, Notes: "tired"}))
+		assert.False(t, entry.Same(&HabitEntry{Value: 20, Status: EntryCompleted, AchievementLevel: &mini}))
+	})
+
+	t.Run("nil entries", func(t *testing.T) {
+		var none *HabitEntry
+		assert.True(t, none.Same(nil))
+		assert.False(t, none.Same(entry))
+		assert.False(t, entry.Same(nil))
+	})
+}
+
+func TestHabitEntry_Describe(t *testing.T) {
+	mini := AchievementMini
+	entry := &HabitEntry{Value: "45m", Status: EntryCompleted, AchievementLevel: &mini, Notes: "tired"}
+	assert.Equal(t, "completed 45m [mini] (notes: tired)", entry.Describe())
+	assert.Equal(t, "skipped", (&HabitEntry{Status: EntrySkipped}).Describe())
+
+	var none *HabitEntry
+	assert.Equal(t, "deleted", none.Describe())
+}
+
 func TestHabitEntry_TimestampMethods(t *testing.T) {
 	t.Run("mark created and updated", func(t *testing.T) {
 		entry := HabitEntry{
@@ -0,0 +1,188 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// GoalSchema represents the goals.yml file structure, kept next to habits.yml in
+// each context.
+type GoalSchema struct {
+	Version string `yaml:"version"`
+	Goals   []Goal `yaml:"goals"`
+}
+
+// Goal is a target for a habit to reach by a date: "meditate 100 sessions by
+// 2027-01-01", "read 20 hours this quarter".
+type Goal struct {
+	ID        string      `yaml:"id,omitempty"`
+	Title     string      `yaml:"title"`
+	HabitID   string      `yaml:"habit_id"`
+	Measure   GoalMeasure `yaml:"measure,omitempty"`    // completions (default) or total
+	Target    float64     `yaml:"target"`               // Completions, or a total in criteria units (minutes for durations)
+	StartDate string      `yaml:"start_date,omitempty"` // YYYY-MM-DD; entries before it don't count
+	DueDate   string      `yaml:"due_date,omitempty"`   // YYYY-MM-DD
+	Period    GoalPeriod  `yaml:"period,omitempty"`     // Instead of dates: the current calendar period
+}
+
+// GoalMeasure is what a goal counts.
+type GoalMeasure string
+
+// Goal measures.
+const (
+	GoalCompletions GoalMeasure = "completions" // Completed entries
+	GoalTotal       GoalMeasure = "total"       // Sum of entry values
+)
+
+// GoalPeriod is a recurring calendar period a goal resets with.
+type GoalPeriod string
+
+// Goal periods; weeks start on Monday.
+const (
+	GoalWeek    GoalPeriod = "week"
+	GoalMonth   GoalPeriod = "month"
+	GoalQuarter GoalPeriod = "quarter"
+	GoalYear    GoalPeriod = "year"
+)
+
+// Validate validates a goal schema for correctness and consistency. Habit
+// references are checked by ValidateHabits.
+func (gs *GoalSchema) Validate() error {
+	if gs.Version == "" {
+		return fmt.Errorf("goal schema version is required")
+	}
+
+	ids := make(map[string]bool)
+	for i := range gs.Goals {
+		if err := gs.Goals[i].Validate(); err != nil {
+			return fmt.Errorf("goal at index %d: %w", i, err)
+		}
+		if ids[gs.Goals[i].ID] {
+			return fmt.Errorf("duplicate goal ID: %s", gs.Goals[i].ID)
+		}
+		ids[gs.Goals[i].ID] = true
+	}
+	return nil
+}
+
+// ValidateHabits checks that each goal's habit exists and can be measured the
+// goal's way.
+func (gs *GoalSchema) ValidateHabits(schema *Schema) error {
+	habits := make(map[string]*Habit, len(schema.Habits))
+	for i := range schema.Habits {
+		habits[schema.Habits[i].ID] = &schema.Habits[i]
+	}
+
+	for _, goal := range gs.Goals {
+		habit, found := habits[goal.HabitID]
+		if !found {
+			return fmt.Errorf("goal %s: unknown habit %s", goal.ID, goal.HabitID)
+		}
+		if goal.GetMeasure() == GoalTotal {
+			if kind := fieldValueKind(habit.FieldType.Type); kind != "number" && kind != "duration" {
+				return fmt.Errorf("goal %s: %s values of %s cannot be totalled", goal.ID, habit.FieldType.Type, habit.ID)
+			}
+		}
+	}
+	return nil
+}
+
+// Validate validates a goal, generating its ID from the title if missing.
+func (g *Goal) Validate() error {
+	if strings.TrimSpace(g.Title) == "" {
+		return fmt.Errorf("goal title is required")
+	}
+	if g.ID == "" {
+		g.ID = generateIDFromTitle(g.Title)
+	}
+	if !isValidID(g.ID) {
+		return fmt.Errorf("goal ID '%s' is invalid: must contain only letters, numbers, and underscores", g.ID)
+	}
+	if g.HabitID == "" {
+		return fmt.Errorf("habit_id is required")
+	}
+
+	switch g.GetMeasure() {
+	case GoalCompletions:
+		if g.Target != math.Trunc(g.Target) {
+			return fmt.Errorf("completion targets must be whole numbers")
+		}
+	case GoalTotal:
+	default:
+		return fmt.Errorf("invalid measure: %s (valid: completions, total)", g.Measure)
+	}
+	if g.Target <= 0 {
+		return fmt.Errorf("target must be greater than 0")
+	}
+
+	switch {
+	case g.Period != "" && (g.DueDate != "" || g.StartDate != ""):
+		return fmt.Errorf("period cannot be combined with start_date or due_date")
+	case g.Period != "":
+		switch g.Period {
+		case GoalWeek, GoalMonth, GoalQuarter, GoalYear:
+		default:
+			return fmt.Errorf("invalid period: %s (valid: week, month, quarter, year)", g.Period)
+		}
+	case g.DueDate == "":
+		return fmt.Errorf("due_date or period is required")
+	default:
+		due, err := time.Parse("2006-01-02", g.DueDate)
+		if err != nil {
+			return fmt.Errorf("invalid due_date format, expected YYYY-MM-DD: %w", err)
+		}
+		if g.StartDate != "" {
+			start, err := time.Parse("2006-01-02", g.StartDate)
+			if err != nil {
+				return fmt.Errorf("invalid start_date format, expected YYYY-MM-DD: %w", err)
+			}
+			if start.After(due) {
+				return fmt.Errorf("start_date must not be after due_date")
+			}
+		}
+	}
+	return nil
+}
+
+// GetMeasure returns the goal's measure, defaulting to completions.
+func (g *Goal) GetMeasure() GoalMeasure {
+	if g.Measure == "" {
+		return GoalCompletions
+	}
+	return g.Measure
+}
+
+// Span returns the first and last day counting toward the goal as of today. For
+// periods that's the calendar period containing today; otherwise start is zero
+// when no start_date is given. Assumes a validated goal.
+func (g *Goal) Span(today time.Time) (start, due time.Time) {
+	day := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, today.Location())
+
+	switch g.Period {
+	case GoalWeek:
+		start = day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		return start, start.AddDate(0, 0, 6)
+	case GoalMonth:
+		start = time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, day.Location())
+		return start, start.AddDate(0, 1, -1)
+	case GoalQuarter:
+		month := time.Month((int(day.Month())-1)/3*3 + 1)
+		start = time.Date(day.Year(), month, 1, 0, 0, 0, 0, day.Location())
+		return start, start.AddDate(0, 3, -1)
+	case GoalYear:
+		start = time.Date(day.Year(), time.January, 1, 0, 0, 0, 0, day.Location())
+		return start, start.AddDate(1, 0, -1)
+	}
+
+	if g.StartDate != "" {
+		if parsed, err := time.ParseInLocation("2006-01-02", g.StartDate, day.Location()); err == nil {
+			start = parsed
+		}
+	}
+	if parsed, err := time.ParseInLocation("2006-01-02", g.DueDate, day.Location()); err == nil {
+		due = parsed
+	}
+	return start, due
+}
@@ -0,0 +1,119 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoal_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		goal Goal
+		err  string
+	}{
+		{name: "completions by date", goal: Goal{Title: "Meditate 100 sessions", HabitID: "meditation", Target: 100, DueDate: "2027-01-01"}},
+		{name: "total per quarter", goal: Goal{Title: "Read", HabitID: "reading", Measure: GoalTotal, Target: 1200, Period: GoalQuarter}},
+		{name: "start date", goal: Goal{Title: "Run", HabitID: "run", Target: 10, StartDate: "2026-01-01", DueDate: "2026-02-01"}},
+		{name: "missing title", goal: Goal{HabitID: "run", Target: 10, DueDate: "2026-02-01"}, err: "title is required"},
+		{name: "missing habit", goal: Goal{Title: "Run", Target: 10, DueDate: "2026-02-01"}, err: "habit_id is required"},
+		{name: "invalid measure", goal: Goal{Title: "Run", HabitID: "run", Measure: "streak", Target: 10, DueDate: "2026-02-01"}, err: "invalid measure: streak"},
+		{name: "zero target", goal: Goal{Title: "Run", HabitID: "run", DueDate: "2026-02-01"}, err: "target must be greater than 0"},
+		{name: "fractional completions", goal: Goal{Title: "Run", HabitID: "run", Target: 2.5, DueDate: "2026-02-01"}, err: "whole numbers"},
+		{name: "no due date or period", goal: Goal{Title: "Run", HabitID: "run", Target: 10}, err: "due_date or period is required"},
+		{name: "period with dates", goal: Goal{Title: "Run", HabitID: "run", Target: 10, Period: GoalWeek, DueDate: "2026-02-01"}, err: "cannot be combined"},
+		{name: "invalid period", goal: Goal{Title: "Run", HabitID: "run", Target: 10, Period: "decade"}, err: "invalid period: decade"},
+		{name: "invalid due date", goal: Goal{Title: "Run", HabitID: "run", Target: 10, DueDate: "soon"}, err: "invalid due_date format"},
+		{name: "start after due", goal: Goal{Title: "Run", HabitID: "run", Target: 10, StartDate: "2026-03-01", DueDate: "2026-02-01"}, err: "must not be after due_date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			goal := tt.goal
+			err := goal.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+
+	t.Run("ID from title", func(t *testing.T) {
+		goal := Goal{Title: "Meditate 100 sessions", HabitID: "meditation", Target: 100, DueDate: "2027-01-01"}
+		require.NoError(t, goal.Validate())
+		assert.Equal(t, "meditate_100_sessions", goal.ID)
+		assert.Equal(t, GoalCompletions, goal.GetMeasure())
+	})
+}
+
+func TestGoalSchema_Validate(t *testing.T) {
+	goal := func(title, habitID string, measure GoalMeasure) Goal {
+		return Goal{Title: title, HabitID: habitID, Measure: measure, Target: 10, Period: GoalMonth}
+	}
+	habits := &Schema{Version: "1.0.0", Habits: []Habit{
+		{ID: "reading", FieldType: FieldType{Type: DurationFieldType}},
+		{ID: "walk", FieldType: FieldType{Type: BooleanFieldType}},
+	}}
+
+	t.Run("valid", func(t *testing.T) {
+		schema := GoalSchema{Version: "1.0.0", Goals: []Goal{goal("Read", "reading", GoalTotal), goal("Walk", "walk", "")}}
+		require.NoError(t, schema.Validate())
+		assert.NoError(t, schema.ValidateHabits(habits))
+	})
+
+	t.Run("duplicate ID", func(t *testing.T) {
+		schema := GoalSchema{Version: "1.0.0", Goals: []Goal{goal("Walk", "walk", ""), goal("Walk", "walk", GoalCompletions)}}
+		err := schema.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "duplicate goal ID: walk")
+	})
+
+	t.Run("unknown habit", func(t *testing.T) {
+		schema := GoalSchema{Version: "1.0.0", Goals: []Goal{goal("Swim", "swim", "")}}
+		require.NoError(t, schema.Validate())
+		err := schema.ValidateHabits(habits)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown habit swim")
+	})
+
+	t.Run("total of booleans", func(t *testing.T) {
+		schema := GoalSchema{Version: "1.0.0", Goals: []Goal{goal("Walk", "walk", GoalTotal)}}
+		require.NoError(t, schema.Validate())
+		err := schema.ValidateHabits(habits)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be totalled")
+	})
+}
+
+func TestGoal_Span(t *testing.T) {
+	thursday := time.Date(2026, 8, 13, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		goal       Goal
+		start, due string
+	}{
+		{"week", Goal{Period: GoalWeek}, "2026-08-10", "2026-08-16"},
+		{"month", Goal{Period: GoalMonth}, "2026-08-01", "2026-08-31"},
+		{"quarter", Goal{Period: GoalQuarter}, "2026-07-01", "2026-09-30"},
+		{"year", Goal{Period: GoalYear}, "2026-01-01", "2026-12-31"},
+		{"dates", Goal{StartDate: "2026-06-01", DueDate: "2027-01-01"}, "2026-06-01", "2027-01-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, due := tt.goal.Span(thursday)
+			assert.Equal(t, tt.start, start.Format("2006-01-02"))
+			assert.Equal(t, tt.due, due.Format("2006-01-02"))
+		})
+	}
+
+	t.Run("no start date", func(t *testing.T) {
+		start, _ := (&Goal{DueDate: "2027-01-01"}).Span(thursday)
+		assert.True(t, start.IsZero())
+	})
+}
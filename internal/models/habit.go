@@ -34,6 +34,15 @@ type Habit struct {
 	// Informational habit fields (not used for simple habits)
 	Direction string `yaml:"direction,omitempty"`
 
+	// Derived habit fields: how the value is computed from other habits
+	Derivation *Derivation `yaml:"derivation,omitempty"`
+
+	// Periodicity (nil means daily)
+	Schedule *Schedule `yaml:"schedule,omitempty"`
+
+	// Reminder times and nudges for `vice remind`
+	Reminders *Reminders `yaml:"reminders,omitempty"`
+
 	// UI fields
 	Prompt   string `yaml:"prompt,omitempty"`
 	HelpText string `yaml:"help_text,omitempty"`
@@ -48,6 +57,7 @@ const (
 	ElasticHabit       HabitType = "elastic"       // Three-tier achievement habits (mini/midi/maxi)
 	InformationalHabit HabitType = "informational" // Data collection without scoring
 	ChecklistHabit     HabitType = "checklist"     // Checklist completion habits
+	DerivedHabit       HabitType = "derived"       // Computed from other habits' entries
 )
 
 // ScoringType represents how the habit is scored.
@@ -69,6 +79,34 @@ type FieldType struct {
 	Max         *float64 `yaml:"max,omitempty"`
 	Format      string   `yaml:"format,omitempty"`
 	ChecklistID string   `yaml:"checklist_id,omitempty"` // Reference to checklist
+
+	Fields []SubField `yaml:"fields,omitempty"` // Sub-fields of a composite field
+}
+
+// SubField is one named value recorded by a composite field.
+type SubField struct {
+	ID        string    `yaml:"id"`
+	Title     string    `yaml:"title,omitempty"`
+	FieldType FieldType `yaml:"field_type"`
+}
+
+// GetSubField returns the composite sub-field with the given ID.
+func (ft *FieldType) GetSubField(id string) (*SubField, bool) {
+	for i := range ft.Fields {
+		if ft.Fields[i].ID == id {
+			return &ft.Fields[i], true
+		}
+	}
+	return nil, false
+}
+
+// SubFieldIDs returns the IDs of the composite sub-fields, in order.
+func (ft *FieldType) SubFieldIDs() []string {
+	ids := make([]string, 0, len(ft.Fields))
+	for _, field := range ft.Fields {
+		ids = append(ids, field.ID)
+	}
+	return ids
 }
 
 // Field type constants
@@ -81,6 +119,7 @@ const (
 	TimeFieldType            = "time"
 	DurationFieldType        = "duration"
 	ChecklistFieldType       = "checklist"
+	CompositeFieldType       = "composite" // Several typed values, keyed by sub-field ID
 )
 
 // Criteria represents habit achievement criteria.
@@ -107,10 +146,23 @@ type Condition struct {
 	// Boolean equality
 	Equals *bool `yaml:"equals,omitempty"`
 
+	// Composite sub-field the condition (and its nested conditions) applies to
+	Field string `yaml:"field,omitempty"`
+
+	// Text criteria
+	Matches     string   `yaml:"matches,omitempty"`      // Regular expression (RE2 syntax)
+	ContainsAny []string `yaml:"contains_any,omitempty"` // At least one keyword, case-insensitive
+	ContainsAll []string `yaml:"contains_all,omitempty"` // Every keyword, case-insensitive
+	MinWords    *int     `yaml:"min_words,omitempty"`    // Minimum number of words
+	MinLines    *int     `yaml:"min_lines,omitempty"`    // Minimum number of non-blank lines
+
 	// Checklist completion criteria
 	ChecklistCompletion *ChecklistCompletionCondition `yaml:"checklist_completion,omitempty"`
 
-	// Logical operators (for future extension)
+	// Compare an aggregate of recent entries instead of the day's value
+	Window *WindowCondition `yaml:"window,omitempty"`
+
+	// Logical operators; nested conditions are evaluated recursively
 	And []Condition `yaml:"and,omitempty"`
 	Or  []Condition `yaml:"or,omitempty"`
 	Not *Condition  `yaml:"not,omitempty"`
@@ -186,6 +238,23 @@ func (g *Habit) validateInternal() error {
 		return fmt.Errorf("invalid field_type: %w", err)
 	}
 
+	// Validate schedule if present
+	if g.Schedule != nil {
+		if err := g.Schedule.Validate(); err != nil {
+			return fmt.Errorf("invalid schedule: %w", err)
+		}
+	}
+
+	// Validate reminders if present; derived habits are never logged, so never incomplete
+	if g.Reminders != nil {
+		if g.HabitType == DerivedHabit {
+			return fmt.Errorf("derived habits cannot have reminders")
+		}
+		if err := g.Reminders.Validate(); err != nil {
+			return fmt.Errorf("invalid reminders: %w", err)
+		}
+	}
+
 	// Validate scoring requirements for simple habits
 	if g.HabitType == SimpleHabit {
 		if g.ScoringType == "" {
@@ -242,6 +311,66 @@ func (g *Habit) validateInternal() error {
 		}
 	}
 
+	// Validate derivation for derived habits
+	if g.HabitType == DerivedHabit {
+		if g.Derivation == nil {
+			return fmt.Errorf("derivation is required for derived habits")
+		}
+		if err := g.Derivation.Validate(g.ID, g.FieldType); err != nil {
+			return fmt.Errorf("invalid derivation: %w", err)
+		}
+		if g.Criteria != nil && g.ScoringType != AutomaticScoring {
+			return fmt.Errorf("derived habits with criteria must use automatic scoring")
+		}
+		if g.MiniCriteria != nil || g.MidiCriteria != nil || g.MaxiCriteria != nil {
+			return fmt.Errorf("derived habits are scored with criteria, not mini/midi/maxi criteria")
+		}
+		if g.UsesWindowCriteria() {
+			return fmt.Errorf("derived habits aggregate over days with window_days, not window conditions")
+		}
+	} else if g.Derivation != nil {
+		return fmt.Errorf("derivation is only valid for derived habits")
+	}
+
+	// Reject criteria no value could ever meet
+	if err := g.validateCriteriaSatisfiable(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateCriteriaSatisfiable checks that every criteria condition can be met by
+// some value of the habit's field type.
+func (g *Habit) validateCriteriaSatisfiable() error {
+	for _, named := range []struct {
+		name     string
+		criteria *Criteria
+	}{
+		{"criteria", g.Criteria},
+		{"mini_criteria", g.MiniCriteria},
+		{"midi_criteria", g.MidiCriteria},
+		{"maxi_criteria", g.MaxiCriteria},
+	} {
+		if named.criteria == nil || named.criteria.Condition == nil {
+			continue
+		}
+		if err := named.criteria.Condition.validateWindows(g.FieldType); err != nil {
+			return fmt.Errorf("invalid %s: %w", named.name, err)
+		}
+		if err := named.criteria.Condition.validateFieldReferences(g.FieldType); err != nil {
+			return fmt.Errorf("invalid %s: %w", named.name, err)
+		}
+		if g.FieldType.Type == CompositeFieldType {
+			continue // Sub-field conditions are checked by validateFieldReferences
+		}
+		if named.criteria.Condition.UsesWindows() {
+			continue // Window thresholds don't apply to the day's value
+		}
+		if err := named.criteria.Condition.validateSatisfiable(g.FieldType); err != nil {
+			return fmt.Errorf("invalid %s: %w", named.name, err)
+		}
+	}
 	return nil
 }
 
@@ -315,6 +444,10 @@ func (ft *FieldType) Validate() error {
 		if ft.ChecklistID == "" {
 			return fmt.Errorf("checklist_id is required for checklist field type")
 		}
+	case CompositeFieldType:
+		if err := ft.validateSubFields(); err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("unknown field type: %s", ft.Type)
 	}
@@ -327,6 +460,35 @@ func (ft *FieldType) Validate() error {
 	return nil
 }
 
+// validateSubFields checks a composite field's sub-fields: at least one, with
+// unique IDs, and no nested composite or checklist fields.
+func (ft *FieldType) validateSubFields() error {
+	if len(ft.Fields) == 0 {
+		return fmt.Errorf("composite fields need at least one sub-field")
+	}
+
+	seen := make(map[string]bool)
+	for i := range ft.Fields {
+		field := &ft.Fields[i]
+		if !isValidID(field.ID) {
+			return fmt.Errorf("invalid sub-field ID %q: must contain only letters, numbers, and underscores", field.ID)
+		}
+		if seen[field.ID] {
+			return fmt.Errorf("duplicate sub-field ID: %s", field.ID)
+		}
+		seen[field.ID] = true
+
+		switch field.FieldType.Type {
+		case CompositeFieldType, ChecklistFieldType:
+			return fmt.Errorf("sub-field %s: %s fields cannot be used in a composite field", field.ID, field.FieldType.Type)
+		}
+		if err := field.FieldType.Validate(); err != nil {
+			return fmt.Errorf("sub-field %s: %w", field.ID, err)
+		}
+	}
+	return nil
+}
+
 // Validate validates a schema for correctness and consistency.
 func (s *Schema) Validate() error {
 	// Version is required
@@ -360,7 +522,7 @@ func (s *Schema) Validate() error {
 		ids[s.Habits[i].ID] = true
 	}
 
-	return nil
+	return s.validateDerivations()
 }
 
 // ValidateAndTrackChanges validates a schema and returns whether it was modified.
@@ -402,6 +564,9 @@ func (s *Schema) ValidateAndTrackChanges() (bool, error) {
 		ids[s.Habits[i].ID] = true
 	}
 
+	if err := s.validateDerivations(); err != nil {
+		return false, err
+	}
 	return wasModified, nil
 }
 
@@ -441,7 +606,7 @@ func isValidID(id string) bool {
 // isValidHabitType checks if a habit type is valid.
 func isValidHabitType(gt HabitType) bool {
 	switch gt {
-	case SimpleHabit, ElasticHabit, InformationalHabit, ChecklistHabit:
+	case SimpleHabit, ElasticHabit, InformationalHabit, ChecklistHabit, DerivedHabit:
 		return true
 	default:
 		return false
@@ -473,6 +638,22 @@ func (g *Habit) IsInformational() bool {
 	return g.HabitType == InformationalHabit
 }
 
+// IsDerived returns true if this habit is computed from other habits rather than entered.
+func (g *Habit) IsDerived() bool {
+	return g.HabitType == DerivedHabit
+}
+
+// RecordableHabits returns the habits entered by hand, leaving out derived habits.
+func RecordableHabits(habits []Habit) []Habit {
+	recordable := make([]Habit, 0, len(habits))
+	for _, habit := range habits {
+		if !habit.IsDerived() {
+			recordable = append(recordable, habit)
+		}
+	}
+	return recordable
+}
+
 // RequiresAutomaticScoring returns true if this habit uses automatic scoring.
 func (g *Habit) RequiresAutomaticScoring() bool {
 	return g.ScoringType == AutomaticScoring
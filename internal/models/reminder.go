@@ -0,0 +1,52 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// ReminderTimeFormat is the "HH:MM" layout of reminder and nudge times.
+const ReminderTimeFormat = "15:04"
+
+// Reminders configures when `vice remind` notifies about a habit. Both kinds
+// only fire on days the habit is due and while it is still incomplete.
+// AIDEV-NOTE: habit-reminders; delivery and due/incomplete evaluation live in internal/remind
+type Reminders struct {
+	At      []string `yaml:"at,omitempty"`       // "HH:MM" times to remind
+	NudgeBy string   `yaml:"nudge_by,omitempty"` // "HH:MM": nudge if still incomplete by then
+	Message string   `yaml:"message,omitempty"`  // Replaces the default notification text
+}
+
+// Validate validates the reminder times.
+func (r *Reminders) Validate() error {
+	if len(r.At) == 0 && r.NudgeBy == "" {
+		return fmt.Errorf("reminders require at least one time in at or a nudge_by time")
+	}
+
+	seen := make(map[string]bool, len(r.At))
+	for _, at := range r.At {
+		if _, err := ParseReminderTime(at); err != nil {
+			return err
+		}
+		if seen[at] {
+			return fmt.Errorf("duplicate reminder time: %s", at)
+		}
+		seen[at] = true
+	}
+
+	if r.NudgeBy != "" {
+		if _, err := ParseReminderTime(r.NudgeBy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseReminderTime parses an "HH:MM" reminder time into an offset from midnight.
+func ParseReminderTime(value string) (time.Duration, error) {
+	parsed, err := time.Parse(ReminderTimeFormat, value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid reminder time %q: expected HH:MM", value)
+	}
+	return time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute, nil
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReminders_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		reminders Reminders
+		errMsg    string
+	}{
+		{"times", Reminders{At: []string{"08:00", "18:30"}}, ""},
+		{"nudge only", Reminders{NudgeBy: "21:00"}, ""},
+		{"times and nudge", Reminders{At: []string{"07:15"}, NudgeBy: "20:00", Message: "Stretch!"}, ""},
+		{"empty", Reminders{Message: "Stretch!"}, "at least one time"},
+		{"invalid time", Reminders{At: []string{"8am"}}, `invalid reminder time "8am"`},
+		{"out of range", Reminders{At: []string{"24:30"}}, "expected HH:MM"},
+		{"duplicate time", Reminders{At: []string{"08:00", "08:00"}}, "duplicate reminder time: 08:00"},
+		{"invalid nudge", Reminders{NudgeBy: "9"}, `invalid reminder time "9"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.reminders.Validate()
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestParseReminderTime(t *testing.T) {
+	offset, err := ParseReminderTime("18:45")
+	require.NoError(t, err)
+	assert.Equal(t, 18*time.Hour+45*time.Minute, offset)
+}
+
+func TestHabit_ValidateReminders(t *testing.T) {
+	habit := Habit{
+		Title:       "Stretch",
+		HabitType:   SimpleHabit,
+		FieldType:   FieldType{Type: BooleanFieldType},
+		ScoringType: ManualScoring,
+		Reminders:   &Reminders{At: []string{"7:5pm"}},
+	}
+
+	err := habit.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid reminders")
+
+	habit.Reminders.At = []string{"19:05"}
+	assert.NoError(t, habit.Validate())
+}
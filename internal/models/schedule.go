@@ -0,0 +1,174 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Schedule defines how often a habit is expected. A habit without a schedule is daily.
+// AIDEV-NOTE: habit-periodicity; pure date logic lives here, history-aware evaluation in scoring.Engine.EvaluateSchedule
+type Schedule struct {
+	Frequency  Frequency `yaml:"frequency"`
+	Weekdays   []string  `yaml:"weekdays,omitempty"`     // "weekdays": mon, tue, ... sun
+	Times      int       `yaml:"times,omitempty"`        // "times_per_week": completions per rolling 7 days
+	Interval   int       `yaml:"interval,omitempty"`     // "every_n_days": days between completions
+	DayOfMonth int       `yaml:"day_of_month,omitempty"` // "monthly": 1-31 (clamped to month end); 0 = any day
+}
+
+// Frequency represents the kind of schedule a habit follows.
+type Frequency string
+
+// Frequencies supported by habit schedules.
+const (
+	DailyFrequency        Frequency = "daily"          // Due every day (the default)
+	WeekdaysFrequency     Frequency = "weekdays"       // Due on specific days of the week
+	TimesPerWeekFrequency Frequency = "times_per_week" // N completions in any rolling 7 days
+	EveryNDaysFrequency   Frequency = "every_n_days"   // Due when not completed in the last N days
+	MonthlyFrequency      Frequency = "monthly"        // Once per calendar month, optionally on a fixed day
+)
+
+// ScheduleStatus describes where a habit stands in its schedule on a given day.
+type ScheduleStatus string
+
+// Schedule statuses for a habit on a given day.
+const (
+	ScheduleDue       ScheduleStatus = "due"        // An entry is expected today
+	ScheduleNotDue    ScheduleStatus = "not_due"    // Today is not a scheduled day
+	ScheduleTargetMet ScheduleStatus = "target_met" // The current period's target was already met
+)
+
+// weekdayNames maps accepted weekday spellings to time.Weekday.
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// Validate validates a schedule for correctness.
+func (s *Schedule) Validate() error {
+	switch s.Frequency {
+	case DailyFrequency:
+		// No parameters
+	case WeekdaysFrequency:
+		if len(s.Weekdays) == 0 {
+			return fmt.Errorf("weekdays schedule requires at least one weekday")
+		}
+		for _, day := range s.Weekdays {
+			if _, ok := weekdayNames[strings.ToLower(strings.TrimSpace(day))]; !ok {
+				return fmt.Errorf("invalid weekday: %s", day)
+			}
+		}
+	case TimesPerWeekFrequency:
+		if s.Times < 1 || s.Times > 7 {
+			return fmt.Errorf("times_per_week schedule requires times between 1 and 7")
+		}
+	case EveryNDaysFrequency:
+		if s.Interval < 1 {
+			return fmt.Errorf("every_n_days schedule requires a positive interval")
+		}
+	case MonthlyFrequency:
+		if s.DayOfMonth < 0 || s.DayOfMonth > 31 {
+			return fmt.Errorf("day_of_month must be between 0 and 31 (0 = any day of the month)")
+		}
+	case "":
+		return fmt.Errorf("schedule frequency is required")
+	default:
+		return fmt.Errorf("unknown schedule frequency: %s", s.Frequency)
+	}
+
+	return nil
+}
+
+// IsScheduledOn reports whether the date is a possible due day. Period-based
+// schedules (times_per_week, every_n_days, monthly without a fixed day) are
+// scheduled every day until their target is met.
+func (s *Schedule) IsScheduledOn(date time.Time) bool {
+	if s == nil {
+		return true
+	}
+
+	switch s.Frequency {
+	case WeekdaysFrequency:
+		for _, day := range s.Weekdays {
+			if weekdayNames[strings.ToLower(strings.TrimSpace(day))] == date.Weekday() {
+				return true
+			}
+		}
+		return false
+	case MonthlyFrequency:
+		if s.DayOfMonth == 0 {
+			return true
+		}
+		return date.Day() == clampDayOfMonth(date, s.DayOfMonth)
+	default:
+		return true
+	}
+}
+
+// Period returns the first and last day of the schedule period containing date.
+func (s *Schedule) Period(date time.Time) (start, end time.Time) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	if s == nil {
+		return day, day
+	}
+
+	switch s.Frequency {
+	case TimesPerWeekFrequency:
+		return day.AddDate(0, 0, -6), day
+	case EveryNDaysFrequency:
+		return day.AddDate(0, 0, -(s.Interval - 1)), day
+	case MonthlyFrequency:
+		if s.DayOfMonth != 0 {
+			return day, day
+		}
+		start = time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, day.Location())
+		return start, start.AddDate(0, 1, -1)
+	default:
+		return day, day
+	}
+}
+
+// Target returns the number of completions expected per period.
+func (s *Schedule) Target() int {
+	if s != nil && s.Frequency == TimesPerWeekFrequency {
+		return s.Times
+	}
+	return 1
+}
+
+// Describe returns a short human-readable description of the schedule.
+func (s *Schedule) Describe() string {
+	if s == nil {
+		return "daily"
+	}
+
+	switch s.Frequency {
+	case WeekdaysFrequency:
+		return "on " + strings.Join(s.Weekdays, ", ")
+	case TimesPerWeekFrequency:
+		return fmt.Sprintf("%d× per week", s.Times)
+	case EveryNDaysFrequency:
+		return fmt.Sprintf("every %d days", s.Interval)
+	case MonthlyFrequency:
+		if s.DayOfMonth != 0 {
+			return fmt.Sprintf("monthly on day %d", s.DayOfMonth)
+		}
+		return "monthly"
+	default:
+		return "daily"
+	}
+}
+
+// clampDayOfMonth limits day to the last day of date's month (e.g. 31 → 28 in February).
+func clampDayOfMonth(date time.Time, day int) int {
+	lastDay := time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, date.Location()).Day()
+	if day > lastDay {
+		return lastDay
+	}
+	return day
+}
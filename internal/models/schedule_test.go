@@ -0,0 +1,106 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedule_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule Schedule
+		errMsg   string
+	}{
+		{"daily", Schedule{Frequency: DailyFrequency}, ""},
+		{"weekdays", Schedule{Frequency: WeekdaysFrequency, Weekdays: []string{"mon", "Wednesday", "FRI"}}, ""},
+		{"weekdays empty", Schedule{Frequency: WeekdaysFrequency}, "at least one weekday"},
+		{"weekdays invalid", Schedule{Frequency: WeekdaysFrequency, Weekdays: []string{"funday"}}, "invalid weekday"},
+		{"times per week", Schedule{Frequency: TimesPerWeekFrequency, Times: 3}, ""},
+		{"times per week out of range", Schedule{Frequency: TimesPerWeekFrequency, Times: 8}, "between 1 and 7"},
+		{"every n days", Schedule{Frequency: EveryNDaysFrequency, Interval: 2}, ""},
+		{"every n days without interval", Schedule{Frequency: EveryNDaysFrequency}, "positive interval"},
+		{"monthly any day", Schedule{Frequency: MonthlyFrequency}, ""},
+		{"monthly invalid day", Schedule{Frequency: MonthlyFrequency, DayOfMonth: 32}, "day_of_month"},
+		{"missing frequency", Schedule{}, "frequency is required"},
+		{"unknown frequency", Schedule{Frequency: "hourly"}, "unknown schedule frequency"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.schedule.Validate()
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestHabit_ValidateSchedule(t *testing.T) {
+	habit := Habit{
+		Title:       "Gym",
+		HabitType:   SimpleHabit,
+		FieldType:   FieldType{Type: BooleanFieldType},
+		ScoringType: ManualScoring,
+		Schedule:    &Schedule{Frequency: TimesPerWeekFrequency, Times: 0},
+	}
+
+	err := habit.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid schedule")
+
+	habit.Schedule.Times = 3
+	assert.NoError(t, habit.Validate())
+}
+
+func TestSchedule_IsScheduledOn(t *testing.T) {
+	sunday := time.Date(2025, 7, 20, 9, 0, 0, 0, time.UTC)
+	monday := sunday.AddDate(0, 0, 1)
+
+	var daily *Schedule
+	assert.True(t, daily.IsScheduledOn(monday), "nil schedule is daily")
+
+	weekly := &Schedule{Frequency: WeekdaysFrequency, Weekdays: []string{"sun"}}
+	assert.True(t, weekly.IsScheduledOn(sunday))
+	assert.False(t, weekly.IsScheduledOn(monday))
+
+	// Day 31 falls back to the last day of shorter months
+	endOfMonth := &Schedule{Frequency: MonthlyFrequency, DayOfMonth: 31}
+	assert.True(t, endOfMonth.IsScheduledOn(time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, endOfMonth.IsScheduledOn(time.Date(2025, 2, 27, 0, 0, 0, 0, time.UTC)))
+
+	perWeek := &Schedule{Frequency: TimesPerWeekFrequency, Times: 3}
+	assert.True(t, perWeek.IsScheduledOn(monday), "period schedules are possible every day")
+}
+
+func TestSchedule_Period(t *testing.T) {
+	date := time.Date(2025, 7, 18, 15, 30, 0, 0, time.UTC)
+	day := time.Date(2025, 7, 18, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		schedule      *Schedule
+		expectedStart time.Time
+		expectedEnd   time.Time
+		expectedTotal int
+	}{
+		{"daily", nil, day, day, 1},
+		{"times per week", &Schedule{Frequency: TimesPerWeekFrequency, Times: 3}, day.AddDate(0, 0, -6), day, 3},
+		{"every 3 days", &Schedule{Frequency: EveryNDaysFrequency, Interval: 3}, day.AddDate(0, 0, -2), day, 1},
+		{"monthly", &Schedule{Frequency: MonthlyFrequency}, time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC), 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := tt.schedule.Period(date)
+			assert.Equal(t, tt.expectedStart, start)
+			assert.Equal(t, tt.expectedEnd, end)
+			assert.Equal(t, tt.expectedTotal, tt.schedule.Target())
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// WindowCondition makes a condition compare an aggregate of the habit's recent
+// entries instead of the day's value: "this week's total >= 150", or with
+// baseline, the day's value against the days before it: "beats the 7-day average".
+type WindowCondition struct {
+	Aggregate string `yaml:"aggregate"`          // sum, average, median, min, max or count
+	Days      int    `yaml:"days,omitempty"`     // Days ending on the scored day
+	Period    string `yaml:"period,omitempty"`   // "week": the calendar week (from Monday) to the scored day
+	Baseline  bool   `yaml:"baseline,omitempty"` // Compare the day's value minus the aggregate of the days before it
+}
+
+// AggregateMedian is the middle source value; only valid in window conditions.
+const AggregateMedian = "median"
+
+// WeekPeriod is the calendar week window period.
+const WeekPeriod = "week"
+
+// Start returns the first day of the window for date. Baseline windows end the
+// day before date; others end on date.
+func (w *WindowCondition) Start(date time.Time) time.Time {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	if w.Period == WeekPeriod {
+		sinceMonday := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -sinceMonday)
+	}
+	if w.Baseline {
+		return day.AddDate(0, 0, -w.Days)
+	}
+	return day.AddDate(0, 0, -(w.Days - 1))
+}
+
+// Validate checks the window against the habit's field type.
+func (w *WindowCondition) Validate(fieldType FieldType) error {
+	switch w.Aggregate {
+	case AggregateSum, AggregateAverage, AggregateMedian, AggregateMin, AggregateMax:
+		if fieldValueKind(fieldType.Type) == "" {
+			return fmt.Errorf("%s needs a numeric, duration or time field type, got %s", w.Aggregate, fieldType.Type)
+		}
+		if w.Aggregate == AggregateSum && fieldType.Type == TimeFieldType {
+			return fmt.Errorf("times of day cannot be summed")
+		}
+	case AggregateCount:
+		if w.Baseline {
+			return fmt.Errorf("baseline compares the day's value, so it cannot be used with count")
+		}
+	case "":
+		return fmt.Errorf("aggregate is required")
+	default:
+		return fmt.Errorf("invalid aggregate: %s (valid: sum, average, median, min, max, count)", w.Aggregate)
+	}
+
+	switch {
+	case w.Period != "" && w.Days != 0:
+		return fmt.Errorf("days and period cannot both be set")
+	case w.Period != "" && w.Period != WeekPeriod:
+		return fmt.Errorf("invalid period: %s (valid: week)", w.Period)
+	case w.Period == "" && (w.Days < 1 || w.Days > maxDerivationWindow):
+		return fmt.Errorf("days must be between 1 and %d", maxDerivationWindow)
+	}
+	return nil
+}
+
+// ComparesNumber reports whether the window's comparisons apply to a plain number
+// rather than a value of the habit's field type: a count, or a baseline difference.
+func (w *WindowCondition) ComparesNumber() bool {
+	return w.Aggregate == AggregateCount || w.Baseline
+}
+
+// UsesWindows reports whether any condition in the tree has a window.
+func (c *Condition) UsesWindows() bool {
+	if c.Window != nil {
+		return true
+	}
+	for _, nested := range c.nested() {
+		if nested.UsesWindows() {
+			return true
+		}
+	}
+	return false
+}
+
+// validateWindows checks the window conditions in the tree. A window applies to
+// its condition's comparisons; nested conditions are evaluated on their own.
+func (c *Condition) validateWindows(fieldType FieldType) error {
+	if c.Window != nil {
+		if fieldType.Type == CompositeFieldType {
+			return fmt.Errorf("window conditions are not supported on composite habits")
+		}
+		if err := c.Window.Validate(fieldType); err != nil {
+			return fmt.Errorf("invalid window: %w", err)
+		}
+		if c.Equals != nil || c.ChecklistCompletion != nil || c.HasTextOperators() {
+			return fmt.Errorf("window conditions only support numeric comparisons")
+		}
+		if (c.Before != "" || c.After != "") && (fieldType.Type != TimeFieldType || c.Window.ComparesNumber()) {
+			return fmt.Errorf("before and after only apply to windows of times of day")
+		}
+		if !c.HasComparisons() {
+			return fmt.Errorf("window conditions need a comparison operator")
+		}
+	}
+
+	for _, nested := range c.nested() {
+		if err := nested.validateWindows(fieldType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UsesWindowCriteria reports whether scoring the habit needs its entry history.
+func (g *Habit) UsesWindowCriteria() bool {
+	for _, criteria := range []*Criteria{g.Criteria, g.MiniCriteria, g.MidiCriteria, g.MaxiCriteria} {
+		if criteria != nil && criteria.Condition != nil && criteria.Condition.UsesWindows() {
+			return true
+		}
+	}
+	return false
+}
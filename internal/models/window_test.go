@@ -0,0 +1,176 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHabit_ValidateWindowCriteria(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name      string
+		fieldType string
+		condition Condition
+		err       string
+	}{
+		{
+			name:      "weekly total",
+			fieldType: DurationFieldType,
+			condition: Condition{Window: &WindowCondition{Aggregate: AggregateSum, Period: WeekPeriod}, GreaterThanOrEqual: f(150)},
+		},
+		{
+			name:      "beats the 7-day average",
+			fieldType: DecimalFieldType,
+			condition: Condition{Window: &WindowCondition{Aggregate: AggregateAverage, Days: 7, Baseline: true}, GreaterThan: f(0)},
+		},
+		{
+			name:      "within 30 minutes of the 14-day median",
+			fieldType: DurationFieldType,
+			condition: Condition{Window: &WindowCondition{Aggregate: AggregateMedian, Days: 14, Baseline: true}, Range: &RangeCondition{Min: -30, Max: 30}},
+		},
+		{
+			name:      "window alongside the day's value",
+			fieldType: DecimalFieldType,
+			condition: Condition{And: []Condition{
+				{GreaterThan: f(2)},
+				{Window: &WindowCondition{Aggregate: AggregateCount, Days: 7}, GreaterThanOrEqual: f(20)},
+			}},
+		},
+		{
+			name:      "average time before",
+			fieldType: TimeFieldType,
+			condition: Condition{Window: &WindowCondition{Aggregate: AggregateAverage, Days: 7}, Before: "07:00"},
+		},
+		{
+			name:      "count of text entries",
+			fieldType: TextFieldType,
+			condition: Condition{Window: &WindowCondition{Aggregate: AggregateCount, Period: WeekPeriod}, GreaterThanOrEqual: f(3)},
+		},
+		{
+			name:      "missing aggregate",
+			fieldType: DecimalFieldType,
+			condition: Condition{Window: &WindowCondition{Days: 7}, GreaterThan: f(0)},
+			err:       "aggregate is required",
+		},
+		{
+			name:      "sum of text",
+			fieldType: TextFieldType,
+			condition: Condition{Window: &WindowCondition{Aggregate: AggregateSum, Days: 7}, GreaterThan: f(0)},
+			err:       "sum needs a numeric, duration or time field type",
+		},
+		{
+			name:      "baseline count",
+			fieldType: DecimalFieldType,
+			condition: Condition{Window: &WindowCondition{Aggregate: AggregateCount, Days: 7, Baseline: true}, GreaterThan: f(0)},
+			err:       "cannot be used with count",
+		},
+		{
+			name:      "no days or period",
+			fieldType: DecimalFieldType,
+			condition: Condition{Window: &WindowCondition{Aggregate: AggregateSum}, GreaterThan: f(0)},
+			err:       "days must be between 1 and 366",
+		},
+		{
+			name:      "days and period",
+			fieldType: DecimalFieldType,
+			condition: Condition{Window: &WindowCondition{Aggregate: AggregateSum, Days: 7, Period: WeekPeriod}, GreaterThan: f(0)},
+			err:       "cannot both be set",
+		},
+		{
+			name:      "unknown period",
+			fieldType: DecimalFieldType,
+			condition: Condition{Window: &WindowCondition{Aggregate: AggregateSum, Period: "fortnight"}, GreaterThan: f(0)},
+			err:       "invalid period: fortnight",
+		},
+		{
+			name:      "no comparison",
+			fieldType: DecimalFieldType,
+			condition: Condition{Window: &WindowCondition{Aggregate: AggregateSum, Days: 7}},
+			err:       "need a comparison operator",
+		},
+		{
+			name:      "before on a baseline",
+			fieldType: TimeFieldType,
+			condition: Condition{Window: &WindowCondition{Aggregate: AggregateAverage, Days: 7, Baseline: true}, Before: "07:00"},
+			err:       "before and after only apply to windows of times of day",
+		},
+		{
+			name:      "nested window",
+			fieldType: DecimalFieldType,
+			condition: Condition{Not: &Condition{Window: &WindowCondition{Aggregate: "mode", Days: 7}, GreaterThan: f(0)}},
+			err:       "invalid aggregate: mode",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition := tt.condition
+			habit := Habit{
+				Title:       "Run",
+				HabitType:   SimpleHabit,
+				FieldType:   FieldType{Type: tt.fieldType},
+				ScoringType: AutomaticScoring,
+				Criteria:    &Criteria{Condition: &condition},
+			}
+			err := habit.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+				assert.True(t, habit.UsesWindowCriteria())
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+
+	t.Run("composite habits", func(t *testing.T) {
+		habit := Habit{
+			Title:       "Ride",
+			HabitType:   SimpleHabit,
+			FieldType:   compositeRunFieldType(),
+			ScoringType: AutomaticScoring,
+			Criteria: &Criteria{Condition: &Condition{
+				Field:       "distance",
+				Window:      &WindowCondition{Aggregate: AggregateSum, Days: 7},
+				GreaterThan: f(20),
+			}},
+		}
+		err := habit.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not supported on composite habits")
+	})
+}
+
+func TestWindowCondition_Start(t *testing.T) {
+	thursday := time.Date(2025, 7, 17, 21, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		window   WindowCondition
+		expected string
+	}{
+		{"days", WindowCondition{Days: 7}, "2025-07-11"},
+		{"single day", WindowCondition{Days: 1}, "2025-07-17"},
+		{"baseline days", WindowCondition{Days: 7, Baseline: true}, "2025-07-10"},
+		{"week", WindowCondition{Period: WeekPeriod}, "2025-07-14"},
+		{"baseline week", WindowCondition{Period: WeekPeriod, Baseline: true}, "2025-07-14"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.window.Start(thursday).Format("2006-01-02"))
+		})
+	}
+
+	t.Run("week starts on Monday", func(t *testing.T) {
+		sunday := time.Date(2025, 7, 20, 0, 0, 0, 0, time.UTC)
+		monday := time.Date(2025, 7, 21, 0, 0, 0, 0, time.UTC)
+		window := WindowCondition{Period: WeekPeriod}
+		assert.Equal(t, "2025-07-14", window.Start(sunday).Format("2006-01-02"))
+		assert.Equal(t, "2025-07-21", window.Start(monday).Format("2006-01-02"))
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/davidlee/vice/internal/models"
+	"github.com/davidlee/vice/internal/safefile"
 )
 
 // ChecklistEntriesParser handles loading and saving checklist_entries.yml.
@@ -27,6 +28,30 @@ func (cep *ChecklistEntriesParser) LoadFromFile(filePath string) (*models.Checkl
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
+	return cep.parse(data)
+}
+
+// LoadFromFileWithVersion loads checklist entries and the version of the file they
+// were read from, to pass to SaveToFileIfUnchanged. Like EnsureSchemaExists, a
+// missing file yields an empty schema (with the zero version).
+func (cep *ChecklistEntriesParser) LoadFromFileWithVersion(filePath string) (*models.ChecklistEntriesSchema, safefile.Version, error) {
+	data, version, err := safefile.ReadFile(filePath)
+	if err != nil {
+		return nil, safefile.Version{}, fmt.Errorf("failed to read file: %w", err)
+	}
+	if !version.Exists {
+		return cep.CreateEmptySchema(), version, nil
+	}
+
+	schema, err := cep.parse(data)
+	if err != nil {
+		return nil, safefile.Version{}, err
+	}
+	return schema, version, nil
+}
+
+// parse unmarshals and validates checklist entries.
+func (cep *ChecklistEntriesParser) parse(data []byte) (*models.ChecklistEntriesSchema, error) {
 	var schema models.ChecklistEntriesSchema
 	if err := yaml.Unmarshal(data, &schema); err != nil {
 		return nil, fmt.Errorf("failed to parse YAML: %w", err)
@@ -41,21 +66,35 @@ func (cep *ChecklistEntriesParser) LoadFromFile(filePath string) (*models.Checkl
 
 // SaveToFile saves checklist entries to a YAML file.
 func (cep *ChecklistEntriesParser) SaveToFile(schema *models.ChecklistEntriesSchema, filePath string) error {
+	_, err := cep.save(schema, filePath, nil)
+	return err
+}
+
+// SaveToFileIfUnchanged saves checklist entries like SaveToFile, but only if the file
+// still matches version. If another process changed it in the meantime nothing is
+// written and the error wraps safefile.ErrConflict.
+func (cep *ChecklistEntriesParser) SaveToFileIfUnchanged(schema *models.ChecklistEntriesSchema, filePath string, version safefile.Version) (safefile.Version, error) {
+	return cep.save(schema, filePath, &version)
+}
+
+// save validates, marshals and writes checklist entries, checking the file against expected unless it's nil.
+func (cep *ChecklistEntriesParser) save(schema *models.ChecklistEntriesSchema, filePath string, expected *safefile.Version) (safefile.Version, error) {
 	if err := schema.Validate(); err != nil {
-		return fmt.Errorf("validation failed: %w", err)
+		return safefile.Version{}, fmt.Errorf("validation failed: %w", err)
 	}
 
 	data, err := yaml.Marshal(schema)
 	if err != nil {
-		return fmt.Errorf("failed to marshal YAML: %w", err)
+		return safefile.Version{}, fmt.Errorf("failed to marshal YAML: %w", err)
 	}
 
 	//nolint:gosec // File permissions 0o644 appropriate for configuration files
-	if err := os.WriteFile(filePath, data, 0o644); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+	version, err := safefile.WriteFileIfUnchanged(filePath, data, 0o644, expected)
+	if err != nil {
+		return safefile.Version{}, fmt.Errorf("failed to write file: %w", err)
 	}
 
-	return nil
+	return version, nil
 }
 
 // GetChecklistEntryForDate retrieves the checklist entry for a specific date and checklist ID.
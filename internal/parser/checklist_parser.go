@@ -8,6 +8,7 @@ import (
 	"github.com/goccy/go-yaml"
 
 	"github.com/davidlee/vice/internal/models"
+	"github.com/davidlee/vice/internal/safefile"
 )
 
 // ChecklistParser handles parsing and validation of checklist schemas.
@@ -54,7 +55,7 @@ func (cp *ChecklistParser) LoadFromFileWithIDPersistence(filePath string, persis
 
 	// If ID persistence is enabled and IDs were generated, save back to file
 	if persistIDs && wasModified {
-		if err := cp.saveGeneratedIDs(schema, filePath); err != nil {
+		if _, err := cp.saveGeneratedIDs(schema, filePath, nil); err != nil {
 			// Log the error but don't fail the load operation
 			// This ensures read-only files or permission issues don't break normal usage
 			fmt.Fprintf(os.Stderr, "Warning: failed to persist generated checklist IDs to %s: %v\n", filePath, err)
@@ -64,6 +65,34 @@ func (cp *ChecklistParser) LoadFromFileWithIDPersistence(filePath string, persis
 	return schema, nil
 }
 
+// LoadFromFileWithVersion loads a checklists.yml file like LoadFromFile and also returns
+// the version of the file it was read from, to pass to SaveToFileIfUnchanged.
+func (cp *ChecklistParser) LoadFromFileWithVersion(filePath string) (*models.ChecklistSchema, safefile.Version, error) {
+	data, version, err := safefile.ReadFile(filePath)
+	if err != nil {
+		return nil, safefile.Version{}, fmt.Errorf("failed to read checklists file: %w", err)
+	}
+	if !version.Exists {
+		return nil, safefile.Version{}, fmt.Errorf("checklists file not found: %s", filePath)
+	}
+
+	schema, wasModified, err := cp.ParseYAMLWithChangeTracking(data)
+	if err != nil {
+		return nil, safefile.Version{}, err
+	}
+
+	if wasModified {
+		saved, err := cp.saveGeneratedIDs(schema, filePath, &version)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to persist generated checklist IDs to %s: %v\n", filePath, err)
+		} else {
+			version = saved
+		}
+	}
+
+	return schema, version, nil
+}
+
 // ParseYAMLWithChangeTracking parses YAML data and tracks whether checklist IDs were generated.
 func (cp *ChecklistParser) ParseYAMLWithChangeTracking(data []byte) (*models.ChecklistSchema, bool, error) {
 	var schema models.ChecklistSchema
@@ -82,25 +111,27 @@ func (cp *ChecklistParser) ParseYAMLWithChangeTracking(data []byte) (*models.Che
 	return &schema, wasModified, nil
 }
 
-// saveGeneratedIDs saves the schema with generated IDs back to the file.
-func (cp *ChecklistParser) saveGeneratedIDs(schema *models.ChecklistSchema, filePath string) error {
+// saveGeneratedIDs saves the schema with generated IDs back to the file,
+// provided it still matches expected (nil skips the check).
+func (cp *ChecklistParser) saveGeneratedIDs(schema *models.ChecklistSchema, filePath string, expected *safefile.Version) (safefile.Version, error) {
 	// Check if file is writable before attempting to save
 	fileInfo, err := os.Stat(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to check file permissions: %w", err)
+		return safefile.Version{}, fmt.Errorf("failed to check file permissions: %w", err)
 	}
 
 	// Check if file is read-only
 	if fileInfo.Mode()&0o200 == 0 {
-		return fmt.Errorf("file is read-only, cannot persist generated IDs")
+		return safefile.Version{}, fmt.Errorf("file is read-only, cannot persist generated IDs")
 	}
 
 	// Save the updated schema back to the file
-	if err := cp.SaveToFile(schema, filePath); err != nil {
-		return fmt.Errorf("failed to save schema with generated IDs: %w", err)
+	version, err := cp.save(schema, filePath, expected)
+	if err != nil {
+		return safefile.Version{}, fmt.Errorf("failed to save schema with generated IDs: %w", err)
 	}
 
-	return nil
+	return version, nil
 }
 
 // ParseYAML parses YAML data into a checklist schema and validates it.
@@ -123,9 +154,23 @@ func (cp *ChecklistParser) ParseYAML(data []byte) (*models.ChecklistSchema, erro
 // SaveToFile saves a checklist schema to a YAML file at the given path.
 // This is useful for creating initial schemas or saving modified ones.
 func (cp *ChecklistParser) SaveToFile(schema *models.ChecklistSchema, filePath string) error {
+	_, err := cp.save(schema, filePath, nil)
+	return err
+}
+
+// SaveToFileIfUnchanged saves a checklist schema like SaveToFile, but only if the file
+// still matches version (the zero version means it must not exist yet). If another
+// process changed it in the meantime nothing is written and the error wraps
+// safefile.ErrConflict. It returns the version of the file as written.
+func (cp *ChecklistParser) SaveToFileIfUnchanged(schema *models.ChecklistSchema, filePath string, version safefile.Version) (safefile.Version, error) {
+	return cp.save(schema, filePath, &version)
+}
+
+// save validates, marshals and writes a schema, checking the file against expected unless it's nil.
+func (cp *ChecklistParser) save(schema *models.ChecklistSchema, filePath string, expected *safefile.Version) (safefile.Version, error) {
 	// Validate before saving
 	if err := schema.Validate(); err != nil {
-		return fmt.Errorf("cannot save invalid schema: %w", err)
+		return safefile.Version{}, fmt.Errorf("cannot save invalid schema: %w", err)
 	}
 
 	// Marshal to YAML with pretty formatting
@@ -134,15 +179,16 @@ func (cp *ChecklistParser) SaveToFile(schema *models.ChecklistSchema, filePath s
 		yaml.IndentSequence(true),
 	)
 	if err != nil {
-		return fmt.Errorf("failed to marshal schema to YAML: %w", err)
+		return safefile.Version{}, fmt.Errorf("failed to marshal schema to YAML: %w", err)
 	}
 
-	// Write to file with appropriate permissions (0600 for security)
-	if err := os.WriteFile(filePath, data, 0o600); err != nil {
-		return fmt.Errorf("failed to write checklists file %s: %w", filePath, err)
+	// Write atomically under the file's lock with appropriate permissions (0600 for security)
+	version, err := safefile.WriteFileIfUnchanged(filePath, data, 0o600, expected)
+	if err != nil {
+		return safefile.Version{}, fmt.Errorf("failed to write checklists file %s: %w", filePath, err)
 	}
 
-	return nil
+	return version, nil
 }
 
 // ToYAML converts a checklist schema to YAML string without writing to file.
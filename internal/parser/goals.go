@@ -0,0 +1,57 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/davidlee/vice/internal/models"
+)
+
+// GoalParser handles parsing and validation of goals.yml.
+type GoalParser struct{}
+
+// NewGoalParser creates a new goal parser instance.
+func NewGoalParser() *GoalParser {
+	return &GoalParser{}
+}
+
+// LoadFromFile loads goals.yml and checks its goals against the habit schema.
+// Goals are optional: a missing file gives an empty schema.
+func (gp *GoalParser) LoadFromFile(filePath string, habits *models.Schema) (*models.GoalSchema, error) {
+	// #nosec G304 -- filePath is the context's goals file, not user input
+	data, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return &models.GoalSchema{Version: "1.0.0"}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read goals file %s: %w", filePath, err)
+	}
+
+	schema, err := gp.ParseYAML(data)
+	if err != nil {
+		return nil, err
+	}
+	if habits != nil {
+		if err := schema.ValidateHabits(habits); err != nil {
+			return nil, fmt.Errorf("schema validation failed: %w", err)
+		}
+	}
+	return schema, nil
+}
+
+// ParseYAML parses YAML data into a goal schema and validates it.
+func (gp *GoalParser) ParseYAML(data []byte) (*models.GoalSchema, error) {
+	var schema models.GoalSchema
+
+	// Parse YAML with strict mode to catch unknown fields
+	if err := yaml.UnmarshalWithOptions(data, &schema, yaml.Strict()); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+
+	if err := schema.Validate(); err != nil {
+		return nil, fmt.Errorf("schema validation failed: %w", err)
+	}
+	return &schema, nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/goccy/go-yaml"
 
 	"github.com/davidlee/vice/internal/models"
+	"github.com/davidlee/vice/internal/safefile"
 )
 
 // HabitParser handles parsing and validation of habit schemas.
@@ -54,7 +55,7 @@ func (gp *HabitParser) LoadFromFileWithIDPersistence(filePath string, persistIDs
 
 	// If ID persistence is enabled and IDs were generated, save back to file
 	if persistIDs && wasModified {
-		if err := gp.saveGeneratedIDs(schema, filePath); err != nil {
+		if _, err := gp.saveGeneratedIDs(schema, filePath, nil); err != nil {
 			// Log the error but don't fail the load operation
 			// This ensures read-only files or permission issues don't break normal usage
 			fmt.Fprintf(os.Stderr, "Warning: failed to persist generated habit IDs to %s: %v\n", filePath, err)
@@ -64,6 +65,34 @@ func (gp *HabitParser) LoadFromFileWithIDPersistence(filePath string, persistIDs
 	return schema, nil
 }
 
+// LoadFromFileWithVersion loads a habits.yml file like LoadFromFile and also returns
+// the version of the file it was read from, to pass to SaveToFileIfUnchanged.
+func (gp *HabitParser) LoadFromFileWithVersion(filePath string) (*models.Schema, safefile.Version, error) {
+	data, version, err := safefile.ReadFile(filePath)
+	if err != nil {
+		return nil, safefile.Version{}, fmt.Errorf("failed to read habits file: %w", err)
+	}
+	if !version.Exists {
+		return nil, safefile.Version{}, fmt.Errorf("habits file not found: %s", filePath)
+	}
+
+	schema, wasModified, err := gp.ParseYAMLWithChangeTracking(data)
+	if err != nil {
+		return nil, safefile.Version{}, err
+	}
+
+	if wasModified {
+		saved, err := gp.saveGeneratedIDs(schema, filePath, &version)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to persist generated habit IDs to %s: %v\n", filePath, err)
+		} else {
+			version = saved
+		}
+	}
+
+	return schema, version, nil
+}
+
 // ParseYAMLWithChangeTracking parses YAML data and tracks whether habit IDs were generated.
 func (gp *HabitParser) ParseYAMLWithChangeTracking(data []byte) (*models.Schema, bool, error) {
 	var schema models.Schema
@@ -82,25 +111,27 @@ func (gp *HabitParser) ParseYAMLWithChangeTracking(data []byte) (*models.Schema,
 	return &schema, wasModified, nil
 }
 
-// saveGeneratedIDs saves the schema with generated IDs back to the file.
-func (gp *HabitParser) saveGeneratedIDs(schema *models.Schema, filePath string) error {
+// saveGeneratedIDs saves the schema with generated IDs back to the file,
+// provided it still matches expected (nil skips the check).
+func (gp *HabitParser) saveGeneratedIDs(schema *models.Schema, filePath string, expected *safefile.Version) (safefile.Version, error) {
 	// Check if file is writable before attempting to save
 	fileInfo, err := os.Stat(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to check file permissions: %w", err)
+		return safefile.Version{}, fmt.Errorf("failed to check file permissions: %w", err)
 	}
 
 	// Check if file is read-only
 	if fileInfo.Mode()&0o200 == 0 {
-		return fmt.Errorf("file is read-only, cannot persist generated IDs")
+		return safefile.Version{}, fmt.Errorf("file is read-only, cannot persist generated IDs")
 	}
 
 	// Save the updated schema back to the file
-	if err := gp.SaveToFile(schema, filePath); err != nil {
-		return fmt.Errorf("failed to save schema with generated IDs: %w", err)
+	version, err := gp.save(schema, filePath, expected)
+	if err != nil {
+		return safefile.Version{}, fmt.Errorf("failed to save schema with generated IDs: %w", err)
 	}
 
-	return nil
+	return version, nil
 }
 
 // ParseYAML parses YAML data into a habit schema and validates it.
@@ -123,9 +154,23 @@ func (gp *HabitParser) ParseYAML(data []byte) (*models.Schema, error) {
 // SaveToFile saves a schema to a YAML file at the given path.
 // This is useful for creating initial schemas or saving modified ones.
 func (gp *HabitParser) SaveToFile(schema *models.Schema, filePath string) error {
+	_, err := gp.save(schema, filePath, nil)
+	return err
+}
+
+// SaveToFileIfUnchanged saves a schema like SaveToFile, but only if the file still
+// matches the version it was loaded at (see LoadFromFileWithVersion). If another
+// process changed it in the meantime nothing is written and the error wraps
+// safefile.ErrConflict. It returns the version of the file as written.
+func (gp *HabitParser) SaveToFileIfUnchanged(schema *models.Schema, filePath string, version safefile.Version) (safefile.Version, error) {
+	return gp.save(schema, filePath, &version)
+}
+
+// save validates, marshals and writes a schema, checking the file against expected unless it's nil.
+func (gp *HabitParser) save(schema *models.Schema, filePath string, expected *safefile.Version) (safefile.Version, error) {
 	// Validate before saving
 	if err := schema.Validate(); err != nil {
-		return fmt.Errorf("cannot save invalid schema: %w", err)
+		return safefile.Version{}, fmt.Errorf("cannot save invalid schema: %w", err)
 	}
 
 	// Marshal to YAML with pretty formatting
@@ -134,15 +179,16 @@ func (gp *HabitParser) SaveToFile(schema *models.Schema, filePath string) error
 		yaml.IndentSequence(true),
 	)
 	if err != nil {
-		return fmt.Errorf("failed to marshal schema to YAML: %w", err)
+		return safefile.Version{}, fmt.Errorf("failed to marshal schema to YAML: %w", err)
 	}
 
-	// Write to file with appropriate permissions (0600 for security)
-	if err := os.WriteFile(filePath, data, 0o600); err != nil {
-		return fmt.Errorf("failed to write habits file %s: %w", filePath, err)
+	// Write atomically under the file's lock with appropriate permissions (0600 for security)
+	version, err := safefile.WriteFileIfUnchanged(filePath, data, 0o600, expected)
+	if err != nil {
+		return safefile.Version{}, fmt.Errorf("failed to write habits file %s: %w", filePath, err)
 	}
 
-	return nil
+	return version, nil
 }
 
 // ToYAML converts a schema to YAML string without writing to file.
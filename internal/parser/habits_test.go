@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/davidlee/vice/internal/models"
+	"github.com/davidlee/vice/internal/safefile"
 )
 
 func TestHabitParser_ParseYAML(t *testing.T) {
@@ -537,6 +538,39 @@ func TestHabitParser_SaveToFile(t *testing.T) {
 	})
 }
 
+func TestHabitParser_SaveToFileIfUnchanged(t *testing.T) {
+	parser := NewHabitParser()
+	habitsFile := filepath.Join(t.TempDir(), "habits.yml")
+	require.NoError(t, parser.SaveToFile(parser.CreateSampleSchema(), habitsFile))
+
+	schema, version, err := parser.LoadFromFileWithVersion(habitsFile)
+	require.NoError(t, err)
+	assert.True(t, version.Exists)
+
+	// Another process edits habits; the schema held in memory is now stale
+	other, err := parser.LoadFromFile(habitsFile)
+	require.NoError(t, err)
+	other.Habits[0].Title = "Edited elsewhere"
+	require.NoError(t, parser.SaveToFile(other, habitsFile))
+
+	schema.Habits[0].Title = "Edited here"
+	_, err = parser.SaveToFileIfUnchanged(schema, habitsFile, version)
+	require.ErrorIs(t, err, safefile.ErrConflict)
+
+	reloaded, version, err := parser.LoadFromFileWithVersion(habitsFile)
+	require.NoError(t, err)
+	assert.Equal(t, "Edited elsewhere", reloaded.Habits[0].Title, "the conflicting save wrote nothing")
+
+	reloaded.Habits[0].Title = "Edited here"
+	_, err = parser.SaveToFileIfUnchanged(reloaded, habitsFile, version)
+	assert.NoError(t, err)
+
+	t.Run("missing file", func(t *testing.T) {
+		_, _, err := parser.LoadFromFileWithVersion(filepath.Join(t.TempDir(), "habits.yml"))
+		assert.ErrorContains(t, err, "habits file not found")
+	})
+}
+
 func TestHabitParser_CreateSampleSchema(t *testing.T) {
 	parser := NewHabitParser()
 
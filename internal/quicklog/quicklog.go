@@ -0,0 +1,373 @@
+// Package quicklog builds habit entries from command-line values, without the interactive UI.
+// AIDEV-NOTE: quicklog-package; mirrors the status/scoring rules of the internal/ui/entry collection flows for `vice log`
+package quicklog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/davidlee/vice/internal/models"
+	"github.com/davidlee/vice/internal/scoring"
+)
+
+// Request is a single entry as given on the command line.
+type Request struct {
+	Value     string            // Raw value; ignored when HasValue is false
+	HasValue  bool              // Whether a value was given at all
+	Notes     string            // Optional notes
+	Skip      bool              // Record the habit as skipped
+	Level     string            // Achievement level for manually scored elastic/checklist habits
+	Checklist *models.Checklist // Required for checklist habits
+}
+
+// Builder turns requests into scored habit entries.
+type Builder struct {
+	engine *scoring.Engine
+}
+
+// NewBuilder creates a new quick-log entry builder.
+func NewBuilder() *Builder {
+	return &Builder{engine: scoring.NewEngine()}
+}
+
+// SetHistory sets the day entries are built for and where window criteria read
+// earlier entries from.
+func (b *Builder) SetHistory(history scoring.EntryHistory, date time.Time) {
+	b.engine.SetHistory(history)
+	b.engine.SetDate(date)
+}
+
+// Build parses, validates and scores a request for a habit. Timestamps are left to the caller.
+func (b *Builder) Build(habit *models.Habit, req Request) (*models.HabitEntry, error) {
+	if habit == nil {
+		return nil, fmt.Errorf("habit cannot be nil")
+	}
+	if habit.IsDerived() {
+		return nil, fmt.Errorf("habit %s is derived from other habits and cannot be logged", habit.ID)
+	}
+
+	entry := &models.HabitEntry{
+		HabitID: habit.ID,
+		Notes:   req.Notes,
+	}
+
+	if req.Skip {
+		if req.HasValue {
+			return nil, fmt.Errorf("cannot give a value when skipping %s", habit.ID)
+		}
+		entry.Status = models.EntrySkipped
+		return entry, nil
+	}
+
+	raw := req.Value
+	if !req.HasValue {
+		if habit.FieldType.Type != models.BooleanFieldType {
+			return nil, fmt.Errorf("habit %s needs a %s value", habit.ID, habit.FieldType.Type)
+		}
+		raw = "true" // `vice log <boolean-habit>` means "done"
+	}
+
+	value, err := ParseValue(habit.FieldType, raw, req.Checklist)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for %s: %w", habit.ID, err)
+	}
+	entry.Value = value
+	entry.Status = models.EntryCompleted
+
+	level, err := b.score(habit, value, req)
+	if err != nil {
+		return nil, err
+	}
+	entry.AchievementLevel = level
+
+	// Pass/fail habits fail when the value says so or their criteria aren't met
+	if habit.IsSimple() {
+		if boolVal, ok := value.(bool); ok && !boolVal {
+			entry.Status = models.EntryFailed
+		}
+		if level != nil && *level == models.AchievementNone {
+			entry.Status = models.EntryFailed
+		}
+	}
+
+	return entry, nil
+}
+
+// score determines the achievement level the same way the interactive flows do.
+func (b *Builder) score(habit *models.Habit, value interface{}, req Request) (*models.AchievementLevel, error) {
+	switch {
+	case habit.IsInformational():
+		return nil, nil
+
+	case habit.IsChecklist():
+		if habit.RequiresAutomaticScoring() {
+			return checklistLevel(habit, value, req.Checklist)
+		}
+		return requireLevel(habit, req.Level)
+
+	case habit.IsElastic():
+		if habit.RequiresAutomaticScoring() {
+			result, err := b.engine.ScoreElasticHabit(habit, value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to score %s: %w", habit.ID, err)
+			}
+			return &result.AchievementLevel, nil
+		}
+		return requireLevel(habit, req.Level)
+
+	default:
+		if habit.RequiresAutomaticScoring() {
+			result, err := b.engine.ScoreSimpleHabit(habit, value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to score %s: %w", habit.ID, err)
+			}
+			return &result.AchievementLevel, nil
+		}
+		level := models.AchievementMini
+		if boolVal, ok := value.(bool); ok && !boolVal {
+			level = models.AchievementNone
+		}
+		return &level, nil
+	}
+}
+
+// requireLevel parses the --level given for a manually scored habit.
+func requireLevel(habit *models.Habit, raw string) (*models.AchievementLevel, error) {
+	if raw == "" {
+		return nil, fmt.Errorf("habit %s is scored manually: give an achievement level (none, mini, midi, maxi)", habit.ID)
+	}
+	level, err := ParseLevel(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &level, nil
+}
+
+// ParseLevel parses an achievement level name.
+func ParseLevel(raw string) (models.AchievementLevel, error) {
+	level := models.AchievementLevel(strings.ToLower(strings.TrimSpace(raw)))
+	switch level {
+	case models.AchievementNone, models.AchievementMini, models.AchievementMidi, models.AchievementMaxi:
+		return level, nil
+	default:
+		return "", fmt.Errorf("invalid achievement level: %s (valid: none, mini, midi, maxi)", raw)
+	}
+}
+
+// checklistLevel scores checklist completion: "all" criteria give maxi or none,
+// otherwise the completed share maps to a level.
+func checklistLevel(habit *models.Habit, value interface{}, checklist *models.Checklist) (*models.AchievementLevel, error) {
+	items, ok := value.([]string)
+	if !ok {
+		return nil, fmt.Errorf("invalid checklist value type: %T", value)
+	}
+	if checklist == nil {
+		return nil, fmt.Errorf("checklist %s not loaded", habit.FieldType.ChecklistID)
+	}
+
+	completed := len(items)
+	total := checklist.GetTotalItemCount()
+
+	level := models.AchievementNone
+	if habit.Criteria != nil && habit.Criteria.Condition != nil && habit.Criteria.Condition.ChecklistCompletion != nil {
+		condition := habit.Criteria.Condition.ChecklistCompletion
+		if condition.RequiredItems != "all" {
+			return nil, fmt.Errorf("unsupported checklist completion criteria: %s", condition.RequiredItems)
+		}
+		if total > 0 && completed >= total {
+			level = models.AchievementMaxi
+		}
+		return &level, nil
+	}
+
+	if total == 0 {
+		return &level, nil
+	}
+	percentage := float64(completed) / float64(total)
+	switch {
+	case percentage >= 1.0:
+		level = models.AchievementMaxi
+	case percentage >= 0.75:
+		level = models.AchievementMidi
+	case percentage >= 0.5:
+		level = models.AchievementMini
+	}
+	return &level, nil
+}
+
+// ParseValue converts a raw string into the value stored for a field type:
+//   - boolean: bool (true/false, yes/no, y/n, 1/0, done)
+//   - unsigned_int, unsigned_decimal, decimal: float64, checked against min/max
+//   - duration: the duration string ("1h30m", or a number of minutes)
+//   - time: "HH:MM" (accepts "15:04" and "3:04")
+//   - checklist: the completed item texts (comma-separated, or "all")
+//   - text: the string as given
+//   - composite: "id=value" pairs separated by commas, each parsed by its sub-field's
+//     type into a map; sub-fields may be left out
+func ParseValue(fieldType models.FieldType, raw string, checklist *models.Checklist) (interface{}, error) {
+	raw = strings.TrimSpace(raw)
+
+	switch fieldType.Type {
+	case models.BooleanFieldType:
+		return parseBool(raw)
+
+	case models.UnsignedIntFieldType:
+		n, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a whole number", raw)
+		}
+		return checkRange(fieldType, float64(n))
+
+	case models.UnsignedDecimalFieldType, models.DecimalFieldType:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", raw)
+		}
+		if fieldType.Type == models.UnsignedDecimalFieldType && f < 0 {
+			return nil, fmt.Errorf("value must be positive")
+		}
+		return checkRange(fieldType, f)
+
+	case models.DurationFieldType:
+		if err := validateDuration(raw); err != nil {
+			return nil, err
+		}
+		return raw, nil
+
+	case models.TimeFieldType:
+		for _, layout := range []string{"15:04", "3:04"} {
+			if t, err := time.Parse(layout, raw); err == nil {
+				return t.Format("15:04"), nil
+			}
+		}
+		return nil, fmt.Errorf("%q is not a time (expected HH:MM)", raw)
+
+	case models.ChecklistFieldType:
+		return parseChecklistItems(raw, checklist)
+
+	case models.TextFieldType:
+		if raw == "" {
+			return nil, fmt.Errorf("text value cannot be empty")
+		}
+		return raw, nil
+
+	case models.CompositeFieldType:
+		return parseComposite(fieldType, raw)
+
+	default:
+		return nil, fmt.Errorf("unsupported field type: %s", fieldType.Type)
+	}
+}
+
+// parseComposite parses "id=value, id=value" into sub-field values.
+func parseComposite(fieldType models.FieldType, raw string) (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+	for _, pair := range strings.Split(raw, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		id, value, found := strings.Cut(pair, "=")
+		id = strings.TrimSpace(id)
+		if !found {
+			return nil, fmt.Errorf("%q is not a field value (expected id=value)", strings.TrimSpace(pair))
+		}
+		subField, known := fieldType.GetSubField(id)
+		if !known {
+			return nil, fmt.Errorf("unknown field %q (valid: %s)", id, strings.Join(fieldType.SubFieldIDs(), ", "))
+		}
+		if _, duplicate := values[id]; duplicate {
+			return nil, fmt.Errorf("field %s given more than once", id)
+		}
+		parsed, err := ParseValue(subField.FieldType, value, nil)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", id, err)
+		}
+		values[id] = parsed
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("composite value needs at least one field (id=value, ...)")
+	}
+	return values, nil
+}
+
+func parseBool(raw string) (bool, error) {
+	switch strings.ToLower(raw) {
+	case "true", "yes", "y", "1", "done":
+		return true, nil
+	case "false", "no", "n", "0":
+		return false, nil
+	default:
+		return false, fmt.Errorf("%q is not a boolean (use true/false or yes/no)", raw)
+	}
+}
+
+func checkRange(fieldType models.FieldType, value float64) (float64, error) {
+	if fieldType.Min != nil && value < *fieldType.Min {
+		return 0, fmt.Errorf("value must be at least %g", *fieldType.Min)
+	}
+	if fieldType.Max != nil && value > *fieldType.Max {
+		return 0, fmt.Errorf("value must be at most %g", *fieldType.Max)
+	}
+	return value, nil
+}
+
+// validateDuration accepts Go durations ("1h30m") or plain minutes ("45"), as the scoring engine does.
+func validateDuration(raw string) error {
+	if d, err := time.ParseDuration(raw); err == nil {
+		if d < 0 {
+			return fmt.Errorf("duration cannot be negative")
+		}
+		return nil
+	}
+	if minutes, err := strconv.ParseFloat(raw, 64); err == nil {
+		if minutes < 0 {
+			return fmt.Errorf("duration cannot be negative")
+		}
+		return nil
+	}
+	return fmt.Errorf("%q is not a duration (e.g. 1h30m, 45m or 45)", raw)
+}
+
+// parseChecklistItems matches comma-separated item names against the checklist, case-insensitively.
+func parseChecklistItems(raw string, checklist *models.Checklist) ([]string, error) {
+	if checklist == nil {
+		return nil, fmt.Errorf("checklist not loaded")
+	}
+
+	var items []string
+	for _, item := range checklist.Items {
+		if !strings.HasPrefix(item, "# ") {
+			items = append(items, item)
+		}
+	}
+
+	if strings.EqualFold(raw, "all") {
+		return items, nil
+	}
+
+	selected := []string{}
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		match := ""
+		for _, item := range items {
+			if strings.EqualFold(item, name) {
+				match = item
+				break
+			}
+		}
+		if match == "" {
+			return nil, fmt.Errorf("checklist %s has no item %q", checklist.ID, name)
+		}
+		if !seen[match] {
+			seen[match] = true
+			selected = append(selected, match)
+		}
+	}
+	return selected, nil
+}
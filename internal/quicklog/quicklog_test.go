@@ -0,0 +1,195 @@
+package quicklog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidlee/vice/internal/models"
+)
+
+func float(f float64) *float64 {
+	return &f
+}
+
+func TestParseValue(t *testing.T) {
+	checklist := &models.Checklist{
+		ID:    "morning",
+		Items: []string{"# Body", "stretch", "water", "# Mind", "journal"},
+	}
+
+	run := models.FieldType{
+		Type: models.CompositeFieldType,
+		Fields: []models.SubField{
+			{ID: "distance", FieldType: models.FieldType{Type: models.UnsignedDecimalFieldType}},
+			{ID: "duration", FieldType: models.FieldType{Type: models.DurationFieldType}},
+			{ID: "outdoors", FieldType: models.FieldType{Type: models.BooleanFieldType}},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		fieldType models.FieldType
+		raw       string
+		expected  interface{}
+		err       string
+	}{
+		{name: "boolean yes", fieldType: models.FieldType{Type: models.BooleanFieldType}, raw: "yes", expected: true},
+		{name: "boolean false", fieldType: models.FieldType{Type: models.BooleanFieldType}, raw: "false", expected: false},
+		{name: "boolean invalid", fieldType: models.FieldType{Type: models.BooleanFieldType}, raw: "maybe", err: "not a boolean"},
+		{name: "unsigned int", fieldType: models.FieldType{Type: models.UnsignedIntFieldType}, raw: "8", expected: float64(8)},
+		{name: "unsigned int negative", fieldType: models.FieldType{Type: models.UnsignedIntFieldType}, raw: "-1", err: "not a whole number"},
+		{name: "unsigned int above max", fieldType: models.FieldType{Type: models.UnsignedIntFieldType, Max: float(10)}, raw: "11", err: "at most 10"},
+		{name: "decimal", fieldType: models.FieldType{Type: models.DecimalFieldType}, raw: "-2.5", expected: -2.5},
+		{name: "unsigned decimal negative", fieldType: models.FieldType{Type: models.UnsignedDecimalFieldType}, raw: "-2.5", err: "positive"},
+		{name: "decimal below min", fieldType: models.FieldType{Type: models.DecimalFieldType, Min: float(1)}, raw: "0.5", err: "at least 1"},
+		{name: "duration", fieldType: models.FieldType{Type: models.DurationFieldType}, raw: "1h30m", expected: "1h30m"},
+		{name: "duration minutes", fieldType: models.FieldType{Type: models.DurationFieldType}, raw: "45", expected: "45"},
+		{name: "duration negative", fieldType: models.FieldType{Type: models.DurationFieldType}, raw: "-5m", err: "negative"},
+		{name: "duration invalid", fieldType: models.FieldType{Type: models.DurationFieldType}, raw: "soon", err: "not a duration"},
+		{name: "time", fieldType: models.FieldType{Type: models.TimeFieldType}, raw: "6:45", expected: "06:45"},
+		{name: "time invalid", fieldType: models.FieldType{Type: models.TimeFieldType}, raw: "25:00", err: "not a time"},
+		{name: "text", fieldType: models.FieldType{Type: models.TextFieldType}, raw: " felt good ", expected: "felt good"},
+		{name: "checklist items", fieldType: models.FieldType{Type: models.ChecklistFieldType}, raw: "Water, journal", expected: []string{"water", "journal"}},
+		{name: "checklist all", fieldType: models.FieldType{Type: models.ChecklistFieldType}, raw: "all", expected: []string{"stretch", "water", "journal"}},
+		{name: "checklist unknown item", fieldType: models.FieldType{Type: models.ChecklistFieldType}, raw: "run", err: "no item \"run\""},
+		{name: "composite", fieldType: run, raw: "distance=5.2, duration=28m, outdoors=yes", expected: map[string]interface{}{"distance": 5.2, "duration": "28m", "outdoors": true}},
+		{name: "composite partial", fieldType: run, raw: "distance=3", expected: map[string]interface{}{"distance": float64(3)}},
+		{name: "composite unknown field", fieldType: run, raw: "pace=5", err: "unknown field \"pace\""},
+		{name: "composite invalid sub-value", fieldType: run, raw: "distance=far", err: "field distance"},
+		{name: "composite missing equals", fieldType: run, raw: "5.2", err: "expected id=value"},
+		{name: "composite repeated field", fieldType: run, raw: "distance=1, distance=2", err: "more than once"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := ParseValue(tt.fieldType, tt.raw, checklist)
+			if tt.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func TestBuilder_Build(t *testing.T) {
+	builder := NewBuilder()
+
+	t.Run("boolean defaults to done", func(t *testing.T) {
+		habit := &models.Habit{ID: "meditate", HabitType: models.SimpleHabit, FieldType: models.FieldType{Type: models.BooleanFieldType}, ScoringType: models.ManualScoring}
+		entry, err := builder.Build(habit, Request{Notes: "calm"})
+		require.NoError(t, err)
+		assert.Equal(t, true, entry.Value)
+		assert.Equal(t, models.EntryCompleted, entry.Status)
+		assert.Equal(t, "calm", entry.Notes)
+	})
+
+	t.Run("boolean false fails", func(t *testing.T) {
+		habit := &models.Habit{ID: "meditate", HabitType: models.SimpleHabit, FieldType: models.FieldType{Type: models.BooleanFieldType}, ScoringType: models.ManualScoring}
+		entry, err := builder.Build(habit, Request{Value: "no", HasValue: true})
+		require.NoError(t, err)
+		assert.Equal(t, models.EntryFailed, entry.Status)
+	})
+
+	t.Run("simple automatic habit fails unmet criteria", func(t *testing.T) {
+		habit := &models.Habit{
+			ID:          "water",
+			HabitType:   models.SimpleHabit,
+			FieldType:   models.FieldType{Type: models.UnsignedIntFieldType},
+			ScoringType: models.AutomaticScoring,
+			Criteria:    &models.Criteria{Condition: &models.Condition{GreaterThanOrEqual: float(8)}},
+		}
+
+		entry, err := builder.Build(habit, Request{Value: "8", HasValue: true})
+		require.NoError(t, err)
+		assert.Equal(t, models.EntryCompleted, entry.Status)
+
+		entry, err = builder.Build(habit, Request{Value: "5", HasValue: true})
+		require.NoError(t, err)
+		assert.Equal(t, models.EntryFailed, entry.Status)
+	})
+
+	t.Run("elastic automatic habit is scored", func(t *testing.T) {
+		habit := &models.Habit{
+			ID:           "exercise",
+			HabitType:    models.ElasticHabit,
+			FieldType:    models.FieldType{Type: models.DurationFieldType},
+			ScoringType:  models.AutomaticScoring,
+			MiniCriteria: &models.Criteria{Condition: &models.Condition{GreaterThanOrEqual: float(15)}},
+			MidiCriteria: &models.Criteria{Condition: &models.Condition{GreaterThanOrEqual: float(30)}},
+			MaxiCriteria: &models.Criteria{Condition: &models.Condition{GreaterThanOrEqual: float(60)}},
+		}
+
+		entry, err := builder.Build(habit, Request{Value: "45m", HasValue: true})
+		require.NoError(t, err)
+		require.NotNil(t, entry.AchievementLevel)
+		assert.Equal(t, models.AchievementMidi, *entry.AchievementLevel)
+		assert.Equal(t, models.EntryCompleted, entry.Status)
+	})
+
+	t.Run("elastic manual habit needs a level", func(t *testing.T) {
+		habit := &models.Habit{ID: "reading", HabitType: models.ElasticHabit, FieldType: models.FieldType{Type: models.UnsignedIntFieldType}, ScoringType: models.ManualScoring}
+
+		_, err := builder.Build(habit, Request{Value: "30", HasValue: true})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "achievement level")
+
+		entry, err := builder.Build(habit, Request{Value: "30", HasValue: true, Level: "Maxi"})
+		require.NoError(t, err)
+		assert.Equal(t, models.AchievementMaxi, *entry.AchievementLevel)
+
+		_, err = builder.Build(habit, Request{Value: "30", HasValue: true, Level: "mega"})
+		assert.Error(t, err)
+	})
+
+	t.Run("checklist with all criteria", func(t *testing.T) {
+		habit := &models.Habit{
+			ID:          "morning",
+			HabitType:   models.ChecklistHabit,
+			FieldType:   models.FieldType{Type: models.ChecklistFieldType, ChecklistID: "morning"},
+			ScoringType: models.AutomaticScoring,
+			Criteria: &models.Criteria{Condition: &models.Condition{
+				ChecklistCompletion: &models.ChecklistCompletionCondition{RequiredItems: "all"},
+			}},
+		}
+		checklist := &models.Checklist{ID: "morning", Items: []string{"stretch", "water"}}
+
+		entry, err := builder.Build(habit, Request{Value: "all", HasValue: true, Checklist: checklist})
+		require.NoError(t, err)
+		assert.Equal(t, models.AchievementMaxi, *entry.AchievementLevel)
+
+		entry, err = builder.Build(habit, Request{Value: "water", HasValue: true, Checklist: checklist})
+		require.NoError(t, err)
+		assert.Equal(t, models.AchievementNone, *entry.AchievementLevel)
+	})
+
+	t.Run("informational habit has no level", func(t *testing.T) {
+		habit := &models.Habit{ID: "weight", HabitType: models.InformationalHabit, FieldType: models.FieldType{Type: models.UnsignedDecimalFieldType}}
+		entry, err := builder.Build(habit, Request{Value: "72.4", HasValue: true})
+		require.NoError(t, err)
+		assert.Equal(t, 72.4, entry.Value)
+		assert.Nil(t, entry.AchievementLevel)
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		habit := &models.Habit{ID: "gym", HabitType: models.SimpleHabit, FieldType: models.FieldType{Type: models.BooleanFieldType}}
+		entry, err := builder.Build(habit, Request{Skip: true, Notes: "travelling"})
+		require.NoError(t, err)
+		assert.Equal(t, models.EntrySkipped, entry.Status)
+		assert.Nil(t, entry.Value)
+
+		_, err = builder.Build(habit, Request{Skip: true, Value: "yes", HasValue: true})
+		assert.Error(t, err)
+	})
+
+	t.Run("missing value", func(t *testing.T) {
+		habit := &models.Habit{ID: "exercise", HabitType: models.InformationalHabit, FieldType: models.FieldType{Type: models.DurationFieldType}}
+		_, err := builder.Build(habit, Request{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "needs a duration value")
+	})
+}
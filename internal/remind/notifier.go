@@ -0,0 +1,102 @@
+package remind
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/davidlee/vice/internal/config"
+)
+
+// DefaultDesktopCommand is the desktop notifier's command when config.toml doesn't set one.
+const DefaultDesktopCommand = "notify-send"
+
+// Notifier delivers a notification.
+type Notifier interface {
+	Notify(notification Notification) error
+}
+
+// NewNotifier creates the notifier selected in the [remind] section of config.toml.
+func NewNotifier(cfg config.RemindConfig, out io.Writer) (Notifier, error) {
+	switch cfg.Notifier {
+	case "", "stdout":
+		return &StdoutNotifier{Out: out}, nil
+	case "desktop":
+		command := cfg.Command
+		if command == "" {
+			command = DefaultDesktopCommand
+		}
+		return &CommandNotifier{Command: command}, nil
+	case "hook":
+		if cfg.Hook == "" {
+			return nil, fmt.Errorf("hook notifier requires a hook command")
+		}
+		return &HookNotifier{Command: cfg.Hook}, nil
+	default:
+		return nil, fmt.Errorf("unknown notifier: %s", cfg.Notifier)
+	}
+}
+
+// StdoutNotifier prints notifications, one per line.
+type StdoutNotifier struct {
+	Out io.Writer
+}
+
+// Notify prints the notification.
+func (n *StdoutNotifier) Notify(notification Notification) error {
+	_, err := fmt.Fprintf(n.Out, "%s %s: %s\n", notification.At.Format("15:04"), notification.Title, notification.Message)
+	return err
+}
+
+// CommandNotifier runs a desktop notification command, such as notify-send, as
+// <command> <title> <message>. The command may include its own arguments.
+type CommandNotifier struct {
+	Command string
+}
+
+// Notify runs the command.
+func (n *CommandNotifier) Notify(notification Notification) error {
+	fields := strings.Fields(n.Command)
+	if len(fields) == 0 {
+		return fmt.Errorf("no notification command configured")
+	}
+
+	args := append(fields[1:], "vice: "+notification.Title, notification.Message)
+	// #nosec G204 -- the command comes from the user's own config.toml
+	cmd := exec.Command(fields[0], args...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("notification command failed: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// HookNotifier runs a shell command with the notification in its environment:
+// VICE_HABIT_ID, VICE_HABIT_TITLE, VICE_REMINDER_KIND, VICE_REMINDER_TIME and
+// VICE_MESSAGE.
+type HookNotifier struct {
+	Command string
+}
+
+// Notify runs the hook through sh -c.
+func (n *HookNotifier) Notify(notification Notification) error {
+	// #nosec G204 -- the hook comes from the user's own config.toml
+	cmd := exec.Command("sh", "-c", n.Command)
+	cmd.Env = append(os.Environ(), HookEnv(notification)...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("hook failed: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// HookEnv returns the environment variables describing a notification to a hook.
+func HookEnv(notification Notification) []string {
+	return []string{
+		"VICE_HABIT_ID=" + notification.HabitID,
+		"VICE_HABIT_TITLE=" + notification.Title,
+		"VICE_REMINDER_KIND=" + string(notification.Kind),
+		"VICE_REMINDER_TIME=" + notification.At.Format("15:04"),
+		"VICE_MESSAGE=" + notification.Message,
+	}
+}
@@ -0,0 +1,248 @@
+// Package remind works out which habits need a reminder or nudge and delivers
+// them through pluggable notifiers, for `vice remind`.
+// AIDEV-NOTE: remind-package; time comes from a Clock so the daemon loop is testable with a fake one
+package remind
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/davidlee/vice/internal/models"
+	"github.com/davidlee/vice/internal/scoring"
+)
+
+// Clock tells the time and waits. SystemClock is the real one; tests substitute a fake.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// SystemClock is the wall clock.
+type SystemClock struct{}
+
+// Now returns the current time.
+func (SystemClock) Now() time.Time { return time.Now() }
+
+// After waits for the duration to elapse.
+func (SystemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// Kind distinguishes reminders from nudges.
+type Kind string
+
+// Notification kinds.
+const (
+	KindReminder Kind = "reminder" // A time from the habit's reminders.at
+	KindNudge    Kind = "nudge"    // The habit's reminders.nudge_by time passed while incomplete
+)
+
+// Notification is one reminder or nudge about a habit.
+type Notification struct {
+	HabitID string
+	Title   string // The habit's title
+	Message string
+	Kind    Kind
+	At      time.Time // When it was scheduled to fire
+}
+
+// Loader loads the current habits and entries. It is called on every check, so
+// entries logged while the daemon runs silence that day's later reminders.
+type Loader func() (*models.Schema, *models.EntryLog, error)
+
+// Reminder checks habits against the clock and delivers their notifications.
+type Reminder struct {
+	load     Loader
+	notifier Notifier
+	clock    Clock
+	engine   *scoring.Engine
+}
+
+// NewReminder creates a reminder delivering through notifier.
+func NewReminder(load Loader, notifier Notifier, clock Clock) *Reminder {
+	return &Reminder{
+		load:     load,
+		notifier: notifier,
+		clock:    clock,
+		engine:   scoring.NewEngine(),
+	}
+}
+
+// Due returns the notifications scheduled after since and up to until, in time
+// order. A habit's reminders fire only on days it is due (see
+// scoring.Engine.EvaluateSchedule) and while that day has no completed or
+// skipped entry for it.
+func (r *Reminder) Due(since, until time.Time) ([]Notification, error) {
+	schema, entryLog, err := r.load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load habits and entries: %w", err)
+	}
+
+	var due []Notification
+	for day := startOfDay(since); !day.After(until); day = day.AddDate(0, 0, 1) {
+		for i := range schema.Habits {
+			habit := &schema.Habits[i]
+			if habit.Reminders == nil {
+				continue
+			}
+
+			notifications, err := r.habitNotifications(habit, entryLog, day)
+			if err != nil {
+				return nil, err
+			}
+			for _, notification := range notifications {
+				if notification.At.After(since) && !notification.At.After(until) {
+					due = append(due, notification)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(due, func(i, j int) bool { return due[i].At.Before(due[j].At) })
+	return due, nil
+}
+
+// habitNotifications returns all of a habit's notifications for day, or none if
+// the habit isn't due or is already done.
+func (r *Reminder) habitNotifications(habit *models.Habit, entryLog *models.EntryLog, day time.Time) ([]Notification, error) {
+	schedule, err := r.engine.EvaluateSchedule(habit, entryLog, day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate schedule for habit %s: %w", habit.ID, err)
+	}
+	if schedule.Status != models.ScheduleDue || isDone(habit.ID, entryLog, day) {
+		return nil, nil
+	}
+
+	var notifications []Notification
+	for _, at := range habit.Reminders.At {
+		notification, err := newNotification(habit, KindReminder, at, day)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+	if habit.Reminders.NudgeBy != "" {
+		notification, err := newNotification(habit, KindNudge, habit.Reminders.NudgeBy, day)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications, nil
+}
+
+// Check delivers the notifications due after since and up to until. Every
+// notification is attempted; delivery errors are joined.
+func (r *Reminder) Check(since, until time.Time) ([]Notification, error) {
+	return r.check(since, until, make(map[notificationKey]bool))
+}
+
+// notificationKey identifies a notification across checks of the same window.
+type notificationKey struct {
+	habitID string
+	kind    Kind
+	at      int64
+}
+
+// check is Check, skipping the notifications in sent and adding the ones it delivers.
+func (r *Reminder) check(since, until time.Time, sent map[notificationKey]bool) ([]Notification, error) {
+	due, err := r.Due(since, until)
+	if err != nil {
+		return nil, err
+	}
+
+	var delivered []Notification
+	var errs []error
+	for _, notification := range due {
+		key := notificationKey{notification.HabitID, notification.Kind, notification.At.Unix()}
+		if sent[key] {
+			continue
+		}
+		if err := r.notifier.Notify(notification); err != nil {
+			errs = append(errs, fmt.Errorf("failed to notify about %s: %w", notification.HabitID, err))
+			continue
+		}
+		sent[key] = true
+		delivered = append(delivered, notification)
+	}
+	return delivered, errors.Join(errs...)
+}
+
+// Run checks every interval until ctx is cancelled, starting with the
+// notifications due after since. Errors are written to errOut and don't stop
+// the loop: since only advances after a successful check, so the notifications
+// in a failed window (a broken notifier, a half-written entries file) are
+// retried on the next tick, without repeating the ones already delivered.
+func (r *Reminder) Run(ctx context.Context, since time.Time, interval time.Duration, errOut io.Writer) error {
+	if interval <= 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+
+	sent := make(map[notificationKey]bool) // Delivered since the last successful check
+	for {
+		now := r.clock.Now()
+		if _, err := r.check(since, now, sent); err != nil {
+			_, _ = fmt.Fprintf(errOut, "remind: %v\n", err)
+		} else {
+			since = now
+			clear(sent)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.clock.After(interval):
+		}
+	}
+}
+
+// newNotification builds a habit's notification at an "HH:MM" time on day.
+func newNotification(habit *models.Habit, kind Kind, at string, day time.Time) (Notification, error) {
+	offset, err := models.ParseReminderTime(at)
+	if err != nil {
+		return Notification{}, fmt.Errorf("habit %s: %w", habit.ID, err)
+	}
+
+	message := habit.Reminders.Message
+	if message == "" {
+		message = defaultMessage(habit, kind)
+	}
+	return Notification{
+		HabitID: habit.ID,
+		Title:   habit.Title,
+		Message: message,
+		Kind:    kind,
+		At:      time.Date(day.Year(), day.Month(), day.Day(), 0, int(offset/time.Minute), 0, 0, day.Location()),
+	}, nil
+}
+
+// defaultMessage is the notification text when the habit doesn't set one.
+func defaultMessage(habit *models.Habit, kind Kind) string {
+	if kind == KindNudge {
+		return fmt.Sprintf("%s is still incomplete today", habit.Title)
+	}
+	if habit.Prompt != "" {
+		return habit.Prompt
+	}
+	return fmt.Sprintf("Time for %s", habit.Title)
+}
+
+// isDone reports whether the habit has a completed or skipped entry on day.
+func isDone(habitID string, entryLog *models.EntryLog, day time.Time) bool {
+	if entryLog == nil {
+		return false
+	}
+	dayEntry, found := entryLog.GetDayEntry(day.Format("2006-01-02"))
+	if !found {
+		return false
+	}
+	entry, found := dayEntry.GetHabitEntry(habitID)
+	return found && (entry.IsCompleted() || entry.IsSkipped())
+}
+
+// startOfDay returns midnight at the start of t's day.
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,252 @@
+package remind
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidlee/vice/internal/config"
+	"github.com/davidlee/vice/internal/models"
+)
+
+// fakeClock advances by the requested duration whenever it is waited on, and
+// cancels the run after a number of waits.
+type fakeClock struct {
+	now    time.Time
+	waits  int
+	limit  int
+	cancel context.CancelFunc
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.waits++
+	if c.waits > c.limit {
+		c.cancel()
+		return nil // never fires, so Run sees the cancellation
+	}
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+// fakeNotifier records notifications, failing for habits in fail.
+type fakeNotifier struct {
+	sent []Notification
+	fail map[string]bool
+}
+
+func (n *fakeNotifier) Notify(notification Notification) error {
+	if n.fail[notification.HabitID] {
+		return errors.New("unavailable")
+	}
+	n.sent = append(n.sent, notification)
+	return nil
+}
+
+func (n *fakeNotifier) summary() []string {
+	var lines []string
+	for _, notification := range n.sent {
+		lines = append(lines, notification.At.Format("01-02 15:04")+" "+string(notification.Kind)+" "+notification.HabitID)
+	}
+	return lines
+}
+
+func testHabits() *models.Schema {
+	return &models.Schema{Version: "1.0.0", Habits: []models.Habit{
+		{ID: "meditation", Title: "Meditation", Reminders: &models.Reminders{At: []string{"08:00", "18:00"}, NudgeBy: "21:00"}},
+		{ID: "gym", Title: "Gym", Prompt: "Off to the gym?", Reminders: &models.Reminders{At: []string{"17:30"}},
+			Schedule: &models.Schedule{Frequency: models.WeekdaysFrequency, Weekdays: []string{"mon", "wed", "fri"}}},
+		{ID: "water", Title: "Water", Reminders: &models.Reminders{NudgeBy: "12:00", Message: "Drink up"}},
+		{ID: "reading", Title: "Reading"},
+	}}
+}
+
+// Wednesday
+var day = time.Date(2025, 7, 16, 0, 0, 0, 0, time.UTC)
+
+func at(hour, minute int) time.Time {
+	return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+}
+
+func TestReminder_Due(t *testing.T) {
+	entryLog := &models.EntryLog{Version: "1.0.0"}
+	load := func() (*models.Schema, *models.EntryLog, error) { return testHabits(), entryLog, nil }
+	reminder := NewReminder(load, &fakeNotifier{}, &fakeClock{})
+
+	t.Run("whole day in time order", func(t *testing.T) {
+		due, err := reminder.Due(day, at(23, 59))
+		require.NoError(t, err)
+
+		var got []string
+		for _, notification := range due {
+			got = append(got, notification.At.Format("15:04")+" "+string(notification.Kind)+" "+notification.HabitID+": "+notification.Message)
+		}
+		assert.Equal(t, []string{
+			"08:00 reminder meditation: Time for Meditation",
+			"12:00 nudge water: Drink up",
+			"17:30 reminder gym: Off to the gym?",
+			"18:00 reminder meditation: Time for Meditation",
+			"21:00 nudge meditation: Meditation is still incomplete today",
+		}, got)
+	})
+
+	t.Run("window excludes since and includes until", func(t *testing.T) {
+		due, err := reminder.Due(at(8, 0), at(12, 0))
+		require.NoError(t, err)
+		require.Len(t, due, 1)
+		assert.Equal(t, "water", due[0].HabitID)
+	})
+
+	t.Run("not due today", func(t *testing.T) {
+		thursday := day.AddDate(0, 0, 1)
+		due, err := reminder.Due(thursday, thursday.Add(18*time.Hour))
+		require.NoError(t, err)
+		for _, notification := range due {
+			assert.NotEqual(t, "gym", notification.HabitID)
+		}
+	})
+
+	t.Run("completed and skipped habits are quiet, failed ones are not", func(t *testing.T) {
+		entryLog.Entries = []models.DayEntry{{Date: "2025-07-16", Habits: []models.HabitEntry{
+			{HabitID: "meditation", Status: models.EntryCompleted},
+			{HabitID: "gym", Status: models.EntrySkipped},
+			{HabitID: "water", Status: models.EntryFailed, Value: "3"},
+		}}}
+		defer func() { entryLog.Entries = nil }()
+
+		due, err := reminder.Due(day, at(23, 59))
+		require.NoError(t, err)
+		require.Len(t, due, 1)
+		assert.Equal(t, "water", due[0].HabitID)
+	})
+
+	t.Run("spans midnight", func(t *testing.T) {
+		due, err := reminder.Due(at(20, 0), at(32, 0))
+		require.NoError(t, err)
+		require.Len(t, due, 2)
+		assert.Equal(t, at(21, 0), due[0].At)
+		assert.Equal(t, at(32, 0), due[1].At) // 08:00 the next day
+	})
+
+	t.Run("load error", func(t *testing.T) {
+		failing := NewReminder(func() (*models.Schema, *models.EntryLog, error) {
+			return nil, nil, errors.New("no habits")
+		}, &fakeNotifier{}, &fakeClock{})
+		_, err := failing.Due(day, at(12, 0))
+		assert.ErrorContains(t, err, "no habits")
+	})
+}
+
+func TestReminder_Check(t *testing.T) {
+	load := func() (*models.Schema, *models.EntryLog, error) { return testHabits(), nil, nil }
+	notifier := &fakeNotifier{fail: map[string]bool{"water": true}}
+	reminder := NewReminder(load, notifier, &fakeClock{})
+
+	delivered, err := reminder.Check(day, at(13, 0))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to notify about water: unavailable")
+
+	// The failure doesn't stop the rest
+	require.Len(t, delivered, 1)
+	assert.Equal(t, "meditation", delivered[0].HabitID)
+	assert.Equal(t, []string{"07-16 08:00 reminder meditation"}, notifier.summary())
+}
+
+func TestReminder_Run(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clock := &fakeClock{now: at(7, 0), limit: 24, cancel: cancel} // 07:00 to 19:00 in 30 minute steps
+	entryLog := &models.EntryLog{Version: "1.0.0"}
+	notifier := &fakeNotifier{fail: map[string]bool{"water": true}}
+	load := func() (*models.Schema, *models.EntryLog, error) {
+		// Meditation is logged at 09:00; the evening reminder and nudge stay quiet
+		if !clock.now.Before(at(9, 0)) && len(entryLog.Entries) == 0 {
+			entryLog.Entries = []models.DayEntry{{Date: "2025-07-16", Habits: []models.HabitEntry{
+				{HabitID: "meditation", Status: models.EntryCompleted},
+			}}}
+		}
+		// The water notifier is down from 12:00 until 13:30
+		if !clock.now.Before(at(13, 30)) {
+			notifier.fail = nil
+		}
+		return testHabits(), entryLog, nil
+	}
+	var errOut bytes.Buffer
+
+	require.NoError(t, NewReminder(load, notifier, clock).Run(ctx, clock.now, 30*time.Minute, &errOut))
+
+	assert.Equal(t, []string{
+		"07-16 08:00 reminder meditation",
+		"07-16 12:00 nudge water",
+		"07-16 17:30 reminder gym",
+	}, notifier.summary(), "the failed nudge is retried until delivered, once")
+	assert.Equal(t, at(19, 0), clock.now)
+	assert.Equal(t, 3, strings.Count(errOut.String(), "failed to notify about water"), "12:00, 12:30 and 13:00")
+
+	t.Run("invalid interval", func(t *testing.T) {
+		assert.Error(t, NewReminder(load, notifier, clock).Run(ctx, clock.now, 0, &errOut))
+	})
+}
+
+func TestNewNotifier(t *testing.T) {
+	var out bytes.Buffer
+
+	notifier, err := NewNotifier(config.RemindConfig{}, &out)
+	require.NoError(t, err)
+	require.NoError(t, notifier.Notify(Notification{Title: "Meditation", Message: "Time for Meditation", At: at(8, 0)}))
+	assert.Equal(t, "08:00 Meditation: Time for Meditation\n", out.String())
+
+	notifier, err = NewNotifier(config.RemindConfig{Notifier: "desktop"}, &out)
+	require.NoError(t, err)
+	assert.Equal(t, &CommandNotifier{Command: DefaultDesktopCommand}, notifier)
+
+	_, err = NewNotifier(config.RemindConfig{Notifier: "hook"}, &out)
+	assert.Error(t, err)
+
+	_, err = NewNotifier(config.RemindConfig{Notifier: "pager"}, &out)
+	assert.ErrorContains(t, err, "unknown notifier: pager")
+}
+
+func TestCommandAndHookNotifiers(t *testing.T) {
+	dir := t.TempDir()
+	output := filepath.Join(dir, "out")
+	notification := Notification{HabitID: "water", Title: "Water", Message: "Drink up", Kind: KindNudge, At: at(12, 0)}
+
+	t.Run("command", func(t *testing.T) {
+		script := filepath.Join(dir, "notify")
+		require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\nprintf '%s|' \"$@\" > \""+output+"\"\n"), 0o700)) // #nosec G306 -- test script must be executable
+		require.NoError(t, (&CommandNotifier{Command: script + " -u critical"}).Notify(notification))
+
+		data, err := os.ReadFile(output) // #nosec G304 -- test file in temp dir
+		require.NoError(t, err)
+		assert.Equal(t, "-u|critical|vice: Water|Drink up|", string(data))
+
+		err = (&CommandNotifier{Command: "false"}).Notify(notification)
+		assert.ErrorContains(t, err, "notification command failed")
+	})
+
+	t.Run("hook", func(t *testing.T) {
+		hook := `echo "$VICE_HABIT_ID $VICE_REMINDER_KIND $VICE_REMINDER_TIME $VICE_HABIT_TITLE: $VICE_MESSAGE" > "` + output + `"`
+		require.NoError(t, (&HookNotifier{Command: hook}).Notify(notification))
+
+		data, err := os.ReadFile(output) // #nosec G304 -- test file in temp dir
+		require.NoError(t, err)
+		assert.Equal(t, "water nudge 12:00 Water: Drink up\n", string(data))
+
+		err = (&HookNotifier{Command: "echo oops >&2; exit 3"}).Notify(notification)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "oops")
+	})
+}
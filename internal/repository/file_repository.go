@@ -7,6 +7,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -15,6 +16,8 @@ import (
 	init_pkg "github.com/davidlee/vice/internal/init"
 	"github.com/davidlee/vice/internal/models"
 	"github.com/davidlee/vice/internal/parser"
+	"github.com/davidlee/vice/internal/safefile"
+	"github.com/davidlee/vice/internal/scoring"
 	"github.com/davidlee/vice/internal/storage"
 	"gopkg.in/yaml.v3"
 )
@@ -34,8 +37,19 @@ type FileRepository struct {
 	currentEntries          *models.EntryLog
 	currentChecklists       *models.ChecklistSchema
 	currentChecklistEntries *models.ChecklistEntriesSchema
+
+	// What was loaded from disk, so saves don't clobber changes made by another
+	// vice process in the meantime; nil means nothing was loaded (saves overwrite)
+	// AIDEV-NOTE: repository-concurrency; YAML files are version-checked, entries three-way merged
+	habitsVersion           *safefile.Version
+	checklistsVersion       *safefile.Version
+	checklistEntriesVersion *safefile.Version
+	entriesBase             *models.EntryLog
 }
 
+// FileRepository provides the entry history for window criteria.
+var _ scoring.EntryHistory = (*FileRepository)(nil)
+
 // NewFileRepository creates a new file-based repository.
 func NewFileRepository(viceEnv *config.ViceEnv) *FileRepository {
 	return &FileRepository{
@@ -120,7 +134,7 @@ func (r *FileRepository) LoadHabits() (*models.Schema, error) {
 	}
 
 	habitsPath := r.viceEnv.GetHabitsFile()
-	schema, err := r.habitParser.LoadFromFile(habitsPath)
+	schema, version, err := r.habitParser.LoadFromFileWithVersion(habitsPath)
 	if err != nil {
 		return nil, &Error{
 			Operation: "LoadHabits",
@@ -130,14 +144,24 @@ func (r *FileRepository) LoadHabits() (*models.Schema, error) {
 	}
 
 	r.currentSchema = schema
+	r.habitsVersion = &version
 	r.dataLoaded = true
 	return schema, nil
 }
 
-// SaveHabits saves the habit schema for the current context.
+// SaveHabits saves the habit schema for the current context. If habits were
+// loaded, the save fails (wrapping safefile.ErrConflict) when the file has
+// changed on disk since.
 func (r *FileRepository) SaveHabits(schema *models.Schema) error {
 	habitsPath := r.viceEnv.GetHabitsFile()
-	if err := r.habitParser.SaveToFile(schema, habitsPath); err != nil {
+	var version safefile.Version
+	var err error
+	if r.habitsVersion != nil {
+		version, err = r.habitParser.SaveToFileIfUnchanged(schema, habitsPath, *r.habitsVersion)
+	} else {
+		err = r.habitParser.SaveToFile(schema, habitsPath)
+	}
+	if err != nil {
 		return &Error{
 			Operation: "SaveHabits",
 			Context:   r.viceEnv.Context,
@@ -146,6 +170,9 @@ func (r *FileRepository) SaveHabits(schema *models.Schema) error {
 	}
 
 	r.currentSchema = schema
+	if r.habitsVersion != nil {
+		r.habitsVersion = &version
+	}
 	return nil
 }
 
@@ -172,13 +199,34 @@ func (r *FileRepository) LoadEntries(_ time.Time) (*models.EntryLog, error) {
 	}
 
 	r.currentEntries = entries
+	r.entriesBase = cloneEntryLog(entries)
 	return entries, nil
 }
 
-// SaveEntries saves entries for the current context.
+// SaveEntries saves entries for the current context, recomputing derived habits
+// if habits have been loaded. If entries were loaded, the changes made since are
+// merged into the file under its lock (storage.MergeEntryLogs, latest edit wins),
+// keeping entries other vice processes saved in the meantime.
 func (r *FileRepository) SaveEntries(entries *models.EntryLog) error {
 	entriesPath := r.viceEnv.GetEntriesFile()
-	if err := r.entryStorage.SaveToFile(entries, entriesPath); err != nil {
+	r.entryStorage.SetBeforeSave(nil)
+	if r.currentSchema != nil {
+		r.entryStorage.SetBeforeSave(scoring.NewEngine().RecomputeHook(r.currentSchema))
+	}
+	base := r.entriesBase
+	saved, err := r.entryStorage.Modify(entriesPath, func(current *models.EntryLog) error {
+		if base == nil {
+			*current = *entries
+			return nil
+		}
+		merged, _, err := storage.MergeEntryLogs(base, entries, current, nil)
+		if err != nil {
+			return err
+		}
+		*current = *merged
+		return nil
+	})
+	if err != nil {
 		return &Error{
 			Operation: "SaveEntries",
 			Context:   r.viceEnv.Context,
@@ -186,10 +234,22 @@ func (r *FileRepository) SaveEntries(entries *models.EntryLog) error {
 		}
 	}
 
-	r.currentEntries = entries
+	r.currentEntries = saved
+	r.entriesBase = cloneEntryLog(saved)
 	return nil
 }
 
+// cloneEntryLog copies an entry log's days and entries, so the caller's in-place
+// edits to the original don't reach the copy.
+func cloneEntryLog(entryLog *models.EntryLog) *models.EntryLog {
+	clone := &models.EntryLog{Version: entryLog.Version, Entries: make([]models.DayEntry, len(entryLog.Entries))}
+	for i, day := range entryLog.Entries {
+		day.Habits = slices.Clone(day.Habits)
+		clone.Entries[i] = day
+	}
+	return clone
+}
+
 // LoadChecklists loads checklist templates for the current context.
 // AIDEV-NOTE: T028/2.2-file-init; automatically ensures context files exist before loading
 func (r *FileRepository) LoadChecklists() (*models.ChecklistSchema, error) {
@@ -206,7 +266,7 @@ func (r *FileRepository) LoadChecklists() (*models.ChecklistSchema, error) {
 
 	// Use checklist parser - need to implement this based on existing patterns
 	checklistParser := parser.NewChecklistParser()
-	checklists, err := checklistParser.LoadFromFile(checklistsPath)
+	checklists, version, err := checklistParser.LoadFromFileWithVersion(checklistsPath)
 	if err != nil {
 		return nil, &Error{
 			Operation: "LoadChecklists",
@@ -216,15 +276,25 @@ func (r *FileRepository) LoadChecklists() (*models.ChecklistSchema, error) {
 	}
 
 	r.currentChecklists = checklists
+	r.checklistsVersion = &version
 	return checklists, nil
 }
 
-// SaveChecklists saves checklist templates for the current context.
+// SaveChecklists saves checklist templates for the current context. If they were
+// loaded, the save fails (wrapping safefile.ErrConflict) when the file has
+// changed on disk since.
 func (r *FileRepository) SaveChecklists(checklists *models.ChecklistSchema) error {
 	checklistsPath := r.viceEnv.GetChecklistsFile()
 
 	checklistParser := parser.NewChecklistParser()
-	if err := checklistParser.SaveToFile(checklists, checklistsPath); err != nil {
+	var version safefile.Version
+	var err error
+	if r.checklistsVersion != nil {
+		version, err = checklistParser.SaveToFileIfUnchanged(checklists, checklistsPath, *r.checklistsVersion)
+	} else {
+		err = checklistParser.SaveToFile(checklists, checklistsPath)
+	}
+	if err != nil {
 		return &Error{
 			Operation: "SaveChecklists",
 			Context:   r.viceEnv.Context,
@@ -233,6 +303,9 @@ func (r *FileRepository) SaveChecklists(checklists *models.ChecklistSchema) erro
 	}
 
 	r.currentChecklists = checklists
+	if r.checklistsVersion != nil {
+		r.checklistsVersion = &version
+	}
 	return nil
 }
 
@@ -241,7 +314,7 @@ func (r *FileRepository) LoadChecklistEntries() (*models.ChecklistEntriesSchema,
 	entriesPath := r.viceEnv.GetChecklistEntriesFile()
 
 	entriesParser := parser.NewChecklistEntriesParser()
-	entries, err := entriesParser.LoadFromFile(entriesPath)
+	entries, version, err := entriesParser.LoadFromFileWithVersion(entriesPath)
 	if err != nil {
 		return nil, &Error{
 			Operation: "LoadChecklistEntries",
@@ -251,15 +324,25 @@ func (r *FileRepository) LoadChecklistEntries() (*models.ChecklistEntriesSchema,
 	}
 
 	r.currentChecklistEntries = entries
+	r.checklistEntriesVersion = &version
 	return entries, nil
 }
 
-// SaveChecklistEntries saves checklist entry data for the current context.
+// SaveChecklistEntries saves checklist entry data for the current context. If it
+// was loaded, the save fails (wrapping safefile.ErrConflict) when the file has
+// changed on disk since.
 func (r *FileRepository) SaveChecklistEntries(entries *models.ChecklistEntriesSchema) error {
 	entriesPath := r.viceEnv.GetChecklistEntriesFile()
 
 	entriesParser := parser.NewChecklistEntriesParser()
-	if err := entriesParser.SaveToFile(entries, entriesPath); err != nil {
+	var version safefile.Version
+	var err error
+	if r.checklistEntriesVersion != nil {
+		version, err = entriesParser.SaveToFileIfUnchanged(entries, entriesPath, *r.checklistEntriesVersion)
+	} else {
+		err = entriesParser.SaveToFile(entries, entriesPath)
+	}
+	if err != nil {
 		return &Error{
 			Operation: "SaveChecklistEntries",
 			Context:   r.viceEnv.Context,
@@ -268,6 +351,9 @@ func (r *FileRepository) SaveChecklistEntries(entries *models.ChecklistEntriesSc
 	}
 
 	r.currentChecklistEntries = entries
+	if r.checklistEntriesVersion != nil {
+		r.checklistEntriesVersion = &version
+	}
 	return nil
 }
 
@@ -277,6 +363,10 @@ func (r *FileRepository) UnloadAllData() error {
 	r.currentEntries = nil
 	r.currentChecklists = nil
 	r.currentChecklistEntries = nil
+	r.habitsVersion = nil
+	r.checklistsVersion = nil
+	r.checklistEntriesVersion = nil
+	r.entriesBase = nil
 	r.dataLoaded = false
 	return nil
 }
@@ -1,12 +1,17 @@
 package repository
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/davidlee/vice/internal/config"
 	"github.com/davidlee/vice/internal/models"
+	"github.com/davidlee/vice/internal/parser"
+	"github.com/davidlee/vice/internal/safefile"
+	"github.com/davidlee/vice/internal/storage"
 )
 
 func createTestViceEnv(t *testing.T) *config.ViceEnv {
@@ -253,3 +258,65 @@ func TestError(t *testing.T) {
 		t.Errorf("Unwrap() = %v, want %v", baseErr.Unwrap(), os.ErrNotExist)
 	}
 }
+
+func TestSaveEntriesKeepsConcurrentChanges(t *testing.T) {
+	env := createTestViceEnv(t)
+	repo := NewFileRepository(env)
+
+	entries, err := repo.LoadEntries(time.Now())
+	if err != nil {
+		t.Fatalf("LoadEntries() failed: %v", err)
+	}
+
+	// Another vice process logs a habit after the repository loaded entries
+	habitEntry := func(habitID string) models.HabitEntry {
+		return models.HabitEntry{HabitID: habitID, Value: true, Status: models.EntryCompleted, CreatedAt: time.Now()}
+	}
+	if err := storage.NewEntryStorage().UpdateHabitEntry(env.GetEntriesFile(), "2025-07-20", habitEntry("read")); err != nil {
+		t.Fatalf("UpdateHabitEntry() failed: %v", err)
+	}
+
+	if err := entries.UpdateDayEntry(models.DayEntry{Date: "2025-07-20", Habits: []models.HabitEntry{habitEntry("walk")}}); err != nil {
+		t.Fatalf("UpdateDayEntry() failed: %v", err)
+	}
+	if err := repo.SaveEntries(entries); err != nil {
+		t.Fatalf("SaveEntries() failed: %v", err)
+	}
+
+	saved, err := storage.NewEntryStorage().LoadFromFile(env.GetEntriesFile())
+	if err != nil {
+		t.Fatalf("LoadFromFile() failed: %v", err)
+	}
+	day, ok := saved.GetDayEntry("2025-07-20")
+	if !ok {
+		t.Fatal("expected an entry for 2025-07-20")
+	}
+	if len(day.Habits) != 2 {
+		t.Errorf("expected both processes' entries to be saved, got %+v", day.Habits)
+	}
+}
+
+func TestSaveHabitsRefusesStaleSchema(t *testing.T) {
+	env := createTestViceEnv(t)
+	repo := NewFileRepository(env)
+
+	schema, err := repo.LoadHabits()
+	if err != nil {
+		t.Fatalf("LoadHabits() failed: %v", err)
+	}
+
+	// Another vice process edits habits after the repository loaded them
+	other, err := parser.NewHabitParser().LoadFromFile(env.GetHabitsFile())
+	if err != nil {
+		t.Fatalf("LoadFromFile() failed: %v", err)
+	}
+	other.Habits[0].Title = "Edited elsewhere"
+	if err := parser.NewHabitParser().SaveToFile(other, env.GetHabitsFile()); err != nil {
+		t.Fatalf("SaveToFile() failed: %v", err)
+	}
+
+	err = repo.SaveHabits(schema)
+	if !errors.Is(err, safefile.ErrConflict) {
+		t.Errorf("SaveHabits() error = %v, want a conflict", err)
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !unix
+
+package safefile
+
+import "os"
+
+// tryLock is a no-op where flock isn't available; writes are still atomic.
+func tryLock(_ *os.File) (bool, error) {
+	return true, nil
+}
+
+// unlock is a no-op where flock isn't available.
+func unlock(_ *os.File) error {
+	return nil
+}
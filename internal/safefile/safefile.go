@@ -0,0 +1,223 @@
+// Package safefile provides the crash-safe, lock-protected file writes shared by vice's YAML stores.
+// AIDEV-NOTE: safefile-core; every write takes an advisory lock on <path>.lock, writes a temp file in the
+// same directory and renames it over the target, so readers never see a partial file and concurrent
+// vice processes (menu + hotkey `vice log`) serialize their load-modify-save cycles
+package safefile
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// LockSuffix is appended to a file's path to name its lock file.
+const LockSuffix = ".lock"
+
+// LockTimeout is how long a writer waits for another process to release a lock.
+var LockTimeout = 10 * time.Second
+
+// ErrConflict is returned when a file changed on disk since the version the caller read.
+var ErrConflict = errors.New("file changed on disk since it was read")
+
+// ConflictError reports a failed optimistic concurrency check for a file.
+type ConflictError struct {
+	Path string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Path, ErrConflict)
+}
+
+// Unwrap allows errors.Is(err, ErrConflict).
+func (e *ConflictError) Unwrap() error {
+	return ErrConflict
+}
+
+// Version identifies the contents of a file at the time it was read.
+// The zero Version means the file didn't exist.
+type Version struct {
+	Exists  bool
+	ModTime time.Time
+	Size    int64
+	Hash    string // SHA-256 of the contents, hex encoded
+}
+
+// ReadFile reads a file and returns its contents with their version. A missing
+// file is not an error: it returns nil data and the zero Version.
+func ReadFile(path string) ([]byte, Version, error) {
+	// #nosec G304 -- path is a vice data file chosen by the application
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, Version{}, nil
+	}
+	if err != nil {
+		return nil, Version{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, Version{}, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	return data, versionOf(data, info), nil
+}
+
+// CurrentVersion returns the version of the file currently on disk.
+func CurrentVersion(path string) (Version, error) {
+	_, version, err := ReadFile(path)
+	return version, err
+}
+
+// Unchanged reports whether the file on disk still matches version. A matching
+// modification time and size is taken as unchanged without reading the file;
+// otherwise the contents are hashed, so a rewrite with identical data still matches.
+func Unchanged(path string, version Version) (bool, error) {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return !version.Exists, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	if !version.Exists {
+		return false, nil
+	}
+	if info.ModTime().Equal(version.ModTime) && info.Size() == version.Size {
+		return true, nil
+	}
+
+	current, err := CurrentVersion(path)
+	if err != nil {
+		return false, err
+	}
+	return current.Exists && current.Hash == version.Hash, nil
+}
+
+// WriteFile atomically replaces path with data while holding its lock.
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	_, err := WriteFileIfUnchanged(path, data, perm, nil)
+	return err
+}
+
+// WriteFileIfUnchanged atomically replaces path with data while holding its lock,
+// but only if the file still matches expected (a nil expected skips the check).
+// It returns a *ConflictError if the file changed, and the new version on success.
+func WriteFileIfUnchanged(path string, data []byte, perm os.FileMode, expected *Version) (Version, error) {
+	unlock, err := Lock(path)
+	if err != nil {
+		return Version{}, err
+	}
+	defer unlock()
+
+	if expected != nil {
+		unchanged, err := Unchanged(path, *expected)
+		if err != nil {
+			return Version{}, err
+		}
+		if !unchanged {
+			return Version{}, &ConflictError{Path: path}
+		}
+	}
+
+	return WriteLocked(path, data, perm)
+}
+
+// WriteLocked atomically replaces path with data. The caller must hold the
+// file's lock (see Lock); taking it again from the same process would block.
+func WriteLocked(path string, data []byte, perm os.FileMode) (Version, error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return Version{}, fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	// Temp file in the same directory so the rename stays on one filesystem
+	temp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return Version{}, fmt.Errorf("failed to create temporary file for %s: %w", path, err)
+	}
+	tempPath := temp.Name()
+	cleanup := func() {
+		_ = temp.Close()
+		_ = os.Remove(tempPath) // Ignore error since we're already in error state
+	}
+
+	if _, err := temp.Write(data); err != nil {
+		cleanup()
+		return Version{}, fmt.Errorf("failed to write temporary file %s: %w", tempPath, err)
+	}
+	if err := temp.Chmod(perm); err != nil {
+		cleanup()
+		return Version{}, fmt.Errorf("failed to set permissions on %s: %w", tempPath, err)
+	}
+	if err := temp.Sync(); err != nil {
+		cleanup()
+		return Version{}, fmt.Errorf("failed to sync temporary file %s: %w", tempPath, err)
+	}
+	if err := temp.Close(); err != nil {
+		_ = os.Remove(tempPath)
+		return Version{}, fmt.Errorf("failed to close temporary file %s: %w", tempPath, err)
+	}
+
+	if err := os.Rename(tempPath, path); err != nil {
+		_ = os.Remove(tempPath)
+		return Version{}, fmt.Errorf("failed to rename temporary file to %s: %w", path, err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return Version{}, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	return versionOf(data, info), nil
+}
+
+// Lock takes the advisory lock for path, waiting up to LockTimeout for other
+// processes to release it. The returned function releases the lock.
+func Lock(path string) (func(), error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	lockPath := path + LockSuffix
+	// #nosec G304 -- lock file sits next to a vice data file
+	file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file %s: %w", lockPath, err)
+	}
+
+	deadline := time.Now().Add(LockTimeout)
+	for {
+		locked, err := tryLock(file)
+		if err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("failed to lock %s: %w", lockPath, err)
+		}
+		if locked {
+			break
+		}
+		if time.Now().After(deadline) {
+			_ = file.Close()
+			return nil, fmt.Errorf("timed out waiting for lock on %s (held by another vice process?)", path)
+		}
+		time.Sleep(25 * time.Millisecond)
+	}
+
+	return func() {
+		_ = unlock(file)
+		_ = file.Close()
+	}, nil
+}
+
+// versionOf builds the version of data as stat'ed by info.
+func versionOf(data []byte, info os.FileInfo) Version {
+	sum := sha256.Sum256(data)
+	return Version{
+		Exists:  true,
+		ModTime: info.ModTime(),
+		Size:    info.Size(),
+		Hash:    hex.EncodeToString(sum[:]),
+	}
+}
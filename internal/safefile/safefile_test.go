@@ -0,0 +1,111 @@
+package safefile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "nested", "habits.yml")
+
+	require.NoError(t, WriteFile(path, []byte("one\n"), 0o600))
+	data, version, err := ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "one\n", string(data))
+	assert.True(t, version.Exists)
+	assert.Equal(t, int64(4), version.Size)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// No temp files left behind
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.ElementsMatch(t, []string{"habits.yml", "habits.yml.lock"}, names)
+}
+
+func TestReadFile_Missing(t *testing.T) {
+	data, version, err := ReadFile(filepath.Join(t.TempDir(), "missing.yml"))
+	require.NoError(t, err)
+	assert.Nil(t, data)
+	assert.Equal(t, Version{}, version)
+}
+
+func TestWriteFileIfUnchanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "entries.yml")
+
+	t.Run("new file", func(t *testing.T) {
+		_, err := WriteFileIfUnchanged(path, []byte("one\n"), 0o600, &Version{})
+		require.NoError(t, err)
+	})
+
+	_, loaded, err := ReadFile(path)
+	require.NoError(t, err)
+
+	t.Run("unchanged", func(t *testing.T) {
+		written, err := WriteFileIfUnchanged(path, []byte("two\n"), 0o600, &loaded)
+		require.NoError(t, err)
+		loaded = written
+	})
+
+	t.Run("changed elsewhere", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte("three\n"), 0o600))
+		_, err := WriteFileIfUnchanged(path, []byte("four\n"), 0o600, &loaded)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrConflict))
+
+		data, _, err := ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "three\n", string(data))
+	})
+
+	t.Run("rewritten with the same contents", func(t *testing.T) {
+		_, stale, err := ReadFile(path)
+		require.NoError(t, err)
+		later := time.Now().Add(time.Hour)
+		require.NoError(t, os.Chtimes(path, later, later))
+
+		unchanged, err := Unchanged(path, stale)
+		require.NoError(t, err)
+		assert.True(t, unchanged)
+	})
+
+	t.Run("deleted elsewhere", func(t *testing.T) {
+		_, stale, err := ReadFile(path)
+		require.NoError(t, err)
+		require.NoError(t, os.Remove(path))
+		_, err = WriteFileIfUnchanged(path, []byte("five\n"), 0o600, &stale)
+		assert.ErrorIs(t, err, ErrConflict)
+	})
+}
+
+func TestLock_Timeout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "entries.yml")
+	timeout := LockTimeout
+	LockTimeout = 100 * time.Millisecond
+	defer func() { LockTimeout = timeout }()
+
+	unlock, err := Lock(path)
+	require.NoError(t, err)
+
+	_, err = Lock(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timed out waiting for lock")
+
+	unlock()
+	unlockAgain, err := Lock(path)
+	require.NoError(t, err)
+	unlockAgain()
+}
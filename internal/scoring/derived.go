@@ -0,0 +1,261 @@
+package scoring
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/davidlee/vice/internal/models"
+)
+
+// AIDEV-NOTE: derived-habits; derived entries are never entered by hand. RecomputeDerived
+// rewrites them from their sources, and storage calls it before every save (see
+// storage.EntryStorage.SetBeforeSave) so they follow any change to a source entry.
+
+const dateFormat = "2006-01-02"
+
+// ComputeDerived computes a derived habit's entry for the last day in days, which
+// must be the window of consecutive days ending on that day (missing days may be
+// omitted). Sources maps habit IDs to habits. Returns nil if no source habit has
+// an entry in the window.
+func (e *Engine) ComputeDerived(habit *models.Habit, sources map[string]*models.Habit, days []*models.DayEntry) (*models.HabitEntry, error) {
+	if habit == nil || habit.Derivation == nil {
+		return nil, fmt.Errorf("habit is not a derived habit")
+	}
+	derivation := habit.Derivation
+
+	var values []float64
+	completed, recorded := 0, 0
+	for _, day := range days {
+		if day == nil {
+			continue
+		}
+		for _, sourceID := range derivation.Sources {
+			entry, found := day.GetHabitEntry(sourceID)
+			if !found {
+				continue
+			}
+			recorded++
+			if entry.Status == models.EntryCompleted {
+				completed++
+			}
+			if derivation.Aggregate == models.AggregateCount || entry.Value == nil {
+				continue
+			}
+
+			source, known := sources[sourceID]
+			if !known {
+				return nil, fmt.Errorf("unknown source habit: %s", sourceID)
+			}
+			value, err := e.convertValueForEvaluation(entry.Value, source.FieldType.Type)
+			if err != nil {
+				return nil, fmt.Errorf("source %s on %s: %w", sourceID, day.Date, err)
+			}
+			number, ok := value.(float64)
+			if !ok {
+				return nil, fmt.Errorf("source %s on %s: %T values cannot be aggregated", sourceID, day.Date, value)
+			}
+			values = append(values, number)
+		}
+	}
+
+	if recorded == 0 {
+		return nil, nil
+	}
+
+	var result float64
+	switch derivation.Aggregate {
+	case models.AggregateCount:
+		result = float64(completed)
+	case models.AggregateSum, models.AggregateAverage, models.AggregateMin, models.AggregateMax:
+		if len(values) == 0 {
+			return nil, nil // Only skipped source entries
+		}
+		result = aggregate(derivation.Aggregate, values)
+	default:
+		return nil, fmt.Errorf("unsupported aggregate: %s", derivation.Aggregate)
+	}
+
+	entry := &models.HabitEntry{
+		HabitID: habit.ID,
+		Value:   formatDerivedValue(result, habit.FieldType.Type),
+		Status:  models.EntryCompleted,
+	}
+
+	if habit.Criteria != nil {
+		met, err := e.evaluateCriteria(result, habit.Criteria, habit.FieldType.Type, nil)
+		if err != nil {
+			return nil, err
+		}
+		level := models.AchievementNone
+		if met {
+			level = models.AchievementMini
+		} else {
+			entry.Status = models.EntryFailed
+		}
+		entry.AchievementLevel = &level
+	}
+	return entry, nil
+}
+
+// RecomputeDerived rewrites the derived habits' entries on every day of the log
+// from their sources. Unchanged entries keep their timestamps; entries whose
+// sources are all gone are removed. Returns the number of entries changed.
+func (e *Engine) RecomputeDerived(schema *models.Schema, entryLog *models.EntryLog) (int, error) {
+	derived, err := schema.DerivedHabitOrder()
+	if err != nil || len(derived) == 0 {
+		return 0, err
+	}
+
+	sources := make(map[string]*models.Habit, len(schema.Habits))
+	for i := range schema.Habits {
+		sources[schema.Habits[i].ID] = &schema.Habits[i]
+	}
+
+	days := make(map[string]*models.DayEntry, len(entryLog.Entries))
+	dates := make([]string, 0, len(entryLog.Entries))
+	for i := range entryLog.Entries {
+		days[entryLog.Entries[i].Date] = &entryLog.Entries[i]
+		dates = append(dates, entryLog.Entries[i].Date)
+	}
+	sort.Strings(dates)
+
+	changed := 0
+	for _, habit := range derived {
+		for _, date := range dates {
+			window, err := derivationWindow(days, date, habit.Derivation.WindowDays())
+			if err != nil {
+				return changed, err
+			}
+			entry, err := e.ComputeDerived(habit, sources, window)
+			if err != nil {
+				return changed, fmt.Errorf("failed to compute %s on %s: %w", habit.ID, date, err)
+			}
+
+			day := days[date]
+			existing, found := day.GetHabitEntry(habit.ID)
+			switch {
+			case entry == nil:
+				if day.RemoveHabitEntry(habit.ID) {
+					changed++
+				}
+				continue
+			case !found:
+				entry.MarkCreated()
+			case sameDerivedEntry(existing, entry):
+				continue
+			default:
+				entry.CreatedAt = existing.CreatedAt
+				entry.Notes = existing.Notes
+				entry.MarkUpdated()
+			}
+			if err := day.UpdateHabitEntry(*entry); err != nil {
+				return changed, fmt.Errorf("failed to update %s on %s: %w", habit.ID, date, err)
+			}
+			changed++
+		}
+	}
+	return changed, nil
+}
+
+// RecomputeHook returns a function recomputing derived habits, for use as a
+// storage before-save hook. It's a no-op for schemas without derived habits.
+func (e *Engine) RecomputeHook(schema *models.Schema) func(*models.EntryLog) error {
+	return func(entryLog *models.EntryLog) error {
+		if _, err := e.RecomputeDerived(schema, entryLog); err != nil {
+			return fmt.Errorf("failed to recompute derived habits: %w", err)
+		}
+		return nil
+	}
+}
+
+// derivationWindow returns the days of the window ending on date, oldest first.
+func derivationWindow(days map[string]*models.DayEntry, date string, size int) ([]*models.DayEntry, error) {
+	end, err := time.Parse(dateFormat, date)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q: %w", date, err)
+	}
+	window := make([]*models.DayEntry, 0, size)
+	for offset := size - 1; offset >= 0; offset-- {
+		if day, found := days[end.AddDate(0, 0, -offset).Format(dateFormat)]; found {
+			window = append(window, day)
+		}
+	}
+	return window, nil
+}
+
+func aggregate(kind string, values []float64) float64 {
+	result := values[0]
+	switch kind {
+	case models.AggregateSum, models.AggregateAverage:
+		for _, value := range values[1:] {
+			result += value
+		}
+		if kind == models.AggregateAverage {
+			result /= float64(len(values))
+		}
+	case models.AggregateMin:
+		for _, value := range values[1:] {
+			result = math.Min(result, value)
+		}
+	case models.AggregateMax:
+		for _, value := range values[1:] {
+			result = math.Max(result, value)
+		}
+	case models.AggregateMedian:
+		sorted := append([]float64{}, values...)
+		sort.Float64s(sorted)
+		middle := len(sorted) / 2
+		result = sorted[middle]
+		if len(sorted)%2 == 0 {
+			result = (sorted[middle-1] + sorted[middle]) / 2
+		}
+	}
+	return result
+}
+
+// formatDerivedValue stores a computed number as its field type stores values:
+// a float64, a duration string, or "HH:MM".
+func formatDerivedValue(value float64, fieldType string) interface{} {
+	switch fieldType {
+	case models.DurationFieldType:
+		duration := time.Duration(math.Round(value*60)) * time.Second
+		formatted := duration.String()
+		if strings.HasSuffix(formatted, "m0s") {
+			formatted = strings.TrimSuffix(formatted, "0s")
+		}
+		if strings.HasSuffix(formatted, "h0m") {
+			formatted = strings.TrimSuffix(formatted, "0m")
+		}
+		return formatted
+	case models.TimeFieldType:
+		minutes := int(math.Round(value)) % (24 * 60)
+		return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+	case models.UnsignedIntFieldType:
+		return math.Round(value)
+	default:
+		return value
+	}
+}
+
+// sameDerivedEntry reports whether a stored entry already records a computed result.
+// Values are compared by their string form since stored values lose their Go type.
+func sameDerivedEntry(existing, computed *models.HabitEntry) bool {
+	if existing.Status != computed.Status {
+		return false
+	}
+	if (existing.AchievementLevel == nil) != (computed.AchievementLevel == nil) ||
+		(existing.AchievementLevel != nil && *existing.AchievementLevel != *computed.AchievementLevel) {
+		return false
+	}
+	return formatStoredValue(existing.Value) == formatStoredValue(computed.Value)
+}
+
+func formatStoredValue(value interface{}) string {
+	if t, ok := value.(time.Time); ok {
+		return t.Format("15:04")
+	}
+	return fmt.Sprintf("%v", value)
+}
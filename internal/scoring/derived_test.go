@@ -0,0 +1,157 @@
+package scoring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidlee/vice/internal/models"
+)
+
+func derivedTestSchema(t *testing.T) *models.Schema {
+	t.Helper()
+	atLeast := 2.0
+	schema := &models.Schema{Version: "1.0.0", Habits: []models.Habit{
+		{Title: "Focus AM", HabitType: models.InformationalHabit, FieldType: models.FieldType{Type: models.DurationFieldType}},
+		{Title: "Focus PM", HabitType: models.InformationalHabit, FieldType: models.FieldType{Type: models.DurationFieldType}},
+		{Title: "Walk", HabitType: models.SimpleHabit, ScoringType: models.ManualScoring, FieldType: models.FieldType{Type: models.BooleanFieldType}},
+		{
+			Title:      "Deep Work",
+			HabitType:  models.DerivedHabit,
+			FieldType:  models.FieldType{Type: models.DurationFieldType},
+			Derivation: &models.Derivation{Aggregate: models.AggregateSum, Sources: []string{"focus_am", "focus_pm"}},
+		},
+		{
+			Title:       "Good Day",
+			HabitType:   models.DerivedHabit,
+			FieldType:   models.FieldType{Type: models.UnsignedIntFieldType},
+			ScoringType: models.AutomaticScoring,
+			Derivation:  &models.Derivation{Aggregate: models.AggregateCount, Sources: []string{"walk", "deep_work"}},
+			Criteria:    &models.Criteria{Condition: &models.Condition{GreaterThanOrEqual: &atLeast}},
+		},
+		{
+			Title:      "Weekly Deep Work",
+			HabitType:  models.DerivedHabit,
+			FieldType:  models.FieldType{Type: models.DurationFieldType},
+			Derivation: &models.Derivation{Aggregate: models.AggregateSum, Sources: []string{"deep_work"}, Window: 7},
+		},
+	}}
+	require.NoError(t, schema.Validate())
+	return schema
+}
+
+func TestEngine_ComputeDerived(t *testing.T) {
+	engine := NewEngine()
+	habits := map[string]*models.Habit{
+		"a": {ID: "a", FieldType: models.FieldType{Type: models.DecimalFieldType}},
+		"b": {ID: "b", FieldType: models.FieldType{Type: models.DecimalFieldType}},
+		"c": {ID: "c", FieldType: models.FieldType{Type: models.DecimalFieldType}},
+	}
+	day := &models.DayEntry{Date: "2025-07-14", Habits: []models.HabitEntry{
+		{HabitID: "a", Value: 2.0, Status: models.EntryCompleted},
+		{HabitID: "b", Value: 7.0, Status: models.EntryFailed},
+		{HabitID: "c", Status: models.EntrySkipped},
+	}}
+
+	tests := []struct {
+		aggregate string
+		expected  float64
+	}{
+		{models.AggregateSum, 9},
+		{models.AggregateAverage, 4.5},
+		{models.AggregateMin, 2},
+		{models.AggregateMax, 7},
+		{models.AggregateCount, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.aggregate, func(t *testing.T) {
+			habit := &models.Habit{
+				ID:         "derived",
+				FieldType:  models.FieldType{Type: models.DecimalFieldType},
+				Derivation: &models.Derivation{Aggregate: tt.aggregate, Sources: []string{"a", "b", "c"}},
+			}
+			entry, err := engine.ComputeDerived(habit, habits, []*models.DayEntry{day})
+			require.NoError(t, err)
+			require.NotNil(t, entry)
+			assert.Equal(t, tt.expected, entry.Value)
+			assert.Equal(t, models.EntryCompleted, entry.Status)
+		})
+	}
+
+	t.Run("no source entries", func(t *testing.T) {
+		habit := &models.Habit{
+			ID:         "derived",
+			FieldType:  models.FieldType{Type: models.DecimalFieldType},
+			Derivation: &models.Derivation{Aggregate: models.AggregateSum, Sources: []string{"d"}},
+		}
+		entry, err := engine.ComputeDerived(habit, habits, []*models.DayEntry{day})
+		require.NoError(t, err)
+		assert.Nil(t, entry)
+	})
+}
+
+func TestEngine_RecomputeDerived(t *testing.T) {
+	engine := NewEngine()
+	schema := derivedTestSchema(t)
+
+	entryLog := &models.EntryLog{Version: "1.0.0", Entries: []models.DayEntry{
+		{Date: "2025-07-13", Habits: []models.HabitEntry{
+			{HabitID: "focus_am", Value: "30m", Status: models.EntryCompleted},
+		}},
+		{Date: "2025-07-14", Habits: []models.HabitEntry{
+			{HabitID: "focus_am", Value: "1h", Status: models.EntryCompleted},
+			{HabitID: "focus_pm", Value: "45m", Status: models.EntryCompleted},
+			{HabitID: "walk", Value: true, Status: models.EntryCompleted},
+		}},
+	}}
+
+	changed, err := engine.RecomputeDerived(schema, entryLog)
+	require.NoError(t, err)
+	assert.Equal(t, 6, changed)
+
+	day, _ := entryLog.GetDayEntry("2025-07-14")
+	deepWork, found := day.GetHabitEntry("deep_work")
+	require.True(t, found)
+	assert.Equal(t, "1h45m", deepWork.Value)
+	assert.False(t, deepWork.CreatedAt.IsZero())
+
+	goodDay, _ := day.GetHabitEntry("good_day")
+	assert.Equal(t, float64(2), goodDay.Value)
+	assert.Equal(t, models.EntryCompleted, goodDay.Status)
+	assert.Equal(t, models.AchievementMini, *goodDay.AchievementLevel)
+
+	weekly, _ := day.GetHabitEntry("weekly_deep_work")
+	assert.Equal(t, "2h15m", weekly.Value)
+
+	previous, _ := entryLog.GetDayEntry("2025-07-13")
+	goodDay, _ = previous.GetHabitEntry("good_day")
+	assert.Equal(t, models.EntryFailed, goodDay.Status)
+
+	t.Run("unchanged entries are left alone", func(t *testing.T) {
+		changed, err := engine.RecomputeDerived(schema, entryLog)
+		require.NoError(t, err)
+		assert.Equal(t, 0, changed)
+	})
+
+	t.Run("source changes update derived entries", func(t *testing.T) {
+		require.NoError(t, day.UpdateHabitEntry(models.HabitEntry{HabitID: "focus_pm", Value: "1h", Status: models.EntryCompleted, CreatedAt: deepWork.CreatedAt}))
+		_, err := engine.RecomputeDerived(schema, entryLog)
+		require.NoError(t, err)
+
+		deepWork, _ := day.GetHabitEntry("deep_work")
+		assert.Equal(t, "2h", deepWork.Value)
+		assert.NotNil(t, deepWork.UpdatedAt)
+	})
+
+	t.Run("removing sources removes derived entries", func(t *testing.T) {
+		previous.RemoveHabitEntry("focus_am")
+		_, err := engine.RecomputeDerived(schema, entryLog)
+		require.NoError(t, err)
+
+		_, found := previous.GetHabitEntry("deep_work")
+		assert.False(t, found)
+		assert.Empty(t, previous.Habits)
+	})
+}
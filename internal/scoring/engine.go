@@ -3,6 +3,7 @@ package scoring
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -11,7 +12,10 @@ import (
 )
 
 // Engine handles scoring of habit entries against elastic habit criteria.
-type Engine struct{}
+type Engine struct {
+	history EntryHistory // Earlier entries, for window conditions
+	date    time.Time    // Day being scored; zero means today
+}
 
 // NewEngine creates a new scoring engine instance.
 func NewEngine() *Engine {
@@ -56,13 +60,18 @@ func (e *Engine) ScoreSimpleHabit(habit *models.Habit, value interface{}) (*Scor
 	}
 
 	// Convert value to appropriate type for evaluation
-	evaluationValue, err := e.convertValueForEvaluation(value, habit.FieldType.Type)
+	evaluationValue, err := e.convertHabitValue(value, habit.FieldType)
+	if err != nil {
+		return nil, err
+	}
+
+	scope, err := e.newWindowScope(habit)
 	if err != nil {
 		return nil, err
 	}
 
 	// Evaluate against the single criteria
-	met, err := e.evaluateCriteria(evaluationValue, habit.Criteria, habit.FieldType.Type)
+	met, err := e.evaluateCriteria(evaluationValue, habit.Criteria, habit.FieldType.Type, scope)
 	if err != nil {
 		return nil, err
 	}
@@ -100,14 +109,19 @@ func (e *Engine) ScoreElasticHabit(habit *models.Habit, value interface{}) (*Sco
 	}
 
 	// Convert value to appropriate type for evaluation
-	evaluationValue, err := e.convertValueForEvaluation(value, habit.FieldType.Type)
+	evaluationValue, err := e.convertHabitValue(value, habit.FieldType)
+	if err != nil {
+		return nil, err
+	}
+
+	scope, err := e.newWindowScope(habit)
 	if err != nil {
 		return nil, err
 	}
 
 	// Evaluate against each criteria level
 	if habit.MiniCriteria != nil {
-		met, err := e.evaluateCriteria(evaluationValue, habit.MiniCriteria, habit.FieldType.Type)
+		met, err := e.evaluateCriteria(evaluationValue, habit.MiniCriteria, habit.FieldType.Type, scope)
 		if err != nil {
 			return nil, err
 		}
@@ -118,7 +132,7 @@ func (e *Engine) ScoreElasticHabit(habit *models.Habit, value interface{}) (*Sco
 	}
 
 	if habit.MidiCriteria != nil {
-		met, err := e.evaluateCriteria(evaluationValue, habit.MidiCriteria, habit.FieldType.Type)
+		met, err := e.evaluateCriteria(evaluationValue, habit.MidiCriteria, habit.FieldType.Type, scope)
 		if err != nil {
 			return nil, err
 		}
@@ -129,7 +143,7 @@ func (e *Engine) ScoreElasticHabit(habit *models.Habit, value interface{}) (*Sco
 	}
 
 	if habit.MaxiCriteria != nil {
-		met, err := e.evaluateCriteria(evaluationValue, habit.MaxiCriteria, habit.FieldType.Type)
+		met, err := e.evaluateCriteria(evaluationValue, habit.MaxiCriteria, habit.FieldType.Type, scope)
 		if err != nil {
 			return nil, err
 		}
@@ -142,6 +156,56 @@ func (e *Engine) ScoreElasticHabit(habit *models.Habit, value interface{}) (*Sco
 	return result, nil
 }
 
+// compositeValue is a composite entry's sub-field values, converted for evaluation.
+type compositeValue struct {
+	values     map[string]interface{} // by sub-field ID; unrecorded sub-fields are absent
+	fieldTypes map[string]string
+}
+
+// convertHabitValue converts a habit's value for evaluation, converting each
+// sub-field of a composite value by its own type.
+func (e *Engine) convertHabitValue(value interface{}, fieldType models.FieldType) (interface{}, error) {
+	if fieldType.Type != models.CompositeFieldType {
+		return e.convertValueForEvaluation(value, fieldType.Type)
+	}
+
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("cannot convert %T to composite value", value)
+	}
+	composite := compositeValue{
+		values:     make(map[string]interface{}),
+		fieldTypes: make(map[string]string),
+	}
+	for _, subField := range fieldType.Fields {
+		composite.fieldTypes[subField.ID] = subField.FieldType.Type
+		fieldValue, found := fields[subField.ID]
+		if !found || fieldValue == nil {
+			continue
+		}
+		converted, err := e.convertValueForEvaluation(fieldValue, subField.FieldType.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", subField.ID, err)
+		}
+		composite.values[subField.ID] = converted
+	}
+	return composite, nil
+}
+
+// NumericValue converts a stored value to the number criteria compare: the value
+// itself, or minutes for durations and times.
+func (e *Engine) NumericValue(value interface{}, fieldType string) (float64, error) {
+	converted, err := e.convertValueForEvaluation(value, fieldType)
+	if err != nil {
+		return 0, err
+	}
+	number, ok := converted.(float64)
+	if !ok {
+		return 0, fmt.Errorf("%s values are not numeric", fieldType)
+	}
+	return number, nil
+}
+
 // convertValueForEvaluation converts the input value to the appropriate type for evaluation.
 func (e *Engine) convertValueForEvaluation(value interface{}, fieldType string) (interface{}, error) {
 	if value == nil {
@@ -166,14 +230,107 @@ func (e *Engine) convertValueForEvaluation(value interface{}, fieldType string)
 	}
 }
 
-// evaluateCriteria evaluates a value against specific criteria.
-func (e *Engine) evaluateCriteria(value interface{}, criteria *models.Criteria, fieldType string) (bool, error) {
+// evaluateCriteria evaluates a value against specific criteria. Scope is nil
+// unless the criteria have window conditions.
+func (e *Engine) evaluateCriteria(value interface{}, criteria *models.Criteria, fieldType string, scope *windowScope) (bool, error) {
 	if criteria == nil || criteria.Condition == nil {
 		return false, fmt.Errorf("criteria or condition cannot be nil")
 	}
 
-	condition := criteria.Condition
+	return e.evaluateCondition(value, criteria.Condition, fieldType, scope)
+}
+
+// evaluateCondition evaluates a condition and its nested logical conditions recursively.
+// Everything set on one condition must hold: its comparison operators, every condition
+// under and, at least one under or, and the negation of not.
+// AIDEV-NOTE: condition-logic; a condition with only logical operators skips the comparison
+// check, so an empty text condition inside not/and/or doesn't fall back to "non-empty"
+func (e *Engine) evaluateCondition(value interface{}, condition *models.Condition, fieldType string, scope *windowScope) (bool, error) {
+	if condition == nil {
+		return false, fmt.Errorf("condition cannot be nil")
+	}
+
+	// A named sub-field scopes the condition and its nested conditions
+	if composite, ok := value.(compositeValue); ok {
+		if condition.Field != "" {
+			subFieldType, known := composite.fieldTypes[condition.Field]
+			if !known {
+				return false, fmt.Errorf("unknown field: %s", condition.Field)
+			}
+			subValue, recorded := composite.values[condition.Field]
+			if !recorded {
+				return false, nil
+			}
+			value, fieldType = subValue, subFieldType
+		} else if condition.HasComparisons() || !condition.HasLogicalOperators() {
+			return false, fmt.Errorf("conditions on composite habits must name a field")
+		}
+	}
+
+	switch {
+	case condition.Window != nil:
+		// The window's aggregate replaces the value for this condition's comparisons only
+		windowed, found, err := e.windowValue(value, condition.Window, scope, fieldType)
+		if err != nil || !found {
+			return false, err
+		}
+		comparedType := fieldType
+		if condition.Window.ComparesNumber() {
+			comparedType = models.DecimalFieldType
+		}
+		met, err := e.evaluateComparisons(windowed, condition, comparedType)
+		if err != nil || !met {
+			return false, err
+		}
+	case condition.HasComparisons() || !condition.HasLogicalOperators():
+		met, err := e.evaluateComparisons(value, condition, fieldType)
+		if err != nil || !met {
+			return false, err
+		}
+	}
+
+	for i := range condition.And {
+		met, err := e.evaluateCondition(value, &condition.And[i], fieldType, scope)
+		if err != nil {
+			return false, fmt.Errorf("and[%d]: %w", i, err)
+		}
+		if !met {
+			return false, nil
+		}
+	}
+
+	if len(condition.Or) > 0 {
+		anyMet := false
+		for i := range condition.Or {
+			met, err := e.evaluateCondition(value, &condition.Or[i], fieldType, scope)
+			if err != nil {
+				return false, fmt.Errorf("or[%d]: %w", i, err)
+			}
+			if met {
+				anyMet = true
+				break
+			}
+		}
+		if !anyMet {
+			return false, nil
+		}
+	}
+
+	if condition.Not != nil {
+		met, err := e.evaluateCondition(value, condition.Not, fieldType, scope)
+		if err != nil {
+			return false, fmt.Errorf("not: %w", err)
+		}
+		if met {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
 
+// evaluateComparisons evaluates the comparison operators of a single condition.
+func (e *Engine) evaluateComparisons(value interface{}, condition *models.Condition, fieldType string) (bool, error) {
 	switch fieldType {
 	case models.UnsignedIntFieldType, models.UnsignedDecimalFieldType, models.DecimalFieldType, models.DurationFieldType:
 		return e.evaluateNumericCondition(value, condition)
@@ -189,34 +346,45 @@ func (e *Engine) evaluateCriteria(value interface{}, criteria *models.Criteria,
 }
 
 // evaluateNumericCondition evaluates numeric values against numeric conditions.
+// All operators present must be met.
 func (e *Engine) evaluateNumericCondition(value interface{}, condition *models.Condition) (bool, error) {
 	numValue, ok := value.(float64)
 	if !ok {
 		return false, fmt.Errorf("expected numeric value, got %T", value)
 	}
 
-	// Check greater than
-	if condition.GreaterThan != nil {
-		return numValue > *condition.GreaterThan, nil
+	met, found := e.compareNumeric(numValue, condition)
+	if !found {
+		return false, fmt.Errorf("no valid numeric condition found")
 	}
+	return met, nil
+}
+
+// compareNumeric checks a number against every numeric operator of a condition.
+// Returns whether all were met, and whether there were any.
+func (e *Engine) compareNumeric(numValue float64, condition *models.Condition) (met, found bool) {
+	met = true
 
-	// Check greater than or equal
+	if condition.GreaterThan != nil {
+		found = true
+		met = met && numValue > *condition.GreaterThan
+	}
 	if condition.GreaterThanOrEqual != nil {
-		return numValue >= *condition.GreaterThanOrEqual, nil
+		found = true
+		met = met && numValue >= *condition.GreaterThanOrEqual
 	}
-
-	// Check less than
 	if condition.LessThan != nil {
-		return numValue < *condition.LessThan, nil
+		found = true
+		met = met && numValue < *condition.LessThan
 	}
-
-	// Check less than or equal
 	if condition.LessThanOrEqual != nil {
-		return numValue <= *condition.LessThanOrEqual, nil
+		found = true
+		met = met && numValue <= *condition.LessThanOrEqual
 	}
 
 	// Check range
 	if condition.Range != nil {
+		found = true
 		minInclusive := true
 		maxInclusive := true
 		if condition.Range.MinInclusive != nil {
@@ -239,26 +407,31 @@ func (e *Engine) evaluateNumericCondition(value interface{}, condition *models.C
 			maxMet = numValue < condition.Range.Max
 		}
 
-		return minMet && maxMet, nil
+		met = met && minMet && maxMet
 	}
 
-	return false, fmt.Errorf("no valid numeric condition found")
+	return met, found
 }
 
 // evaluateTimeCondition evaluates time values against time conditions.
+// Before and after may be combined with each other and with numeric operators (in minutes).
 func (e *Engine) evaluateTimeCondition(value interface{}, condition *models.Condition) (bool, error) {
 	timeValue, ok := value.(float64) // Time converted to minutes since midnight
 	if !ok {
 		return false, fmt.Errorf("expected time value as minutes, got %T", value)
 	}
 
+	met := true
+	found := false
+
 	// Handle before/after time constraints
 	if condition.Before != "" {
 		beforeMinutes, err := e.parseTimeToMinutes(condition.Before)
 		if err != nil {
 			return false, fmt.Errorf("invalid before time: %w", err)
 		}
-		return timeValue < beforeMinutes, nil
+		found = true
+		met = met && timeValue < beforeMinutes
 	}
 
 	if condition.After != "" {
@@ -266,11 +439,17 @@ func (e *Engine) evaluateTimeCondition(value interface{}, condition *models.Cond
 		if err != nil {
 			return false, fmt.Errorf("invalid after time: %w", err)
 		}
-		return timeValue > afterMinutes, nil
+		found = true
+		met = met && timeValue > afterMinutes
+	}
+
+	// Numeric operators compare minutes since midnight
+	numericMet, numericFound := e.compareNumeric(timeValue, condition)
+	if !found && !numericFound {
+		return false, fmt.Errorf("no valid time condition found")
 	}
 
-	// Fall back to numeric evaluation for other operators
-	return e.evaluateNumericCondition(value, condition)
+	return met && numericMet, nil
 }
 
 // evaluateBooleanCondition evaluates boolean values against boolean conditions.
@@ -288,34 +467,69 @@ func (e *Engine) evaluateBooleanCondition(value interface{}, condition *models.C
 }
 
 // evaluateTextCondition evaluates text values against text conditions.
+// All operators present must be met; numeric operators compare the text's length.
 func (e *Engine) evaluateTextCondition(value interface{}, condition *models.Condition) (bool, error) {
-	// For text fields, we primarily support logical operators and length-based comparisons
 	textValue, ok := value.(string)
 	if !ok {
 		return false, fmt.Errorf("expected string value, got %T", value)
 	}
 
-	// For text, we can evaluate length as a numeric comparison
-	textLength := float64(len(textValue))
+	met, found := e.compareNumeric(float64(len(textValue)), condition)
 
-	// Check if any numeric operators are defined (treating them as length comparisons)
-	if condition.GreaterThan != nil {
-		return textLength > *condition.GreaterThan, nil
+	if condition.Matches != "" {
+		found = true
+		re, err := regexp.Compile(condition.Matches)
+		if err != nil {
+			return false, fmt.Errorf("invalid matches pattern: %w", err)
+		}
+		met = met && re.MatchString(textValue)
 	}
-	if condition.GreaterThanOrEqual != nil {
-		return textLength >= *condition.GreaterThanOrEqual, nil
+
+	lowerText := strings.ToLower(textValue)
+	if len(condition.ContainsAny) > 0 {
+		found = true
+		anyFound := false
+		for _, keyword := range condition.ContainsAny {
+			if strings.Contains(lowerText, strings.ToLower(keyword)) {
+				anyFound = true
+				break
+			}
+		}
+		met = met && anyFound
 	}
-	if condition.LessThan != nil {
-		return textLength < *condition.LessThan, nil
+	for _, keyword := range condition.ContainsAll {
+		found = true
+		met = met && strings.Contains(lowerText, strings.ToLower(keyword))
 	}
-	if condition.LessThanOrEqual != nil {
-		return textLength <= *condition.LessThanOrEqual, nil
+
+	if condition.MinWords != nil {
+		found = true
+		met = met && len(strings.Fields(textValue)) >= *condition.MinWords
+	}
+	if condition.MinLines != nil {
+		found = true
+		met = met && countNonBlankLines(textValue) >= *condition.MinLines
+	}
+
+	if found {
+		return met, nil
 	}
 
 	// For text fields without specific criteria, assume any non-empty text meets the criteria
 	return len(strings.TrimSpace(textValue)) > 0, nil
 }
 
+// countNonBlankLines counts lines with any non-whitespace content.
+func countNonBlankLines(text string) int {
+	count := 0
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) != "" {
+			count++
+		}
+	}
+	return count
+}
+
 // Helper conversion functions
 
 func (e *Engine) convertToFloat64(value interface{}) (float64, error) {
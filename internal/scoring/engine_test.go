@@ -426,6 +426,193 @@ func TestEngine_ParseTimeToMinutes(t *testing.T) {
 	}
 }
 
+func TestEngine_EvaluateLogicalConditions(t *testing.T) {
+	engine := NewEngine()
+	f := func(v float64) *float64 { return &v }
+	b := func(v bool) *bool { return &v }
+
+	tests := []struct {
+		name      string
+		fieldType string
+		value     interface{}
+		condition models.Condition
+		expected  bool
+	}{
+		{
+			name:      "time window with and: inside",
+			fieldType: models.TimeFieldType,
+			value:     "07:30",
+			condition: models.Condition{And: []models.Condition{{After: "06:00"}, {Before: "09:00"}}},
+			expected:  true,
+		},
+		{
+			name:      "time window with and: outside",
+			fieldType: models.TimeFieldType,
+			value:     "09:30",
+			condition: models.Condition{And: []models.Condition{{After: "06:00"}, {Before: "09:00"}}},
+			expected:  false,
+		},
+		{
+			name:      "operators on one condition are combined",
+			fieldType: models.TimeFieldType,
+			value:     "05:30",
+			condition: models.Condition{After: "06:00", Before: "09:00"},
+			expected:  false,
+		},
+		{
+			name:      "duration with or: second branch",
+			fieldType: models.DurationFieldType,
+			value:     "2h",
+			condition: models.Condition{Or: []models.Condition{{LessThan: f(10)}, {GreaterThanOrEqual: f(90)}}},
+			expected:  true,
+		},
+		{
+			name:      "duration with or: no branch",
+			fieldType: models.DurationFieldType,
+			value:     "30m",
+			condition: models.Condition{Or: []models.Condition{{LessThan: f(10)}, {GreaterThanOrEqual: f(90)}}},
+			expected:  false,
+		},
+		{
+			name:      "numeric not range",
+			fieldType: models.UnsignedIntFieldType,
+			value:     12.0,
+			condition: models.Condition{Not: &models.Condition{Range: &models.RangeCondition{Min: 5, Max: 10}}},
+			expected:  true,
+		},
+		{
+			name:      "comparison and nested or",
+			fieldType: models.DecimalFieldType,
+			value:     -3.0,
+			condition: models.Condition{
+				GreaterThan: f(-5),
+				Or:          []models.Condition{{LessThan: f(0)}, {GreaterThan: f(100)}},
+			},
+			expected: true,
+		},
+		{
+			name:      "boolean not",
+			fieldType: models.BooleanFieldType,
+			value:     false,
+			condition: models.Condition{Not: &models.Condition{Equals: b(true)}},
+			expected:  true,
+		},
+		{
+			name:      "text length and",
+			fieldType: models.TextFieldType,
+			value:     "a short note",
+			condition: models.Condition{And: []models.Condition{{GreaterThan: f(5)}, {LessThan: f(10)}}},
+			expected:  false,
+		},
+		{
+			name:      "deeply nested",
+			fieldType: models.UnsignedIntFieldType,
+			value:     7.0,
+			condition: models.Condition{Or: []models.Condition{
+				{And: []models.Condition{{GreaterThan: f(5)}, {Not: &models.Condition{GreaterThan: f(8)}}}},
+				{GreaterThan: f(100)},
+			}},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := engine.convertValueForEvaluation(tt.value, tt.fieldType)
+			require.NoError(t, err)
+			criteria := &models.Criteria{Condition: &tt.condition}
+			result, err := engine.evaluateCriteria(value, criteria, tt.fieldType, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	t.Run("nested condition errors are reported", func(t *testing.T) {
+		condition := &models.Condition{And: []models.Condition{{GreaterThan: f(1)}, {}}}
+		_, err := engine.evaluateCriteria(5.0, &models.Criteria{Condition: condition}, models.UnsignedIntFieldType, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "and[1]: no valid numeric condition found")
+	})
+
+	t.Run("elastic habit with compound criteria", func(t *testing.T) {
+		habit := &models.Habit{
+			ID:           "early_run",
+			HabitType:    models.ElasticHabit,
+			FieldType:    models.FieldType{Type: models.DurationFieldType},
+			ScoringType:  models.AutomaticScoring,
+			MiniCriteria: &models.Criteria{Condition: &models.Condition{GreaterThanOrEqual: f(15)}},
+			MidiCriteria: &models.Criteria{Condition: &models.Condition{
+				And: []models.Condition{{GreaterThanOrEqual: f(30)}, {LessThan: f(120)}},
+			}},
+			MaxiCriteria: &models.Criteria{Condition: &models.Condition{Range: &models.RangeCondition{Min: 60, Max: 90}}},
+		}
+
+		result, err := engine.ScoreElasticHabit(habit, "45m")
+		require.NoError(t, err)
+		assert.Equal(t, models.AchievementMidi, result.AchievementLevel)
+
+		result, err = engine.ScoreElasticHabit(habit, "3h")
+		require.NoError(t, err)
+		assert.Equal(t, models.AchievementMini, result.AchievementLevel)
+	})
+}
+
+func TestEngine_EvaluateTextCriteria(t *testing.T) {
+	engine := NewEngine()
+	n := func(v int) *int { return &v }
+	f := func(v float64) *float64 { return &v }
+	gratitudes := "Grateful for coffee\n\nthe sunny walk\nand a call with Sam\n"
+
+	tests := []struct {
+		name      string
+		value     string
+		condition models.Condition
+		expected  bool
+	}{
+		{"matches", "Done: 3 pages", models.Condition{Matches: `^Done: \d+`}, true},
+		{"matches is case-sensitive", "done: 3 pages", models.Condition{Matches: `^Done`}, false},
+		{"contains any", gratitudes, models.Condition{ContainsAny: []string{"thankful", "GRATEFUL"}}, true},
+		{"contains any: none", gratitudes, models.Condition{ContainsAny: []string{"thankful"}}, false},
+		{"contains all", gratitudes, models.Condition{ContainsAll: []string{"coffee", "walk"}}, true},
+		{"contains all: one missing", gratitudes, models.Condition{ContainsAll: []string{"coffee", "tea"}}, false},
+		{"min words", gratitudes, models.Condition{MinWords: n(11)}, true},
+		{"min words: too few", "one two", models.Condition{MinWords: n(3)}, false},
+		{"min lines skips blank lines", gratitudes, models.Condition{MinLines: n(3)}, true},
+		{"min lines: too few", gratitudes, models.Condition{MinLines: n(4)}, false},
+		{"combined with length", gratitudes, models.Condition{MinLines: n(3), LessThan: f(20)}, false},
+		{"not contains", "skipped the gym", models.Condition{Not: &models.Condition{ContainsAny: []string{"skipped"}}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := engine.evaluateCondition(tt.value, &tt.condition, models.TextFieldType, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	t.Run("elastic gratitudes", func(t *testing.T) {
+		habit := &models.Habit{
+			ID:           "gratitude",
+			HabitType:    models.ElasticHabit,
+			FieldType:    models.FieldType{Type: models.TextFieldType},
+			ScoringType:  models.AutomaticScoring,
+			MiniCriteria: &models.Criteria{Condition: &models.Condition{MinLines: n(1)}},
+			MidiCriteria: &models.Criteria{Condition: &models.Condition{MinLines: n(2)}},
+			MaxiCriteria: &models.Criteria{Condition: &models.Condition{MinLines: n(3)}},
+		}
+
+		result, err := engine.ScoreElasticHabit(habit, "coffee\nsunshine")
+		require.NoError(t, err)
+		assert.Equal(t, models.AchievementMidi, result.AchievementLevel)
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, err := engine.evaluateTextCondition("x", &models.Condition{Matches: "("})
+		assert.Error(t, err)
+	})
+}
+
 // Helper functions for testing
 
 func createTestElasticHabit(fieldType string, mini, midi, maxi float64) *models.Habit {
@@ -516,6 +703,69 @@ func createTestSimpleHabit(fieldType string, threshold float64) models.Habit {
 	}
 }
 
+func TestEngine_ScoreCompositeHabit(t *testing.T) {
+	engine := NewEngine()
+	f := func(v float64) *float64 { return &v }
+
+	run := models.Habit{
+		ID:        "run",
+		HabitType: models.ElasticHabit,
+		FieldType: models.FieldType{
+			Type: models.CompositeFieldType,
+			Fields: []models.SubField{
+				{ID: "distance", FieldType: models.FieldType{Type: models.UnsignedDecimalFieldType, Unit: "km"}},
+				{ID: "duration", FieldType: models.FieldType{Type: models.DurationFieldType}},
+				{ID: "effort", FieldType: models.FieldType{Type: models.UnsignedIntFieldType}},
+			},
+		},
+		ScoringType:  models.AutomaticScoring,
+		MiniCriteria: &models.Criteria{Condition: &models.Condition{Field: "distance", GreaterThanOrEqual: f(3)}},
+		MidiCriteria: &models.Criteria{Condition: &models.Condition{Field: "distance", GreaterThanOrEqual: f(5)}},
+		MaxiCriteria: &models.Criteria{Condition: &models.Condition{And: []models.Condition{
+			{Field: "distance", GreaterThanOrEqual: f(5)},
+			{Field: "duration", LessThan: f(30)},
+		}}},
+	}
+
+	tests := []struct {
+		name     string
+		value    map[string]interface{}
+		expected models.AchievementLevel
+	}{
+		{"maxi", map[string]interface{}{"distance": 5.2, "duration": "28m", "effort": float64(7)}, models.AchievementMaxi},
+		{"midi when slow", map[string]interface{}{"distance": 5.2, "duration": "45m"}, models.AchievementMidi},
+		{"missing sub-field is not met", map[string]interface{}{"duration": "20m"}, models.AchievementNone},
+		{"mini", map[string]interface{}{"distance": 3.0}, models.AchievementMini},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := engine.ScoreElasticHabit(&run, tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.AchievementLevel)
+		})
+	}
+
+	t.Run("comparison without field", func(t *testing.T) {
+		habit := run
+		habit.MiniCriteria = &models.Criteria{Condition: &models.Condition{GreaterThan: f(1)}}
+		_, err := engine.ScoreElasticHabit(&habit, map[string]interface{}{"distance": 5.0})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must name a field")
+	})
+
+	t.Run("invalid sub-field value", func(t *testing.T) {
+		_, err := engine.ScoreElasticHabit(&run, map[string]interface{}{"distance": "far"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "field distance")
+	})
+
+	t.Run("not a composite value", func(t *testing.T) {
+		_, err := engine.ScoreElasticHabit(&run, 5.0)
+		require.Error(t, err)
+	})
+}
+
 func createTestSimpleBooleanHabit() models.Habit {
 	trueValue := true
 	return models.Habit{
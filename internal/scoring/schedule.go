@@ -0,0 +1,75 @@
+package scoring
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/davidlee/vice/internal/models"
+)
+
+// ScheduleResult describes a habit's position in its schedule on a given date.
+type ScheduleResult struct {
+	Status      models.ScheduleStatus
+	Completed   int // completions in the period before the evaluated date
+	Target      int // completions expected per period
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+}
+
+// EvaluateSchedule determines whether a habit is due on date, given its entry history.
+// Only completed entries count toward a period target; skipped entries are neutral.
+// Entries on date itself are ignored so the result describes the day before it is logged.
+// AIDEV-NOTE: schedule-evaluation; shared by TodoDashboard and EntryMenuModel for "not due"/"target met"
+func (e *Engine) EvaluateSchedule(habit *models.Habit, entryLog *models.EntryLog, date time.Time) (*ScheduleResult, error) {
+	if habit == nil {
+		return nil, fmt.Errorf("habit cannot be nil")
+	}
+
+	start, end := habit.Schedule.Period(date)
+	result := &ScheduleResult{
+		Status:      models.ScheduleDue,
+		Target:      habit.Schedule.Target(),
+		PeriodStart: start,
+		PeriodEnd:   end,
+	}
+
+	if !habit.Schedule.IsScheduledOn(date) {
+		result.Status = models.ScheduleNotDue
+		return result, nil
+	}
+
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	if entryLog == nil || !start.Before(day) {
+		return result, nil
+	}
+
+	entries, err := entryLog.GetEntriesForDateRange(start.Format("2006-01-02"), day.AddDate(0, 0, -1).Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load entries for schedule period: %w", err)
+	}
+
+	for _, dayEntry := range entries {
+		if habitEntry, found := dayEntry.GetHabitEntry(habit.ID); found && habitEntry.IsCompleted() {
+			result.Completed++
+		}
+	}
+
+	if result.Completed >= result.Target {
+		result.Status = models.ScheduleTargetMet
+	}
+
+	return result, nil
+}
+
+// EvaluateSchedules evaluates every habit's schedule on date, keyed by habit ID.
+func (e *Engine) EvaluateSchedules(habits []models.Habit, entryLog *models.EntryLog, date time.Time) (map[string]*ScheduleResult, error) {
+	results := make(map[string]*ScheduleResult, len(habits))
+	for i := range habits {
+		result, err := e.EvaluateSchedule(&habits[i], entryLog, date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate schedule for habit %s: %w", habits[i].ID, err)
+		}
+		results[habits[i].ID] = result
+	}
+	return results, nil
+}
@@ -0,0 +1,95 @@
+package scoring
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidlee/vice/internal/models"
+)
+
+func TestEngine_EvaluateSchedule(t *testing.T) {
+	engine := NewEngine()
+	friday := time.Date(2025, 7, 18, 9, 0, 0, 0, time.UTC)
+
+	entryLog := &models.EntryLog{
+		Version: "1.0.0",
+		Entries: []models.DayEntry{
+			{Date: "2025-07-14", Habits: []models.HabitEntry{
+				{HabitID: "gym", Status: models.EntryCompleted, Value: true},
+				{HabitID: "review", Status: models.EntryCompleted, Value: true},
+			}},
+			{Date: "2025-07-15", Habits: []models.HabitEntry{{HabitID: "gym", Status: models.EntrySkipped}}},
+			{Date: "2025-07-16", Habits: []models.HabitEntry{{HabitID: "gym", Status: models.EntryCompleted, Value: true}}},
+			{Date: "2025-07-17", Habits: []models.HabitEntry{{HabitID: "water", Status: models.EntryCompleted, Value: true}}},
+			{Date: "2025-07-18", Habits: []models.HabitEntry{{HabitID: "gym", Status: models.EntryCompleted, Value: true}}},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		habit             models.Habit
+		expectedStatus    models.ScheduleStatus
+		expectedCompleted int
+	}{
+		{
+			name:           "daily habit is always due",
+			habit:          models.Habit{ID: "daily"},
+			expectedStatus: models.ScheduleDue,
+		},
+		{
+			name:           "weekday habit not due on other days",
+			habit:          models.Habit{ID: "sunday", Schedule: &models.Schedule{Frequency: models.WeekdaysFrequency, Weekdays: []string{"sun"}}},
+			expectedStatus: models.ScheduleNotDue,
+		},
+		{
+			// Two completions this week; the skip is neutral and today's entry is ignored
+			name:              "times per week below target",
+			habit:             models.Habit{ID: "gym", Schedule: &models.Schedule{Frequency: models.TimesPerWeekFrequency, Times: 3}},
+			expectedStatus:    models.ScheduleDue,
+			expectedCompleted: 2,
+		},
+		{
+			name:              "times per week target met",
+			habit:             models.Habit{ID: "gym", Schedule: &models.Schedule{Frequency: models.TimesPerWeekFrequency, Times: 2}},
+			expectedStatus:    models.ScheduleTargetMet,
+			expectedCompleted: 2,
+		},
+		{
+			name:              "every n days completed within interval",
+			habit:             models.Habit{ID: "water", Schedule: &models.Schedule{Frequency: models.EveryNDaysFrequency, Interval: 2}},
+			expectedStatus:    models.ScheduleTargetMet,
+			expectedCompleted: 1,
+		},
+		{
+			name:              "monthly completed earlier this month",
+			habit:             models.Habit{ID: "review", Schedule: &models.Schedule{Frequency: models.MonthlyFrequency}},
+			expectedStatus:    models.ScheduleTargetMet,
+			expectedCompleted: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := engine.EvaluateSchedule(&tt.habit, entryLog, friday)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, result.Status)
+			assert.Equal(t, tt.expectedCompleted, result.Completed)
+		})
+	}
+
+	t.Run("nil habit", func(t *testing.T) {
+		_, err := engine.EvaluateSchedule(nil, entryLog, friday)
+		assert.Error(t, err)
+	})
+
+	t.Run("evaluate all habits", func(t *testing.T) {
+		habits := []models.Habit{tests[0].habit, tests[1].habit}
+		results, err := engine.EvaluateSchedules(habits, entryLog, friday)
+		require.NoError(t, err)
+		assert.Equal(t, models.ScheduleDue, results["daily"].Status)
+		assert.Equal(t, models.ScheduleNotDue, results["sunday"].Status)
+	})
+}
@@ -0,0 +1,133 @@
+package scoring
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/davidlee/vice/internal/models"
+)
+
+// AIDEV-NOTE: window-criteria; window conditions look back over the habit's entries
+// via the engine's EntryHistory (repository.DataRepository satisfies it). The value
+// being scored stands in for any entry already logged on the scored day.
+
+// EntryHistory loads the entries window conditions look back over.
+type EntryHistory interface {
+	LoadEntries(date time.Time) (*models.EntryLog, error)
+}
+
+// EntryHistoryFunc adapts a function to EntryHistory.
+type EntryHistoryFunc func(date time.Time) (*models.EntryLog, error)
+
+// LoadEntries calls f(date).
+func (f EntryHistoryFunc) LoadEntries(date time.Time) (*models.EntryLog, error) {
+	return f(date)
+}
+
+// SetHistory sets where window conditions read earlier entries from.
+func (e *Engine) SetHistory(history EntryHistory) {
+	e.history = history
+}
+
+// SetDate sets the day values are scored for; the default is today.
+func (e *Engine) SetDate(date time.Time) {
+	e.date = date
+}
+
+// windowScope is what window conditions need to look back from a scored value.
+type windowScope struct {
+	habit    *models.Habit
+	date     time.Time
+	entryLog *models.EntryLog
+}
+
+// newWindowScope loads the history for a habit with window criteria; it returns
+// nil for other habits.
+func (e *Engine) newWindowScope(habit *models.Habit) (*windowScope, error) {
+	if !habit.UsesWindowCriteria() {
+		return nil, nil
+	}
+	if e.history == nil {
+		return nil, fmt.Errorf("habit %s has window criteria but no entry history is available", habit.ID)
+	}
+
+	date := e.date
+	if date.IsZero() {
+		date = time.Now()
+	}
+	entryLog, err := e.history.LoadEntries(date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load entry history: %w", err)
+	}
+	return &windowScope{habit: habit, date: date, entryLog: entryLog}, nil
+}
+
+// windowValue returns the number a window condition compares for the scored
+// value, and false if the window has no values to aggregate.
+func (e *Engine) windowValue(value interface{}, window *models.WindowCondition, scope *windowScope, fieldType string) (float64, bool, error) {
+	if scope == nil {
+		return 0, false, fmt.Errorf("window conditions need entry history")
+	}
+
+	day := time.Date(scope.date.Year(), scope.date.Month(), scope.date.Day(), 0, 0, 0, 0, scope.date.Location())
+	var values []float64
+	completed := 0
+	if scope.entryLog != nil {
+		for date := window.Start(day); date.Before(day); date = date.AddDate(0, 0, 1) {
+			dayEntry, found := scope.entryLog.GetDayEntry(date.Format(dateFormat))
+			if !found {
+				continue
+			}
+			entry, found := dayEntry.GetHabitEntry(scope.habit.ID)
+			if !found {
+				continue
+			}
+			if entry.Status == models.EntryCompleted {
+				completed++
+			}
+			if window.Aggregate == models.AggregateCount || entry.Value == nil || entry.Status == models.EntrySkipped {
+				continue
+			}
+			converted, err := e.convertValueForEvaluation(entry.Value, fieldType)
+			if err != nil {
+				return 0, false, fmt.Errorf("entry on %s: %w", dayEntry.Date, err)
+			}
+			if number, ok := converted.(float64); ok {
+				values = append(values, number)
+			}
+		}
+	}
+
+	if !window.Baseline {
+		// The scored value stands in for the day's own entry
+		if window.Aggregate == models.AggregateCount {
+			if done, isBool := value.(bool); !isBool || done {
+				completed++
+			}
+		} else if number, ok := value.(float64); ok {
+			values = append(values, number)
+		}
+	}
+
+	switch window.Aggregate {
+	case models.AggregateCount:
+		return float64(completed), true, nil
+	case models.AggregateSum:
+		if len(values) == 0 {
+			return 0, true, nil
+		}
+	}
+	if len(values) == 0 {
+		return 0, false, nil
+	}
+
+	result := aggregate(window.Aggregate, values)
+	if window.Baseline {
+		today, ok := value.(float64)
+		if !ok {
+			return 0, false, fmt.Errorf("baseline needs a numeric value, got %T", value)
+		}
+		result = today - result
+	}
+	return result, true, nil
+}
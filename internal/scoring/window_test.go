@@ -0,0 +1,179 @@
+package scoring
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidlee/vice/internal/models"
+)
+
+// windowTestLog records a habit's value on each day from 2025-07-14 (a Monday).
+func windowTestLog(habitID string, values ...interface{}) *models.EntryLog {
+	entryLog := &models.EntryLog{Version: "1.0.0"}
+	start := time.Date(2025, 7, 14, 0, 0, 0, 0, time.UTC)
+	for i, value := range values {
+		entry := models.HabitEntry{HabitID: habitID, Value: value, Status: models.EntryCompleted}
+		if value == nil {
+			entry.Status = models.EntrySkipped
+		}
+		entryLog.Entries = append(entryLog.Entries, models.DayEntry{
+			Date:   start.AddDate(0, 0, i).Format(dateFormat),
+			Habits: []models.HabitEntry{entry},
+		})
+	}
+	return entryLog
+}
+
+func windowTestEngine(entryLog *models.EntryLog, date string) *Engine {
+	engine := NewEngine()
+	engine.SetHistory(EntryHistoryFunc(func(time.Time) (*models.EntryLog, error) {
+		return entryLog, nil
+	}))
+	day, _ := time.Parse(dateFormat, date)
+	engine.SetDate(day)
+	return engine
+}
+
+func TestEngine_ScoreWindowCriteria(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+
+	running := &models.Habit{
+		ID:           "running",
+		HabitType:    models.ElasticHabit,
+		FieldType:    models.FieldType{Type: models.DurationFieldType},
+		ScoringType:  models.AutomaticScoring,
+		MiniCriteria: &models.Criteria{Condition: &models.Condition{GreaterThan: f(0)}},
+		MidiCriteria: &models.Criteria{Condition: &models.Condition{
+			Window:      &models.WindowCondition{Aggregate: models.AggregateAverage, Days: 7, Baseline: true},
+			GreaterThan: f(0),
+		}},
+		MaxiCriteria: &models.Criteria{Condition: &models.Condition{
+			Window:             &models.WindowCondition{Aggregate: models.AggregateSum, Period: models.WeekPeriod},
+			GreaterThanOrEqual: f(180),
+		}},
+	}
+
+	// Week of Monday 2025-07-14; the 17th was skipped
+	entryLog := windowTestLog("running", "30m", "40m", "20m", nil, "30m")
+
+	tests := []struct {
+		name     string
+		date     string
+		value    string
+		expected models.AchievementLevel
+	}{
+		{"below average and weekly total", "2025-07-19", "30m", models.AchievementMini},
+		{"beats average", "2025-07-19", "40m", models.AchievementMidi},
+		{"weekly total reached", "2025-07-19", "1h", models.AchievementMaxi},
+		// Replaces the logged 30m rather than adding to it
+		{"day already logged", "2025-07-18", "5m", models.AchievementMini},
+		// A new week starts on Monday
+		{"next week", "2025-07-21", "1h", models.AchievementMidi},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := windowTestEngine(entryLog, tt.date).ScoreElasticHabit(running, tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.AchievementLevel)
+		})
+	}
+
+	t.Run("no history", func(t *testing.T) {
+		_, err := NewEngine().ScoreElasticHabit(running, "30m")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no entry history is available")
+	})
+
+	t.Run("history errors", func(t *testing.T) {
+		engine := NewEngine()
+		engine.SetHistory(EntryHistoryFunc(func(time.Time) (*models.EntryLog, error) {
+			return nil, fmt.Errorf("disk on fire")
+		}))
+		_, err := engine.ScoreElasticHabit(running, "30m")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "disk on fire")
+	})
+
+	t.Run("baseline without earlier entries", func(t *testing.T) {
+		result, err := windowTestEngine(entryLog, "2025-07-14").ScoreElasticHabit(running, "2h")
+		require.NoError(t, err)
+		assert.False(t, result.MetMidi)
+		assert.Equal(t, models.AchievementMini, result.AchievementLevel)
+	})
+}
+
+func TestEngine_WindowAggregates(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+
+	// Sleep in minutes, 14 days
+	entryLog := windowTestLog("sleep", 420.0, 480.0, 450.0, 400.0, 470.0, 430.0, 460.0,
+		440.0, 410.0, nil, 490.0, 455.0, 445.0, 435.0)
+
+	tests := []struct {
+		name      string
+		condition models.Condition
+		value     float64
+		expected  bool
+	}{
+		{
+			name: "within 30 of the 14-day median",
+			condition: models.Condition{
+				Window: &models.WindowCondition{Aggregate: models.AggregateMedian, Days: 14, Baseline: true},
+				Range:  &models.RangeCondition{Min: -30, Max: 30},
+			},
+			value: 460, expected: true, // median of 13 values is 445
+		},
+		{
+			name: "outside 30 of the 14-day median",
+			condition: models.Condition{
+				Window: &models.WindowCondition{Aggregate: models.AggregateMedian, Days: 14, Baseline: true},
+				Range:  &models.RangeCondition{Min: -30, Max: 30},
+			},
+			value: 480, expected: false,
+		},
+		{
+			name:      "7-day min includes the day",
+			condition: models.Condition{Window: &models.WindowCondition{Aggregate: models.AggregateMin, Days: 7}, LessThan: f(400)},
+			value:     390, expected: true,
+		},
+		{
+			name:      "7-day max",
+			condition: models.Condition{Window: &models.WindowCondition{Aggregate: models.AggregateMax, Days: 7}, GreaterThanOrEqual: f(490)},
+			value:     300, expected: true,
+		},
+		{
+			name:      "count of completed entries",
+			condition: models.Condition{Window: &models.WindowCondition{Aggregate: models.AggregateCount, Days: 7}, GreaterThanOrEqual: f(7)},
+			value:     450, expected: false, // one skipped day
+		},
+		{
+			name: "window combined with the day's value",
+			condition: models.Condition{And: []models.Condition{
+				{GreaterThanOrEqual: f(420)},
+				{Window: &models.WindowCondition{Aggregate: models.AggregateAverage, Days: 3}, GreaterThan: f(435)},
+			}},
+			value: 430, expected: true, // (445 + 435 + 430) / 3
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition := tt.condition
+			habit := &models.Habit{
+				ID:          "sleep",
+				HabitType:   models.SimpleHabit,
+				FieldType:   models.FieldType{Type: models.UnsignedIntFieldType},
+				ScoringType: models.AutomaticScoring,
+				Criteria:    &models.Criteria{Condition: &condition},
+			}
+			result, err := windowTestEngine(entryLog, "2025-07-28").ScoreSimpleHabit(habit, tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.MetMini)
+		})
+	}
+}
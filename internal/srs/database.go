@@ -39,6 +39,11 @@ type SRSData struct {
 	ConsecutiveCorrect int     `json:"consecutive_correct"`
 	Due                int64   `json:"due"` // Unix timestamp
 	TotalReviews       int     `json:"total_reviews"`
+
+	// FSRS memory state (zero when not yet seeded)
+	Stability  float64 `json:"stability,omitempty"`
+	Difficulty float64 `json:"difficulty,omitempty"`
+	LastReview int64   `json:"last_review,omitempty"` // Unix timestamp, read-only
 }
 
 // NewDatabase creates a new SRS database connection.
@@ -131,6 +136,24 @@ func (d *Database) ensureSchema() error {
 		return fmt.Errorf("failed to create cache metadata table: %w", err)
 	}
 
+	// Add FSRS state to databases created before FSRS support
+	if err := d.ensureColumns("srs_reviews", fsrsReviewColumns); err != nil {
+		return err
+	}
+	if err := d.ensureColumns("srs_reviews", tombstoneReviewColumns); err != nil {
+		return err
+	}
+
+	// Create review history table
+	if err := d.ensureReviewLogSchema(); err != nil {
+		return err
+	}
+
+	// Create link index tables
+	if err := d.ensureNoteIndexSchema(); err != nil {
+		return err
+	}
+
 	// Create performance indexes
 	indexes := []string{
 		`CREATE INDEX IF NOT EXISTS idx_srs_due_date ON srs_reviews (due_date);`,
@@ -154,7 +177,7 @@ func (d *Database) GetDueNotes(contextName string) ([]SRSNote, error) {
 		SELECT note_path, note_id, context, easiness, consecutive_correct, 
 		       due_date, total_reviews, created_at, last_reviewed
 		FROM srs_reviews 
-		WHERE context = ? AND due_date <= ?
+		WHERE context = ? AND due_date <= ? AND deleted_at IS NULL
 		ORDER BY due_date ASC
 	`
 
@@ -200,13 +223,14 @@ func (d *Database) UpdateReview(notePath string, data *SRSData) error {
 	query := `
 		UPDATE srs_reviews 
 		SET easiness = ?, consecutive_correct = ?, due_date = ?, 
-		    total_reviews = total_reviews + 1, last_reviewed = ?
+		    total_reviews = total_reviews + 1, last_reviewed = ?,
+		    stability = ?, difficulty = ?
 		WHERE note_path = ?
 	`
 
 	now := time.Now().Unix()
 	_, err := d.db.Exec(query, data.Easiness, data.ConsecutiveCorrect,
-		data.Due, now, notePath)
+		data.Due, now, nullableFloat(data.Stability), nullableFloat(data.Difficulty), notePath)
 	if err != nil {
 		return fmt.Errorf("failed to update review: %w", err)
 	}
@@ -217,15 +241,19 @@ func (d *Database) UpdateReview(notePath string, data *SRSData) error {
 // GetSRSData retrieves SRS data for a specific note.
 func (d *Database) GetSRSData(notePath string) (*SRSData, error) {
 	query := `
-		SELECT easiness, consecutive_correct, due_date, total_reviews
+		SELECT easiness, consecutive_correct, due_date, total_reviews,
+		       stability, difficulty, last_reviewed
 		FROM srs_reviews 
 		WHERE note_path = ?
 	`
 
 	var data SRSData
+	var stability, difficulty sql.NullFloat64
+	var lastReviewed sql.NullInt64
 	err := d.db.QueryRow(query, notePath).Scan(
 		&data.Easiness, &data.ConsecutiveCorrect,
 		&data.Due, &data.TotalReviews,
+		&stability, &difficulty, &lastReviewed,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -234,6 +262,10 @@ func (d *Database) GetSRSData(notePath string) (*SRSData, error) {
 		return nil, fmt.Errorf("failed to get SRS data: %w", err)
 	}
 
+	data.Stability = stability.Float64
+	data.Difficulty = difficulty.Float64
+	data.LastReview = lastReviewed.Int64
+
 	return &data, nil
 }
 
@@ -242,14 +274,15 @@ func (d *Database) CreateSRSNote(notePath, noteID, context string, initialData *
 	query := `
 		INSERT INTO srs_reviews 
 		(note_path, note_id, context, easiness, consecutive_correct, 
-		 due_date, total_reviews, created_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		 due_date, total_reviews, created_at, stability, difficulty)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	now := time.Now().Unix()
 	_, err := d.db.Exec(query, notePath, noteID, context,
 		initialData.Easiness, initialData.ConsecutiveCorrect,
-		initialData.Due, initialData.TotalReviews, now)
+		initialData.Due, initialData.TotalReviews, now,
+		nullableFloat(initialData.Stability), nullableFloat(initialData.Difficulty))
 	if err != nil {
 		return fmt.Errorf("failed to create SRS note: %w", err)
 	}
@@ -278,7 +311,7 @@ func (d *Database) GetStats(contextName string) (map[string]interface{}, error)
 			AVG(easiness) as avg_easiness,
 			AVG(total_reviews) as avg_reviews
 		FROM srs_reviews 
-		WHERE context = ?
+		WHERE context = ? AND deleted_at IS NULL
 	`
 
 	now := time.Now().Unix()
@@ -308,8 +341,15 @@ type CacheManager struct {
 	db         *Database
 	contextDir string
 	flotsamDir string
+	syncFiles  FileSyncFunc
+	initial    InitialStateFunc
 }
 
+// FileSyncFunc brings the note_files index up to date with the notes in
+// flotsamDir, tracking each file's mtime and checksum. Parsing notes is flotsam's
+// job, which srs can't import; flotsam.NewCacheManager supplies one.
+type FileSyncFunc func(db *Database, flotsamDir string) error
+
 // NewCacheManager creates a new cache manager for the given database and context.
 func NewCacheManager(db *Database, contextDir string) *CacheManager {
 	flotsamDir := filepath.Join(contextDir, "flotsam")
@@ -320,9 +360,20 @@ func NewCacheManager(db *Database, contextDir string) *CacheManager {
 	}
 }
 
+// WithFileSync sets how refreshes index note files and the state new notes are
+// scheduled with, and returns the cache manager. Without them, refreshes only
+// record the directory mtime: reconciling against an index nobody maintains
+// would tombstone every note.
+func (c *CacheManager) WithFileSync(sync FileSyncFunc, initial InitialStateFunc) *CacheManager {
+	c.syncFiles = sync
+	c.initial = initial
+	return c
+}
+
 // ValidateCache checks if the cache is up-to-date and refreshes if necessary.
+// The report is nil when the cache was already up to date.
 // AIDEV-NOTE: fast directory-level mtime check before expensive file scanning
-func (c *CacheManager) ValidateCache() error {
+func (c *CacheManager) ValidateCache() (*RefreshReport, error) {
 	// 1. Get cached directory mtime
 	cachedMtime, err := c.getCachedDirMtime()
 	if err != nil {
@@ -339,41 +390,42 @@ func (c *CacheManager) ValidateCache() error {
 
 	// 3. If directory unchanged, cache is valid
 	if !currentMtime.After(cachedMtime) {
-		return nil
+		return nil, nil
 	}
 
 	// 4. Directory changed - refresh cache
 	return c.RefreshCache()
 }
 
-// RefreshCache scans files and updates cache with current state.
+// RefreshCache re-indexes changed note files and reconciles srs_reviews with
+// them (see ReconcileReviews), reporting what changed.
 // AIDEV-NOTE: file-level granular refresh for precise cache updates
-func (c *CacheManager) RefreshCache() error {
-	// 1. Scan flotsam directory for markdown files (for future file-level caching)
-	pattern := filepath.Join(c.flotsamDir, "*.md")
-	_, err := filepath.Glob(pattern)
-	if err != nil {
-		return fmt.Errorf("failed to scan flotsam files: %w", err)
-	}
-
-	// 2. Get current directory mtime
+func (c *CacheManager) RefreshCache() (*RefreshReport, error) {
+	// 1. Get directory mtime before scanning, so changes made during the scan trigger another refresh
 	currentDirMtime, err := c.getCurrentDirMtime()
 	if err != nil {
 		// If directory doesn't exist, set to epoch time
 		currentDirMtime = time.Unix(0, 0)
 	}
 
-	// 3. Update cache metadata
-	err = c.updateCacheMetadata(currentDirMtime)
-	if err != nil {
-		return fmt.Errorf("failed to update cache metadata: %w", err)
+	// 2. Index new and changed files, then bring SRS rows in line with them
+	report := newRefreshReport()
+	if c.syncFiles != nil {
+		if err := c.syncFiles(c.db, c.flotsamDir); err != nil {
+			return nil, fmt.Errorf("failed to scan flotsam files: %w", err)
+		}
+		report, err = c.db.ReconcileReviews(c.initial)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reconcile SRS notes: %w", err)
+		}
 	}
 
-	// 4. For Unix interop, we don't implement file-level caching yet
-	// The SRS database will be the source of truth for SRS data
-	// File content parsing is delegated to zk or direct file reads
+	// 3. Update cache metadata
+	if err := c.updateCacheMetadata(currentDirMtime); err != nil {
+		return nil, fmt.Errorf("failed to update cache metadata: %w", err)
+	}
 
-	return nil
+	return report, nil
 }
 
 // getCachedDirMtime retrieves the cached directory modification time.
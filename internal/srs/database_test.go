@@ -322,7 +322,7 @@ func TestValidateCache_NewCache(t *testing.T) {
 	cacheManager := db.GetCacheManager(tempDir)
 
 	// First validation should trigger refresh (cache miss)
-	err = cacheManager.ValidateCache()
+	_, err = cacheManager.ValidateCache()
 	require.NoError(t, err)
 
 	// Verify cache metadata was created
@@ -343,14 +343,14 @@ func TestValidateCache_UpToDate(t *testing.T) {
 	cacheManager := db.GetCacheManager(tempDir)
 
 	// Initial cache
-	err = cacheManager.ValidateCache()
+	_, err = cacheManager.ValidateCache()
 	require.NoError(t, err)
 
 	initialMtime, err := cacheManager.getCachedDirMtime()
 	require.NoError(t, err)
 
 	// Second validation should not change anything (cache hit)
-	err = cacheManager.ValidateCache()
+	_, err = cacheManager.ValidateCache()
 	require.NoError(t, err)
 
 	currentMtime, err := cacheManager.getCachedDirMtime()
@@ -372,7 +372,7 @@ func TestValidateCache_DirectoryChanged(t *testing.T) {
 	cacheManager := db.GetCacheManager(tempDir)
 
 	// Initial cache
-	err = cacheManager.ValidateCache()
+	_, err = cacheManager.ValidateCache()
 	require.NoError(t, err)
 
 	initialMtime, err := cacheManager.getCachedDirMtime()
@@ -393,7 +393,7 @@ func TestValidateCache_DirectoryChanged(t *testing.T) {
 	t.Logf("Actual dir mtime after file write: %v", actualDirMtime)
 
 	// Validation should detect change and refresh
-	err = cacheManager.ValidateCache()
+	_, err = cacheManager.ValidateCache()
 	require.NoError(t, err)
 
 	newMtime, err := cacheManager.getCachedDirMtime()
@@ -424,7 +424,7 @@ func TestRefreshCache(t *testing.T) {
 	cacheManager := db.GetCacheManager(tempDir)
 
 	// Refresh cache
-	err = cacheManager.RefreshCache()
+	_, err = cacheManager.RefreshCache()
 	require.NoError(t, err)
 
 	// Verify cache metadata was updated
@@ -450,7 +450,7 @@ func TestInvalidateCache(t *testing.T) {
 	cacheManager := db.GetCacheManager(tempDir)
 
 	// Initial cache
-	err = cacheManager.RefreshCache()
+	_, err = cacheManager.RefreshCache()
 	require.NoError(t, err)
 
 	initialMtime, err := cacheManager.getCachedDirMtime()
@@ -477,7 +477,7 @@ func TestValidateCache_NonexistentDirectory(t *testing.T) {
 	cacheManager := db.GetCacheManager(tempDir)
 
 	// Validation should handle nonexistent directory gracefully
-	err := cacheManager.ValidateCache()
+	_, err := cacheManager.ValidateCache()
 	require.NoError(t, err)
 
 	// Cache should be set with epoch time
@@ -0,0 +1,150 @@
+package srs
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// columnDef describes a column added to an existing table by migration.
+type columnDef struct {
+	name       string
+	definition string
+}
+
+// fsrsReviewColumns are the FSRS memory-state columns on srs_reviews.
+// AIDEV-NOTE: fsrs-columns; NULL means "not yet seeded" - SM-2 columns stay authoritative for SM-2 contexts
+var fsrsReviewColumns = []columnDef{
+	{"stability", "REAL"},
+	{"difficulty", "REAL"},
+}
+
+// tombstoneReviewColumns mark srs_reviews rows whose note file is gone.
+// AIDEV-NOTE: srs-tombstone; deleted_at is set by cache reconciliation rather than deleting the row, so a
+// note that comes back (git checkout, undo) keeps its schedule; tombstoned rows are never due
+var tombstoneReviewColumns = []columnDef{
+	{"deleted_at", "INTEGER"},
+}
+
+// noteFileColumns are columns added to note_files after the link index.
+var noteFileColumns = []columnDef{
+	{"note_type", "TEXT NOT NULL DEFAULT ''"}, // vice:type:* type; empty for notes without one
+}
+
+// fsrsLogColumns are the FSRS memory-state columns on review_log.
+var fsrsLogColumns = []columnDef{
+	{"prev_stability", "REAL"},
+	{"prev_difficulty", "REAL"},
+	{"new_stability", "REAL"},
+	{"new_difficulty", "REAL"},
+}
+
+// ensureColumns adds any missing columns to a table.
+// SQLite has no ADD COLUMN IF NOT EXISTS, so existing columns are read first.
+func (d *Database) ensureColumns(table string, columns []columnDef) error {
+	rows, err := d.db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return fmt.Errorf("failed to read %s columns: %w", table, err)
+	}
+
+	existing := make(map[string]bool)
+	for rows.Next() {
+		var cid int
+		var name, colType string
+		var notNull, pk int
+		var defaultValue interface{}
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &defaultValue, &pk); err != nil {
+			_ = rows.Close() //nolint:errcheck // Error already being returned
+			return fmt.Errorf("failed to scan %s columns: %w", table, err)
+		}
+		existing[name] = true
+	}
+	if err := rows.Close(); err != nil {
+		return fmt.Errorf("failed to read %s columns: %w", table, err)
+	}
+
+	for _, col := range columns {
+		if existing[col.name] {
+			continue
+		}
+		alter := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, col.name, col.definition)
+		if _, err := d.db.Exec(alter); err != nil {
+			return fmt.Errorf("failed to add %s.%s: %w", table, col.name, err)
+		}
+	}
+
+	return nil
+}
+
+// FSRSSeedFunc derives FSRS stability (days) and difficulty (1-10) from SM-2 state.
+// intervalDays is the last scheduled interval, or 0 when the note was never reviewed.
+type FSRSSeedFunc func(easiness float64, consecutiveCorrect int, intervalDays float64) (stability, difficulty float64)
+
+// SeedFSRSState fills in FSRS state for reviewed notes in a context that don't have it yet.
+// The interval is taken from due_date - last_reviewed. Returns the number of notes seeded.
+// AIDEV-NOTE: fsrs-migration; idempotent - only touches rows with NULL stability and at least one review
+func (d *Database) SeedFSRSState(contextName string, seed FSRSSeedFunc) (int, error) {
+	rows, err := d.db.Query(`
+		SELECT note_path, easiness, consecutive_correct, due_date, last_reviewed
+		FROM srs_reviews
+		WHERE context = ? AND stability IS NULL AND total_reviews > 0
+	`, contextName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query notes for FSRS seeding: %w", err)
+	}
+
+	type seedRow struct {
+		notePath   string
+		stability  float64
+		difficulty float64
+	}
+
+	var seeded []seedRow
+	for rows.Next() {
+		var notePath string
+		var easiness float64
+		var consecutive int
+		var due int64
+		var lastReviewed sql.NullInt64
+
+		if err := rows.Scan(&notePath, &easiness, &consecutive, &due, &lastReviewed); err != nil {
+			_ = rows.Close() //nolint:errcheck // Error already being returned
+			return 0, fmt.Errorf("failed to scan note for FSRS seeding: %w", err)
+		}
+
+		var intervalDays float64
+		if lastReviewed.Valid && due > lastReviewed.Int64 {
+			intervalDays = float64(due-lastReviewed.Int64) / (24 * time.Hour).Seconds()
+		}
+
+		stability, difficulty := seed(easiness, consecutive, intervalDays)
+		seeded = append(seeded, seedRow{notePath, stability, difficulty})
+	}
+	if err := rows.Close(); err != nil {
+		return 0, fmt.Errorf("failed to query notes for FSRS seeding: %w", err)
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin FSRS seeding: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() //nolint:errcheck // No-op after commit
+
+	for _, row := range seeded {
+		if _, err := tx.Exec(`UPDATE srs_reviews SET stability = ?, difficulty = ? WHERE note_path = ?`,
+			row.stability, row.difficulty, row.notePath); err != nil {
+			return 0, fmt.Errorf("failed to seed FSRS state for %s: %w", row.notePath, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit FSRS seeding: %w", err)
+	}
+
+	return len(seeded), nil
+}
+
+// nullableFloat stores zero as NULL, used for optional FSRS state.
+func nullableFloat(v float64) sql.NullFloat64 {
+	return sql.NullFloat64{Float64: v, Valid: v != 0}
+}
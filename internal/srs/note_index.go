@@ -0,0 +1,278 @@
+package srs
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// IndexedFile is a note file in the link index, with the state it was indexed at.
+// AIDEV-NOTE: note-index; note_files/note_links mirror zk's notebook index so backlinks etc. work
+// without zk; rows are keyed by absolute path like srs_reviews.note_path
+type IndexedFile struct {
+	Path     string    `json:"path"`
+	NoteID   string    `json:"note_id"`
+	Title    string    `json:"title"`
+	ModTime  time.Time `json:"mod_time"`
+	Checksum string    `json:"checksum"`
+	Type     string    `json:"type,omitempty"` // vice:type:* note type; empty for untyped notes
+}
+
+// IndexedLink is an outbound link from an indexed note. SourceID and TargetPath
+// are filled in by queries: TargetPath is the note the link resolves to, empty
+// for external and broken links.
+type IndexedLink struct {
+	SourcePath string   `json:"source_path"`
+	SourceID   string   `json:"source_id"`
+	Target     string   `json:"target"`              // Href as written in the note
+	TargetID   string   `json:"target_id,omitempty"` // Note ID the href refers to; empty for external links
+	TargetPath string   `json:"target_path,omitempty"`
+	Title      string   `json:"title,omitempty"`
+	Type       string   `json:"type"` // markdown, wiki-link or url, matching flotsam.LinkType
+	Rels       []string `json:"rels,omitempty"`
+	External   bool     `json:"external"`
+	Snippet    string   `json:"snippet,omitempty"`
+}
+
+// ensureNoteIndexSchema creates the note_files and note_links tables and their indexes.
+func (d *Database) ensureNoteIndexSchema() error {
+	schemas := []string{`
+		CREATE TABLE IF NOT EXISTS note_files (
+			path TEXT PRIMARY KEY,
+			note_id TEXT NOT NULL,
+			title TEXT NOT NULL DEFAULT '',
+			mtime INTEGER NOT NULL, -- Unix nanoseconds; seconds miss quick successive edits
+			checksum TEXT NOT NULL,
+			indexed_at INTEGER NOT NULL
+		);
+	`, `
+		CREATE TABLE IF NOT EXISTS note_links (
+			source_path TEXT NOT NULL,
+			position INTEGER NOT NULL,
+			target TEXT NOT NULL,
+			target_id TEXT NOT NULL DEFAULT '',
+			title TEXT NOT NULL DEFAULT '',
+			link_type TEXT NOT NULL,
+			rels TEXT NOT NULL DEFAULT '',
+			external INTEGER NOT NULL DEFAULT 0,
+			snippet TEXT NOT NULL DEFAULT '',
+			PRIMARY KEY (source_path, position)
+		);
+	`}
+
+	for _, schema := range schemas {
+		if _, err := d.db.Exec(schema); err != nil {
+			return fmt.Errorf("failed to create note index table: %w", err)
+		}
+	}
+
+	if err := d.ensureColumns("note_files", noteFileColumns); err != nil {
+		return err
+	}
+
+	indexes := []string{
+		`CREATE INDEX IF NOT EXISTS idx_note_files_note_id ON note_files (note_id);`,
+		`CREATE INDEX IF NOT EXISTS idx_note_links_target_id ON note_links (target_id);`,
+	}
+
+	for _, indexSQL := range indexes {
+		if _, err := d.db.Exec(indexSQL); err != nil {
+			return fmt.Errorf("failed to create note index index: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetIndexedFiles returns every indexed note file, keyed by path.
+func (d *Database) GetIndexedFiles() (map[string]IndexedFile, error) {
+	files, err := d.queryIndexedFiles(`SELECT ` + indexedFileColumns + ` FROM note_files`)
+	if err != nil {
+		return nil, err
+	}
+
+	byPath := make(map[string]IndexedFile, len(files))
+	for _, file := range files {
+		byPath[file.Path] = file
+	}
+	return byPath, nil
+}
+
+// FindIndexedNote returns the indexed file for a note ID. If several files share
+// the ID the first by path wins, matching how links resolve.
+func (d *Database) FindIndexedNote(noteID string) (*IndexedFile, error) {
+	files, err := d.queryIndexedFiles(`
+		SELECT `+indexedFileColumns+` FROM note_files
+		WHERE note_id = ? ORDER BY path LIMIT 1
+	`, noteID)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("note not found in link index: %s", noteID)
+	}
+	return &files[0], nil
+}
+
+// IndexFile records a note file and replaces its outbound links.
+func (d *Database) IndexFile(file IndexedFile, links []IndexedLink) error {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin indexing %s: %w", file.Path, err)
+	}
+	defer func() { _ = tx.Rollback() }() //nolint:errcheck // No-op after commit
+
+	_, err = tx.Exec(`
+		INSERT OR REPLACE INTO note_files (path, note_id, title, mtime, checksum, note_type, indexed_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, file.Path, file.NoteID, file.Title, file.ModTime.UnixNano(), file.Checksum, file.Type, time.Now().Unix())
+	if err != nil {
+		return fmt.Errorf("failed to index %s: %w", file.Path, err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM note_links WHERE source_path = ?`, file.Path); err != nil {
+		return fmt.Errorf("failed to clear links of %s: %w", file.Path, err)
+	}
+
+	for position, link := range links {
+		_, err := tx.Exec(`
+			INSERT INTO note_links
+			(source_path, position, target, target_id, title, link_type, rels, external, snippet)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, file.Path, position, link.Target, link.TargetID, link.Title, link.Type,
+			strings.Join(link.Rels, " "), link.External, link.Snippet)
+		if err != nil {
+			return fmt.Errorf("failed to index link in %s: %w", file.Path, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit index of %s: %w", file.Path, err)
+	}
+	return nil
+}
+
+// TouchIndexedFile records a new modification time for a file whose content is unchanged.
+func (d *Database) TouchIndexedFile(path string, modTime time.Time) error {
+	if _, err := d.db.Exec(`UPDATE note_files SET mtime = ? WHERE path = ?`, modTime.UnixNano(), path); err != nil {
+		return fmt.Errorf("failed to update index of %s: %w", path, err)
+	}
+	return nil
+}
+
+// RemoveIndexedFile removes a note file and its outbound links from the index.
+func (d *Database) RemoveIndexedFile(path string) error {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin removing %s from index: %w", path, err)
+	}
+	defer func() { _ = tx.Rollback() }() //nolint:errcheck // No-op after commit
+
+	if _, err := tx.Exec(`DELETE FROM note_links WHERE source_path = ?`, path); err != nil {
+		return fmt.Errorf("failed to remove links of %s: %w", path, err)
+	}
+	if _, err := tx.Exec(`DELETE FROM note_files WHERE path = ?`, path); err != nil {
+		return fmt.Errorf("failed to remove %s from index: %w", path, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit removing %s from index: %w", path, err)
+	}
+	return nil
+}
+
+// indexedFileColumns is the column list scanned by queryIndexedFiles.
+const indexedFileColumns = `path, note_id, title, mtime, checksum, note_type`
+
+// resolvedLinks selects indexed links with their source note ID and the path of
+// the note they resolve to. Internal links match a note ID first, then a title
+// (case-insensitive), as zk does for [[Note Title]] links.
+const resolvedLinks = `
+	SELECT l.source_path, s.note_id AS source_id, l.target, l.target_id,
+	       CASE WHEN l.external THEN '' ELSE COALESCE(
+	           (SELECT f.path FROM note_files f WHERE f.note_id = l.target_id ORDER BY f.path LIMIT 1),
+	           (SELECT f.path FROM note_files f WHERE lower(f.title) = lower(l.target) ORDER BY f.path LIMIT 1),
+	           '') END AS target_path,
+	       l.title, l.link_type, l.rels, l.external, l.snippet, l.position
+	FROM note_links l JOIN note_files s ON s.path = l.source_path
+`
+
+// GetOutlinks returns the links from a note, in the order they appear.
+func (d *Database) GetOutlinks(notePath string) ([]IndexedLink, error) {
+	return d.queryLinks(`SELECT * FROM (`+resolvedLinks+`) WHERE source_path = ? ORDER BY position`, notePath)
+}
+
+// GetBacklinks returns the links to a note from other notes.
+func (d *Database) GetBacklinks(notePath string) ([]IndexedLink, error) {
+	return d.queryLinks(`SELECT * FROM (`+resolvedLinks+`)
+		WHERE target_path = ? AND source_path != target_path
+		ORDER BY source_path, position`, notePath)
+}
+
+// GetBrokenLinks returns internal links that don't resolve to an indexed note.
+func (d *Database) GetBrokenLinks() ([]IndexedLink, error) {
+	return d.queryLinks(`SELECT * FROM (` + resolvedLinks + `)
+		WHERE external = 0 AND target_path = ''
+		ORDER BY source_path, position`)
+}
+
+// GetOrphanNotes returns the indexed notes no other note links to.
+func (d *Database) GetOrphanNotes() ([]IndexedFile, error) {
+	return d.queryIndexedFiles(`
+		SELECT ` + indexedFileColumns + ` FROM note_files f
+		WHERE NOT EXISTS (
+			SELECT 1 FROM (` + resolvedLinks + `) r
+			WHERE r.target_path = f.path AND r.source_path != f.path
+		)
+		ORDER BY path
+	`)
+}
+
+// queryIndexedFiles runs a note_files query and scans the resulting rows.
+func (d *Database) queryIndexedFiles(query string, args ...interface{}) ([]IndexedFile, error) {
+	rows, err := d.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query note index: %w", err)
+	}
+	defer func() { _ = rows.Close() }() //nolint:errcheck // Defer cleanup
+
+	var files []IndexedFile
+	for rows.Next() {
+		var file IndexedFile
+		var mtime int64
+		if err := rows.Scan(&file.Path, &file.NoteID, &file.Title, &mtime, &file.Checksum, &file.Type); err != nil {
+			return nil, fmt.Errorf("failed to scan indexed file: %w", err)
+		}
+		file.ModTime = time.Unix(0, mtime)
+		files = append(files, file)
+	}
+
+	return files, rows.Err()
+}
+
+// queryLinks runs a resolvedLinks query and scans the resulting rows.
+func (d *Database) queryLinks(query string, args ...interface{}) ([]IndexedLink, error) {
+	rows, err := d.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query links: %w", err)
+	}
+	defer func() { _ = rows.Close() }() //nolint:errcheck // Defer cleanup
+
+	var links []IndexedLink
+	for rows.Next() {
+		var link IndexedLink
+		var rels string
+		var position int
+		err := rows.Scan(
+			&link.SourcePath, &link.SourceID, &link.Target, &link.TargetID, &link.TargetPath,
+			&link.Title, &link.Type, &rels, &link.External, &link.Snippet, &position,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan link: %w", err)
+		}
+		link.Rels = strings.Fields(rels)
+		links = append(links, link)
+	}
+
+	return links, rows.Err()
+}
@@ -0,0 +1,116 @@
+package srs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoteIndexSchema(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }() //nolint:errcheck // Test cleanup
+
+	columns, err := getTableColumns(db, "note_files")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"path", "note_id", "title", "mtime", "checksum", "indexed_at", "note_type"}, columns)
+
+	columns, err = getTableColumns(db, "note_links")
+	require.NoError(t, err)
+	assert.Contains(t, columns, "target_id")
+	assert.Contains(t, columns, "rels")
+}
+
+func TestNoteIndexQueries(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }() //nolint:errcheck // Test cleanup
+
+	modTime := time.Date(2025, 7, 18, 9, 30, 0, 123, time.UTC)
+	index := func(path, id, title string, links ...IndexedLink) {
+		t.Helper()
+		require.NoError(t, db.IndexFile(IndexedFile{Path: path, NoteID: id, Title: title, ModTime: modTime, Checksum: id}, links))
+	}
+	wiki := func(target, targetID string, rels ...string) IndexedLink {
+		return IndexedLink{Target: target, TargetID: targetID, Type: "wiki-link", Rels: rels}
+	}
+
+	index("/n/abc1.md", "abc1", "Graphs",
+		wiki("def2", "def2", "down"),
+		wiki("Trees", "Trees"),
+		wiki("gone", "gone"),
+		IndexedLink{Target: "https://example.com", Type: "url", External: true},
+		wiki("abc1", "abc1"))
+	index("/n/def2.md", "def2", "Trees", wiki("abc1.md", "abc1"))
+	index("/n/ghi3.md", "ghi3", "Lonely")
+
+	t.Run("outlinks resolve by ID then title", func(t *testing.T) {
+		links, err := db.GetOutlinks("/n/abc1.md")
+		require.NoError(t, err)
+		require.Len(t, links, 5)
+
+		assert.Equal(t, "abc1", links[0].SourceID)
+		assert.Equal(t, "/n/def2.md", links[0].TargetPath)
+		assert.Equal(t, []string{"down"}, links[0].Rels)
+		assert.Equal(t, "/n/def2.md", links[1].TargetPath, "title match")
+		assert.Empty(t, links[2].TargetPath, "broken")
+		assert.True(t, links[3].External)
+		assert.Empty(t, links[3].TargetPath)
+	})
+
+	t.Run("backlinks exclude self links", func(t *testing.T) {
+		links, err := db.GetBacklinks("/n/abc1.md")
+		require.NoError(t, err)
+		require.Len(t, links, 1)
+		assert.Equal(t, "/n/def2.md", links[0].SourcePath)
+		assert.Equal(t, "def2", links[0].SourceID)
+
+		links, err = db.GetBacklinks("/n/def2.md")
+		require.NoError(t, err)
+		assert.Len(t, links, 2, "by ID and by title")
+	})
+
+	t.Run("broken links and orphans", func(t *testing.T) {
+		broken, err := db.GetBrokenLinks()
+		require.NoError(t, err)
+		require.Len(t, broken, 1)
+		assert.Equal(t, "gone", broken[0].Target)
+
+		orphans, err := db.GetOrphanNotes()
+		require.NoError(t, err)
+		require.Len(t, orphans, 1)
+		assert.Equal(t, "ghi3", orphans[0].NoteID)
+		assert.True(t, modTime.Equal(orphans[0].ModTime))
+	})
+
+	t.Run("find, touch and remove", func(t *testing.T) {
+		note, err := db.FindIndexedNote("def2")
+		require.NoError(t, err)
+		assert.Equal(t, "Trees", note.Title)
+
+		touched := modTime.Add(time.Minute)
+		require.NoError(t, db.TouchIndexedFile("/n/def2.md", touched))
+		files, err := db.GetIndexedFiles()
+		require.NoError(t, err)
+		assert.True(t, touched.Equal(files["/n/def2.md"].ModTime))
+
+		require.NoError(t, db.RemoveIndexedFile("/n/def2.md"))
+		_, err = db.FindIndexedNote("def2")
+		assert.Error(t, err)
+
+		orphans, err := db.GetOrphanNotes()
+		require.NoError(t, err)
+		assert.Len(t, orphans, 2, "abc1 lost its only backlink")
+
+		broken, err := db.GetBrokenLinks()
+		require.NoError(t, err)
+		assert.Len(t, broken, 3)
+	})
+
+	t.Run("reindexing replaces links", func(t *testing.T) {
+		index("/n/abc1.md", "abc1", "Graphs")
+		links, err := db.GetOutlinks("/n/abc1.md")
+		require.NoError(t, err)
+		assert.Empty(t, links)
+	})
+}
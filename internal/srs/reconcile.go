@@ -0,0 +1,185 @@
+package srs
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// RefreshReport describes what a cache refresh changed in srs_reviews.
+type RefreshReport struct {
+	Added    []string          `json:"added"`    // Newly scheduled vice-typed notes
+	Renamed  map[string]string `json:"renamed"`  // Old path to new path, matched by note ID
+	Removed  []string          `json:"removed"`  // Tombstoned because the file is gone
+	Restored []string          `json:"restored"` // Tombstoned rows whose file came back
+}
+
+// Changed reports whether the refresh changed any SRS rows.
+func (r *RefreshReport) Changed() bool {
+	return r != nil && len(r.Added)+len(r.Renamed)+len(r.Removed)+len(r.Restored) > 0
+}
+
+// newRefreshReport returns an empty report.
+func newRefreshReport() *RefreshReport {
+	return &RefreshReport{Added: []string{}, Renamed: map[string]string{}, Removed: []string{}, Restored: []string{}}
+}
+
+// reviewRow is the identity of an srs_reviews row, as reconciliation sees it.
+type reviewRow struct {
+	path    string
+	noteID  string
+	deleted bool
+}
+
+// InitialStateFunc returns the SRS state of a note that has never been reviewed,
+// due at now, under the configured algorithm. Scheduling state is flotsam's job,
+// which srs can't import; flotsam.NewCacheManager supplies one.
+type InitialStateFunc func(now time.Time) (*SRSData, error)
+
+// ReconcileReviews brings this context's srs_reviews rows in line with the note
+// files in the link index: rows follow renamed notes by ID, rows whose file is
+// gone are tombstoned (and restored if it comes back), and vice-typed notes
+// without a row are scheduled for review now, starting from the state initial
+// returns. The index must be up to date.
+// AIDEV-NOTE: srs-reconcile; renames update review_log.note_path too so GetReviewHistory follows the note
+func (d *Database) ReconcileReviews(initial InitialStateFunc) (*RefreshReport, error) {
+	if initial == nil {
+		return nil, fmt.Errorf("no initial SRS state for new notes")
+	}
+
+	files, err := d.GetIndexedFiles()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := d.reviewRows()
+	if err != nil {
+		return nil, err
+	}
+
+	report := newRefreshReport()
+	byPath := make(map[string]*reviewRow, len(rows))
+	for i := range rows {
+		byPath[rows[i].path] = &rows[i]
+	}
+
+	// Files without a row that could be a renamed note, by note ID
+	unclaimed := make(map[string][]string)
+	for path, file := range files {
+		if byPath[path] == nil {
+			unclaimed[file.NoteID] = append(unclaimed[file.NoteID], path)
+		}
+	}
+	for id := range unclaimed {
+		sort.Strings(unclaimed[id])
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin reconciliation: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() //nolint:errcheck // No-op after commit
+
+	now := time.Now().Unix()
+	for i := range rows {
+		row := &rows[i]
+		if _, exists := files[row.path]; exists {
+			if row.deleted {
+				if _, err := tx.Exec(`UPDATE srs_reviews SET deleted_at = NULL WHERE note_path = ?`, row.path); err != nil {
+					return nil, fmt.Errorf("failed to restore %s: %w", row.path, err)
+				}
+				report.Restored = append(report.Restored, row.path)
+			}
+			continue
+		}
+
+		if candidates := unclaimed[row.noteID]; len(candidates) > 0 {
+			newPath := candidates[0]
+			unclaimed[row.noteID] = candidates[1:]
+			if err := renameReview(tx, row.path, newPath); err != nil {
+				return nil, err
+			}
+			report.Renamed[row.path] = newPath
+			byPath[newPath] = row
+			continue
+		}
+
+		if !row.deleted {
+			if _, err := tx.Exec(`UPDATE srs_reviews SET deleted_at = ? WHERE note_path = ?`, now, row.path); err != nil {
+				return nil, fmt.Errorf("failed to tombstone %s: %w", row.path, err)
+			}
+			report.Removed = append(report.Removed, row.path)
+		}
+	}
+
+	var state *SRSData
+	for path, file := range files {
+		if byPath[path] != nil || file.Type == "" {
+			continue
+		}
+		// Same state as a note created with `vice flotsam add`: due now
+		if state == nil {
+			if state, err = initial(time.Unix(now, 0)); err != nil {
+				return nil, fmt.Errorf("failed to initialize SRS state: %w", err)
+			}
+		}
+		_, err := tx.Exec(`
+			INSERT INTO srs_reviews
+			(note_path, note_id, context, easiness, consecutive_correct,
+			 due_date, total_reviews, created_at, stability, difficulty)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, path, file.NoteID, d.context, state.Easiness, state.ConsecutiveCorrect,
+			state.Due, state.TotalReviews, now,
+			nullableFloat(state.Stability), nullableFloat(state.Difficulty))
+		if err != nil {
+			return nil, fmt.Errorf("failed to schedule %s: %w", path, err)
+		}
+		report.Added = append(report.Added, path)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit reconciliation: %w", err)
+	}
+
+	sort.Strings(report.Added)
+	sort.Strings(report.Removed)
+	sort.Strings(report.Restored)
+	return report, nil
+}
+
+// reviewRows returns the identity of every srs_reviews row in this context.
+func (d *Database) reviewRows() ([]reviewRow, error) {
+	rows, err := d.db.Query(`
+		SELECT note_path, note_id, deleted_at IS NOT NULL
+		FROM srs_reviews WHERE context = ?
+		ORDER BY note_path
+	`, d.context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query SRS notes: %w", err)
+	}
+	defer func() { _ = rows.Close() }() //nolint:errcheck // Defer cleanup
+
+	var result []reviewRow
+	for rows.Next() {
+		var row reviewRow
+		if err := rows.Scan(&row.path, &row.noteID, &row.deleted); err != nil {
+			return nil, fmt.Errorf("failed to scan SRS note: %w", err)
+		}
+		result = append(result, row)
+	}
+
+	return result, rows.Err()
+}
+
+// renameReview moves a note's schedule and review history to its new path,
+// restoring it if it had been tombstoned.
+func renameReview(tx *sql.Tx, oldPath, newPath string) error {
+	if _, err := tx.Exec(`UPDATE srs_reviews SET note_path = ?, deleted_at = NULL WHERE note_path = ?`,
+		newPath, oldPath); err != nil {
+		return fmt.Errorf("failed to rename %s to %s: %w", oldPath, newPath, err)
+	}
+	if _, err := tx.Exec(`UPDATE review_log SET note_path = ? WHERE note_path = ?`, newPath, oldPath); err != nil {
+		return fmt.Errorf("failed to rename review history of %s: %w", oldPath, err)
+	}
+	return nil
+}
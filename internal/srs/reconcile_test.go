@@ -0,0 +1,121 @@
+package srs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcileReviews(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }() //nolint:errcheck // Test cleanup
+
+	index := func(path, id, noteType string) {
+		t.Helper()
+		require.NoError(t, db.IndexFile(IndexedFile{Path: path, NoteID: id, ModTime: time.Now(), Checksum: id, Type: noteType}, nil))
+	}
+	schedule := func(path, id string) {
+		t.Helper()
+		require.NoError(t, db.CreateSRSNote(path, id, "test-context", &SRSData{Easiness: 2.5, Due: time.Now().Add(-time.Hour).Unix()}))
+	}
+	duePaths := func() []string {
+		t.Helper()
+		due, err := db.GetDueNotes("test-context")
+		require.NoError(t, err)
+		var paths []string
+		for _, note := range due {
+			paths = append(paths, note.NotePath)
+		}
+		return paths
+	}
+
+	// kept: scheduled and present; moved: renamed on disk; gone: deleted;
+	// fresh: vice-typed note created outside vice; plain: untyped note
+	schedule("/n/kept.md", "kept")
+	schedule("/n/old/moved.md", "mvd1")
+	schedule("/n/gone.md", "gone")
+	require.NoError(t, db.RecordReview(&ReviewLogEntry{NotePath: "/n/old/moved.md", Quality: 5,
+		Updated: SRSData{Easiness: 2.6, Due: time.Now().Add(-time.Minute).Unix(), TotalReviews: 1}}))
+	index("/n/kept.md", "kept", "flashcard")
+	index("/n/new/moved.md", "mvd1", "flashcard")
+	index("/n/fresh.md", "fresh", "idea")
+	index("/n/plain.md", "plain", "")
+
+	initial := func(now time.Time) (*SRSData, error) {
+		return &SRSData{Easiness: 2.5, Due: now.Unix(), Stability: 3.2, Difficulty: 5.3}, nil
+	}
+	report, err := db.ReconcileReviews(initial)
+	require.NoError(t, err)
+	assert.True(t, report.Changed())
+	assert.Equal(t, []string{"/n/fresh.md"}, report.Added)
+	assert.Equal(t, map[string]string{"/n/old/moved.md": "/n/new/moved.md"}, report.Renamed)
+	assert.Equal(t, []string{"/n/gone.md"}, report.Removed)
+	assert.Empty(t, report.Restored)
+
+	assert.ElementsMatch(t, []string{"/n/kept.md", "/n/new/moved.md", "/n/fresh.md"}, duePaths(),
+		"renamed note keeps its schedule; tombstoned note isn't due")
+
+	data, err := db.GetSRSData("/n/new/moved.md")
+	require.NoError(t, err)
+	assert.Equal(t, 1, data.TotalReviews)
+	data, err = db.GetSRSData("/n/fresh.md")
+	require.NoError(t, err)
+	assert.InDelta(t, 3.2, data.Stability, 1e-9, "new notes start from the initial state")
+	assert.InDelta(t, 5.3, data.Difficulty, 1e-9)
+	history, err := db.GetReviewHistory("/n/new/moved.md")
+	require.NoError(t, err)
+	assert.Len(t, history, 1, "review history follows the rename")
+
+	t.Run("idempotent", func(t *testing.T) {
+		report, err := db.ReconcileReviews(initial)
+		require.NoError(t, err)
+		assert.False(t, report.Changed())
+	})
+
+	t.Run("tombstoned note comes back", func(t *testing.T) {
+		index("/n/gone.md", "gone", "idea")
+		report, err := db.ReconcileReviews(initial)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"/n/gone.md"}, report.Restored)
+		assert.Empty(t, report.Added)
+		assert.Contains(t, duePaths(), "/n/gone.md")
+	})
+
+	t.Run("tombstoned note comes back renamed", func(t *testing.T) {
+		require.NoError(t, db.RemoveIndexedFile("/n/gone.md"))
+		report, err := db.ReconcileReviews(initial)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"/n/gone.md"}, report.Removed)
+
+		index("/n/back.md", "gone", "idea")
+		report, err = db.ReconcileReviews(initial)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"/n/gone.md": "/n/back.md"}, report.Renamed)
+		assert.Contains(t, duePaths(), "/n/back.md")
+	})
+}
+
+func TestReconcileReviewsRequiresInitialState(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }() //nolint:errcheck // Test cleanup
+
+	_, err := db.ReconcileReviews(nil)
+	assert.Error(t, err)
+}
+
+func TestRefreshCacheWithoutFileSync(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }() //nolint:errcheck // Test cleanup
+
+	require.NoError(t, db.CreateSRSNote("/n/note.md", "note", "test-context", &SRSData{Easiness: 2.5, Due: time.Now().Unix()}))
+
+	report, err := db.GetCacheManager(t.TempDir()).RefreshCache()
+	require.NoError(t, err)
+	assert.False(t, report.Changed(), "nothing maintains the index, so rows are left alone")
+
+	due, err := db.GetDueNotes("test-context")
+	require.NoError(t, err)
+	assert.Len(t, due, 1)
+}
@@ -0,0 +1,270 @@
+package srs
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// ReviewLogEntry is a single graded review with the SRS state before and after.
+// AIDEV-NOTE: review-log; append-only history - foundation for retention graphs, undo and algorithm tuning
+type ReviewLogEntry struct {
+	ID         int64         `json:"id"`
+	NotePath   string        `json:"note_path"`
+	NoteID     string        `json:"note_id"`
+	Context    string        `json:"context"`
+	SessionID  string        `json:"session_id,omitempty"`
+	ReviewedAt time.Time     `json:"reviewed_at"`
+	Quality    int           `json:"quality"`            // 0-6 scale, matches flotsam.Quality
+	Previous   *SRSData      `json:"previous,omitempty"` // nil for a card's first review
+	Updated    SRSData       `json:"updated"`
+	TimeSpent  time.Duration `json:"time_spent"`
+}
+
+// DailyReviewTotal summarizes the reviews performed on one calendar day.
+type DailyReviewTotal struct {
+	Date      string        `json:"date"` // YYYY-MM-DD in local time
+	Reviews   int           `json:"reviews"`
+	Correct   int           `json:"correct"`
+	TimeSpent time.Duration `json:"time_spent"`
+}
+
+// correctQualityThreshold mirrors flotsam.CorrectThreshold without importing flotsam.
+const correctQualityThreshold = 4
+
+// ensureReviewLogSchema creates the review_log table and its indexes.
+func (d *Database) ensureReviewLogSchema() error {
+	schema := `
+		CREATE TABLE IF NOT EXISTS review_log (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			note_path TEXT NOT NULL,
+			note_id TEXT NOT NULL,
+			context TEXT NOT NULL,
+			session_id TEXT NOT NULL DEFAULT '',
+			reviewed_at INTEGER NOT NULL,
+			quality INTEGER NOT NULL,
+
+			-- SRS state before the review (NULL for first review)
+			prev_easiness REAL,
+			prev_consecutive_correct INTEGER,
+			prev_due_date INTEGER,
+			prev_total_reviews INTEGER,
+
+			-- SRS state after the review
+			new_easiness REAL NOT NULL,
+			new_consecutive_correct INTEGER NOT NULL,
+			new_due_date INTEGER NOT NULL,
+			new_total_reviews INTEGER NOT NULL,
+
+			time_spent_ms INTEGER NOT NULL DEFAULT 0
+		);
+	`
+
+	if _, err := d.db.Exec(schema); err != nil {
+		return fmt.Errorf("failed to create review log table: %w", err)
+	}
+
+	if err := d.ensureColumns("review_log", fsrsLogColumns); err != nil {
+		return err
+	}
+
+	indexes := []string{
+		`CREATE INDEX IF NOT EXISTS idx_review_log_note ON review_log (note_path, reviewed_at);`,
+		`CREATE INDEX IF NOT EXISTS idx_review_log_note_id ON review_log (note_id, reviewed_at);`,
+		`CREATE INDEX IF NOT EXISTS idx_review_log_context_time ON review_log (context, reviewed_at);`,
+	}
+
+	for _, indexSQL := range indexes {
+		if _, err := d.db.Exec(indexSQL); err != nil {
+			return fmt.Errorf("failed to create review log index: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// RecordReview updates a note's schedule and appends the review to the log atomically.
+// Note ID and context are taken from the note's srs_reviews row; entry.ID,
+// entry.NoteID and entry.Context are filled in on success.
+func (d *Database) RecordReview(entry *ReviewLogEntry) error {
+	if entry == nil {
+		return fmt.Errorf("review log entry cannot be nil")
+	}
+
+	if entry.ReviewedAt.IsZero() {
+		entry.ReviewedAt = time.Now()
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin review transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() //nolint:errcheck // No-op after commit
+
+	result, err := tx.Exec(`
+		UPDATE srs_reviews
+		SET easiness = ?, consecutive_correct = ?, due_date = ?,
+		    total_reviews = total_reviews + 1, last_reviewed = ?,
+		    stability = ?, difficulty = ?
+		WHERE note_path = ?
+	`, entry.Updated.Easiness, entry.Updated.ConsecutiveCorrect, entry.Updated.Due,
+		entry.ReviewedAt.Unix(), nullableFloat(entry.Updated.Stability), nullableFloat(entry.Updated.Difficulty),
+		entry.NotePath)
+	if err != nil {
+		return fmt.Errorf("failed to update review: %w", err)
+	}
+
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return fmt.Errorf("note not found: %s", entry.NotePath)
+	}
+
+	var prevEasiness, prevStability, prevDifficulty sql.NullFloat64
+	var prevConsecutive, prevDue, prevTotal sql.NullInt64
+	if entry.Previous != nil {
+		prevEasiness = sql.NullFloat64{Float64: entry.Previous.Easiness, Valid: true}
+		prevConsecutive = sql.NullInt64{Int64: int64(entry.Previous.ConsecutiveCorrect), Valid: true}
+		prevDue = sql.NullInt64{Int64: entry.Previous.Due, Valid: true}
+		prevTotal = sql.NullInt64{Int64: int64(entry.Previous.TotalReviews), Valid: true}
+		prevStability = nullableFloat(entry.Previous.Stability)
+		prevDifficulty = nullableFloat(entry.Previous.Difficulty)
+	}
+
+	result, err = tx.Exec(`
+		INSERT INTO review_log
+		(note_path, note_id, context, session_id, reviewed_at, quality,
+		 prev_easiness, prev_consecutive_correct, prev_due_date, prev_total_reviews,
+		 new_easiness, new_consecutive_correct, new_due_date, new_total_reviews,
+		 time_spent_ms, prev_stability, prev_difficulty, new_stability, new_difficulty)
+		SELECT note_path, note_id, context, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+		FROM srs_reviews WHERE note_path = ?
+	`, entry.SessionID, entry.ReviewedAt.Unix(), entry.Quality,
+		prevEasiness, prevConsecutive, prevDue, prevTotal,
+		entry.Updated.Easiness, entry.Updated.ConsecutiveCorrect, entry.Updated.Due, entry.Updated.TotalReviews,
+		entry.TimeSpent.Milliseconds(), prevStability, prevDifficulty,
+		nullableFloat(entry.Updated.Stability), nullableFloat(entry.Updated.Difficulty), entry.NotePath)
+	if err != nil {
+		return fmt.Errorf("failed to log review: %w", err)
+	}
+
+	if err := tx.QueryRow(`SELECT note_id, context FROM srs_reviews WHERE note_path = ?`, entry.NotePath).
+		Scan(&entry.NoteID, &entry.Context); err != nil {
+		return fmt.Errorf("failed to read note identity: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit review: %w", err)
+	}
+
+	entry.ID, _ = result.LastInsertId() //nolint:errcheck // sqlite always supports LastInsertId
+	return nil
+}
+
+// reviewLogColumns is the column list shared by review log queries.
+const reviewLogColumns = `
+	id, note_path, note_id, context, session_id, reviewed_at, quality,
+	prev_easiness, prev_consecutive_correct, prev_due_date, prev_total_reviews,
+	new_easiness, new_consecutive_correct, new_due_date, new_total_reviews,
+	time_spent_ms, prev_stability, prev_difficulty, new_stability, new_difficulty
+`
+
+// GetReviewHistory returns all logged reviews for a note path, oldest first.
+func (d *Database) GetReviewHistory(notePath string) ([]ReviewLogEntry, error) {
+	query := `SELECT ` + reviewLogColumns + `
+		FROM review_log WHERE note_path = ?
+		ORDER BY reviewed_at ASC, id ASC`
+
+	return d.queryReviewLog(query, notePath)
+}
+
+// GetReviewHistoryByNoteID returns all logged reviews for a note ID in this
+// database's context, oldest first. Unlike GetReviewHistory this survives renames.
+func (d *Database) GetReviewHistoryByNoteID(noteID string) ([]ReviewLogEntry, error) {
+	query := `SELECT ` + reviewLogColumns + `
+		FROM review_log WHERE note_id = ? AND context = ?
+		ORDER BY reviewed_at ASC, id ASC`
+
+	return d.queryReviewLog(query, noteID, d.context)
+}
+
+// GetReviewsBetween returns all logged reviews in a context within [from, to), oldest first.
+func (d *Database) GetReviewsBetween(contextName string, from, to time.Time) ([]ReviewLogEntry, error) {
+	query := `SELECT ` + reviewLogColumns + `
+		FROM review_log WHERE context = ? AND reviewed_at >= ? AND reviewed_at < ?
+		ORDER BY reviewed_at ASC, id ASC`
+
+	return d.queryReviewLog(query, contextName, from.Unix(), to.Unix())
+}
+
+// GetDailyReviewTotals returns per-day review totals for a context within [from, to).
+// Days are bucketed in local time; days without reviews are omitted.
+func (d *Database) GetDailyReviewTotals(contextName string, from, to time.Time) ([]DailyReviewTotal, error) {
+	entries, err := d.GetReviewsBetween(contextName, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var totals []DailyReviewTotal
+	for _, entry := range entries {
+		date := entry.ReviewedAt.Format("2006-01-02")
+		if len(totals) == 0 || totals[len(totals)-1].Date != date {
+			totals = append(totals, DailyReviewTotal{Date: date})
+		}
+
+		total := &totals[len(totals)-1]
+		total.Reviews++
+		if entry.Quality >= correctQualityThreshold {
+			total.Correct++
+		}
+		total.TimeSpent += entry.TimeSpent
+	}
+
+	return totals, nil
+}
+
+// queryReviewLog runs a review log query and scans the resulting rows.
+func (d *Database) queryReviewLog(query string, args ...interface{}) ([]ReviewLogEntry, error) {
+	rows, err := d.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query review log: %w", err)
+	}
+	defer func() { _ = rows.Close() }() //nolint:errcheck // Defer cleanup
+
+	var entries []ReviewLogEntry
+	for rows.Next() {
+		var entry ReviewLogEntry
+		var reviewedAt, timeSpentMs int64
+		var prevEasiness, prevStability, prevDifficulty, newStability, newDifficulty sql.NullFloat64
+		var prevConsecutive, prevDue, prevTotal sql.NullInt64
+
+		err := rows.Scan(
+			&entry.ID, &entry.NotePath, &entry.NoteID, &entry.Context, &entry.SessionID,
+			&reviewedAt, &entry.Quality,
+			&prevEasiness, &prevConsecutive, &prevDue, &prevTotal,
+			&entry.Updated.Easiness, &entry.Updated.ConsecutiveCorrect,
+			&entry.Updated.Due, &entry.Updated.TotalReviews,
+			&timeSpentMs, &prevStability, &prevDifficulty, &newStability, &newDifficulty,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan review log entry: %w", err)
+		}
+
+		entry.ReviewedAt = time.Unix(reviewedAt, 0)
+		entry.TimeSpent = time.Duration(timeSpentMs) * time.Millisecond
+		entry.Updated.Stability = newStability.Float64
+		entry.Updated.Difficulty = newDifficulty.Float64
+		if prevEasiness.Valid {
+			entry.Previous = &SRSData{
+				Easiness:           prevEasiness.Float64,
+				ConsecutiveCorrect: int(prevConsecutive.Int64),
+				Due:                prevDue.Int64,
+				TotalReviews:       int(prevTotal.Int64),
+				Stability:          prevStability.Float64,
+				Difficulty:         prevDifficulty.Float64,
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
@@ -0,0 +1,182 @@
+package srs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewLogSchema(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }() //nolint:errcheck // Test cleanup
+
+	columns, err := getTableColumns(db, "review_log")
+	require.NoError(t, err)
+
+	expectedColumns := []string{
+		"id", "note_path", "note_id", "context", "session_id", "reviewed_at", "quality",
+		"prev_easiness", "prev_consecutive_correct", "prev_due_date", "prev_total_reviews",
+		"new_easiness", "new_consecutive_correct", "new_due_date", "new_total_reviews",
+		"time_spent_ms",
+	}
+	for _, col := range expectedColumns {
+		assert.Contains(t, columns, col, "Missing column: %s", col)
+	}
+}
+
+func TestRecordReview(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }() //nolint:errcheck // Test cleanup
+
+	notePath := "test/note.md"
+	initial := &SRSData{Easiness: 2.5, Due: time.Now().Unix()}
+	require.NoError(t, db.CreateSRSNote(notePath, "abc1", "test-context", initial))
+
+	reviewedAt := time.Date(2025, 7, 18, 9, 30, 0, 0, time.Local)
+	first := &ReviewLogEntry{
+		NotePath:   notePath,
+		SessionID:  "session-1",
+		ReviewedAt: reviewedAt,
+		Quality:    5,
+		Updated:    SRSData{Easiness: 2.6, ConsecutiveCorrect: 1, Due: reviewedAt.AddDate(0, 0, 1).Unix(), TotalReviews: 1},
+		TimeSpent:  12 * time.Second,
+	}
+	require.NoError(t, db.RecordReview(first))
+	assert.NotZero(t, first.ID)
+	assert.Equal(t, "abc1", first.NoteID)
+	assert.Equal(t, "test-context", first.Context)
+
+	second := &ReviewLogEntry{
+		NotePath:   notePath,
+		ReviewedAt: reviewedAt.AddDate(0, 0, 1),
+		Quality:    2,
+		Previous:   &first.Updated,
+		Updated:    SRSData{Easiness: 2.3, ConsecutiveCorrect: 0, Due: reviewedAt.AddDate(0, 0, 2).Unix(), TotalReviews: 2},
+		TimeSpent:  1500 * time.Millisecond,
+	}
+	require.NoError(t, db.RecordReview(second))
+
+	// Schedule was updated alongside the log
+	data, err := db.GetSRSData(notePath)
+	require.NoError(t, err)
+	assert.Equal(t, 2, data.TotalReviews)
+	assert.Equal(t, second.Updated.Due, data.Due)
+
+	history, err := db.GetReviewHistory(notePath)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+
+	assert.Equal(t, "session-1", history[0].SessionID)
+	assert.Equal(t, 5, history[0].Quality)
+	assert.Nil(t, history[0].Previous)
+	assert.Equal(t, 12*time.Second, history[0].TimeSpent)
+	assert.True(t, history[0].ReviewedAt.Equal(reviewedAt))
+
+	require.NotNil(t, history[1].Previous)
+	assert.Equal(t, first.Updated, *history[1].Previous)
+	assert.Equal(t, second.Updated, history[1].Updated)
+	assert.Equal(t, 1500*time.Millisecond, history[1].TimeSpent)
+
+	byID, err := db.GetReviewHistoryByNoteID("abc1")
+	require.NoError(t, err)
+	assert.Len(t, byID, 2)
+}
+
+func TestRecordReview_UnknownNote(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }() //nolint:errcheck // Test cleanup
+
+	err := db.RecordReview(&ReviewLogEntry{NotePath: "missing.md", Quality: 4})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "note not found")
+
+	history, err := db.GetReviewHistory("missing.md")
+	require.NoError(t, err)
+	assert.Empty(t, history)
+}
+
+func TestGetDailyReviewTotals(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }() //nolint:errcheck // Test cleanup
+
+	require.NoError(t, db.CreateSRSNote("a.md", "aaaa", "test-context", &SRSData{Easiness: 2.5}))
+	require.NoError(t, db.CreateSRSNote("b.md", "bbbb", "test-context", &SRSData{Easiness: 2.5}))
+	require.NoError(t, db.CreateSRSNote("c.md", "cccc", "other-context", &SRSData{Easiness: 2.5}))
+
+	day1 := time.Date(2025, 7, 18, 9, 0, 0, 0, time.Local)
+	day2 := day1.AddDate(0, 0, 1)
+
+	reviews := []ReviewLogEntry{
+		{NotePath: "a.md", ReviewedAt: day1, Quality: 5, TimeSpent: 10 * time.Second},
+		{NotePath: "b.md", ReviewedAt: day1.Add(2 * time.Hour), Quality: 1, TimeSpent: 20 * time.Second},
+		{NotePath: "a.md", ReviewedAt: day2, Quality: 6, TimeSpent: 5 * time.Second},
+		{NotePath: "c.md", ReviewedAt: day2, Quality: 6, TimeSpent: 5 * time.Second},
+	}
+	for i := range reviews {
+		require.NoError(t, db.RecordReview(&reviews[i]))
+	}
+
+	totals, err := db.GetDailyReviewTotals("test-context", day1.Add(-time.Hour), day2.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, totals, 2)
+
+	assert.Equal(t, DailyReviewTotal{Date: "2025-07-18", Reviews: 2, Correct: 1, TimeSpent: 30 * time.Second}, totals[0])
+	assert.Equal(t, DailyReviewTotal{Date: "2025-07-19", Reviews: 1, Correct: 1, TimeSpent: 5 * time.Second}, totals[1])
+
+	// Range end is exclusive
+	totals, err = db.GetDailyReviewTotals("test-context", day1, day2)
+	require.NoError(t, err)
+	require.Len(t, totals, 1)
+	assert.Equal(t, 2, totals[0].Reviews)
+}
+
+func TestSeedFSRSState(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }() //nolint:errcheck // Test cleanup
+
+	columns, err := getTableColumns(db, "srs_reviews")
+	require.NoError(t, err)
+	assert.Contains(t, columns, "stability")
+	assert.Contains(t, columns, "difficulty")
+
+	reviewedAt := time.Date(2025, 7, 18, 9, 0, 0, 0, time.Local)
+	require.NoError(t, db.CreateSRSNote("reviewed.md", "aaaa", "test-context", &SRSData{Easiness: 2.5}))
+	require.NoError(t, db.CreateSRSNote("new.md", "bbbb", "test-context", &SRSData{Easiness: 2.5}))
+	require.NoError(t, db.CreateSRSNote("other.md", "cccc", "other-context", &SRSData{Easiness: 2.5}))
+	for _, path := range []string{"reviewed.md", "other.md"} {
+		require.NoError(t, db.RecordReview(&ReviewLogEntry{
+			NotePath:   path,
+			ReviewedAt: reviewedAt,
+			Quality:    5,
+			Updated:    SRSData{Easiness: 2.6, ConsecutiveCorrect: 3, Due: reviewedAt.AddDate(0, 0, 6).Unix(), TotalReviews: 1},
+		}))
+	}
+
+	var gotInterval float64
+	seed := func(easiness float64, _ int, intervalDays float64) (float64, float64) {
+		gotInterval = intervalDays
+		return intervalDays, 11 - 2*easiness
+	}
+
+	count, err := db.SeedFSRSState("test-context", seed)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count, "only reviewed notes in the context are seeded")
+	assert.InDelta(t, 6.0, gotInterval, 0.001)
+
+	data, err := db.GetSRSData("reviewed.md")
+	require.NoError(t, err)
+	assert.InDelta(t, 6.0, data.Stability, 0.001)
+	assert.InDelta(t, 5.8, data.Difficulty, 0.001)
+	assert.Equal(t, reviewedAt.Unix(), data.LastReview)
+
+	unseeded, err := db.GetSRSData("new.md")
+	require.NoError(t, err)
+	assert.Zero(t, unseeded.Stability)
+
+	// Already-seeded notes are left alone
+	count, err = db.SeedFSRSState("test-context", seed)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
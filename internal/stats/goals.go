@@ -0,0 +1,213 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/davidlee/vice/internal/models"
+)
+
+// PaceDays is how many recent days a goal's pace is measured over.
+const PaceDays = 14
+
+// GoalStatus describes where a goal stands.
+type GoalStatus string
+
+// Goal statuses.
+const (
+	GoalAchieved GoalStatus = "achieved" // Target reached
+	GoalOnTrack  GoalStatus = "on_track" // Recent pace reaches the target by the due date
+	GoalBehind   GoalStatus = "behind"   // Recent pace falls short of the target
+	GoalMissed   GoalStatus = "missed"   // Due date passed without reaching the target
+	GoalUpcoming GoalStatus = "upcoming" // Start date still ahead
+)
+
+// GoalProgress is a goal's progress as of today.
+type GoalProgress struct {
+	GoalID    string             `json:"goal_id"`
+	Title     string             `json:"title"`
+	HabitID   string             `json:"habit_id"`
+	Measure   models.GoalMeasure `json:"measure"`
+	FieldType string             `json:"field_type"`
+	Current   float64            `json:"current"`
+	Target    float64            `json:"target"`
+	Percent   float64            `json:"percent"` // 0-100
+	Start     string             `json:"start,omitempty"`
+	Due       string             `json:"due"`
+	Pace      float64            `json:"pace_per_day"`        // Over the last PaceDays days
+	Projected string             `json:"projected,omitempty"` // Date the target is reached at the current pace
+	Status    GoalStatus         `json:"status"`
+}
+
+// Goals returns the progress of every goal, in goal order.
+func (c *Calculator) Goals(goals []models.Goal, habits []models.Habit, entryLog *models.EntryLog) ([]GoalProgress, error) {
+	habitsByID := make(map[string]*models.Habit, len(habits))
+	for i := range habits {
+		habitsByID[habits[i].ID] = &habits[i]
+	}
+
+	result := make([]GoalProgress, 0, len(goals))
+	for i := range goals {
+		habit, found := habitsByID[goals[i].HabitID]
+		if !found {
+			return nil, fmt.Errorf("goal %s: unknown habit %s", goals[i].ID, goals[i].HabitID)
+		}
+		progress, err := c.GoalProgress(&goals[i], habit, entryLog)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *progress)
+	}
+	return result, nil
+}
+
+// GoalProgress measures a goal from its habit's entries between the goal's start
+// and today (or its due date, if passed), and projects when the target will be
+// reached at the pace of the last PaceDays days.
+// AIDEV-NOTE: goal-projection; on track means the projected date isn't after the due date
+func (c *Calculator) GoalProgress(goal *models.Goal, habit *models.Habit, entryLog *models.EntryLog) (*GoalProgress, error) {
+	if goal == nil || habit == nil {
+		return nil, fmt.Errorf("goal and habit cannot be nil")
+	}
+
+	start, due := goal.Span(c.today)
+	progress := &GoalProgress{
+		GoalID:    goal.ID,
+		Title:     goal.Title,
+		HabitID:   habit.ID,
+		Measure:   goal.GetMeasure(),
+		FieldType: habit.FieldType.Type,
+		Target:    goal.Target,
+		Due:       due.Format(dateFormat),
+	}
+	if !start.IsZero() {
+		progress.Start = start.Format(dateFormat)
+	}
+
+	end := c.today
+	if due.Before(end) {
+		end = due
+	}
+	paceStart := c.today.AddDate(0, 0, -(PaceDays - 1))
+	if start.After(paceStart) {
+		paceStart = start
+	}
+
+	recent := 0.0
+	if entryLog != nil {
+		for _, dayEntry := range entryLog.Entries {
+			date, err := time.ParseInLocation(dateFormat, dayEntry.Date, c.today.Location())
+			if err != nil {
+				return nil, fmt.Errorf("invalid entry date %q: %w", dayEntry.Date, err)
+			}
+			if date.Before(start) || date.After(end) {
+				continue
+			}
+			entry, found := dayEntry.GetHabitEntry(habit.ID)
+			if !found {
+				continue
+			}
+			amount, err := c.goalAmount(goal, habit, entry)
+			if err != nil {
+				return nil, fmt.Errorf("goal %s: entry on %s: %w", goal.ID, dayEntry.Date, err)
+			}
+			progress.Current += amount
+			if !date.Before(paceStart) {
+				recent += amount
+			}
+		}
+	}
+
+	progress.Percent = math.Min(100, progress.Current/goal.Target*100)
+	if paceDays := int(c.today.Sub(paceStart).Hours()/24) + 1; paceDays > 0 {
+		progress.Pace = recent / float64(paceDays)
+	}
+
+	remaining := goal.Target - progress.Current
+	switch {
+	case remaining <= 0:
+		progress.Status = GoalAchieved
+		return progress, nil
+	case c.today.After(due):
+		progress.Status = GoalMissed
+		return progress, nil
+	case c.today.Before(start):
+		progress.Status = GoalUpcoming
+		return progress, nil
+	}
+
+	progress.Status = GoalBehind
+	if progress.Pace > 0 {
+		projected := c.today.AddDate(0, 0, int(math.Ceil(remaining/progress.Pace)))
+		progress.Projected = projected.Format(dateFormat)
+		if !projected.After(due) {
+			progress.Status = GoalOnTrack
+		}
+	}
+	return progress, nil
+}
+
+// goalAmount is what one entry adds to a goal: 1 per completion, or its value.
+func (c *Calculator) goalAmount(goal *models.Goal, habit *models.Habit, entry *models.HabitEntry) (float64, error) {
+	if goal.GetMeasure() == models.GoalCompletions {
+		if entry.Status == models.EntryCompleted {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	if entry.Value == nil || entry.Status == models.EntrySkipped {
+		return 0, nil
+	}
+	return c.engine.NumericValue(entry.Value, habit.FieldType.Type)
+}
+
+// FormatGoalAmount renders a goal amount in the habit's units: hours and minutes
+// for durations, otherwise a plain number.
+func FormatGoalAmount(amount float64, fieldType string) string {
+	if fieldType == models.DurationFieldType {
+		minutes := int(math.Round(amount))
+		switch {
+		case minutes < 60:
+			return fmt.Sprintf("%dm", minutes)
+		case minutes%60 == 0:
+			return fmt.Sprintf("%dh", minutes/60)
+		default:
+			return fmt.Sprintf("%dh%dm", minutes/60, minutes%60)
+		}
+	}
+	return fmt.Sprintf("%g", math.Round(amount*100)/100)
+}
+
+// Label returns the status for display, e.g. "on track".
+func (s GoalStatus) Label() string {
+	return strings.ReplaceAll(string(s), "_", " ")
+}
+
+// Amounts renders the progress as current/target, e.g. "42/100" or "8h20m/20h".
+func (p *GoalProgress) Amounts() string {
+	if p.Measure == models.GoalCompletions {
+		return fmt.Sprintf("%g/%g", p.Current, p.Target)
+	}
+	return FormatGoalAmount(p.Current, p.FieldType) + "/" + FormatGoalAmount(p.Target, p.FieldType)
+}
+
+// Summary describes the progress in one line, e.g.
+// "42/100 (42%) on track, projected 2026-11-20, due 2027-01-01".
+func (p *GoalProgress) Summary() string {
+	summary := fmt.Sprintf("%s (%.0f%%) %s", p.Amounts(), p.Percent, p.Status.Label())
+
+	switch p.Status {
+	case GoalOnTrack, GoalBehind:
+		if p.Projected != "" {
+			summary += ", projected " + p.Projected
+		} else {
+			summary += ", no recent progress"
+		}
+		summary += ", due " + p.Due
+	case GoalUpcoming:
+		summary += ", starts " + p.Start
+	}
+	return summary
+}
@@ -0,0 +1,124 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidlee/vice/internal/models"
+)
+
+func TestCalculator_GoalProgress(t *testing.T) {
+	sunday := time.Date(2025, 7, 20, 18, 0, 0, 0, time.UTC)
+	calculator := NewCalculator(sunday)
+	entryLog := testEntryLog() // walk completed on 7 days, 07-10 to 07-19
+	walk := &models.Habit{ID: "walk", HabitType: models.SimpleHabit, FieldType: models.FieldType{Type: models.BooleanFieldType}}
+
+	tests := []struct {
+		name      string
+		goal      models.Goal
+		current   float64
+		status    GoalStatus
+		projected string
+	}{
+		{
+			// 7 in the last 14 days: 0.5 a day, 13 to go
+			name:    "on track",
+			goal:    models.Goal{ID: "g", HabitID: "walk", Target: 20, DueDate: "2025-08-31"},
+			current: 7, status: GoalOnTrack, projected: "2025-08-15",
+		},
+		{
+			name:    "behind",
+			goal:    models.Goal{ID: "g", HabitID: "walk", Target: 20, DueDate: "2025-07-31"},
+			current: 7, status: GoalBehind, projected: "2025-08-15",
+		},
+		{
+			name:    "achieved",
+			goal:    models.Goal{ID: "g", HabitID: "walk", Target: 5, DueDate: "2025-07-31"},
+			current: 7, status: GoalAchieved,
+		},
+		{
+			// Only entries up to the due date count
+			name:    "missed",
+			goal:    models.Goal{ID: "g", HabitID: "walk", Target: 20, DueDate: "2025-07-15"},
+			current: 4, status: GoalMissed,
+		},
+		{
+			// 4 in the 6 days since the start
+			name:    "start date",
+			goal:    models.Goal{ID: "g", HabitID: "walk", Target: 8, StartDate: "2025-07-15", DueDate: "2025-07-31"},
+			current: 4, status: GoalOnTrack, projected: "2025-07-26",
+		},
+		{
+			name:    "upcoming",
+			goal:    models.Goal{ID: "g", HabitID: "walk", Target: 8, StartDate: "2025-08-01", DueDate: "2025-08-31"},
+			current: 0, status: GoalUpcoming,
+		},
+		{
+			// July so far
+			name:    "period",
+			goal:    models.Goal{ID: "g", HabitID: "walk", Target: 25, Period: models.GoalMonth},
+			current: 7, status: GoalBehind, projected: "2025-08-25",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			progress, err := calculator.GoalProgress(&tt.goal, walk, entryLog)
+			require.NoError(t, err)
+			assert.Equal(t, tt.current, progress.Current)
+			assert.Equal(t, tt.status, progress.Status)
+			assert.Equal(t, tt.projected, progress.Projected)
+		})
+	}
+
+	t.Run("no recent progress", func(t *testing.T) {
+		later := NewCalculator(sunday.AddDate(0, 1, 0))
+		goal := &models.Goal{ID: "g", HabitID: "walk", Target: 20, DueDate: "2025-12-31"}
+		progress, err := later.GoalProgress(goal, walk, entryLog)
+		require.NoError(t, err)
+		assert.Equal(t, GoalBehind, progress.Status)
+		assert.Empty(t, progress.Projected)
+		assert.Contains(t, progress.Summary(), "no recent progress")
+	})
+}
+
+func TestCalculator_GoalProgressTotal(t *testing.T) {
+	today := time.Date(2025, 7, 20, 0, 0, 0, 0, time.UTC)
+	reading := models.Habit{ID: "reading", HabitType: models.InformationalHabit, FieldType: models.FieldType{Type: models.DurationFieldType}}
+	entryLog := &models.EntryLog{Version: "1.0.0", Entries: []models.DayEntry{
+		{Date: "2025-06-30", Habits: []models.HabitEntry{{HabitID: "reading", Value: "3h", Status: models.EntryCompleted}}},
+		{Date: "2025-07-01", Habits: []models.HabitEntry{{HabitID: "reading", Value: "1h30m", Status: models.EntryCompleted}}},
+		{Date: "2025-07-10", Habits: []models.HabitEntry{{HabitID: "reading", Value: "45m", Status: models.EntryFailed}}},
+		{Date: "2025-07-12", Habits: []models.HabitEntry{{HabitID: "reading", Status: models.EntrySkipped}}},
+		{Date: "2025-07-19", Habits: []models.HabitEntry{{HabitID: "reading", Value: "2h05m", Status: models.EntryCompleted}}},
+	}}
+	goals := []models.Goal{{ID: "read_20_hours", Title: "Read 20 hours", HabitID: "reading", Measure: models.GoalTotal, Target: 1200, Period: models.GoalQuarter}}
+
+	progress, err := NewCalculator(today).Goals(goals, []models.Habit{reading}, entryLog)
+	require.NoError(t, err)
+	require.Len(t, progress, 1)
+
+	// The quarter started on 07-01; failed entries still add their value
+	assert.Equal(t, float64(260), progress[0].Current)
+	assert.Equal(t, "2025-07-01", progress[0].Start)
+	assert.Equal(t, "2025-09-30", progress[0].Due)
+	assert.Equal(t, "4h20m/20h", progress[0].Amounts())
+	assert.Equal(t, GoalBehind, progress[0].Status)
+	assert.Contains(t, progress[0].Summary(), "4h20m/20h (22%) behind, projected ")
+
+	t.Run("unknown habit", func(t *testing.T) {
+		_, err := NewCalculator(today).Goals(goals, nil, entryLog)
+		assert.Error(t, err)
+	})
+}
+
+func TestFormatGoalAmount(t *testing.T) {
+	assert.Equal(t, "45m", FormatGoalAmount(45, models.DurationFieldType))
+	assert.Equal(t, "20h", FormatGoalAmount(1200, models.DurationFieldType))
+	assert.Equal(t, "2h5m", FormatGoalAmount(125, models.DurationFieldType))
+	assert.Equal(t, "12.5", FormatGoalAmount(12.5, models.DecimalFieldType))
+	assert.Equal(t, "100", FormatGoalAmount(100, models.UnsignedIntFieldType))
+}
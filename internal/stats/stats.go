@@ -0,0 +1,263 @@
+// Package stats computes streak and completion statistics from habit entry history.
+// AIDEV-NOTE: stats-package; read-only analysis over models.EntryLog, reused by `vice stats` and history views
+package stats
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/davidlee/vice/internal/models"
+	"github.com/davidlee/vice/internal/scoring"
+)
+
+// DefaultWindows are the completion-rate windows reported by default, in days.
+var DefaultWindows = []int{7, 30, 90}
+
+const dateFormat = "2006-01-02"
+
+// DayOutcome classifies a single day for a habit.
+type DayOutcome int
+
+// Day outcomes used for streaks and completion rates.
+const (
+	OutcomeNeutral   DayOutcome = iota // Not due, target met, or no entry where none was required
+	OutcomeCompleted                   // Completed entry
+	OutcomeFailed                      // Failed entry
+	OutcomeSkipped                     // Skipped entry - streak-neutral
+	OutcomeMissed                      // Due but no entry
+)
+
+// Day is one day of a habit's history.
+type Day struct {
+	Date    time.Time
+	Outcome DayOutcome
+	Entry   *models.HabitEntry // nil when there is no entry
+}
+
+// CompletionRate is the share of due days completed within a window ending today.
+type CompletionRate struct {
+	Days      int     `json:"days"`
+	Completed int     `json:"completed"`
+	Due       int     `json:"due"`  // completed + failed + missed
+	Rate      float64 `json:"rate"` // 0-100; 0 when nothing was due
+}
+
+// HabitStats summarizes a single habit's history.
+type HabitStats struct {
+	HabitID       string                          `json:"habit_id"`
+	Title         string                          `json:"title"`
+	HabitType     models.HabitType                `json:"habit_type"`
+	CurrentStreak int                             `json:"current_streak"`
+	LongestStreak int                             `json:"longest_streak"`
+	Rates         []CompletionRate                `json:"completion_rates"`
+	Completed     int                             `json:"completed"`
+	Failed        int                             `json:"failed"`
+	Skipped       int                             `json:"skipped"`
+	Missed        int                             `json:"missed"`
+	Levels        map[models.AchievementLevel]int `json:"levels,omitempty"` // Elastic habits only
+}
+
+// Calculator computes statistics relative to a fixed "today".
+type Calculator struct {
+	engine  *scoring.Engine
+	today   time.Time
+	windows []int
+}
+
+// NewCalculator creates a calculator using now as today and the default windows.
+func NewCalculator(now time.Time) *Calculator {
+	return &Calculator{
+		engine:  scoring.NewEngine(),
+		today:   time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()),
+		windows: DefaultWindows,
+	}
+}
+
+// WithWindows overrides the completion-rate windows (in days).
+func (c *Calculator) WithWindows(windows []int) *Calculator {
+	c.windows = windows
+	return c
+}
+
+// Compute returns statistics for every habit, in habit order.
+func (c *Calculator) Compute(habits []models.Habit, entryLog *models.EntryLog) ([]HabitStats, error) {
+	result := make([]HabitStats, 0, len(habits))
+	for i := range habits {
+		habitStats, err := c.ComputeHabit(&habits[i], entryLog)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *habitStats)
+	}
+	return result, nil
+}
+
+// ComputeHabit returns statistics for one habit. History starts at the habit's
+// first entry, so days before it was tracked are not counted as missed.
+func (c *Calculator) ComputeHabit(habit *models.Habit, entryLog *models.EntryLog) (*HabitStats, error) {
+	if habit == nil {
+		return nil, fmt.Errorf("habit cannot be nil")
+	}
+
+	habitStats := &HabitStats{
+		HabitID:   habit.ID,
+		Title:     habit.Title,
+		HabitType: habit.HabitType,
+	}
+	if habit.IsElastic() {
+		habitStats.Levels = make(map[models.AchievementLevel]int)
+	}
+
+	days, err := c.History(habit, entryLog)
+	if err != nil {
+		return nil, err
+	}
+
+	// Totals, elastic levels and streaks, oldest day first
+	streak := 0
+	for _, day := range days {
+		switch day.Outcome {
+		case OutcomeCompleted:
+			habitStats.Completed++
+			streak++
+			if streak > habitStats.LongestStreak {
+				habitStats.LongestStreak = streak
+			}
+		case OutcomeFailed:
+			habitStats.Failed++
+			streak = 0
+		case OutcomeMissed:
+			habitStats.Missed++
+			streak = 0
+		case OutcomeSkipped:
+			habitStats.Skipped++
+		}
+
+		if habitStats.Levels != nil && day.Entry != nil && !day.Entry.IsSkipped() && day.Entry.AchievementLevel != nil {
+			habitStats.Levels[*day.Entry.AchievementLevel]++
+		}
+	}
+	habitStats.CurrentStreak = streak
+
+	for _, window := range c.windows {
+		habitStats.Rates = append(habitStats.Rates, completionRate(days, window))
+	}
+
+	return habitStats, nil
+}
+
+// History classifies each day from the habit's first entry through today, oldest first.
+// Returns nil if the habit has no entries.
+func (c *Calculator) History(habit *models.Habit, entryLog *models.EntryLog) ([]Day, error) {
+	if entryLog == nil {
+		return nil, nil
+	}
+
+	start, ok := c.firstEntryDate(entryLog, habit.ID)
+	if !ok || start.After(c.today) {
+		return nil, nil
+	}
+
+	dayEntries, err := entryLog.GetEntriesForDateRange(start.Format(dateFormat), c.today.Format(dateFormat))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load entries for habit %s: %w", habit.ID, err)
+	}
+
+	entries := make(map[string]*models.HabitEntry)
+	for i := range dayEntries {
+		if entry, found := dayEntries[i].GetHabitEntry(habit.ID); found {
+			entries[dayEntries[i].Date] = entry
+		}
+	}
+
+	// completedBefore[i] counts completions before day i, so the completions in a
+	// schedule period are a difference of two counts rather than a rescan of the
+	// log per day. Periods starting before the first entry begin at index 0.
+	// AIDEV-NOTE: stats-history; single pass - mirrors scoring.Engine.EvaluateSchedule's due check,
+	// except that multi-day calendar periods record at most one miss, when they end short
+	index := make(map[string]int)
+	completedBefore := []int{0}
+
+	var days []Day
+	for i, date := 0, start; !date.After(c.today); i, date = i+1, date.AddDate(0, 0, 1) {
+		key := date.Format(dateFormat)
+		index[key] = i
+		day := Day{Date: date, Entry: entries[key]}
+
+		switch {
+		case day.Entry != nil && day.Entry.IsCompleted():
+			day.Outcome = OutcomeCompleted
+		case day.Entry != nil && day.Entry.HasFailure():
+			day.Outcome = OutcomeFailed
+		case day.Entry != nil && day.Entry.IsSkipped():
+			day.Outcome = OutcomeSkipped
+		case day.Entry != nil || date.Equal(c.today) || habit.Schedule.Target() > 1:
+			// Times-per-week habits have no fixed days, so a blank day is never a miss
+			day.Outcome = OutcomeNeutral
+		case habit.Schedule.IsScheduledOn(date):
+			// A calendar period longer than a day (monthly with no fixed day) has no
+			// fixed due day either: it's judged once, on its last day, and a blank
+			// day before that stays neutral like a times-per-week day
+			periodStart, periodEnd := habit.Schedule.Period(date)
+			if periodEnd.After(date) {
+				break
+			}
+			if completedBefore[i]-completedBefore[index[periodStart.Format(dateFormat)]] < habit.Schedule.Target() {
+				day.Outcome = OutcomeMissed
+			}
+		}
+
+		completed := completedBefore[i]
+		if day.Outcome == OutcomeCompleted {
+			completed++
+		}
+		completedBefore = append(completedBefore, completed)
+		days = append(days, day)
+	}
+
+	return days, nil
+}
+
+// completionRate summarizes the last window days (including today).
+func completionRate(days []Day, window int) CompletionRate {
+	rate := CompletionRate{Days: window}
+
+	start := len(days) - window
+	if start < 0 {
+		start = 0
+	}
+	for _, day := range days[start:] {
+		switch day.Outcome {
+		case OutcomeCompleted:
+			rate.Completed++
+			rate.Due++
+		case OutcomeFailed, OutcomeMissed:
+			rate.Due++
+		}
+	}
+
+	if rate.Due > 0 {
+		rate.Rate = float64(rate.Completed) / float64(rate.Due) * 100
+	}
+	return rate
+}
+
+// firstEntryDate returns the earliest date with an entry for the habit.
+func (c *Calculator) firstEntryDate(entryLog *models.EntryLog, habitID string) (time.Time, bool) {
+	var first time.Time
+	found := false
+	for i := range entryLog.Entries {
+		if _, ok := entryLog.Entries[i].GetHabitEntry(habitID); !ok {
+			continue
+		}
+		date, err := time.ParseInLocation(dateFormat, entryLog.Entries[i].Date, c.today.Location())
+		if err != nil {
+			continue
+		}
+		if !found || date.Before(first) {
+			first = date
+			found = true
+		}
+	}
+	return first, found
+}
@@ -0,0 +1,217 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidlee/vice/internal/models"
+)
+
+func completed(habitID string) models.HabitEntry {
+	return models.HabitEntry{HabitID: habitID, Status: models.EntryCompleted, Value: true}
+}
+
+func elastic(habitID string, level models.AchievementLevel) models.HabitEntry {
+	entry := completed(habitID)
+	entry.AchievementLevel = &level
+	return entry
+}
+
+func testEntryLog() *models.EntryLog {
+	return &models.EntryLog{
+		Version: "1.0.0",
+		Entries: []models.DayEntry{
+			{Date: "2025-07-10", Habits: []models.HabitEntry{completed("walk")}},
+			{Date: "2025-07-11", Habits: []models.HabitEntry{completed("walk")}},
+			// 2025-07-12 missing: missed
+			{Date: "2025-07-13", Habits: []models.HabitEntry{completed("walk")}},
+			{Date: "2025-07-14", Habits: []models.HabitEntry{
+				{HabitID: "walk", Status: models.EntrySkipped},
+				completed("gym"),
+			}},
+			{Date: "2025-07-15", Habits: []models.HabitEntry{completed("walk"), completed("yoga")}},
+			{Date: "2025-07-16", Habits: []models.HabitEntry{completed("walk"), completed("gym")}},
+			{Date: "2025-07-17", Habits: []models.HabitEntry{{HabitID: "walk", Status: models.EntryFailed, Value: false}}},
+			{Date: "2025-07-18", Habits: []models.HabitEntry{
+				completed("walk"),
+				completed("yoga"),
+				{HabitID: "run", Status: models.EntrySkipped},
+			}},
+			{Date: "2025-07-19", Habits: []models.HabitEntry{completed("walk"), elastic("run", models.AchievementMini)}},
+			{Date: "2025-07-20", Habits: []models.HabitEntry{elastic("run", models.AchievementMaxi)}},
+		},
+	}
+}
+
+func TestCalculator_ComputeHabit(t *testing.T) {
+	sunday := time.Date(2025, 7, 20, 18, 0, 0, 0, time.UTC)
+	calculator := NewCalculator(sunday)
+	entryLog := testEntryLog()
+
+	t.Run("daily habit streaks and totals", func(t *testing.T) {
+		habitStats, err := calculator.ComputeHabit(&models.Habit{ID: "walk", HabitType: models.SimpleHabit}, entryLog)
+		require.NoError(t, err)
+
+		// The skip on 07-14 keeps the streak alive; today without an entry doesn't break it
+		assert.Equal(t, 2, habitStats.CurrentStreak)
+		assert.Equal(t, 3, habitStats.LongestStreak)
+		assert.Equal(t, 7, habitStats.Completed)
+		assert.Equal(t, 1, habitStats.Failed)
+		assert.Equal(t, 1, habitStats.Skipped)
+		assert.Equal(t, 1, habitStats.Missed)
+		assert.Nil(t, habitStats.Levels)
+
+		require.Len(t, habitStats.Rates, 3)
+		assert.Equal(t, CompletionRate{Days: 7, Completed: 4, Due: 5, Rate: 80}, habitStats.Rates[0])
+		assert.Equal(t, 7, habitStats.Rates[1].Completed)
+		assert.Equal(t, 9, habitStats.Rates[1].Due)
+		assert.InDelta(t, 77.78, habitStats.Rates[1].Rate, 0.01)
+	})
+
+	t.Run("weekday schedule only counts scheduled days", func(t *testing.T) {
+		habit := &models.Habit{ID: "gym", Schedule: &models.Schedule{
+			Frequency: models.WeekdaysFrequency,
+			Weekdays:  []string{"mon", "wed", "fri"},
+		}}
+		habitStats, err := calculator.ComputeHabit(habit, entryLog)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, habitStats.CurrentStreak, "friday was missed")
+		assert.Equal(t, 2, habitStats.LongestStreak)
+		assert.Equal(t, 1, habitStats.Missed)
+		assert.Equal(t, CompletionRate{Days: 7, Completed: 2, Due: 3, Rate: float64(2) / 3 * 100}, habitStats.Rates[0])
+	})
+
+	t.Run("times per week habit has no daily misses", func(t *testing.T) {
+		habit := &models.Habit{ID: "yoga", Schedule: &models.Schedule{Frequency: models.TimesPerWeekFrequency, Times: 3}}
+		habitStats, err := calculator.ComputeHabit(habit, entryLog)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, habitStats.CurrentStreak)
+		assert.Equal(t, 0, habitStats.Missed)
+		assert.InDelta(t, 100.0, habitStats.Rates[0].Rate, 0.001)
+	})
+
+	t.Run("elastic habit level distribution", func(t *testing.T) {
+		habitStats, err := calculator.ComputeHabit(&models.Habit{ID: "run", HabitType: models.ElasticHabit}, entryLog)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, habitStats.CurrentStreak)
+		assert.Equal(t, 1, habitStats.Skipped)
+		assert.Equal(t, map[models.AchievementLevel]int{
+			models.AchievementMini: 1,
+			models.AchievementMaxi: 1,
+		}, habitStats.Levels)
+	})
+
+	t.Run("habit without entries", func(t *testing.T) {
+		habitStats, err := calculator.ComputeHabit(&models.Habit{ID: "unused"}, entryLog)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, habitStats.CurrentStreak)
+		assert.Equal(t, 0, habitStats.Missed)
+		for _, rate := range habitStats.Rates {
+			assert.Zero(t, rate.Due)
+			assert.Zero(t, rate.Rate)
+		}
+	})
+}
+
+func TestCalculator_Compute(t *testing.T) {
+	calculator := NewCalculator(time.Date(2025, 7, 20, 0, 0, 0, 0, time.UTC)).WithWindows([]int{7})
+	habits := []models.Habit{{ID: "walk"}, {ID: "run", HabitType: models.ElasticHabit}}
+
+	result, err := calculator.Compute(habits, testEntryLog())
+	require.NoError(t, err)
+
+	require.Len(t, result, 2)
+	assert.Equal(t, "walk", result[0].HabitID)
+	assert.Equal(t, "run", result[1].HabitID)
+	assert.Len(t, result[0].Rates, 1)
+}
+
+func TestCalculator_History(t *testing.T) {
+	calculator := NewCalculator(time.Date(2025, 7, 20, 0, 0, 0, 0, time.UTC))
+
+	days, err := calculator.History(&models.Habit{ID: "walk"}, testEntryLog())
+	require.NoError(t, err)
+
+	require.Len(t, days, 11)
+	assert.Equal(t, "2025-07-10", days[0].Date.Format("2006-01-02"))
+	assert.Equal(t, OutcomeMissed, days[2].Outcome)
+	assert.Nil(t, days[2].Entry)
+	assert.Equal(t, OutcomeSkipped, days[4].Outcome)
+	assert.Equal(t, OutcomeFailed, days[7].Outcome)
+	assert.Equal(t, OutcomeNeutral, days[10].Outcome)
+}
+
+func TestCalculator_HistoryMonthly(t *testing.T) {
+	calculator := NewCalculator(time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC))
+	habit := &models.Habit{ID: "budget", Schedule: &models.Schedule{Frequency: models.MonthlyFrequency}}
+
+	t.Run("done once a month", func(t *testing.T) {
+		entryLog := &models.EntryLog{Version: "1.0.0", Entries: []models.DayEntry{
+			{Date: "2026-09-01", Habits: []models.HabitEntry{completed("budget")}},
+			{Date: "2026-09-20", Habits: []models.HabitEntry{completed("budget")}},
+			{Date: "2026-10-15", Habits: []models.HabitEntry{completed("budget")}},
+		}}
+		habitStats, err := calculator.ComputeHabit(habit, entryLog)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, habitStats.Missed, "days before the month's completion aren't misses")
+		for _, rate := range habitStats.Rates {
+			assert.InDelta(t, 100.0, rate.Rate, 0.001, "%d-day rate", rate.Days)
+		}
+	})
+
+	t.Run("month without a completion", func(t *testing.T) {
+		entryLog := &models.EntryLog{Version: "1.0.0", Entries: []models.DayEntry{
+			{Date: "2026-08-10", Habits: []models.HabitEntry{completed("budget")}},
+			{Date: "2026-10-15", Habits: []models.HabitEntry{completed("budget")}},
+		}}
+		days, err := calculator.History(habit, entryLog)
+		require.NoError(t, err)
+
+		var missed []string
+		for _, day := range days {
+			if day.Outcome == OutcomeMissed {
+				missed = append(missed, day.Date.Format(dateFormat))
+			}
+		}
+		assert.Equal(t, []string{"2026-09-30"}, missed, "one miss, on the last day of the short month")
+	})
+}
+
+func TestCalculator_HistoryMatchesSchedule(t *testing.T) {
+	today := time.Date(2025, 7, 20, 0, 0, 0, 0, time.UTC)
+	calculator := NewCalculator(today)
+	entryLog := testEntryLog()
+
+	schedules := map[string]*models.Schedule{
+		"every 3 days": {Frequency: models.EveryNDaysFrequency, Interval: 3},
+		"once a week":  {Frequency: models.TimesPerWeekFrequency, Times: 1},
+		"weekdays":     {Frequency: models.WeekdaysFrequency, Weekdays: []string{"monday", "saturday"}},
+		"unscheduled":  nil,
+		"day of month": {Frequency: models.MonthlyFrequency, DayOfMonth: 12},
+		"every 2 days": {Frequency: models.EveryNDaysFrequency, Interval: 2},
+	}
+	for name, schedule := range schedules {
+		t.Run(name, func(t *testing.T) {
+			habit := &models.Habit{ID: "walk", Schedule: schedule}
+			days, err := calculator.History(habit, entryLog)
+			require.NoError(t, err)
+
+			for _, day := range days {
+				if day.Entry != nil || day.Date.Equal(today) {
+					continue
+				}
+				result, err := calculator.engine.EvaluateSchedule(habit, entryLog, day.Date)
+				require.NoError(t, err)
+				assert.Equal(t, result.Status == models.ScheduleDue, day.Outcome == OutcomeMissed, day.Date.Format(dateFormat))
+			}
+		})
+	}
+}
@@ -3,14 +3,15 @@
 package storage
 
 import (
+	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"gopkg.in/yaml.v3"
 
 	"github.com/davidlee/vice/internal/models"
+	"github.com/davidlee/vice/internal/safefile"
 )
 
 // BackupConfig defines backup behavior for entries storage
@@ -32,13 +33,21 @@ func DefaultBackupConfig() BackupConfig {
 
 // EntryStorage handles the persistent storage of entry logs.
 // AIDEV-NOTE: T021 storage-with-config; future enhancement to include BackupConfig field
-type EntryStorage struct{}
+type EntryStorage struct {
+	beforeSave func(*models.EntryLog) error
+}
 
 // NewEntryStorage creates a new entry storage instance.
 func NewEntryStorage() *EntryStorage {
 	return &EntryStorage{}
 }
 
+// SetBeforeSave registers a hook run on the entry log before every save, such as
+// recomputing derived habits. Passing nil removes it.
+func (es *EntryStorage) SetBeforeSave(hook func(*models.EntryLog) error) {
+	es.beforeSave = hook
+}
+
 // LoadFromFile loads an entry log from the specified file path.
 // If the file doesn't exist, it returns an empty entry log.
 // AIDEV-NOTE: T021 file-load-pattern; graceful handling of missing files, strict YAML parsing
@@ -81,12 +90,74 @@ func (es *EntryStorage) ParseYAML(data []byte) (*models.EntryLog, error) {
 }
 
 // SaveToFile saves an entry log to the specified file path with atomic writes.
-// This creates a temporary file first, then renames it to prevent corruption.
+// This writes a temporary file under the file's lock, then renames it to prevent corruption.
 // AIDEV-NOTE: T021 atomic-write-pattern; temp-file + rename ensures data consistency, validates before marshaling
 func (es *EntryStorage) SaveToFile(entryLog *models.EntryLog, filePath string) error {
+	data, err := es.marshal(entryLog)
+	if err != nil {
+		return err
+	}
+	return safefile.WriteFile(filePath, data, 0o600)
+}
+
+// ErrNoChanges can be returned by a Modify function to leave the file as it is.
+var ErrNoChanges = errors.New("no changes to save")
+
+// Modify runs a load-modify-save cycle on the entries file while holding its lock,
+// so concurrent vice processes can't interleave their changes. If modify returns
+// ErrNoChanges nothing is written and Modify succeeds.
+func (es *EntryStorage) Modify(filePath string, modify func(*models.EntryLog) error) (*models.EntryLog, error) {
+	return es.modify(filePath, nil, modify)
+}
+
+// ModifyWithBackup is Modify, backing up the file per config before it is rewritten.
+func (es *EntryStorage) ModifyWithBackup(filePath string, config BackupConfig, modify func(*models.EntryLog) error) (*models.EntryLog, error) {
+	return es.modify(filePath, &config, modify)
+}
+
+// modify is Modify with an optional backup of the file taken under the lock.
+// AIDEV-NOTE: entries-locked-update; every load-modify-save helper below goes through here
+func (es *EntryStorage) modify(filePath string, backup *BackupConfig, modify func(*models.EntryLog) error) (*models.EntryLog, error) {
+	unlock, err := safefile.Lock(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	entryLog, err := es.LoadFromFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load existing entries: %w", err)
+	}
+	if err := modify(entryLog); errors.Is(err, ErrNoChanges) {
+		return entryLog, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if backup != nil {
+		es.backupBeforeWrite(filePath, *backup)
+	}
+	data, err := es.marshal(entryLog)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := safefile.WriteLocked(filePath, data, 0o600); err != nil {
+		return nil, err
+	}
+	return entryLog, nil
+}
+
+// marshal runs the before-save hook, validates the entry log and renders it as YAML.
+func (es *EntryStorage) marshal(entryLog *models.EntryLog) ([]byte, error) {
+	if es.beforeSave != nil {
+		if err := es.beforeSave(entryLog); err != nil {
+			return nil, err
+		}
+	}
+
 	// Validate before saving
 	if err := entryLog.Validate(); err != nil {
-		return fmt.Errorf("cannot save invalid entry log: %w", err)
+		return nil, fmt.Errorf("cannot save invalid entry log: %w", err)
 	}
 
 	// Marshal to YAML with pretty formatting
@@ -94,7 +165,7 @@ func (es *EntryStorage) SaveToFile(entryLog *models.EntryLog, filePath string) e
 	encoder := yaml.NewEncoder(&buf)
 	encoder.SetIndent(2)
 	if err := encoder.Encode(entryLog); err != nil {
-		return fmt.Errorf("failed to marshal entry log to YAML: %w", err)
+		return nil, fmt.Errorf("failed to marshal entry log to YAML: %w", err)
 	}
 	_ = encoder.Close()
 	data := []byte(buf.String())
@@ -103,48 +174,24 @@ func (es *EntryStorage) SaveToFile(entryLog *models.EntryLog, filePath string) e
 	// AIDEV-NOTE: T021 marshal-validation; prevents corrupted data from being written
 	var testLog models.EntryLog
 	if err := yaml.Unmarshal(data, &testLog); err != nil {
-		return fmt.Errorf("marshalled data failed validation - would produce corrupted file: %w", err)
-	}
-
-	// Ensure the directory exists
-	dir := filepath.Dir(filePath)
-	if err := os.MkdirAll(dir, 0o750); err != nil {
-		return fmt.Errorf("failed to create directory %s: %w", dir, err)
-	}
-
-	// Write to temporary file first for atomic operation
-	tempFile := filePath + ".tmp"
-	if err := os.WriteFile(tempFile, data, 0o600); err != nil {
-		return fmt.Errorf("failed to write temporary file %s: %w", tempFile, err)
-	}
-
-	// Atomically rename temporary file to final file
-	if err := os.Rename(tempFile, filePath); err != nil {
-		// Clean up temporary file on failure
-		_ = os.Remove(tempFile) // Ignore error since we're already in error state
-		return fmt.Errorf("failed to rename temporary file to %s: %w", filePath, err)
+		return nil, fmt.Errorf("marshalled data failed validation - would produce corrupted file: %w", err)
 	}
 
-	return nil
+	return data, nil
 }
 
 // AddDayEntry adds a day entry to the entry log file.
 // This loads the existing log, adds the entry, and saves it back.
 func (es *EntryStorage) AddDayEntry(filePath string, dayEntry models.DayEntry) error {
-	// Load existing entry log
-	entryLog, err := es.LoadFromFile(filePath)
-	if err != nil {
-		return fmt.Errorf("failed to load existing entries: %w", err)
-	}
-
-	// Add the day entry
-	if err := entryLog.AddDayEntry(dayEntry); err != nil {
-		return fmt.Errorf("failed to add day entry: %w", err)
-	}
-
-	// Save the updated log with automatic backup
+	// Add the day entry and save the updated log with automatic backup
 	config := DefaultBackupConfig()
-	if err := es.SaveToFileWithBackup(entryLog, filePath, config); err != nil {
+	_, err := es.modify(filePath, &config, func(entryLog *models.EntryLog) error {
+		if err := entryLog.AddDayEntry(dayEntry); err != nil {
+			return fmt.Errorf("failed to add day entry: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
 		return fmt.Errorf("failed to save updated entries: %w", err)
 	}
 
@@ -154,41 +201,52 @@ func (es *EntryStorage) AddDayEntry(filePath string, dayEntry models.DayEntry) e
 // SaveToFileWithBackup saves an entry log with optional automatic backup based on configuration.
 // AIDEV-NOTE: T021 resilient-save; automatic backup + validation before atomic write
 func (es *EntryStorage) SaveToFileWithBackup(entryLog *models.EntryLog, filePath string, config BackupConfig) error {
-	// Create automatic backup if enabled and file exists
-	if config.Enabled && config.CreateBeforeWrite {
-		if _, err := os.Stat(filePath); err == nil {
-			// File exists, create backup
-			if backupErr := es.BackupFile(filePath); backupErr != nil {
-				// Log warning but don't fail - backup is best-effort
-				// In a real implementation, this would use a proper logger
-				fmt.Fprintf(os.Stderr, "Warning: failed to create backup before write: %v\n", backupErr)
-			}
-		}
+	data, err := es.marshal(entryLog)
+	if err != nil {
+		return err
+	}
+
+	unlock, err := safefile.Lock(filePath)
+	if err != nil {
+		return err
 	}
+	defer unlock()
+
+	// Proceed with standard atomic save once the backup is taken
+	es.backupBeforeWrite(filePath, config)
+	_, err = safefile.WriteLocked(filePath, data, 0o600)
+	return err
+}
 
-	// Proceed with standard atomic save (includes validation)
-	return es.SaveToFile(entryLog, filePath)
+// backupBeforeWrite backs up an existing file if config asks for it. The caller holds the file's lock.
+func (es *EntryStorage) backupBeforeWrite(filePath string, config BackupConfig) {
+	if !config.Enabled || !config.CreateBeforeWrite {
+		return
+	}
+	if _, err := os.Stat(filePath); err == nil {
+		// File exists, create backup
+		if backupErr := es.BackupFile(filePath); backupErr != nil {
+			// Log warning but don't fail - backup is best-effort
+			// In a real implementation, this would use a proper logger
+			fmt.Fprintf(os.Stderr, "Warning: failed to create backup before write: %v\n", backupErr)
+		}
+	}
 }
 
 // UpdateDayEntry updates or creates a day entry in the entry log file.
 // This loads the existing log, updates the entry, and saves it back.
 // AIDEV-NOTE: T021 load-modify-save-pattern; most common entry operation, full file rewrite on each save
 func (es *EntryStorage) UpdateDayEntry(filePath string, dayEntry models.DayEntry) error {
-	// Load existing entry log
-	entryLog, err := es.LoadFromFile(filePath)
-	if err != nil {
-		return fmt.Errorf("failed to load existing entries: %w", err)
-	}
-
-	// Update the day entry
-	if err := entryLog.UpdateDayEntry(dayEntry); err != nil {
-		return fmt.Errorf("failed to update day entry: %w", err)
-	}
-
-	// Save the updated log with automatic backup
+	// Update the day entry and save the updated log with automatic backup
 	// AIDEV-NOTE: T021 auto-backup-integration; uses default config for automatic backup
 	config := DefaultBackupConfig()
-	if err := es.SaveToFileWithBackup(entryLog, filePath, config); err != nil {
+	_, err := es.modify(filePath, &config, func(entryLog *models.EntryLog) error {
+		if err := entryLog.UpdateDayEntry(dayEntry); err != nil {
+			return fmt.Errorf("failed to update day entry: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
 		return fmt.Errorf("failed to save updated entries: %w", err)
 	}
 
@@ -221,33 +279,28 @@ func (es *EntryStorage) GetTodayEntry(filePath string) (*models.DayEntry, error)
 // AddHabitEntry adds a habit entry to a specific day in the entry log file.
 // If the day doesn't exist, it creates a new day entry.
 func (es *EntryStorage) AddHabitEntry(filePath string, date string, habitEntry models.HabitEntry) error {
-	// Load existing entry log
-	entryLog, err := es.LoadFromFile(filePath)
-	if err != nil {
-		return fmt.Errorf("failed to load existing entries: %w", err)
-	}
-
-	// Find or create day entry
-	dayEntry, found := entryLog.GetDayEntry(date)
-	if !found {
-		// Create new day entry
-		newDayEntry := models.DayEntry{
-			Date:   date,
-			Habits: []models.HabitEntry{},
-		}
-		if err := entryLog.AddDayEntry(newDayEntry); err != nil {
-			return fmt.Errorf("failed to create day entry for %s: %w", date, err)
+	_, err := es.Modify(filePath, func(entryLog *models.EntryLog) error {
+		// Find or create day entry
+		dayEntry, found := entryLog.GetDayEntry(date)
+		if !found {
+			// Create new day entry
+			newDayEntry := models.DayEntry{
+				Date:   date,
+				Habits: []models.HabitEntry{},
+			}
+			if err := entryLog.AddDayEntry(newDayEntry); err != nil {
+				return fmt.Errorf("failed to create day entry for %s: %w", date, err)
+			}
+			dayEntry, _ = entryLog.GetDayEntry(date)
 		}
-		dayEntry, _ = entryLog.GetDayEntry(date)
-	}
-
-	// Add the habit entry
-	if err := dayEntry.AddHabitEntry(habitEntry); err != nil {
-		return fmt.Errorf("failed to add habit entry: %w", err)
-	}
 
-	// Save the updated log
-	if err := es.SaveToFile(entryLog, filePath); err != nil {
+		// Add the habit entry
+		if err := dayEntry.AddHabitEntry(habitEntry); err != nil {
+			return fmt.Errorf("failed to add habit entry: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
 		return fmt.Errorf("failed to save updated entries: %w", err)
 	}
 
@@ -257,33 +310,28 @@ func (es *EntryStorage) AddHabitEntry(filePath string, date string, habitEntry m
 // UpdateHabitEntry updates or creates a habit entry for a specific day in the entry log file.
 // If the day doesn't exist, it creates a new day entry.
 func (es *EntryStorage) UpdateHabitEntry(filePath string, date string, habitEntry models.HabitEntry) error {
-	// Load existing entry log
-	entryLog, err := es.LoadFromFile(filePath)
-	if err != nil {
-		return fmt.Errorf("failed to load existing entries: %w", err)
-	}
-
-	// Find or create day entry
-	dayEntry, found := entryLog.GetDayEntry(date)
-	if !found {
-		// Create new day entry
-		newDayEntry := models.DayEntry{
-			Date:   date,
-			Habits: []models.HabitEntry{},
-		}
-		if err := entryLog.UpdateDayEntry(newDayEntry); err != nil {
-			return fmt.Errorf("failed to create day entry for %s: %w", date, err)
+	_, err := es.Modify(filePath, func(entryLog *models.EntryLog) error {
+		// Find or create day entry
+		dayEntry, found := entryLog.GetDayEntry(date)
+		if !found {
+			// Create new day entry
+			newDayEntry := models.DayEntry{
+				Date:   date,
+				Habits: []models.HabitEntry{},
+			}
+			if err := entryLog.UpdateDayEntry(newDayEntry); err != nil {
+				return fmt.Errorf("failed to create day entry for %s: %w", date, err)
+			}
+			dayEntry, _ = entryLog.GetDayEntry(date)
 		}
-		dayEntry, _ = entryLog.GetDayEntry(date)
-	}
-
-	// Update the habit entry
-	if err := dayEntry.UpdateHabitEntry(habitEntry); err != nil {
-		return fmt.Errorf("failed to update habit entry: %w", err)
-	}
 
-	// Save the updated log
-	if err := es.SaveToFile(entryLog, filePath); err != nil {
+		// Update the habit entry
+		if err := dayEntry.UpdateHabitEntry(habitEntry); err != nil {
+			return fmt.Errorf("failed to update habit entry: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
 		return fmt.Errorf("failed to save updated entries: %w", err)
 	}
 
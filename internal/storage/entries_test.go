@@ -1,8 +1,10 @@
 package storage
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
@@ -232,6 +234,24 @@ func TestEntryStorage_SaveToFile(t *testing.T) {
 		require.NoError(t, err)
 		assert.True(t, info.IsDir())
 	})
+
+	t.Run("before-save hook", func(t *testing.T) {
+		entriesFile := filepath.Join(t.TempDir(), "entries.yml")
+		hooked := NewEntryStorage()
+		hooked.SetBeforeSave(func(entryLog *models.EntryLog) error {
+			entryLog.Entries = append(entryLog.Entries, models.DayEntry{Date: "2024-01-02", Habits: []models.HabitEntry{}})
+			return nil
+		})
+
+		require.NoError(t, hooked.SaveToFile(models.CreateEmptyEntryLog(), entriesFile))
+		loadedLog, err := storage.LoadFromFile(entriesFile)
+		require.NoError(t, err)
+		require.Len(t, loadedLog.Entries, 1)
+		assert.Equal(t, "2024-01-02", loadedLog.Entries[0].Date)
+
+		hooked.SetBeforeSave(func(*models.EntryLog) error { return assert.AnError })
+		assert.ErrorIs(t, hooked.SaveToFile(models.CreateEmptyEntryLog(), entriesFile), assert.AnError)
+	})
 }
 
 func TestEntryStorage_AddDayEntry(t *testing.T) {
@@ -657,3 +677,25 @@ func TestEntryStorage_CreateSampleEntryLog(t *testing.T) {
 	assert.Equal(t, false, dayEntry.Habits[1].Value)
 	assert.NotEmpty(t, dayEntry.Habits[1].Notes)
 }
+
+func TestEntryStorage_ConcurrentUpdates(t *testing.T) {
+	storage := NewEntryStorage()
+	entriesFile := filepath.Join(t.TempDir(), "entries.yml")
+
+	// Each update is a locked load-modify-save, so none are lost
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			habitEntry := models.HabitEntry{HabitID: fmt.Sprintf("habit_%d", i), Value: true, Status: models.EntryCompleted, CreatedAt: time.Now()}
+			assert.NoError(t, storage.UpdateHabitEntry(entriesFile, "2025-07-20", habitEntry))
+		}(i)
+	}
+	wg.Wait()
+
+	entryLog, err := storage.LoadFromFile(entriesFile)
+	require.NoError(t, err)
+	require.Len(t, entryLog.Entries, 1)
+	assert.Len(t, entryLog.Entries[0].Habits, 10)
+}
@@ -0,0 +1,189 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/davidlee/vice/internal/models"
+)
+
+// Sides of a merge a conflict can be resolved to.
+const (
+	KeepOurs   = "ours"
+	KeepTheirs = "theirs"
+)
+
+// MergeConflict records a habit entry changed differently on both sides of a
+// merge, and which side was kept.
+type MergeConflict struct {
+	Date    string
+	HabitID string
+	Ours    *models.HabitEntry // nil if deleted on our side
+	Theirs  *models.HabitEntry // nil if deleted on their side
+	Kept    string             // KeepOurs or KeepTheirs
+}
+
+// MergeChange is an entry a merge took from theirs without a conflict.
+type MergeChange struct {
+	Date    string
+	HabitID string
+	Entry   *models.HabitEntry // nil if theirs deleted it
+	Ours    *models.HabitEntry // what ours had, nil if nothing
+}
+
+// MergeReport describes what a merge did to ours.
+type MergeReport struct {
+	FromTheirs []MergeChange   // Entries theirs added, changed or deleted
+	Conflicts  []MergeConflict // Entries both changed, with the side kept
+	Kept       int             // Entries ours already had right
+}
+
+// ConflictResolver chooses the side of a conflict to keep: KeepOurs or KeepTheirs.
+type ConflictResolver func(conflict *MergeConflict) (string, error)
+
+// KeepLatest resolves a conflict to the most recently modified entry
+// (UpdatedAt, else CreatedAt), ours on a tie. An edit beats a deletion.
+func KeepLatest(conflict *MergeConflict) (string, error) {
+	if conflict.Ours == nil || (conflict.Theirs != nil && conflict.Theirs.GetLastModified().After(conflict.Ours.GetLastModified())) {
+		return KeepTheirs, nil
+	}
+	return KeepOurs, nil
+}
+
+// entryKey identifies a habit entry within an entry log.
+type entryKey struct {
+	date    string
+	habitID string
+}
+
+// MergeEntries merges two copies of an entry log with no common ancestor, such
+// as entries.yml from two machines: the union of their days by date and of
+// each day's entries by habit_id. Entries that differ are conflicts, settled by
+// resolve (KeepLatest if nil).
+func MergeEntries(ours, theirs *models.EntryLog, resolve ConflictResolver) (*models.EntryLog, *MergeReport, error) {
+	return MergeEntryLogs(nil, ours, theirs, resolve)
+}
+
+// MergeEntryLogs merges two entry logs that diverged from base, entry by entry:
+// each (date, habit_id) pair takes whichever side changed it, so deletions on
+// one side carry over. When both changed the same entry differently, resolve
+// (KeepLatest if nil) chooses. base may be nil or empty when there is no
+// common ancestor.
+// AIDEV-NOTE: entries-merge; shared by the git merge driver (vice sync merge-driver) and vice merge
+func MergeEntryLogs(base, ours, theirs *models.EntryLog, resolve ConflictResolver) (*models.EntryLog, *MergeReport, error) {
+	if resolve == nil {
+		resolve = KeepLatest
+	}
+	baseEntries := indexEntries(base)
+	ourEntries := indexEntries(ours)
+	theirEntries := indexEntries(theirs)
+
+	merged := &models.EntryLog{Version: ours.Version}
+	if merged.Version == "" {
+		merged.Version = theirs.Version
+	}
+
+	report := &MergeReport{}
+	for _, date := range mergedDates(ours, theirs) {
+		day := models.DayEntry{Date: date, Habits: []models.HabitEntry{}}
+		for _, habitID := range mergedHabitIDs(date, ours, theirs) {
+			key := entryKey{date: date, habitID: habitID}
+			entry, err := mergeEntry(key, baseEntries[key], ourEntries[key], theirEntries[key], resolve, report)
+			if err != nil {
+				return nil, nil, err
+			}
+			if entry != nil {
+				day.Habits = append(day.Habits, *entry)
+			}
+		}
+
+		_, ourDay := ours.GetDayEntry(date)
+		if len(day.Habits) > 0 || ourDay {
+			merged.Entries = append(merged.Entries, day)
+		}
+	}
+
+	return merged, report, nil
+}
+
+// mergeEntry three-way merges one habit entry, recording the outcome in the
+// report; nil means absent.
+func mergeEntry(key entryKey, base, ours, theirs *models.HabitEntry, resolve ConflictResolver, report *MergeReport) (*models.HabitEntry, error) {
+	switch {
+	case ours.Same(theirs), theirs.Same(base):
+		if ours != nil {
+			report.Kept++
+		}
+		return ours, nil
+	case ours.Same(base):
+		report.FromTheirs = append(report.FromTheirs, MergeChange{Date: key.date, HabitID: key.habitID, Entry: theirs, Ours: ours})
+		return theirs, nil
+	}
+
+	conflict := MergeConflict{Date: key.date, HabitID: key.habitID, Ours: ours, Theirs: theirs}
+	kept, err := resolve(&conflict)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s %s: %w", key.date, key.habitID, err)
+	}
+	if kept != KeepOurs && kept != KeepTheirs {
+		return nil, fmt.Errorf("invalid resolution for %s %s: %s", key.date, key.habitID, kept)
+	}
+	conflict.Kept = kept
+	report.Conflicts = append(report.Conflicts, conflict)
+
+	if kept == KeepTheirs {
+		return theirs, nil
+	}
+	return ours, nil
+}
+
+// indexEntries maps each habit entry in the log by date and habit ID.
+func indexEntries(entryLog *models.EntryLog) map[entryKey]*models.HabitEntry {
+	index := make(map[entryKey]*models.HabitEntry)
+	if entryLog == nil {
+		return index
+	}
+	for i := range entryLog.Entries {
+		day := &entryLog.Entries[i]
+		for j := range day.Habits {
+			index[entryKey{date: day.Date, habitID: day.Habits[j].HabitID}] = &day.Habits[j]
+		}
+	}
+	return index
+}
+
+// mergedDates returns every date on either side, in order.
+func mergedDates(ours, theirs *models.EntryLog) []string {
+	seen := make(map[string]bool)
+	var dates []string
+	for _, entryLog := range []*models.EntryLog{ours, theirs} {
+		for _, day := range entryLog.Entries {
+			if !seen[day.Date] {
+				seen[day.Date] = true
+				dates = append(dates, day.Date)
+			}
+		}
+	}
+	sort.Strings(dates)
+	return dates
+}
+
+// mergedHabitIDs returns the habits recorded on date on either side, ours first
+// in their order, then any only theirs has.
+func mergedHabitIDs(date string, ours, theirs *models.EntryLog) []string {
+	seen := make(map[string]bool)
+	var habitIDs []string
+	for _, entryLog := range []*models.EntryLog{ours, theirs} {
+		day, found := entryLog.GetDayEntry(date)
+		if !found {
+			continue
+		}
+		for _, entry := range day.Habits {
+			if !seen[entry.HabitID] {
+				seen[entry.HabitID] = true
+				habitIDs = append(habitIDs, entry.HabitID)
+			}
+		}
+	}
+	return habitIDs
+}
@@ -0,0 +1,207 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidlee/vice/internal/models"
+)
+
+func mergeTestEntry(habitID string, value interface{}, created time.Time) models.HabitEntry {
+	return models.HabitEntry{HabitID: habitID, Value: value, Status: models.EntryCompleted, CreatedAt: created}
+}
+
+func TestMergeEntryLogs(t *testing.T) {
+	morning := time.Date(2025, 7, 16, 8, 0, 0, 0, time.UTC)
+	evening := morning.Add(12 * time.Hour)
+
+	base := &models.EntryLog{Version: "1.0.0", Entries: []models.DayEntry{
+		{Date: "2025-07-15", Habits: []models.HabitEntry{
+			mergeTestEntry("meditation", true, morning.AddDate(0, 0, -1)),
+			mergeTestEntry("reading", "30m", morning.AddDate(0, 0, -1)),
+		}},
+	}}
+
+	t.Run("different habits on the same day", func(t *testing.T) {
+		ours := &models.EntryLog{Version: "1.0.0", Entries: append(base.Entries[:1:1],
+			models.DayEntry{Date: "2025-07-16", Habits: []models.HabitEntry{mergeTestEntry("meditation", true, morning)}})}
+		theirs := &models.EntryLog{Version: "1.0.0", Entries: append(base.Entries[:1:1],
+			models.DayEntry{Date: "2025-07-16", Habits: []models.HabitEntry{mergeTestEntry("reading", "45m", evening)}})}
+
+		merged, report, err := MergeEntryLogs(base, ours, theirs, nil)
+		require.NoError(t, err)
+		conflicts := report.Conflicts
+		assert.Empty(t, conflicts)
+		require.Len(t, merged.Entries, 2)
+		day, found := merged.GetDayEntry("2025-07-16")
+		require.True(t, found)
+		require.Len(t, day.Habits, 2)
+		assert.Equal(t, "meditation", day.Habits[0].HabitID)
+		assert.Equal(t, "reading", day.Habits[1].HabitID)
+		assert.NoError(t, merged.Validate())
+	})
+
+	t.Run("one side edits, the other deletes something else", func(t *testing.T) {
+		edited := mergeTestEntry("reading", "1h", morning.AddDate(0, 0, -1))
+		updated := evening
+		edited.UpdatedAt = &updated
+		ours := &models.EntryLog{Version: "1.0.0", Entries: []models.DayEntry{
+			{Date: "2025-07-15", Habits: []models.HabitEntry{base.Entries[0].Habits[0], edited}},
+		}}
+		theirs := &models.EntryLog{Version: "1.0.0", Entries: []models.DayEntry{
+			{Date: "2025-07-15", Habits: []models.HabitEntry{base.Entries[0].Habits[1]}},
+		}}
+
+		merged, report, err := MergeEntryLogs(base, ours, theirs, nil)
+		require.NoError(t, err)
+		conflicts := report.Conflicts
+		assert.Empty(t, conflicts)
+		require.Len(t, merged.Entries, 1)
+		assert.Equal(t, []models.HabitEntry{edited}, merged.Entries[0].Habits)
+	})
+
+	t.Run("both edit the same entry", func(t *testing.T) {
+		ours := &models.EntryLog{Version: "1.0.0", Entries: []models.DayEntry{
+			{Date: "2025-07-16", Habits: []models.HabitEntry{mergeTestEntry("reading", "20m", morning)}},
+		}}
+		theirs := &models.EntryLog{Version: "1.0.0", Entries: []models.DayEntry{
+			{Date: "2025-07-16", Habits: []models.HabitEntry{mergeTestEntry("reading", "45m", evening)}},
+		}}
+
+		merged, report, err := MergeEntryLogs(&models.EntryLog{}, ours, theirs, nil)
+		require.NoError(t, err)
+		conflicts := report.Conflicts
+		require.Len(t, conflicts, 1)
+		assert.Equal(t, "2025-07-16", conflicts[0].Date)
+		assert.Equal(t, "reading", conflicts[0].HabitID)
+		assert.Equal(t, "theirs", conflicts[0].Kept)
+		assert.Equal(t, "45m", merged.Entries[0].Habits[0].Value)
+
+		// Ours wins when it is newer
+		_, report, err = MergeEntryLogs(&models.EntryLog{}, theirs, ours, nil)
+		require.NoError(t, err)
+		conflicts = report.Conflicts
+		assert.Equal(t, "ours", conflicts[0].Kept)
+	})
+
+	t.Run("edit beats deletion", func(t *testing.T) {
+		edited := base.Entries[0].Habits[0]
+		edited.Notes = "long sit"
+		ours := &models.EntryLog{Version: "1.0.0", Entries: []models.DayEntry{
+			{Date: "2025-07-15", Habits: []models.HabitEntry{base.Entries[0].Habits[1]}},
+		}}
+		theirs := &models.EntryLog{Version: "1.0.0", Entries: []models.DayEntry{
+			{Date: "2025-07-15", Habits: []models.HabitEntry{edited, base.Entries[0].Habits[1]}},
+		}}
+
+		merged, report, err := MergeEntryLogs(base, ours, theirs, nil)
+		require.NoError(t, err)
+		conflicts := report.Conflicts
+		require.Len(t, conflicts, 1)
+		assert.Nil(t, conflicts[0].Ours)
+		assert.Equal(t, "theirs", conflicts[0].Kept)
+		assert.Len(t, merged.Entries[0].Habits, 2)
+	})
+
+	t.Run("day deleted on one side", func(t *testing.T) {
+		ours := &models.EntryLog{Version: "1.0.0", Entries: []models.DayEntry{}}
+		merged, report, err := MergeEntryLogs(base, ours, base, nil)
+		require.NoError(t, err)
+		conflicts := report.Conflicts
+		assert.Empty(t, conflicts)
+		assert.Empty(t, merged.Entries)
+		assert.Equal(t, "1.0.0", merged.Version)
+	})
+}
+
+func TestMergeEntries(t *testing.T) {
+	morning := time.Date(2025, 7, 16, 8, 0, 0, 0, time.UTC)
+	evening := morning.Add(12 * time.Hour)
+
+	// Laptop and desktop copies with no common ancestor
+	laptop := &models.EntryLog{Version: "1.0.0", Entries: []models.DayEntry{
+		{Date: "2025-07-15", Habits: []models.HabitEntry{mergeTestEntry("meditation", true, morning.AddDate(0, 0, -1))}},
+		{Date: "2025-07-16", Habits: []models.HabitEntry{
+			mergeTestEntry("meditation", true, morning),
+			mergeTestEntry("reading", "20m", morning),
+		}},
+	}}
+	desktop := &models.EntryLog{Version: "1.0.0", Entries: []models.DayEntry{
+		{Date: "2025-07-14", Habits: []models.HabitEntry{mergeTestEntry("reading", "1h", morning.AddDate(0, 0, -2))}},
+		{Date: "2025-07-16", Habits: []models.HabitEntry{
+			mergeTestEntry("meditation", true, morning),
+			mergeTestEntry("reading", "45m", evening),
+			mergeTestEntry("walk", true, evening),
+		}},
+	}}
+
+	t.Run("union with the latest edit kept", func(t *testing.T) {
+		merged, report, err := MergeEntries(laptop, desktop, nil)
+		require.NoError(t, err)
+		require.NoError(t, merged.Validate())
+
+		var dates []string
+		for _, day := range merged.Entries {
+			dates = append(dates, day.Date)
+		}
+		assert.Equal(t, []string{"2025-07-14", "2025-07-15", "2025-07-16"}, dates)
+
+		day, _ := merged.GetDayEntry("2025-07-16")
+		require.Len(t, day.Habits, 3)
+		assert.Equal(t, "45m", day.Habits[1].Value)
+
+		require.Len(t, report.FromTheirs, 2)
+		assert.Equal(t, MergeChange{Date: "2025-07-14", HabitID: "reading", Entry: &desktop.Entries[0].Habits[0]}, report.FromTheirs[0])
+		assert.Equal(t, "walk", report.FromTheirs[1].HabitID)
+		require.Len(t, report.Conflicts, 1)
+		assert.Equal(t, KeepTheirs, report.Conflicts[0].Kept)
+		assert.Equal(t, 2, report.Kept)
+
+		// The inputs are left alone
+		assert.Len(t, laptop.Entries, 2)
+	})
+
+	t.Run("resolver overrides", func(t *testing.T) {
+		var seen []string
+		keepOurs := func(conflict *MergeConflict) (string, error) {
+			suggested, err := KeepLatest(conflict)
+			seen = append(seen, conflict.HabitID+" "+suggested)
+			return KeepOurs, err
+		}
+		merged, report, err := MergeEntries(laptop, desktop, keepOurs)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"reading theirs"}, seen)
+		assert.Equal(t, KeepOurs, report.Conflicts[0].Kept)
+		day, _ := merged.GetDayEntry("2025-07-16")
+		assert.Equal(t, "20m", day.Habits[1].Value)
+	})
+
+	t.Run("resolver errors abort", func(t *testing.T) {
+		_, _, err := MergeEntries(laptop, desktop, func(*MergeConflict) (string, error) { return "", assert.AnError })
+		assert.ErrorContains(t, err, "failed to resolve 2025-07-16 reading")
+
+		_, _, err = MergeEntries(laptop, desktop, func(*MergeConflict) (string, error) { return "both", nil })
+		assert.ErrorContains(t, err, "invalid resolution for 2025-07-16 reading: both")
+	})
+	t.Run("same check-in on both sides", func(t *testing.T) {
+		// Logged on both machines at different times; YAML decoded one value as an int
+		ours := &models.EntryLog{Version: "1.0.0", Entries: []models.DayEntry{
+			{Date: "2025-07-16", Habits: []models.HabitEntry{mergeTestEntry("pushups", 20, morning)}},
+		}}
+		updated := evening
+		theirEntry := mergeTestEntry("pushups", 20.0, evening)
+		theirEntry.UpdatedAt = &updated
+		theirs := &models.EntryLog{Version: "1.0.0", Entries: []models.DayEntry{
+			{Date: "2025-07-16", Habits: []models.HabitEntry{theirEntry}},
+		}}
+
+		_, report, err := MergeEntries(ours, theirs, nil)
+		require.NoError(t, err)
+		assert.Empty(t, report.Conflicts)
+		assert.Empty(t, report.FromTheirs)
+		assert.Equal(t, 1, report.Kept)
+	})
+}
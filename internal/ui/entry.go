@@ -3,6 +3,7 @@ package ui
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
@@ -14,7 +15,7 @@ import (
 	"github.com/davidlee/vice/internal/ui/entry"
 )
 
-// EntryCollector handles the interactive collection of today's habit entries.
+// EntryCollector handles the interactive collection of habit entries for a single day (today by default).
 // AIDEV-NOTE: T010-entry-system-complete; All habit collection flows with field input components and scoring integration
 // Architecture: Uses habit collection flows from internal/ui/entry/ package with complete scoring engine integration
 type EntryCollector struct {
@@ -23,10 +24,24 @@ type EntryCollector struct {
 	scoringEngine *scoring.Engine
 	flowFactory   *entry.HabitCollectionFlowFactory
 	habits        []models.Habit
+	date          string                              // Day being recorded (YYYY-MM-DD)
 	entries       map[string]interface{}              // Stores raw values for all habit types
 	achievements  map[string]*models.AchievementLevel // Stores achievement levels for elastic habits
 	notes         map[string]string
 	statuses      map[string]models.EntryStatus // T012/2.1-enhanced: Stores entry completion status for skip functionality
+	stored        map[string]models.HabitEntry  // The day's entries as last loaded or saved, to spot changes made elsewhere
+	entryLog      *models.EntryLog              // Entry log as of the last save
+}
+
+// StaleEntriesError reports habits whose entries were changed by another process
+// since the collector loaded them, while also being changed in the collector.
+// The stored entries were kept; entering them again overwrites them.
+type StaleEntriesError struct {
+	HabitIDs []string
+}
+
+func (e *StaleEntriesError) Error() string {
+	return fmt.Sprintf("entries for %s changed on disk since they were loaded; kept the saved entries", strings.Join(e.HabitIDs, ", "))
 }
 
 // NewEntryCollector creates a new entry collector instance.
@@ -40,28 +55,54 @@ func NewEntryCollector(checklistsPath string) *EntryCollector {
 		entryStorage:  storage.NewEntryStorage(),
 		scoringEngine: scoringEngine,
 		flowFactory:   flowFactory,
+		date:          time.Now().Format("2006-01-02"),
 		entries:       make(map[string]interface{}),
 		achievements:  make(map[string]*models.AchievementLevel),
 		notes:         make(map[string]string),
 		statuses:      make(map[string]models.EntryStatus),
+		stored:        make(map[string]models.HabitEntry),
+	}
+}
+
+// SetSchema registers the schema so derived habits are recomputed whenever entries are saved.
+func (ec *EntryCollector) SetSchema(schema *models.Schema) {
+	ec.entryStorage.SetBeforeSave(ec.scoringEngine.RecomputeHook(schema))
+}
+
+// SetHistory sets where window criteria read earlier entries from, usually the repository.
+func (ec *EntryCollector) SetHistory(history scoring.EntryHistory) {
+	ec.scoringEngine.SetHistory(history)
+}
+
+// SetDate sets the day (YYYY-MM-DD) that entries are loaded from, saved to and scored for.
+func (ec *EntryCollector) SetDate(date string) {
+	ec.date = date
+	if day, err := time.ParseInLocation("2006-01-02", date, time.Local); err == nil {
+		ec.scoringEngine.SetDate(day)
 	}
 }
 
-// CollectTodayEntries runs the interactive UI to collect today's habit entries.
-func (ec *EntryCollector) CollectTodayEntries(habitsFile, entriesFile string) error {
+// Date returns the day (YYYY-MM-DD) entries are recorded for.
+func (ec *EntryCollector) Date() string {
+	return ec.date
+}
+
+// CollectEntries runs the interactive UI to collect habit entries for the collector's date.
+func (ec *EntryCollector) CollectEntries(habitsFile, entriesFile string) error {
 	// Load habit schema
 	schema, err := ec.habitParser.LoadFromFile(habitsFile)
 	if err != nil {
 		return fmt.Errorf("failed to load habits: %w", err)
 	}
 
-	// Get all habits (simple, elastic, and informational)
-	ec.habits = schema.Habits
+	// Get all habits entered by hand; derived habits are recomputed on save
+	ec.SetSchema(schema)
+	ec.habits = models.RecordableHabits(schema.Habits)
 	if len(ec.habits) == 0 {
 		return fmt.Errorf("no habits found in %s", habitsFile)
 	}
 
-	// Load existing entries for today (if any)
+	// Load existing entries for the day (if any)
 	if err := ec.loadExistingEntries(entriesFile); err != nil {
 		return fmt.Errorf("failed to load existing entries: %w", err)
 	}
@@ -87,18 +128,17 @@ func (ec *EntryCollector) CollectTodayEntries(habitsFile, entriesFile string) er
 	return nil
 }
 
-// loadExistingEntries loads any existing entries for today.
+// loadExistingEntries loads any existing entries for the collector's date.
 func (ec *EntryCollector) loadExistingEntries(entriesFile string) error {
-	today := time.Now().Format("2006-01-02")
-
-	dayEntry, err := ec.entryStorage.GetDayEntry(entriesFile, today)
+	dayEntry, err := ec.entryStorage.GetDayEntry(entriesFile, ec.date)
 	if err != nil {
-		// No existing entries for today, which is fine
-		return nil //nolint:nilerr // No entries for today is expected
+		// No existing entries for the day, which is fine
+		return nil //nolint:nilerr // No entries for the day is expected
 	}
 
 	// Load existing entries into our maps
 	for _, habitEntry := range dayEntry.Habits {
+		ec.stored[habitEntry.HabitID] = habitEntry
 		ec.entries[habitEntry.HabitID] = habitEntry.Value
 		ec.notes[habitEntry.HabitID] = habitEntry.Notes
 		ec.statuses[habitEntry.HabitID] = habitEntry.Status
@@ -150,42 +190,135 @@ func (ec *EntryCollector) collectHabitEntry(habit models.Habit) error {
 	return nil
 }
 
-// saveEntries saves all collected entries to the entries file.
+// saveEntries saves collected entries for the collector's date to the entries file.
+// Only new or changed habit entries are written; existing entries keep their CreatedAt
+// and get UpdatedAt set when they change.
+// Entries saved by another process since the collector loaded the day are merged in:
+// they're picked up where the collector has no change of its own, and kept (returning a
+// *StaleEntriesError) where both changed, rather than being overwritten.
+// AIDEV-NOTE: entry-timestamps; one locked load-modify-save so backfilled days and other habits are left intact
+// AIDEV-NOTE: entry-stale-merge; ec.stored is the merge base, disk is theirs, the collector's maps are ours
 func (ec *EntryCollector) saveEntries(entriesFile string) error {
-	today := time.Now().Format("2006-01-02")
+	var stale []string
+	entryLog, err := ec.entryStorage.Modify(entriesFile, func(entryLog *models.EntryLog) error {
+		stale = nil
+		existingDay, _ := entryLog.GetDayEntry(ec.date)
+		dayEntry := models.DayEntry{Date: ec.date}
+		if existingDay != nil {
+			dayEntry.Habits = append(dayEntry.Habits, existingDay.Habits...)
+		}
 
-	// Create habit entries from collected data
-	var habitEntries []models.HabitEntry
-	for _, habit := range ec.habits {
-		value, exists := ec.entries[habit.ID]
-		if !exists {
-			continue // Skip habits that weren't processed
+		for _, habit := range ec.habits {
+			var previous *models.HabitEntry
+			if existingDay != nil {
+				previous, _ = existingDay.GetHabitEntry(habit.ID)
+			}
+
+			habitEntry, collected := ec.collectedEntry(habit.ID)
+			stored, wasStored := ec.stored[habit.ID]
+			changedOnDisk := !sameStoredEntry(previous, stored, wasStored)
+			changedHere := collected && !sameStoredEntry(&habitEntry, stored, wasStored)
+
+			switch {
+			case changedOnDisk && !changedHere:
+				ec.adoptEntry(habit.ID, previous) // Saved elsewhere; nothing of ours to write
+				continue
+			case !collected:
+				continue // Skip habits that weren't processed
+			case changedOnDisk && !previous.Same(&habitEntry):
+				stale = append(stale, habit.ID)
+				ec.adoptEntry(habit.ID, previous)
+				continue
+			}
+
+			switch {
+			case previous == nil:
+				habitEntry.MarkCreated()
+			case previous.Same(&habitEntry):
+				continue // Unchanged - keep stored timestamps
+			default:
+				habitEntry.CreatedAt = previous.CreatedAt
+				habitEntry.MarkUpdated()
+			}
+
+			if err := dayEntry.UpdateHabitEntry(habitEntry); err != nil {
+				return fmt.Errorf("failed to update entry for habit %s: %w", habit.ID, err)
+			}
 		}
 
-		habitEntry := models.HabitEntry{
-			HabitID:          habit.ID,
-			Value:            value,
-			AchievementLevel: ec.achievements[habit.ID], // Will be nil for simple/informational habits
-			Notes:            ec.notes[habit.ID],
-			Status:           ec.statuses[habit.ID], // Use collected status
+		if len(dayEntry.Habits) == 0 {
+			return nil
 		}
-		habitEntry.MarkCreated()
+		return entryLog.UpdateDayEntry(dayEntry)
+	})
+	if err != nil {
+		return err
+	}
 
-		habitEntries = append(habitEntries, habitEntry)
+	ec.entryLog = entryLog
+	ec.stored = make(map[string]models.HabitEntry)
+	if dayEntry, found := entryLog.GetDayEntry(ec.date); found {
+		for _, habitEntry := range dayEntry.Habits {
+			ec.stored[habitEntry.HabitID] = habitEntry
+		}
 	}
 
-	// Create day entry
-	dayEntry := models.DayEntry{
-		Date:   today,
-		Habits: habitEntries,
+	if len(stale) > 0 {
+		return &StaleEntriesError{HabitIDs: stale}
 	}
+	return nil
+}
 
-	// Save to storage
-	if err := ec.entryStorage.UpdateDayEntry(entriesFile, dayEntry); err != nil {
-		return fmt.Errorf("failed to update day entry: %w", err)
+// collectedEntry returns the collector's entry for a habit, if it has one.
+func (ec *EntryCollector) collectedEntry(habitID string) (models.HabitEntry, bool) {
+	value, exists := ec.entries[habitID]
+	if !exists {
+		return models.HabitEntry{}, false
 	}
+	return models.HabitEntry{
+		HabitID:          habitID,
+		Value:            value,
+		AchievementLevel: ec.achievements[habitID], // Will be nil for simple/informational habits
+		Notes:            ec.notes[habitID],
+		Status:           ec.statuses[habitID], // Use collected status
+	}, true
+}
 
-	return nil
+// adoptEntry replaces the collector's entry for a habit with a stored one (nil removes it).
+func (ec *EntryCollector) adoptEntry(habitID string, habitEntry *models.HabitEntry) {
+	delete(ec.entries, habitID)
+	delete(ec.notes, habitID)
+	delete(ec.statuses, habitID)
+	delete(ec.achievements, habitID)
+	if habitEntry == nil {
+		return
+	}
+	ec.entries[habitID] = habitEntry.Value
+	ec.notes[habitID] = habitEntry.Notes
+	ec.statuses[habitID] = habitEntry.Status
+	if habitEntry.AchievementLevel != nil {
+		ec.achievements[habitID] = habitEntry.AchievementLevel
+	}
+}
+
+// sameStoredEntry reports whether entry (nil = none) matches the collector's stored entry.
+func sameStoredEntry(entry *models.HabitEntry, stored models.HabitEntry, wasStored bool) bool {
+	if entry == nil || !wasStored {
+		return entry == nil && !wasStored
+	}
+	return entry.Same(&stored)
+}
+
+// dayLabel describes the collector's date for messages: "today" or the formatted date.
+func (ec *EntryCollector) dayLabel() string {
+	if ec.date == time.Now().Format("2006-01-02") {
+		return "today"
+	}
+	date, err := time.Parse("2006-01-02", ec.date)
+	if err != nil {
+		return ec.date
+	}
+	return date.Format("Monday, January 2")
 }
 
 // displayWelcome shows a welcome message with today's date.
@@ -197,14 +330,17 @@ func (ec *EntryCollector) displayWelcome() {
 		Padding(1, 2).
 		Margin(1, 0)
 
-	today := time.Now().Format("Monday, January 2, 2006")
-	welcome := fmt.Sprintf("🎯 Habit Tracker - %s", today)
+	day := time.Now()
+	if date, err := time.Parse("2006-01-02", ec.date); err == nil {
+		day = date
+	}
+	welcome := fmt.Sprintf("🎯 Habit Tracker - %s", day.Format("Monday, January 2, 2006"))
 
 	habitCountStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("8")). // Gray
 		Margin(0, 0, 1, 0)
 
-	habitCount := habitCountStyle.Render(fmt.Sprintf("Ready to track %d habits for today!", len(ec.habits)))
+	habitCount := habitCountStyle.Render(fmt.Sprintf("Ready to track %d habits for %s!", len(ec.habits), ec.dayLabel()))
 
 	fmt.Println(headerStyle.Render(welcome))
 	fmt.Println(habitCount)
@@ -275,7 +411,11 @@ func (ec *EntryCollector) displayCompletion() {
 		Padding(1, 2).
 		Margin(1, 0)
 
-	summary := fmt.Sprintf("%s Completed %d out of %d habits today!", emoji, completedCount, totalCount)
+	day := ec.dayLabel()
+	if day != "today" {
+		day = "on " + day
+	}
+	summary := fmt.Sprintf("%s Completed %d out of %d habits %s!", emoji, completedCount, totalCount, day)
 
 	// Add motivational message
 	var message string
@@ -340,9 +480,11 @@ func (ec *EntryCollector) InitializeForMenu(habits []models.Habit, entries map[s
 	ec.achievements = make(map[string]*models.AchievementLevel)
 	ec.notes = make(map[string]string)
 	ec.statuses = make(map[string]models.EntryStatus)
+	ec.stored = make(map[string]models.HabitEntry)
 
 	// Load existing entries into collector format
 	for _, entry := range entries {
+		ec.stored[entry.HabitID] = entry
 		ec.entries[entry.HabitID] = entry.Value
 		ec.notes[entry.HabitID] = entry.Notes
 		ec.statuses[entry.HabitID] = entry.Status
@@ -359,6 +501,12 @@ func (ec *EntryCollector) SaveEntriesToFile(entriesFile string) error {
 	return ec.saveEntries(entriesFile)
 }
 
+// EntryLog returns the entry log as of the last save, including entries saved by
+// other processes, or nil before the first save.
+func (ec *EntryCollector) EntryLog() *models.EntryLog {
+	return ec.entryLog
+}
+
 // StoreEntryResult stores an entry result from modal processing into the collector.
 // AIDEV-NOTE: T024-modal-integration; stores modal results in collector for menu state sync
 func (ec *EntryCollector) StoreEntryResult(habitID string, result *entry.EntryResult) {
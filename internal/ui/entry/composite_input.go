@@ -0,0 +1,284 @@
+package entry
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/davidlee/vice/internal/models"
+	"github.com/davidlee/vice/internal/quicklog"
+)
+
+// AIDEV-NOTE: entry-composite-input; implements EntryFieldInput for composite fields with one
+// input per sub-field. Values are parsed with quicklog.ParseValue so the form, vice log and
+// imports agree on formats. Blank sub-fields are left out of the value map.
+
+// CompositeEntryInput handles composite field value input for entry collection
+type CompositeEntryInput struct {
+	texts         map[string]*string // text-like sub-field inputs, by sub-field ID
+	bools         map[string]*bool   // boolean sub-field inputs, by sub-field ID
+	action        InputAction
+	habit         models.Habit
+	fieldType     models.FieldType
+	existingEntry *ExistingEntry
+	showScoring   bool
+	validationErr error
+	form          *huh.Form
+}
+
+// NewCompositeEntryInput creates a new composite entry input component
+func NewCompositeEntryInput(config EntryFieldInputConfig) *CompositeEntryInput {
+	input := &CompositeEntryInput{
+		texts:         make(map[string]*string),
+		bools:         make(map[string]*bool),
+		habit:         config.Habit,
+		fieldType:     config.FieldType,
+		existingEntry: config.ExistingEntry,
+		showScoring:   config.ShowScoring,
+		action:        ActionSubmit, // Default to submit
+	}
+
+	for _, subField := range config.FieldType.Fields {
+		if subField.FieldType.Type == models.BooleanFieldType {
+			input.bools[subField.ID] = new(bool)
+		} else {
+			input.texts[subField.ID] = new(string)
+		}
+	}
+
+	// Set existing value if available
+	if config.ExistingEntry != nil && config.ExistingEntry.Value != nil {
+		_ = input.SetExistingValue(config.ExistingEntry.Value)
+	}
+
+	return input
+}
+
+// CreateInputForm creates a form with one input per sub-field
+func (ci *CompositeEntryInput) CreateInputForm(habit models.Habit) *huh.Form {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("12")). // Bright blue
+		Margin(1, 0)
+
+	title := titleStyle.Render(habit.Title)
+
+	var fields []huh.Field
+	if description := ci.buildDescription(habit); description != "" {
+		fields = append(fields, huh.NewNote().Description(description))
+	}
+
+	for _, subField := range ci.fieldType.Fields {
+		label := subFieldLabel(subField)
+		if value, ok := ci.bools[subField.ID]; ok {
+			fields = append(fields, huh.NewConfirm().
+				Title(label).
+				Value(value))
+			continue
+		}
+		fields = append(fields, huh.NewInput().
+			Title(label).
+			Description(subFieldHint(subField.FieldType)+" (leave blank if not recorded)").
+			Value(ci.texts[subField.ID]).
+			Validate(ci.subFieldValidator(subField)))
+	}
+
+	fields = append(fields, huh.NewSelect[InputAction]().
+		Title("Action").
+		Options(
+			huh.NewOption("✅ Submit Values", ActionSubmit),
+			huh.NewOption("⏭️ Skip Habit", ActionSkip),
+		).
+		Value(&ci.action))
+
+	ci.form = huh.NewForm(huh.NewGroup(fields...).Title(title))
+
+	// Add help text if available
+	if habit.HelpText != "" {
+		ci.form = ci.form.WithShowHelp(true)
+	}
+
+	return ci.form
+}
+
+// GetValue returns the sub-field values as a map keyed by sub-field ID (nil for skipped)
+func (ci *CompositeEntryInput) GetValue() interface{} {
+	if ci.action == ActionSkip {
+		return nil
+	}
+	values, err := ci.parseValues()
+	if err != nil {
+		return nil
+	}
+	return values
+}
+
+// GetStringValue returns the sub-field values as "id=value" pairs
+func (ci *CompositeEntryInput) GetStringValue() string {
+	if ci.action == ActionSkip {
+		return "skip"
+	}
+
+	var pairs []string
+	for _, subField := range ci.fieldType.Fields {
+		if value, ok := ci.bools[subField.ID]; ok {
+			pairs = append(pairs, fmt.Sprintf("%s=%t", subField.ID, *value))
+		} else if text := strings.TrimSpace(*ci.texts[subField.ID]); text != "" {
+			pairs = append(pairs, fmt.Sprintf("%s=%s", subField.ID, text))
+		}
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// GetStatus returns the entry completion status based on action and validation
+func (ci *CompositeEntryInput) GetStatus() models.EntryStatus {
+	switch ci.action {
+	case ActionSkip:
+		return models.EntrySkipped
+	case ActionSubmit:
+		if ci.GetValidationError() != nil {
+			return models.EntryFailed
+		}
+		return models.EntryCompleted
+	default:
+		return models.EntryCompleted
+	}
+}
+
+// Validate validates every sub-field value
+func (ci *CompositeEntryInput) Validate() error {
+	if ci.action == ActionSkip {
+		ci.validationErr = nil
+		return nil
+	}
+	_, ci.validationErr = ci.parseValues()
+	return ci.validationErr
+}
+
+// GetFieldType returns the field type
+func (ci *CompositeEntryInput) GetFieldType() string {
+	return models.CompositeFieldType
+}
+
+// SetExistingValue sets existing sub-field values for editing scenarios
+func (ci *CompositeEntryInput) SetExistingValue(value interface{}) error {
+	values, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("invalid composite value type: %T", value)
+	}
+
+	for id, fieldValue := range values {
+		if boolValue, ok := ci.bools[id]; ok {
+			if b, ok := fieldValue.(bool); ok {
+				*boolValue = b
+			}
+		} else if text, ok := ci.texts[id]; ok && fieldValue != nil {
+			*text = formatSubFieldValue(fieldValue)
+		}
+	}
+	return nil
+}
+
+// GetValidationError returns the current validation error state
+func (ci *CompositeEntryInput) GetValidationError() error {
+	return ci.validationErr
+}
+
+// CanShowScoring returns true for composite inputs with automatic scoring
+func (ci *CompositeEntryInput) CanShowScoring() bool {
+	return ci.showScoring && ci.habit.ScoringType == models.AutomaticScoring
+}
+
+// UpdateScoringDisplay updates the form to show scoring feedback
+func (ci *CompositeEntryInput) UpdateScoringDisplay(_ *models.AchievementLevel) error {
+	return nil
+}
+
+// Private methods
+
+func (ci *CompositeEntryInput) buildDescription(habit models.Habit) string {
+	if habit.Description == "" {
+		return ""
+	}
+	descStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("8")). // Gray
+		Italic(true)
+	return descStyle.Render(habit.Description)
+}
+
+// subFieldValidator validates one text-like sub-field; blank means not recorded.
+func (ci *CompositeEntryInput) subFieldValidator(subField models.SubField) func(string) error {
+	return func(s string) error {
+		if strings.TrimSpace(s) == "" {
+			return nil
+		}
+		_, err := quicklog.ParseValue(subField.FieldType, s, nil)
+		return err
+	}
+}
+
+// parseValues parses every recorded sub-field into the value map.
+func (ci *CompositeEntryInput) parseValues() (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+	for _, subField := range ci.fieldType.Fields {
+		if value, ok := ci.bools[subField.ID]; ok {
+			values[subField.ID] = *value
+			continue
+		}
+		text := strings.TrimSpace(*ci.texts[subField.ID])
+		if text == "" {
+			continue
+		}
+		parsed, err := quicklog.ParseValue(subField.FieldType, text, nil)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", subFieldLabel(subField), err)
+		}
+		values[subField.ID] = parsed
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("at least one value is required")
+	}
+	return values, nil
+}
+
+func subFieldLabel(subField models.SubField) string {
+	label := subField.Title
+	if label == "" {
+		label = subField.ID
+	}
+	if subField.FieldType.Unit != "" {
+		label = fmt.Sprintf("%s (%s)", label, subField.FieldType.Unit)
+	}
+	return label
+}
+
+func subFieldHint(fieldType models.FieldType) string {
+	switch fieldType.Type {
+	case models.UnsignedIntFieldType:
+		return "Whole number"
+	case models.UnsignedDecimalFieldType, models.DecimalFieldType:
+		return "Number"
+	case models.DurationFieldType:
+		return "Duration, e.g. 1h30m or 45m"
+	case models.TimeFieldType:
+		return "Time, HH:MM"
+	default:
+		return "Text"
+	}
+}
+
+// formatSubFieldValue formats a stored sub-field value for editing.
+func formatSubFieldValue(value interface{}) string {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.Format("15:04")
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -39,6 +39,7 @@ func TestElasticHabitCollectionFlow(t *testing.T) {
 		models.TimeFieldType,
 		models.DurationFieldType,
 		models.ChecklistFieldType,
+		models.CompositeFieldType,
 	}
 
 	supportedTypes := flow.GetExpectedFieldTypes()
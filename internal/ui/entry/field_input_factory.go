@@ -40,6 +40,9 @@ func (f *EntryFieldInputFactory) CreateInput(config EntryFieldInputConfig) (Entr
 	case models.ChecklistFieldType:
 		return NewChecklistEntryInput(config), nil
 
+	case models.CompositeFieldType:
+		return NewCompositeEntryInput(config), nil
+
 	default:
 		return nil, fmt.Errorf("unsupported field type for entry collection: %s", config.FieldType.Type)
 	}
@@ -72,6 +75,7 @@ func (f *EntryFieldInputFactory) GetSupportedFieldTypes() []string {
 		models.TimeFieldType,
 		models.DurationFieldType,
 		models.ChecklistFieldType,
+		models.CompositeFieldType,
 	}
 }
 
@@ -62,6 +62,16 @@ func TestEntryFieldInputFactory(t *testing.T) {
 			},
 			wantType: models.ChecklistFieldType,
 		},
+		{
+			name: "Composite field type",
+			fieldType: models.FieldType{
+				Type: models.CompositeFieldType,
+				Fields: []models.SubField{
+					{ID: "distance", FieldType: models.FieldType{Type: models.DecimalFieldType}},
+				},
+			},
+			wantType: models.CompositeFieldType,
+		},
 		{
 			name: "Unsupported field type",
 			fieldType: models.FieldType{
@@ -283,6 +293,63 @@ func TestNumericEntryInput(t *testing.T) {
 	}
 }
 
+func TestCompositeEntryInput(t *testing.T) {
+	config := EntryFieldInputConfig{
+		Habit: models.Habit{Title: "Run"},
+		FieldType: models.FieldType{
+			Type: models.CompositeFieldType,
+			Fields: []models.SubField{
+				{ID: "distance", Title: "Distance", FieldType: models.FieldType{Type: models.UnsignedDecimalFieldType, Unit: "km"}},
+				{ID: "duration", FieldType: models.FieldType{Type: models.DurationFieldType}},
+				{ID: "outdoors", FieldType: models.FieldType{Type: models.BooleanFieldType}},
+			},
+		},
+		ExistingEntry: &ExistingEntry{
+			Value: map[string]interface{}{"distance": 5.2, "outdoors": true},
+		},
+	}
+
+	input := NewCompositeEntryInput(config)
+	if input.CreateInputForm(config.Habit) == nil {
+		t.Fatal("CreateInputForm() returned nil")
+	}
+
+	// Existing values are loaded; blank sub-fields are left out
+	if err := input.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+	if got, want := input.GetStringValue(), "distance=5.2, outdoors=true"; got != want {
+		t.Errorf("GetStringValue() = %q, want %q", got, want)
+	}
+
+	*input.texts["duration"] = "28m"
+	value, ok := input.GetValue().(map[string]interface{})
+	if !ok {
+		t.Fatalf("GetValue() = %T, want map", input.GetValue())
+	}
+	if value["distance"] != 5.2 || value["duration"] != "28m" || value["outdoors"] != true {
+		t.Errorf("GetValue() = %v", value)
+	}
+	if input.GetStatus() != models.EntryCompleted {
+		t.Errorf("GetStatus() = %v, want completed", input.GetStatus())
+	}
+
+	// Invalid sub-field values fail validation
+	*input.texts["distance"] = "far"
+	if err := input.Validate(); err == nil {
+		t.Error("Validate() expected error for invalid distance")
+	}
+	if input.GetValue() != nil {
+		t.Error("GetValue() expected nil for invalid input")
+	}
+
+	// Skipping clears the value
+	input.action = ActionSkip
+	if input.GetValue() != nil || input.GetStatus() != models.EntrySkipped {
+		t.Error("expected skipped entry with no value")
+	}
+}
+
 func TestScoringAwareInput(t *testing.T) {
 	factory := NewEntryFieldInputFactory()
 
@@ -199,6 +199,7 @@ func (f *SimpleHabitCollectionFlow) GetExpectedFieldTypes() []string {
 		models.DecimalFieldType,
 		models.TimeFieldType,
 		models.DurationFieldType,
+		models.CompositeFieldType,
 	}
 }
 
@@ -402,6 +403,7 @@ func (f *ElasticHabitCollectionFlow) GetExpectedFieldTypes() []string {
 		models.TimeFieldType,
 		models.DurationFieldType,
 		models.ChecklistFieldType,
+		models.CompositeFieldType,
 	}
 }
 
@@ -515,6 +517,7 @@ func (f *InformationalHabitCollectionFlow) GetExpectedFieldTypes() []string {
 		models.TimeFieldType,
 		models.DurationFieldType,
 		models.ChecklistFieldType,
+		models.CompositeFieldType,
 	}
 }
 
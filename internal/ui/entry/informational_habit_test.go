@@ -44,6 +44,7 @@ func TestInformationalHabitCollectionFlow_GetExpectedFieldTypes(t *testing.T) {
 		models.TimeFieldType,
 		models.DurationFieldType,
 		models.ChecklistFieldType,
+		models.CompositeFieldType,
 	}
 
 	assert.ElementsMatch(t, expectedTypes, fieldTypes)
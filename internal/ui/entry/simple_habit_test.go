@@ -37,6 +37,7 @@ func TestSimpleHabitCollectionFlow(t *testing.T) {
 		models.DecimalFieldType,
 		models.TimeFieldType,
 		models.DurationFieldType,
+		models.CompositeFieldType,
 	}
 
 	supportedTypes := flow.GetExpectedFieldTypes()
@@ -205,6 +205,130 @@ func TestEntryCollector_saveEntries(t *testing.T) {
 	})
 }
 
+func TestEntryCollector_saveEntriesForPastDate(t *testing.T) {
+	tempDir := t.TempDir()
+	entriesFile := filepath.Join(tempDir, "entries.yml")
+	entryStorage := storage.NewEntryStorage()
+
+	// Another habit already recorded that day must be left alone
+	created := time.Date(2025, 7, 14, 21, 0, 0, 0, time.UTC)
+	require.NoError(t, entryStorage.UpdateHabitEntry(entriesFile, "2025-07-14", models.HabitEntry{
+		HabitID: "reading", Value: true, Status: models.EntryCompleted, CreatedAt: created,
+	}))
+
+	collector := NewEntryCollector("checklists.yml")
+	collector.SetDate("2025-07-14")
+	require.NoError(t, collector.loadExistingEntries(entriesFile))
+	collector.habits = []models.Habit{{ID: "reading"}, {ID: "meditation"}}
+	collector.entries["meditation"] = true
+	collector.statuses["meditation"] = models.EntryCompleted
+
+	require.NoError(t, collector.saveEntries(entriesFile))
+
+	loadEntry := func(habitID string) *models.HabitEntry {
+		dayEntry, err := entryStorage.GetDayEntry(entriesFile, "2025-07-14")
+		require.NoError(t, err)
+		habitEntry, found := dayEntry.GetHabitEntry(habitID)
+		require.True(t, found)
+		return habitEntry
+	}
+
+	t.Run("new entry is created on the chosen date", func(t *testing.T) {
+		meditation := loadEntry("meditation")
+		assert.False(t, meditation.CreatedAt.IsZero())
+		assert.Nil(t, meditation.UpdatedAt)
+
+		_, err := entryStorage.GetDayEntry(entriesFile, time.Now().Format("2006-01-02"))
+		assert.Error(t, err, "nothing should be saved for today")
+	})
+
+	t.Run("unchanged entries keep their timestamps", func(t *testing.T) {
+		reading := loadEntry("reading")
+		assert.True(t, created.Equal(reading.CreatedAt))
+		assert.Nil(t, reading.UpdatedAt)
+	})
+
+	t.Run("changed entries keep CreatedAt and set UpdatedAt", func(t *testing.T) {
+		collector.entries["reading"] = false
+		collector.statuses["reading"] = models.EntryFailed
+		require.NoError(t, collector.saveEntries(entriesFile))
+
+		reading := loadEntry("reading")
+		assert.Equal(t, false, reading.Value)
+		assert.True(t, created.Equal(reading.CreatedAt))
+		require.NotNil(t, reading.UpdatedAt)
+		assert.True(t, reading.UpdatedAt.After(created))
+	})
+}
+
+func TestEntryCollector_saveEntriesWhenStale(t *testing.T) {
+	entriesFile := filepath.Join(t.TempDir(), "entries.yml")
+	entryStorage := storage.NewEntryStorage()
+	const date = "2025-07-14"
+	logHabit := func(habitID string, value bool) {
+		status := models.EntryCompleted
+		if !value {
+			status = models.EntryFailed
+		}
+		require.NoError(t, entryStorage.UpdateHabitEntry(entriesFile, date, models.HabitEntry{
+			HabitID: habitID, Value: value, Status: status, CreatedAt: time.Now(),
+		}))
+	}
+	logHabit("reading", true)
+
+	// The menu loads the day, then `vice log` runs from elsewhere
+	collector := NewEntryCollector("checklists.yml")
+	collector.SetDate(date)
+	collector.habits = []models.Habit{{ID: "reading"}, {ID: "meditation"}, {ID: "walk"}}
+	require.NoError(t, collector.loadExistingEntries(entriesFile))
+	logHabit("walk", true)
+	logHabit("reading", false)
+
+	t.Run("entries saved elsewhere are merged", func(t *testing.T) {
+		collector.entries["meditation"] = true
+		collector.statuses["meditation"] = models.EntryCompleted
+		require.NoError(t, collector.saveEntries(entriesFile))
+
+		dayEntry, err := entryStorage.GetDayEntry(entriesFile, date)
+		require.NoError(t, err)
+		assert.Len(t, dayEntry.Habits, 3)
+		reading, _ := dayEntry.GetHabitEntry("reading")
+		assert.Equal(t, false, reading.Value, "the stale copy must not overwrite it")
+
+		// The collector picks up what was saved elsewhere
+		assert.Equal(t, true, collector.entries["walk"])
+		assert.Equal(t, false, collector.entries["reading"])
+		assert.Len(t, collector.EntryLog().Entries[0].Habits, 3)
+	})
+
+	t.Run("conflicting changes are refused", func(t *testing.T) {
+		logHabit("walk", false)
+		collector.notes["walk"] = "Around the park"
+
+		err := collector.saveEntries(entriesFile)
+		var stale *StaleEntriesError
+		require.ErrorAs(t, err, &stale)
+		assert.Equal(t, []string{"walk"}, stale.HabitIDs)
+
+		dayEntry, err := entryStorage.GetDayEntry(entriesFile, date)
+		require.NoError(t, err)
+		walk, _ := dayEntry.GetHabitEntry("walk")
+		assert.Equal(t, false, walk.Value)
+		assert.Empty(t, walk.Notes)
+		assert.Equal(t, false, collector.entries["walk"])
+
+		// Entering it again overwrites the saved entry
+		collector.entries["walk"] = true
+		collector.statuses["walk"] = models.EntryCompleted
+		collector.notes["walk"] = "Around the park"
+		require.NoError(t, collector.saveEntries(entriesFile))
+		dayEntry, err = entryStorage.GetDayEntry(entriesFile, date)
+		require.NoError(t, err)
+		walk, _ = dayEntry.GetHabitEntry("walk")
+		assert.Equal(t, true, walk.Value)
+	})
+}
+
 func TestEntryCollector_displayWelcome(t *testing.T) {
 	// Create collector with test habits
 	collector := NewEntryCollector("checklists.yml")
@@ -279,11 +403,11 @@ func TestEntryCollector_timePtr(t *testing.T) {
 	assert.Equal(t, now, *ptr)
 }
 
-func TestEntryCollector_CollectTodayEntries_ErrorCases(t *testing.T) {
+func TestEntryCollector_CollectEntries_ErrorCases(t *testing.T) {
 	t.Run("habits file not found", func(t *testing.T) {
 		collector := NewEntryCollector("checklists.yml")
 
-		err := collector.CollectTodayEntries("/nonexistent/habits.yml", "/tmp/entries.yml")
+		err := collector.CollectEntries("/nonexistent/habits.yml", "/tmp/entries.yml")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to load habits")
 	})
@@ -304,7 +428,7 @@ func TestEntryCollector_CollectTodayEntries_ErrorCases(t *testing.T) {
 		require.NoError(t, err)
 
 		collector := NewEntryCollector("checklists.yml")
-		err = collector.CollectTodayEntries(habitsFile, entriesFile)
+		err = collector.CollectEntries(habitsFile, entriesFile)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "no habits found")
 	})
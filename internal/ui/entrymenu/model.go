@@ -14,8 +14,11 @@ import (
 
 	"github.com/davidlee/vice/internal/debug"
 	"github.com/davidlee/vice/internal/models"
+	"github.com/davidlee/vice/internal/scoring"
+	"github.com/davidlee/vice/internal/storage"
 	"github.com/davidlee/vice/internal/ui"
 	"github.com/davidlee/vice/internal/ui/entry"
+	"github.com/davidlee/vice/internal/ui/heatmap"
 	"github.com/davidlee/vice/internal/ui/modal"
 )
 
@@ -29,6 +32,18 @@ type EntryMenuItem struct {
 	HasEntry         bool
 	Value            interface{}
 	AchievementLevel *models.AchievementLevel
+	Schedule         models.ScheduleStatus // empty is treated as due
+}
+
+// NeedsEntry returns true if the habit has no entry and is due today.
+// AIDEV-NOTE: habit-periodicity; not-due and target-met habits are excluded from "incomplete" navigation
+func (e EntryMenuItem) NeedsEntry() bool {
+	return !e.HasEntry && !isOffSchedule(e.Schedule)
+}
+
+// isOffSchedule returns true if a schedule status means no entry is expected today.
+func isOffSchedule(status models.ScheduleStatus) bool {
+	return status == models.ScheduleNotDue || status == models.ScheduleTargetMet
 }
 
 // FilterValue returns the value used for filtering this item.
@@ -47,16 +62,38 @@ func (e EntryMenuItem) Title() string {
 
 // Description returns the secondary display text.
 func (e EntryMenuItem) Description() string {
-	if e.Habit.Description == "" {
+	description := e.Habit.Description
+	if e.Habit.Schedule != nil {
+		schedule := e.Habit.Schedule.Describe()
+		switch e.Schedule {
+		case models.ScheduleNotDue:
+			schedule = "not due today - " + schedule
+		case models.ScheduleTargetMet:
+			schedule = "target met - " + schedule
+		}
+		if description == "" {
+			description = schedule
+		} else {
+			description = fmt.Sprintf("%s (%s)", description, schedule)
+		}
+	}
+
+	if description == "" {
 		return ""
 	}
-	return fmt.Sprintf("   %s", e.Habit.Description)
+	return fmt.Sprintf("   %s", description)
 }
 
 // getHabitStatusEmoji returns the emoji representing the habit's entry status.
 // AIDEV-NOTE: status-emoji-design; T018 user-requested change from habit type to status emojis
 func (e EntryMenuItem) getHabitStatusEmoji() string {
 	if !e.HasEntry {
+		switch e.Schedule {
+		case models.ScheduleNotDue:
+			return "·" // not due today - dot
+		case models.ScheduleTargetMet:
+			return "◎" // period target already met - ring
+		}
 		return "☐" // incomplete - empty box
 	}
 
@@ -75,6 +112,12 @@ func (e EntryMenuItem) getHabitStatusEmoji() string {
 // getStatusColor returns the color for the habit based on entry status.
 func (e EntryMenuItem) getStatusColor() lipgloss.Color {
 	if !e.HasEntry {
+		switch e.Schedule {
+		case models.ScheduleNotDue:
+			return lipgloss.Color("240") // dark grey - not due
+		case models.ScheduleTargetMet:
+			return lipgloss.Color("108") // muted green - target met
+		}
 		return lipgloss.Color("250") // light grey - incomplete
 	}
 
@@ -129,6 +172,11 @@ type EntryMenuKeyMap struct {
 	FilterSkipped        key.Binding
 	FilterPrevious       key.Binding
 	ClearFilters         key.Binding
+	History              key.Binding
+
+	// Date navigation
+	PreviousDay key.Binding
+	NextDay     key.Binding
 
 	// Exit
 	Quit key.Binding
@@ -178,6 +226,20 @@ func DefaultEntryMenuKeyMap() EntryMenuKeyMap {
 			key.WithKeys("c"),
 			key.WithHelp("c", "clear filters"),
 		),
+		History: key.NewBinding(
+			key.WithKeys("H"),
+			key.WithHelp("H", "history"),
+		),
+
+		// Date navigation
+		PreviousDay: key.NewBinding(
+			key.WithKeys("["),
+			key.WithHelp("[", "prev day"),
+		),
+		NextDay: key.NewBinding(
+			key.WithKeys("]"),
+			key.WithHelp("]", "next day"),
+		),
 
 		// Exit
 		Quit: key.NewBinding(
@@ -195,6 +257,7 @@ type EntryMenuModel struct {
 	list           list.Model
 	habits         []models.Habit
 	entries        map[string]models.HabitEntry
+	schedule       map[string]models.ScheduleStatus // habit ID -> schedule status today (nil = all due)
 	keys           EntryMenuKeyMap
 	width          int
 	height         int
@@ -210,6 +273,14 @@ type EntryMenuModel struct {
 	directModal       modal.Modal // Direct modal handling like prototype
 	fieldInputFactory *entry.EntryFieldInputFactory
 
+	// Day being recorded; entries holds this day's habit entries
+	date time.Time
+
+	// History heatmap for the selected habit
+	entryLog    *models.EntryLog // Stored entries; the current day's come from entries (nil = no history)
+	historyView string           // Rendered heatmap while the history view is open
+	saveError   string           // Error from the last auto-save, shown until the next one
+
 	// Navigation state
 	selectedHabitID string // ID of habit selected for entry
 	shouldQuit      bool   // Flag to quit the menu
@@ -217,7 +288,7 @@ type EntryMenuModel struct {
 
 // NewEntryMenuModel creates a new entry menu model with the provided habits and entries.
 func NewEntryMenuModel(habits []models.Habit, entries map[string]models.HabitEntry, collector *ui.EntryCollector, entriesFile string) *EntryMenuModel {
-	items := createMenuItems(habits, entries)
+	items := createMenuItems(habits, entries, nil)
 
 	// Create list with default delegate
 	l := list.New(items, list.NewDefaultDelegate(), 0, 0)
@@ -234,6 +305,7 @@ func NewEntryMenuModel(habits []models.Habit, entries map[string]models.HabitEnt
 		return []key.Binding{
 			keyMap.NextIncomplete, keyMap.ToggleReturnBehavior,
 			keyMap.FilterSkipped, keyMap.FilterPrevious, keyMap.ClearFilters,
+			keyMap.History, keyMap.PreviousDay, keyMap.NextDay,
 		}
 	}
 
@@ -246,6 +318,7 @@ func NewEntryMenuModel(habits []models.Habit, entries map[string]models.HabitEnt
 		returnBehavior: ReturnToMenu,
 		entryCollector: collector,
 		entriesFile:    entriesFile,
+		date:           today(),
 		viewRenderer:   NewViewRenderer(0, 0), // Will be updated on first WindowSizeMsg
 		navEnhancer:    NewNavigationEnhancer(),
 		// modalManager:      modal.NewModalManager(0, 0), // TEMPORARILY REMOVED for ModalManager experiment
@@ -256,7 +329,7 @@ func NewEntryMenuModel(habits []models.Habit, entries map[string]models.HabitEnt
 
 // NewEntryMenuModelForTesting creates a headless entry menu model for testing.
 func NewEntryMenuModelForTesting(habits []models.Habit, entries map[string]models.HabitEntry) *EntryMenuModel {
-	items := createMenuItems(habits, entries)
+	items := createMenuItems(habits, entries, nil)
 
 	// Create minimal list for testing
 	l := list.New(items, list.NewDefaultDelegate(), 80, 24)
@@ -269,6 +342,7 @@ func NewEntryMenuModelForTesting(habits []models.Habit, entries map[string]model
 		keys:           DefaultEntryMenuKeyMap(),
 		filterState:    FilterNone,
 		returnBehavior: ReturnToMenu,
+		date:           today(),
 		viewRenderer:   NewViewRenderer(80, 24), // Fixed size for testing
 		navEnhancer:    NewNavigationEnhancer(),
 		// modalManager:      modal.NewModalManager(80, 24), // TEMPORARILY REMOVED for ModalManager experiment
@@ -279,21 +353,177 @@ func NewEntryMenuModelForTesting(habits []models.Habit, entries map[string]model
 
 // createMenuItems converts habits and entries into menu items.
 // AIDEV-NOTE: T024-bug1-analysis; status display logic - check entry status mapping
-func createMenuItems(habits []models.Habit, entries map[string]models.HabitEntry) []list.Item {
+func createMenuItems(habits []models.Habit, entries map[string]models.HabitEntry, schedule map[string]models.ScheduleStatus) []list.Item {
 	items := make([]list.Item, len(habits))
 	for i, habit := range habits {
-		entry, hasEntry := entries[habit.ID]
-		items[i] = EntryMenuItem{
-			Habit:            habit,
-			EntryStatus:      entry.Status,
-			HasEntry:         hasEntry,
-			Value:            entry.Value,
-			AchievementLevel: entry.AchievementLevel,
-		}
+		items[i] = newMenuItem(habit, entries, schedule)
 	}
 	return items
 }
 
+// newMenuItem builds the menu item for a single habit.
+func newMenuItem(habit models.Habit, entries map[string]models.HabitEntry, schedule map[string]models.ScheduleStatus) EntryMenuItem {
+	entry, hasEntry := entries[habit.ID]
+	return EntryMenuItem{
+		Habit:            habit,
+		EntryStatus:      entry.Status,
+		HasEntry:         hasEntry,
+		Value:            entry.Value,
+		AchievementLevel: entry.AchievementLevel,
+		Schedule:         schedule[habit.ID],
+	}
+}
+
+// SetSchedule records which habits are due today and refreshes the menu items.
+// Habits missing from the map are treated as due.
+func (m *EntryMenuModel) SetSchedule(schedule map[string]models.ScheduleStatus) {
+	m.schedule = schedule
+	m.navEnhancer.helper.SetSchedule(schedule)
+	m.list.SetItems(createMenuItems(m.habits, m.entries, m.schedule))
+}
+
+// dueHabits returns the habits expected today: those on schedule plus any already entered.
+func (m *EntryMenuModel) dueHabits() []models.Habit {
+	if m.schedule == nil {
+		return m.habits
+	}
+
+	var due []models.Habit
+	for _, habit := range m.habits {
+		if _, hasEntry := m.entries[habit.ID]; hasEntry || !isOffSchedule(m.schedule[habit.ID]) {
+			due = append(due, habit)
+		}
+	}
+	return due
+}
+
+// SetEntryLog provides stored entries for the history view.
+func (m *EntryMenuModel) SetEntryLog(entryLog *models.EntryLog) {
+	m.entryLog = entryLog
+}
+
+// SetDate switches the menu to record entries for date, loading that day's entries
+// and schedule from entryLog. The entry collector saves to the same day.
+// AIDEV-NOTE: entry-backfill; all per-day state (entries, schedule, collector date) is reset here
+func (m *EntryMenuModel) SetDate(date time.Time, entryLog *models.EntryLog) error {
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	if entryLog == nil {
+		entryLog = models.CreateEmptyEntryLog()
+	}
+
+	entries := make(map[string]models.HabitEntry)
+	if dayEntry, found := entryLog.GetDayEntry(date.Format("2006-01-02")); found {
+		for _, habitEntry := range dayEntry.Habits {
+			entries[habitEntry.HabitID] = habitEntry
+		}
+	}
+
+	// Mark periodic habits that aren't due that day or have met their period target
+	results, err := scoring.NewEngine().EvaluateSchedules(m.habits, entryLog, date)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate habit schedules: %w", err)
+	}
+	schedule := make(map[string]models.ScheduleStatus, len(results))
+	for habitID, result := range results {
+		schedule[habitID] = result.Status
+	}
+
+	m.date = date
+	m.entryLog = entryLog
+	m.entries = entries
+	if m.entryCollector != nil {
+		m.entryCollector.SetDate(date.Format("2006-01-02"))
+		m.entryCollector.InitializeForMenu(m.habits, entries)
+	}
+	m.SetSchedule(schedule)
+	m.SelectFirstIncompleteHabit()
+	return nil
+}
+
+// Date returns the day entries are being recorded for.
+func (m *EntryMenuModel) Date() time.Time {
+	return m.date
+}
+
+// shiftDate moves the menu by days, reloading entries saved so far. Future days are not allowed.
+func (m *EntryMenuModel) shiftDate(days int) {
+	date := m.date.AddDate(0, 0, days)
+	if date.After(today()) {
+		return
+	}
+
+	entryLog := m.entryLog
+	if m.entriesFile != "" {
+		loaded, err := storage.NewEntryStorage().LoadFromFile(m.entriesFile)
+		if err != nil {
+			debug.EntryMenu("Failed to reload entries for %s: %v", date.Format("2006-01-02"), err)
+			return
+		}
+		entryLog = loaded
+	}
+
+	if err := m.SetDate(date, entryLog); err != nil {
+		debug.EntryMenu("Failed to switch to %s: %v", date.Format("2006-01-02"), err)
+	}
+}
+
+// isToday returns true if the menu is recording today's entries.
+func (m *EntryMenuModel) isToday() bool {
+	return m.date.Equal(today())
+}
+
+// today returns the start of the current day.
+func today() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+}
+
+// openHistory renders the heatmap for the selected habit, including entries made this session.
+// AIDEV-NOTE: entry-menu-history; read-only overlay, any key returns to the menu
+func (m *EntryMenuModel) openHistory() {
+	item, ok := m.list.SelectedItem().(EntryMenuItem)
+	if !ok {
+		return
+	}
+
+	rendered, err := heatmap.New(m.date, m.historyWeeks()).RenderHabit(&item.Habit, m.historyLog(m.date.Format("2006-01-02")))
+	if err != nil {
+		debug.EntryMenu("Failed to render history for habit %s: %v", item.Habit.ID, err)
+		return
+	}
+	m.historyView = rendered
+}
+
+// historyLog returns the stored entry log with the menu's day replaced by its current entries.
+func (m *EntryMenuModel) historyLog(date string) *models.EntryLog {
+	historyLog := models.CreateEmptyEntryLog()
+	if m.entryLog != nil {
+		for _, dayEntry := range m.entryLog.Entries {
+			if dayEntry.Date != date {
+				historyLog.Entries = append(historyLog.Entries, dayEntry)
+			}
+		}
+	}
+
+	currentEntry := models.DayEntry{Date: date}
+	for _, habit := range m.habits {
+		if habitEntry, hasEntry := m.entries[habit.ID]; hasEntry {
+			currentEntry.Habits = append(currentEntry.Habits, habitEntry)
+		}
+	}
+	historyLog.Entries = append(historyLog.Entries, currentEntry)
+	return historyLog
+}
+
+// historyWeeks fits the heatmap to the terminal width (4 columns of labels, 2 per week).
+func (m *EntryMenuModel) historyWeeks() int {
+	weeks := (m.width - 4) / 2
+	if weeks <= 0 || weeks > heatmap.DefaultWeeks {
+		return heatmap.DefaultWeeks
+	}
+	return weeks
+}
+
 // Init implements the tea.Model interface.
 func (m *EntryMenuModel) Init() tea.Cmd {
 	return nil
@@ -331,14 +561,7 @@ func (m *EntryMenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.updateEntriesFromCollector()
 
 				// Auto-save entries after collection
-				if m.entriesFile != "" && m.entryCollector != nil {
-					err := m.entryCollector.SaveEntriesToFile(m.entriesFile)
-					if err != nil {
-						debug.EntryMenu("Failed to save entries for habit %s: %v", m.selectedHabitID, err)
-						// Log error but continue - could add error display later
-						_ = err // TODO: Consider adding save error handling UI
-					}
-				}
+				m.autoSave(m.selectedHabitID)
 
 				// Smart navigation based on return behavior preference
 				if m.returnBehavior == ReturnToNextHabit {
@@ -373,7 +596,21 @@ func (m *EntryMenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
+		if m.historyView != "" {
+			m.historyView = ""
+			return m, nil
+		}
+
 		switch {
+		case key.Matches(msg, m.keys.History):
+			m.openHistory()
+			return m, nil
+		case key.Matches(msg, m.keys.PreviousDay):
+			m.shiftDate(-1)
+			return m, nil
+		case key.Matches(msg, m.keys.NextDay):
+			m.shiftDate(1)
+			return m, nil
 		case key.Matches(msg, m.keys.Quit):
 			m.shouldQuit = true
 			return m, tea.Quit
@@ -460,8 +697,25 @@ func (m *EntryMenuModel) View() string {
 		return "Loading..."
 	}
 
-	header := m.viewRenderer.RenderHeader(m.habits, m.entries, m.filterState)
+	if m.historyView != "" {
+		return m.historyView + "\n" + helpStyle.Render("press any key to return")
+	}
+
+	due := m.dueHabits()
+	var header string
+	if len(due) > 0 || len(m.habits) == 0 {
+		header = m.viewRenderer.RenderHeader(due, m.entries, m.filterState)
+	}
+	if offSchedule := len(m.habits) - len(due); offSchedule > 0 {
+		if header != "" {
+			header = strings.TrimSuffix(header, "\n") + "\n"
+		}
+		header += m.viewRenderer.RenderOffSchedule(offSchedule) + "\n"
+	}
 	m.list.Title = "Entry Menu"
+	if !m.isToday() {
+		m.list.Title = "Entry Menu - " + m.date.Format("Monday, January 2, 2006")
+	}
 
 	// Get list view with return behavior inserted before help
 	listView := m.renderListWithFooter()
@@ -529,14 +783,8 @@ func (m *EntryMenuModel) processDeferredStateSync(msg DeferredStateSyncMsg) {
 	m.updateEntriesFromCollector()
 
 	// Auto-save entries after collection
-	if m.entriesFile != "" && m.entryCollector != nil {
-		debug.EntryMenu("Executing Auto-Save - SaveEntriesToFile")
-		err := m.entryCollector.SaveEntriesToFile(m.entriesFile)
-		if err != nil {
-			debug.EntryMenu("Failed to save entries for habit %s: %v", msg.habitID, err)
-			_ = err // TODO: Consider adding save error handling UI
-		}
-	}
+	debug.EntryMenu("Executing Auto-Save - SaveEntriesToFile")
+	m.autoSave(msg.habitID)
 
 	// Smart navigation based on return behavior preference
 	if m.returnBehavior == ReturnToNextHabit {
@@ -545,6 +793,32 @@ func (m *EntryMenuModel) processDeferredStateSync(msg DeferredStateSyncMsg) {
 	}
 }
 
+// autoSave saves the collector's entries, then syncs the menu with what was stored,
+// including entries saved by other vice processes while the menu was open.
+// AIDEV-NOTE: entry-stale-merge; the collector merges or refuses stale entries, the menu just shows the outcome
+func (m *EntryMenuModel) autoSave(habitID string) {
+	if m.entriesFile == "" || m.entryCollector == nil {
+		return
+	}
+
+	err := m.entryCollector.SaveEntriesToFile(m.entriesFile)
+	if entryLog := m.entryCollector.EntryLog(); entryLog != nil {
+		m.entryLog = entryLog
+	}
+	m.updateEntriesFromCollector()
+
+	m.saveError = ""
+	if err != nil {
+		debug.EntryMenu("Failed to save entries for habit %s: %v", habitID, err)
+		m.saveError = err.Error()
+	}
+}
+
+// SaveError returns the error from the last auto-save, or "" if it succeeded.
+func (m *EntryMenuModel) SaveError() string {
+	return m.saveError
+}
+
 // renderWithDirectModal renders modal overlay directly (for ModalManager experiment)
 func (m *EntryMenuModel) renderWithDirectModal(background, modalContent string) string {
 	// Simple modal overlay implementation like ModalManager
@@ -595,6 +869,9 @@ func (m *EntryMenuModel) renderListWithFooter() string {
 	var parts []string
 	parts = append(parts, listContent)
 	parts = append(parts, returnLine)
+	if m.saveError != "" {
+		parts = append(parts, saveErrorStyle.Render("Save failed: "+m.saveError))
+	}
 
 	if showHelp {
 		// Get the list's help text by temporarily restoring help and getting just that part
@@ -638,7 +915,7 @@ func (m *EntryMenuModel) GetReturnBehavior() ReturnBehavior {
 // UpdateEntries updates the entries and refreshes the menu items.
 func (m *EntryMenuModel) UpdateEntries(entries map[string]models.HabitEntry) {
 	m.entries = entries
-	items := createMenuItems(m.habits, entries)
+	items := createMenuItems(m.habits, entries, m.schedule)
 	m.list.SetItems(items)
 }
 
@@ -686,7 +963,7 @@ func (m *EntryMenuModel) updateEntriesFromCollector() {
 	}
 
 	// Recreate menu items with updated entry data
-	items := createMenuItems(m.habits, m.entries)
+	items := createMenuItems(m.habits, m.entries, m.schedule)
 	m.list.SetItems(items)
 }
 
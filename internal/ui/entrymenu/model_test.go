@@ -1,13 +1,18 @@
 package entrymenu
 
 import (
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/davidlee/vice/internal/models"
+	"github.com/davidlee/vice/internal/storage"
+	"github.com/davidlee/vice/internal/ui"
+	"github.com/davidlee/vice/internal/ui/entry"
 )
 
 func TestNewEntryMenuModelForTesting(t *testing.T) {
@@ -384,3 +389,175 @@ func TestEntryMenuModel_EnhancedKeybindings(t *testing.T) {
 		}
 	}
 }
+
+func TestEntryMenuModelSchedule(t *testing.T) {
+	habits := []models.Habit{
+		{ID: "daily", Title: "Daily", HabitType: models.SimpleHabit},
+		{
+			ID: "weekly", Title: "Weekly Review", HabitType: models.SimpleHabit,
+			Schedule: &models.Schedule{Frequency: models.WeekdaysFrequency, Weekdays: []string{"sun"}},
+		},
+	}
+	model := NewEntryMenuModelForTesting(habits, map[string]models.HabitEntry{})
+	model.SetSchedule(map[string]models.ScheduleStatus{"weekly": models.ScheduleNotDue})
+
+	items := model.list.Items()
+	weekly, ok := items[1].(EntryMenuItem)
+	if !ok {
+		t.Fatal("Expected EntryMenuItem")
+	}
+	if weekly.NeedsEntry() {
+		t.Error("Expected habit not due today to need no entry")
+	}
+	if weekly.getHabitStatusEmoji() != "·" {
+		t.Errorf("Expected not-due emoji, got %s", weekly.getHabitStatusEmoji())
+	}
+	if !strings.Contains(weekly.Description(), "not due today - on sun") {
+		t.Errorf("Expected schedule in description, got %q", weekly.Description())
+	}
+
+	if due := model.dueHabits(); len(due) != 1 || due[0].ID != "daily" {
+		t.Errorf("Expected only the daily habit to be due, got %v", due)
+	}
+
+	model.width = 80
+	if view := model.View(); !strings.Contains(view, "1 not due today") || !strings.Contains(view, "0/1 completed") {
+		t.Errorf("Expected progress to exclude habits not due, got:\n%s", view)
+	}
+}
+
+func TestEntryMenuModelHistoryView(t *testing.T) {
+	habits := []models.Habit{{ID: "walk", Title: "Walk", HabitType: models.SimpleHabit}}
+	today := time.Now().Format("2006-01-02")
+	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+
+	model := NewEntryMenuModelForTesting(habits, map[string]models.HabitEntry{
+		"walk": {HabitID: "walk", Status: models.EntrySkipped},
+	})
+	model.SetEntryLog(&models.EntryLog{
+		Version: "1.0.0",
+		Entries: []models.DayEntry{
+			{Date: yesterday, Habits: []models.HabitEntry{{HabitID: "walk", Status: models.EntryCompleted}}},
+			{Date: today, Habits: []models.HabitEntry{{HabitID: "walk", Status: models.EntryCompleted}}},
+		},
+	})
+	model.width = 80
+
+	// Today's stored entry is superseded by the menu's current state
+	historyLog := model.historyLog(today)
+	todayEntry, found := historyLog.GetDayEntry(today)
+	if !found || len(todayEntry.Habits) != 1 || todayEntry.Habits[0].Status != models.EntrySkipped {
+		t.Errorf("Expected today's history to reflect menu entries, got %+v", todayEntry)
+	}
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("H")})
+	view := model.View()
+	if !strings.Contains(view, "Walk - last") || !strings.Contains(view, "press any key to return") {
+		t.Errorf("Expected history view, got:\n%s", view)
+	}
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if model.historyView != "" {
+		t.Error("Expected any key to close the history view")
+	}
+	if model.ShouldQuit() {
+		t.Error("Closing the history view should not quit")
+	}
+}
+
+func TestEntryMenuModelDateNavigation(t *testing.T) {
+	habits := []models.Habit{{ID: "walk", Title: "Walk", HabitType: models.SimpleHabit}}
+	now := time.Now()
+	today := now.Format("2006-01-02")
+	yesterday := now.AddDate(0, 0, -1).Format("2006-01-02")
+
+	entryLog := &models.EntryLog{
+		Version: "1.0.0",
+		Entries: []models.DayEntry{
+			{Date: yesterday, Habits: []models.HabitEntry{{HabitID: "walk", Status: models.EntryFailed}}},
+		},
+	}
+
+	model := NewEntryMenuModelForTesting(habits, map[string]models.HabitEntry{})
+	model.width = 80
+	if err := model.SetDate(now, entryLog); err != nil {
+		t.Fatalf("SetDate() error = %v", err)
+	}
+	if len(model.entries) != 0 {
+		t.Errorf("Expected no entries today, got %v", model.entries)
+	}
+
+	// Next day from today is in the future and ignored
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("]")})
+	if got := model.Date().Format("2006-01-02"); got != today {
+		t.Errorf("Expected to stay on today, got %s", got)
+	}
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("[")})
+	if got := model.Date().Format("2006-01-02"); got != yesterday {
+		t.Fatalf("Expected previous day, got %s", got)
+	}
+	if entry, ok := model.entries["walk"]; !ok || entry.Status != models.EntryFailed {
+		t.Errorf("Expected yesterday's entry to be loaded, got %v", model.entries)
+	}
+	if view := model.View(); !strings.Contains(view, now.AddDate(0, 0, -1).Format("Monday, January 2, 2006")) {
+		t.Errorf("Expected the date in the title, got:\n%s", view)
+	}
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("]")})
+	if !model.isToday() || len(model.entries) != 0 {
+		t.Errorf("Expected to return to today with no entries, got %s %v", model.Date(), model.entries)
+	}
+}
+
+func TestEntryMenuModelStaleSave(t *testing.T) {
+	habits := []models.Habit{
+		{ID: "walk", Title: "Walk", HabitType: models.SimpleHabit},
+		{ID: "read", Title: "Read", HabitType: models.SimpleHabit},
+	}
+	entriesFile := filepath.Join(t.TempDir(), "entries.yml")
+	entryStorage := storage.NewEntryStorage()
+	today := time.Now().Format("2006-01-02")
+
+	collector := ui.NewEntryCollector("")
+	model := NewEntryMenuModel(habits, map[string]models.HabitEntry{}, collector, entriesFile)
+	model.width = 80
+	if err := model.SetDate(time.Now(), models.CreateEmptyEntryLog()); err != nil {
+		t.Fatalf("SetDate() error = %v", err)
+	}
+
+	// Both habits are logged from another process while the menu is open
+	for _, habitID := range []string{"walk", "read"} {
+		if err := entryStorage.UpdateHabitEntry(entriesFile, today, models.HabitEntry{
+			HabitID: habitID, Value: true, Status: models.EntryCompleted, CreatedAt: time.Now(),
+		}); err != nil {
+			t.Fatalf("UpdateHabitEntry() error = %v", err)
+		}
+	}
+
+	model.processDeferredStateSync(DeferredStateSyncMsg{
+		habitID: "walk",
+		result:  &entry.EntryResult{Value: false, Status: models.EntryFailed},
+	})
+
+	if !strings.Contains(model.SaveError(), "walk") {
+		t.Errorf("Expected a stale entry error for walk, got %q", model.SaveError())
+	}
+	if view := model.View(); !strings.Contains(view, "Save failed") {
+		t.Errorf("Expected the save error in the view, got:\n%s", view)
+	}
+
+	// The menu shows what was saved elsewhere, and nothing was overwritten
+	for _, habitID := range []string{"walk", "read"} {
+		if model.entries[habitID].Status != models.EntryCompleted {
+			t.Errorf("Expected %s to show the stored entry, got %v", habitID, model.entries[habitID])
+		}
+	}
+	dayEntry, err := entryStorage.GetDayEntry(entriesFile, today)
+	if err != nil {
+		t.Fatalf("GetDayEntry() error = %v", err)
+	}
+	if walk, _ := dayEntry.GetHabitEntry("walk"); walk.Status != models.EntryCompleted {
+		t.Errorf("Expected the stored walk entry to be kept, got %v", walk)
+	}
+}
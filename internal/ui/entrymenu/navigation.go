@@ -9,25 +9,40 @@ import (
 
 // NavigationHelper provides enhanced navigation capabilities for the entry menu.
 // AIDEV-NOTE: navigation-helper; centralizes smart navigation logic for entry workflow
-type NavigationHelper struct{}
+type NavigationHelper struct {
+	schedule map[string]models.ScheduleStatus // habits off schedule today are never "incomplete"
+}
 
 // NewNavigationHelper creates a new navigation helper.
 func NewNavigationHelper() *NavigationHelper {
 	return &NavigationHelper{}
 }
 
+// SetSchedule sets today's schedule statuses used to skip habits that aren't due.
+func (n *NavigationHelper) SetSchedule(schedule map[string]models.ScheduleStatus) {
+	n.schedule = schedule
+}
+
+// isIncomplete returns true if a habit has no entry and is due today.
+func (n *NavigationHelper) isIncomplete(habit models.Habit, entries map[string]models.HabitEntry) bool {
+	if _, hasEntry := entries[habit.ID]; hasEntry {
+		return false
+	}
+	return !isOffSchedule(n.schedule[habit.ID])
+}
+
 // FindNextIncompleteHabit finds the next habit that hasn't been entered yet.
 func (n *NavigationHelper) FindNextIncompleteHabit(habits []models.Habit, entries map[string]models.HabitEntry, currentIndex int) int {
 	// Start from the next position after current
 	for i := currentIndex + 1; i < len(habits); i++ {
-		if _, hasEntry := entries[habits[i].ID]; !hasEntry {
+		if n.isIncomplete(habits[i], entries) {
 			return i
 		}
 	}
 
 	// Wrap around to the beginning
 	for i := 0; i <= currentIndex; i++ {
-		if _, hasEntry := entries[habits[i].ID]; !hasEntry {
+		if n.isIncomplete(habits[i], entries) {
 			return i
 		}
 	}
@@ -40,14 +55,14 @@ func (n *NavigationHelper) FindNextIncompleteHabit(habits []models.Habit, entrie
 func (n *NavigationHelper) FindPreviousIncompleteHabit(habits []models.Habit, entries map[string]models.HabitEntry, currentIndex int) int {
 	// Start from the previous position
 	for i := currentIndex - 1; i >= 0; i-- {
-		if _, hasEntry := entries[habits[i].ID]; !hasEntry {
+		if n.isIncomplete(habits[i], entries) {
 			return i
 		}
 	}
 
 	// Wrap around to the end
 	for i := len(habits) - 1; i >= currentIndex; i-- {
-		if _, hasEntry := entries[habits[i].ID]; !hasEntry {
+		if n.isIncomplete(habits[i], entries) {
 			return i
 		}
 	}
@@ -110,7 +125,7 @@ func (n *NavigationHelper) GetFilterDescription(filterState FilterState) string
 // GetShortHelp returns the short help bindings for the entry menu.
 func (k *EntryMenuKeyMap) GetShortHelp() []key.Binding {
 	return []key.Binding{
-		k.Up, k.Down, k.Select, k.NextIncomplete, k.ToggleReturnBehavior, k.FilterSkipped, k.FilterPrevious, k.Quit,
+		k.Up, k.Down, k.Select, k.NextIncomplete, k.ToggleReturnBehavior, k.FilterSkipped, k.FilterPrevious, k.History, k.Quit,
 	}
 }
 
@@ -120,7 +135,9 @@ func (k *EntryMenuKeyMap) GetFullHelp() [][]key.Binding {
 		// Navigation
 		{k.Up, k.Down, k.Select},
 		// Menu controls
-		{k.ToggleReturnBehavior, k.FilterSkipped, k.FilterPrevious, k.ClearFilters},
+		{k.ToggleReturnBehavior, k.FilterSkipped, k.FilterPrevious, k.ClearFilters, k.History},
+		// Date
+		{k.PreviousDay, k.NextDay},
 		// Exit
 		{k.Quit},
 	}
@@ -174,14 +191,7 @@ func (e *NavigationEnhancer) UpdateListAfterFilterChange(model *EntryMenuModel)
 	// Create menu items for visible habits
 	var items []list.Item
 	for _, habit := range visibleHabits {
-		entry, hasEntry := model.entries[habit.ID]
-		items = append(items, EntryMenuItem{
-			Habit:            habit,
-			EntryStatus:      entry.Status,
-			HasEntry:         hasEntry,
-			Value:            entry.Value,
-			AchievementLevel: entry.AchievementLevel,
-		})
+		items = append(items, newMenuItem(habit, model.entries, model.schedule))
 	}
 
 	// Update the list
@@ -198,7 +208,7 @@ func (m *EntryMenuModel) SelectFirstIncompleteHabit() {
 	items := m.list.Items()
 	for i, item := range items {
 		if menuItem, ok := item.(EntryMenuItem); ok {
-			if !menuItem.HasEntry {
+			if menuItem.NeedsEntry() {
 				m.list.Select(i)
 				return
 			}
@@ -371,14 +371,14 @@ func TestEntryMenuKeyMap_HelpMethods(t *testing.T) {
 
 	// Test short help
 	shortHelp := keyMap.GetShortHelp()
-	if len(shortHelp) != 8 { // up, down, select, next incomplete, return behavior, filter skipped, filter previous, quit
-		t.Errorf("Expected 8 short help bindings, got %d", len(shortHelp))
+	if len(shortHelp) != 9 { // up, down, select, next incomplete, return behavior, filter skipped, filter previous, history, quit
+		t.Errorf("Expected 9 short help bindings, got %d", len(shortHelp))
 	}
 
 	// Test full help
 	fullHelp := keyMap.GetFullHelp()
-	if len(fullHelp) != 3 {
-		t.Errorf("Expected 3 groups in full help, got %d", len(fullHelp))
+	if len(fullHelp) != 4 {
+		t.Errorf("Expected 4 groups in full help, got %d", len(fullHelp))
 	}
 
 	// Check navigation group has 3 bindings (up, down, select)
@@ -386,13 +386,42 @@ func TestEntryMenuKeyMap_HelpMethods(t *testing.T) {
 		t.Errorf("Expected 3 navigation bindings, got %d", len(fullHelp[0]))
 	}
 
-	// Check menu controls group has 4 bindings (return, filter skipped, filter previous, clear filters)
-	if len(fullHelp[1]) != 4 {
-		t.Errorf("Expected 4 menu control bindings, got %d", len(fullHelp[1]))
+	// Check menu controls group has 5 bindings (return, filter skipped, filter previous, clear filters, history)
+	if len(fullHelp[1]) != 5 {
+		t.Errorf("Expected 5 menu control bindings, got %d", len(fullHelp[1]))
+	}
+
+	// Check date group has 2 bindings (prev day, next day)
+	if len(fullHelp[2]) != 2 {
+		t.Errorf("Expected 2 date bindings, got %d", len(fullHelp[2]))
 	}
 
 	// Check exit group has 1 binding
-	if len(fullHelp[2]) != 1 {
-		t.Errorf("Expected 1 exit binding, got %d", len(fullHelp[2]))
+	if len(fullHelp[3]) != 1 {
+		t.Errorf("Expected 1 exit binding, got %d", len(fullHelp[3]))
+	}
+}
+
+func TestNavigationHelper_SkipsHabitsNotDue(t *testing.T) {
+	helper := NewNavigationHelper()
+
+	habits := []models.Habit{
+		{ID: "habit1", Title: "Daily Habit"},
+		{ID: "habit2", Title: "Weekly Habit"},
+		{ID: "habit3", Title: "Three Times a Week"},
+		{ID: "habit4", Title: "Another Daily Habit"},
+	}
+	entries := map[string]models.HabitEntry{}
+
+	helper.SetSchedule(map[string]models.ScheduleStatus{
+		"habit2": models.ScheduleNotDue,
+		"habit3": models.ScheduleTargetMet,
+	})
+
+	if next := helper.FindNextIncompleteHabit(habits, entries, 0); next != 3 {
+		t.Errorf("Expected to skip habits not due and land on index 3, got %d", next)
+	}
+	if prev := helper.FindPreviousIncompleteHabit(habits, entries, 3); prev != 0 {
+		t.Errorf("Expected to skip habits not due and land on index 0, got %d", prev)
 	}
 }
@@ -79,6 +79,11 @@ func (v *ViewRenderer) RenderHeader(habits []models.Habit, entries map[string]mo
 	return header + "\n"
 }
 
+// RenderOffSchedule renders the count of habits not expected today (not due or target met).
+func (v *ViewRenderer) RenderOffSchedule(count int) string {
+	return offScheduleStyle.Render(fmt.Sprintf("%d not due today", count))
+}
+
 // renderProgress renders progress bar with statistics.
 func (v *ViewRenderer) renderProgress(habits []models.Habit, entries map[string]models.HabitEntry) string {
 	if len(habits) == 0 {
@@ -195,8 +200,17 @@ var (
 			Foreground(lipgloss.Color("11")). // bright yellow
 			Italic(true)
 
+	// Off-schedule habit count styling
+	offScheduleStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("240")). // dark grey
+				Italic(true)
+
 	// Return behavior styling
 	returnBehaviorStyle = lipgloss.NewStyle().
 				Foreground(lipgloss.Color("14")). // bright cyan
 				Italic(true)
+
+	// Auto-save error styling
+	saveErrorStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("9")) // bright red
 )
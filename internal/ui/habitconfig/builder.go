@@ -206,10 +206,26 @@ func (gb *HabitBuilder) configToCriteria(config *CriteriaConfig, fieldType model
 		}
 
 	case models.TextFieldType:
-		// Text condition fields don't exist in current model
-		// For now, we'll need to extend the model or use a different approach
-		// This is a placeholder that will need model updates
-		return nil, fmt.Errorf("text field criteria not yet implemented in the model")
+		switch config.ComparisonType {
+		case textContainsAny:
+			criteria.Condition.ContainsAny = splitKeywords(config.Value)
+		case textContainsAll:
+			criteria.Condition.ContainsAll = splitKeywords(config.Value)
+		case textRegex:
+			criteria.Condition.Matches = strings.TrimSpace(config.Value)
+		case textMinWords, textMinLines:
+			count, err := strconv.Atoi(strings.TrimSpace(config.Value))
+			if err != nil {
+				return nil, fmt.Errorf("invalid count: %w", err)
+			}
+			if config.ComparisonType == textMinWords {
+				criteria.Condition.MinWords = &count
+			} else {
+				criteria.Condition.MinLines = &count
+			}
+		default:
+			return nil, fmt.Errorf("unknown text comparison: %s", config.ComparisonType)
+		}
 	}
 
 	return criteria, nil
@@ -1,6 +1,7 @@
 package habitconfig
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/davidlee/vice/internal/models"
 	"github.com/davidlee/vice/internal/parser"
+	"github.com/davidlee/vice/internal/safefile"
 	"github.com/davidlee/vice/internal/ui/habitconfig/wizard"
 )
 
@@ -56,7 +58,7 @@ func (gc *HabitConfigurator) WithLegacyMode(prefer bool) *HabitConfigurator {
 // AddHabit presents an interactive UI to create a new habit
 func (gc *HabitConfigurator) AddHabit(habitsFilePath string) error {
 	// Load existing schema
-	schema, err := gc.loadSchema(habitsFilePath)
+	schema, version, err := gc.loadSchema(habitsFilePath)
 	if err != nil {
 		return fmt.Errorf("failed to load existing habits: %w", err)
 	}
@@ -116,7 +118,7 @@ func (gc *HabitConfigurator) AddHabit(habitsFilePath string) error {
 	}
 
 	// Save updated schema
-	if err := gc.saveSchema(schema, habitsFilePath); err != nil {
+	if err := gc.saveSchema(schema, habitsFilePath, version); err != nil {
 		return fmt.Errorf("failed to save habits: %w", err)
 	}
 
@@ -243,7 +245,7 @@ func (gc *HabitConfigurator) EditHabit(habitsFilePath string) error {
 // Critical for future reordering feature - habits stay in same list position after editing
 func (gc *HabitConfigurator) EditHabitByID(habitsFilePath string, habitID string) error {
 	// Load existing schema
-	schema, err := gc.loadSchema(habitsFilePath)
+	schema, version, err := gc.loadSchema(habitsFilePath)
 	if err != nil {
 		return fmt.Errorf("failed to load existing habits: %w", err)
 	}
@@ -313,7 +315,7 @@ func (gc *HabitConfigurator) EditHabitByID(habitsFilePath string, habitID string
 	}
 
 	// Save updated schema
-	if err := gc.saveSchema(schema, habitsFilePath); err != nil {
+	if err := gc.saveSchema(schema, habitsFilePath, version); err != nil {
 		return fmt.Errorf("failed to save habits: %w", err)
 	}
 
@@ -335,7 +337,7 @@ func (gc *HabitConfigurator) RemoveHabit(habitsFilePath string) error {
 // RemoveHabitByID removes a specific habit by ID (used internally by habit list UI)
 func (gc *HabitConfigurator) RemoveHabitByID(habitsFilePath string, habitID string) error {
 	// Load existing schema
-	schema, err := gc.loadSchema(habitsFilePath)
+	schema, version, err := gc.loadSchema(habitsFilePath)
 	if err != nil {
 		return fmt.Errorf("failed to load existing habits: %w", err)
 	}
@@ -384,7 +386,7 @@ func (gc *HabitConfigurator) RemoveHabitByID(habitsFilePath string, habitID stri
 	}
 
 	// Save updated schema
-	if err := gc.saveSchema(schema, habitsFilePath); err != nil {
+	if err := gc.saveSchema(schema, habitsFilePath, version); err != nil {
 		return fmt.Errorf("failed to save habits after removal: %w", err)
 	}
 
@@ -394,14 +396,21 @@ func (gc *HabitConfigurator) RemoveHabitByID(habitsFilePath string, habitID stri
 	return nil
 }
 
-// loadSchema loads and parses the habits schema from file
-func (gc *HabitConfigurator) loadSchema(habitsFilePath string) (*models.Schema, error) {
-	return gc.habitParser.LoadFromFileWithIDPersistence(habitsFilePath, true)
+// loadSchema loads and parses the habits schema from file, with the version it was read at
+func (gc *HabitConfigurator) loadSchema(habitsFilePath string) (*models.Schema, safefile.Version, error) {
+	return gc.habitParser.LoadFromFileWithVersion(habitsFilePath)
 }
 
-// saveSchema saves the habits schema back to file
-func (gc *HabitConfigurator) saveSchema(schema *models.Schema, habitsFilePath string) error {
-	return gc.habitParser.SaveToFile(schema, habitsFilePath)
+// saveSchema saves the habits schema back to file, refusing if another vice process
+// changed it since it was loaded (the forms can stay open for a while)
+func (gc *HabitConfigurator) saveSchema(schema *models.Schema, habitsFilePath string, version safefile.Version) error {
+	if _, err := gc.habitParser.SaveToFileIfUnchanged(schema, habitsFilePath, version); err != nil {
+		if errors.Is(err, safefile.ErrConflict) {
+			return fmt.Errorf("habits changed on disk while editing; nothing was saved, run the command again: %w", err)
+		}
+		return err
+	}
+	return nil
 }
 
 // BasicInfo holds the pre-collected basic information for all habits
@@ -587,7 +596,7 @@ func (gc *HabitConfigurator) runInformationalHabitCreator(basicInfo *BasicInfo,
 // This is used for dry-run operations where the user wants to preview the generated YAML.
 func (gc *HabitConfigurator) AddHabitWithYAMLOutput(habitsFilePath string) (string, error) {
 	// Load existing schema
-	schema, err := gc.loadSchema(habitsFilePath)
+	schema, _, err := gc.loadSchema(habitsFilePath)
 	if err != nil {
 		return "", fmt.Errorf("failed to load existing habits: %w", err)
 	}
@@ -2,6 +2,7 @@ package habitconfig
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -147,22 +148,14 @@ func (cb *CriteriaBuilder) createComparisonCriteriaForm(fieldType models.FieldTy
 			huh.NewSelect[string]().
 				Title("Text Matching").
 				Description("How should the text be evaluated?").
-				Options(
-					huh.NewOption("Contains text", "contains").Selected(true),
-					huh.NewOption("Matches regex pattern", "regex"),
-				).
+				Options(textComparisonOptions()...).
 				Value(&config.ComparisonType),
 
 			huh.NewInput().
-				Title("Pattern").
-				Description("Text to search for or regex pattern").
+				Title("Value").
+				DescriptionFunc(func() string { return textValueDescription(config.ComparisonType) }, &config.ComparisonType).
 				Value(&config.Value).
-				Validate(func(s string) error {
-					if strings.TrimSpace(s) == "" {
-						return fmt.Errorf("pattern cannot be empty")
-					}
-					return nil
-				}),
+				Validate(cb.createTextValidator(config)),
 		)
 	}
 
@@ -227,22 +220,14 @@ func (cb *CriteriaBuilder) createElasticComparisonForm(fieldType models.FieldTyp
 			huh.NewSelect[string]().
 				Title("Text Matching").
 				Description(fmt.Sprintf("How should text be evaluated for %s level?", level)).
-				Options(
-					huh.NewOption("Contains text", "contains").Selected(true),
-					huh.NewOption("Matches regex pattern", "regex"),
-				).
+				Options(textComparisonOptions()...).
 				Value(&config.ComparisonType),
 
 			huh.NewInput().
-				Title(fmt.Sprintf("%s Level Pattern", levelTitle)).
-				Description("Text to search for or regex pattern").
+				Title(fmt.Sprintf("%s Level Value", levelTitle)).
+				DescriptionFunc(func() string { return textValueDescription(config.ComparisonType) }, &config.ComparisonType).
 				Value(&config.Value).
-				Validate(func(s string) error {
-					if strings.TrimSpace(s) == "" {
-						return fmt.Errorf("pattern cannot be empty")
-					}
-					return nil
-				}),
+				Validate(cb.createTextValidator(config)),
 		)
 	}
 
@@ -307,6 +292,77 @@ func (cb *CriteriaBuilder) createValueValidator(fieldType models.FieldType) func
 	}
 }
 
+// Text comparison types, stored in CriteriaConfig.ComparisonType
+const (
+	textContainsAny = "contains_any"
+	textContainsAll = "contains_all"
+	textRegex       = "regex"
+	textMinWords    = "min_words"
+	textMinLines    = "min_lines"
+)
+
+func textComparisonOptions() []huh.Option[string] {
+	return []huh.Option[string]{
+		huh.NewOption("Contains any of these words", textContainsAny).Selected(true),
+		huh.NewOption("Contains all of these words", textContainsAll),
+		huh.NewOption("Matches regex pattern", textRegex),
+		huh.NewOption("At least N words", textMinWords),
+		huh.NewOption("At least N lines", textMinLines),
+	}
+}
+
+func textValueDescription(comparisonType string) string {
+	switch comparisonType {
+	case textContainsAll, textContainsAny:
+		return "Comma-separated keywords, case-insensitive (e.g., grateful, thankful)"
+	case textRegex:
+		return "Regular expression (e.g., (?i)^done)"
+	case textMinWords:
+		return "Minimum number of words"
+	case textMinLines:
+		return "Minimum number of non-blank lines (e.g., 3 for three gratitudes)"
+	default:
+		return "Enter the comparison value"
+	}
+}
+
+func (cb *CriteriaBuilder) createTextValidator(config *CriteriaConfig) func(string) error {
+	return func(s string) error {
+		if strings.TrimSpace(s) == "" {
+			return fmt.Errorf("value cannot be empty")
+		}
+
+		switch config.ComparisonType {
+		case textContainsAny, textContainsAll:
+			if len(splitKeywords(s)) == 0 {
+				return fmt.Errorf("enter at least one keyword")
+			}
+		case textRegex:
+			if _, err := regexp.Compile(s); err != nil {
+				return fmt.Errorf("invalid regex: %w", err)
+			}
+		case textMinWords, textMinLines:
+			val, err := strconv.Atoi(strings.TrimSpace(s))
+			if err != nil || val < 1 {
+				return fmt.Errorf("must be a whole number of at least 1")
+			}
+		}
+
+		return nil
+	}
+}
+
+// splitKeywords splits a comma-separated keyword list, dropping blanks.
+func splitKeywords(s string) []string {
+	var keywords []string
+	for _, keyword := range strings.Split(s, ",") {
+		if keyword = strings.TrimSpace(keyword); keyword != "" {
+			keywords = append(keywords, keyword)
+		}
+	}
+	return keywords
+}
+
 func (cb *CriteriaBuilder) createTimeValidator() func(string) error {
 	return func(s string) error {
 		if strings.TrimSpace(s) == "" {
@@ -0,0 +1,53 @@
+package habitconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidlee/vice/internal/models"
+)
+
+func TestHabitBuilder_TextCriteria(t *testing.T) {
+	builder := NewHabitBuilder()
+	textField := models.FieldType{Type: models.TextFieldType}
+
+	criteria, err := builder.configToCriteria(&CriteriaConfig{ComparisonType: "contains_any", Value: "grateful, thankful,"}, textField)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"grateful", "thankful"}, criteria.Condition.ContainsAny)
+
+	criteria, err = builder.configToCriteria(&CriteriaConfig{ComparisonType: "contains_all", Value: "run,stretch"}, textField)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"run", "stretch"}, criteria.Condition.ContainsAll)
+
+	criteria, err = builder.configToCriteria(&CriteriaConfig{ComparisonType: "regex", Value: " ^done "}, textField)
+	require.NoError(t, err)
+	assert.Equal(t, "^done", criteria.Condition.Matches)
+
+	criteria, err = builder.configToCriteria(&CriteriaConfig{ComparisonType: "min_lines", Value: "3"}, textField)
+	require.NoError(t, err)
+	require.NotNil(t, criteria.Condition.MinLines)
+	assert.Equal(t, 3, *criteria.Condition.MinLines)
+
+	_, err = builder.configToCriteria(&CriteriaConfig{ComparisonType: "min_words", Value: "many"}, textField)
+	assert.Error(t, err)
+}
+
+func TestCriteriaBuilder_TextValidator(t *testing.T) {
+	config := &CriteriaConfig{}
+	validate := NewCriteriaBuilder().createTextValidator(config)
+
+	config.ComparisonType = "regex"
+	assert.NoError(t, validate(`(?i)\bgrateful\b`))
+	assert.Error(t, validate("(unclosed"))
+
+	config.ComparisonType = "contains_any"
+	assert.NoError(t, validate("walk"))
+	assert.Error(t, validate(" , "))
+
+	config.ComparisonType = "min_words"
+	assert.NoError(t, validate("10"))
+	assert.Error(t, validate("0"))
+	assert.Error(t, validate(""))
+}
@@ -196,6 +196,12 @@ func convertCriteriaToElasticData(criteria *models.Criteria) (criteriaType, valu
 	if cond.After != "" {
 		return "after", "", "", cond.After, false
 	}
+	if cond.MinWords != nil {
+		return textMinWords, strconv.Itoa(*cond.MinWords), "", "", false
+	}
+	if cond.MinLines != nil {
+		return textMinLines, strconv.Itoa(*cond.MinLines), "", "", false
+	}
 	return "", "", "", "", false
 }
 
@@ -550,12 +556,8 @@ func (m *ElasticHabitCreator) createScoringTypeForm() *huh.Form {
 
 // supportsAutomaticScoring returns true if the selected field type supports automatic scoring
 func (m *ElasticHabitCreator) supportsAutomaticScoring() bool {
-	switch m.selectedFieldType {
-	case models.TextFieldType:
-		return false // Text fields restricted to manual scoring
-	default:
-		return true // Numeric, time, duration support automatic scoring
-	}
+	// Numeric, time, duration and text (by word or line count) support automatic scoring
+	return true
 }
 
 // AIDEV-NOTE: elastic-criteria-dispatch; creates three-tier criteria forms for mini/midi/maxi
@@ -570,6 +572,8 @@ func (m *ElasticHabitCreator) createCriteriaDefinitionForm() *huh.Form {
 		return m.createTimeElasticCriteriaForm()
 	case models.DurationFieldType:
 		return m.createDurationElasticCriteriaForm()
+	case models.TextFieldType:
+		return m.createTextElasticCriteriaForm()
 	default:
 		// This shouldn't happen due to supportsAutomaticScoring check
 		return huh.NewForm(
@@ -754,6 +758,77 @@ func (m *ElasticHabitCreator) createDurationElasticCriteriaForm() *huh.Form {
 	)
 }
 
+// createTextElasticCriteriaForm creates three-tier criteria form for text fields,
+// scored by word or line count (e.g. one, two or three gratitudes)
+func (m *ElasticHabitCreator) createTextElasticCriteriaForm() *huh.Form {
+	if m.miniCriteriaType != textMinLines {
+		m.miniCriteriaType = textMinWords
+	}
+
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewNote().
+				Title("Three-Tier Text Achievement Criteria").
+				Description("Score entries by how much was written.\nCounts should increase: mini ≤ midi ≤ maxi"),
+
+			huh.NewSelect[string]().
+				Title("Measure").
+				Options(
+					huh.NewOption("Words", textMinWords),
+					huh.NewOption("Non-blank lines", textMinLines),
+				).
+				Value(&m.miniCriteriaType),
+		),
+
+		// Mini criteria
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Mini Achievement Count").
+				Description("Minimum count for basic achievement").
+				Value(&m.miniCriteriaValue).
+				Placeholder("1").
+				Validate(m.validateCountInput(nil, "")),
+		),
+
+		// Midi criteria
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Midi Achievement Count").
+				Description("Count for good achievement (must be ≥ mini)").
+				Value(&m.midiCriteriaValue).
+				Placeholder("2").
+				Validate(m.validateCountInput(&m.miniCriteriaValue, "mini")),
+		),
+
+		// Maxi criteria
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Maxi Achievement Count").
+				Description("Count for excellent achievement (must be ≥ midi)").
+				Value(&m.maxiCriteriaValue).
+				Placeholder("3").
+				Validate(m.validateCountInput(&m.midiCriteriaValue, "midi")),
+		),
+	)
+}
+
+// validateCountInput validates a word or line count, which must be at least the
+// lower tier's count if one is given.
+func (m *ElasticHabitCreator) validateCountInput(lowerTier *string, lowerName string) func(string) error {
+	return func(s string) error {
+		count, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil || count < 1 {
+			return fmt.Errorf("must be a whole number of at least 1")
+		}
+		if lowerTier != nil {
+			if lower, err := strconv.Atoi(strings.TrimSpace(*lowerTier)); err == nil && count < lower {
+				return fmt.Errorf("count (%d) must be ≥ %s count (%d)", count, lowerName, lower)
+			}
+		}
+		return nil
+	}
+}
+
 // validateTimeInput validates time input format
 func (m *ElasticHabitCreator) validateTimeInput(s string) error {
 	if strings.TrimSpace(s) == "" {
@@ -925,6 +1000,21 @@ func (m *ElasticHabitCreator) buildCriteriaFromData(tier string) (*models.Criter
 		condition.After = durationValue // Using After field for duration >= comparison
 		description = fmt.Sprintf("%s achievement when duration >= %s", strings.ToUpper(tier[:1])+tier[1:], durationValue)
 
+	case models.TextFieldType:
+		// Text criteria - every tier counts the same measure, chosen with the mini tier
+		count, err := strconv.Atoi(strings.TrimSpace(criteriaValue))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s criteria value: %w", tier, err)
+		}
+		measure := "words"
+		if m.miniCriteriaType == textMinLines {
+			measure = "lines"
+			condition.MinLines = &count
+		} else {
+			condition.MinWords = &count
+		}
+		description = fmt.Sprintf("%s achievement when text has at least %d %s", strings.ToUpper(tier[:1])+tier[1:], count, measure)
+
 	default:
 		return nil, fmt.Errorf("automatic scoring not supported for field type: %s", m.selectedFieldType)
 	}
@@ -221,6 +221,23 @@ func TestElasticHabitCreator_Integration_AllCombinations(t *testing.T) {
 			expectCriteria:    true,
 			expectedFieldType: models.DurationFieldType,
 		},
+		{
+			name:        "Text + Automatic (three gratitudes by line)",
+			fieldType:   models.TextFieldType,
+			scoringType: models.AutomaticScoring,
+			testData: TestElasticHabitData{
+				FieldType:         models.TextFieldType,
+				ScoringType:       models.AutomaticScoring,
+				MultilineText:     true,
+				Prompt:            "What are you grateful for?",
+				MiniCriteriaType:  "min_lines",
+				MiniCriteriaValue: "1",
+				MidiCriteriaValue: "2",
+				MaxiCriteriaValue: "3",
+			},
+			expectCriteria:    true,
+			expectedFieldType: models.TextFieldType,
+		},
 	}
 
 	for _, tt := range tests {
@@ -41,9 +41,9 @@ func TestElasticHabitCreator_FieldTypeSupport(t *testing.T) {
 func TestElasticHabitCreator_AutomaticScoringSupport(t *testing.T) {
 	creator := NewElasticHabitCreator("Test", "Test", models.ElasticHabit)
 
-	// Test automatic scoring support by field type (text excluded for elastic habits)
+	// Test automatic scoring support by field type (text is scored by word or line count)
 	creator.selectedFieldType = models.TextFieldType
-	assert.False(t, creator.supportsAutomaticScoring()) // Text restricted to manual
+	assert.True(t, creator.supportsAutomaticScoring())
 
 	creator.selectedFieldType = "numeric"
 	assert.True(t, creator.supportsAutomaticScoring())
@@ -365,3 +365,30 @@ func TestElasticHabitCreator_ValidationDurationInput(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "duration must include time units")
 }
+
+func TestElasticHabitCreator_TextCriteria(t *testing.T) {
+	creator := NewElasticHabitCreatorForTesting("Journal", "", models.ElasticHabit, TestElasticHabitData{
+		FieldType:         models.TextFieldType,
+		ScoringType:       models.AutomaticScoring,
+		MiniCriteriaValue: "50",
+		MidiCriteriaValue: "150",
+		MaxiCriteriaValue: "300",
+	})
+
+	habit, err := creator.CreateHabitDirectly()
+	require.NoError(t, err)
+	require.NotNil(t, habit.MaxiCriteria.Condition.MinWords)
+	assert.Equal(t, 300, *habit.MaxiCriteria.Condition.MinWords)
+	assert.Nil(t, habit.MaxiCriteria.Condition.MinLines)
+	assert.Equal(t, "Maxi achievement when text has at least 300 words", habit.MaxiCriteria.Description)
+
+	// Editing restores the measure and counts
+	data := habitToTestElasticData(habit)
+	assert.Equal(t, "min_words", data.MiniCriteriaType)
+	assert.Equal(t, "150", data.MidiCriteriaValue)
+
+	validate := creator.validateCountInput(&creator.midiCriteriaValue, "midi")
+	assert.NoError(t, validate("150"))
+	assert.Error(t, validate("100"))
+	assert.Error(t, validate("0"))
+}
@@ -0,0 +1,282 @@
+// Package heatmap renders a GitHub-style calendar heatmap of habit history.
+// AIDEV-NOTE: heatmap-package; cells come from stats.Calculator.History so streak/miss semantics match `vice stats`
+package heatmap
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/davidlee/vice/internal/models"
+	"github.com/davidlee/vice/internal/stats"
+)
+
+// DefaultWeeks is the number of weeks shown by default (about six months).
+const DefaultWeeks = 26
+
+const dateFormat = "2006-01-02"
+
+// Cell is the shading of a single day.
+type Cell int
+
+// Cell shades, from nothing recorded to fully completed.
+const (
+	CellEmpty   Cell = iota // Before tracking started, not due, or no entry required
+	CellMissed              // Due but nothing entered
+	CellFailed              // Failed entry (or nothing completed when all habits are shown)
+	CellSkipped             // Skipped entry
+	CellLevel1              // Completed: elastic "none", or up to 25% of habits
+	CellLevel2              // Completed: elastic "mini", or up to 50% of habits
+	CellLevel3              // Completed: elastic "midi", or up to 75% of habits
+	CellLevel4              // Completed: elastic "maxi", simple habits, or every habit
+)
+
+// Heatmap renders the weeks ending on a given day.
+type Heatmap struct {
+	calculator *stats.Calculator
+	end        time.Time
+	weeks      int
+}
+
+// New creates a heatmap of the given number of weeks ending on now's date.
+func New(now time.Time, weeks int) *Heatmap {
+	if weeks <= 0 {
+		weeks = DefaultWeeks
+	}
+	return &Heatmap{
+		calculator: stats.NewCalculator(now),
+		end:        time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()),
+		weeks:      weeks,
+	}
+}
+
+// HabitCells shades each day of a single habit's history, keyed by date (YYYY-MM-DD).
+// Elastic habits are shaded by achievement level.
+func (h *Heatmap) HabitCells(habit *models.Habit, entryLog *models.EntryLog) (map[string]Cell, error) {
+	days, err := h.calculator.History(habit, entryLog)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load history for habit %s: %w", habit.ID, err)
+	}
+
+	cells := make(map[string]Cell, len(days))
+	for _, day := range days {
+		cells[day.Date.Format(dateFormat)] = habitCell(habit, day)
+	}
+	return cells, nil
+}
+
+// AllCells shades each day by the share of tracked habits completed that day.
+// Skipped and not-due habits are left out of the share.
+func (h *Heatmap) AllCells(habits []models.Habit, entryLog *models.EntryLog) (map[string]Cell, error) {
+	type tally struct{ completed, due, skipped int }
+	tallies := make(map[string]*tally)
+
+	for i := range habits {
+		days, err := h.calculator.History(&habits[i], entryLog)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load history for habit %s: %w", habits[i].ID, err)
+		}
+		for _, day := range days {
+			date := day.Date.Format(dateFormat)
+			if tallies[date] == nil {
+				tallies[date] = &tally{}
+			}
+			switch day.Outcome {
+			case stats.OutcomeCompleted:
+				tallies[date].completed++
+				tallies[date].due++
+			case stats.OutcomeFailed, stats.OutcomeMissed:
+				tallies[date].due++
+			case stats.OutcomeSkipped:
+				tallies[date].skipped++
+			}
+		}
+	}
+
+	cells := make(map[string]Cell, len(tallies))
+	for date, t := range tallies {
+		switch {
+		case t.completed > 0:
+			level := int(math.Ceil(float64(t.completed) / float64(t.due) * 4))
+			cells[date] = CellLevel1 + Cell(level-1)
+		case t.due > 0:
+			cells[date] = CellFailed
+		case t.skipped > 0:
+			cells[date] = CellSkipped
+		}
+	}
+	return cells, nil
+}
+
+// RenderHabit renders a titled heatmap for a single habit.
+func (h *Heatmap) RenderHabit(habit *models.Habit, entryLog *models.EntryLog) (string, error) {
+	cells, err := h.HabitCells(habit, entryLog)
+	if err != nil {
+		return "", err
+	}
+	title := habit.Title
+	if title == "" {
+		title = habit.ID
+	}
+	return h.Render(title, cells), nil
+}
+
+// RenderAll renders a titled heatmap combining every habit.
+func (h *Heatmap) RenderAll(habits []models.Habit, entryLog *models.EntryLog) (string, error) {
+	cells, err := h.AllCells(habits, entryLog)
+	if err != nil {
+		return "", err
+	}
+	return h.Render("All habits", cells), nil
+}
+
+// Render draws the grid: one column per week (Monday first), one row per weekday,
+// with month labels above and a legend below.
+func (h *Heatmap) Render(title string, cells map[string]Cell) string {
+	start := h.gridStart()
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(fmt.Sprintf("%s - last %d weeks", title, h.weeks)))
+	b.WriteString("\n")
+	b.WriteString(h.monthLabels(start))
+	b.WriteString("\n")
+
+	for weekday := 0; weekday < 7; weekday++ {
+		b.WriteString(labelStyle.Render(fmt.Sprintf("%-4s", dayLabels[weekday])))
+		for week := 0; week < h.weeks; week++ {
+			date := start.AddDate(0, 0, week*7+weekday)
+			if date.After(h.end) {
+				b.WriteString("  ")
+				continue
+			}
+			b.WriteString(renderCell(cells[date.Format(dateFormat)]))
+			b.WriteString(" ")
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(legend())
+	return b.String()
+}
+
+// gridStart returns the Monday of the first week shown.
+func (h *Heatmap) gridStart() time.Time {
+	offset := (int(h.end.Weekday()) + 6) % 7 // days since Monday
+	return h.end.AddDate(0, 0, -offset-(h.weeks-1)*7)
+}
+
+// monthLabels labels the first week starting in each month, skipping labels that would overlap.
+// The first column is only labelled if enough of its month is shown to fit the label.
+func (h *Heatmap) monthLabels(start time.Time) string {
+	line := []rune(strings.Repeat(" ", 4+h.weeks*2))
+	nextFree := 0
+	for week := 0; week < h.weeks; week++ {
+		weekStart := start.AddDate(0, 0, week*7)
+		if week == 0 && weekStart.Day() > 21 {
+			continue
+		}
+		if week > 0 && weekStart.AddDate(0, 0, -7).Month() == weekStart.Month() {
+			continue
+		}
+
+		label := weekStart.Format("Jan")
+		pos := 4 + week*2
+		if pos < nextFree || pos+len(label) > len(line) {
+			continue
+		}
+		copy(line[pos:], []rune(label))
+		nextFree = pos + len(label) + 1
+	}
+	return labelStyle.Render(strings.TrimRight(string(line), " "))
+}
+
+// habitCell shades a single habit's day.
+func habitCell(habit *models.Habit, day stats.Day) Cell {
+	switch day.Outcome {
+	case stats.OutcomeCompleted:
+		if habit.IsElastic() && day.Entry != nil && day.Entry.AchievementLevel != nil {
+			switch *day.Entry.AchievementLevel {
+			case models.AchievementMini:
+				return CellLevel2
+			case models.AchievementMidi:
+				return CellLevel3
+			case models.AchievementMaxi:
+				return CellLevel4
+			default:
+				return CellLevel1
+			}
+		}
+		return CellLevel4
+	case stats.OutcomeFailed:
+		return CellFailed
+	case stats.OutcomeMissed:
+		return CellMissed
+	case stats.OutcomeSkipped:
+		return CellSkipped
+	default:
+		return CellEmpty
+	}
+}
+
+// renderCell renders the glyph for a cell. Glyphs stay distinct without colour.
+func renderCell(cell Cell) string {
+	switch cell {
+	case CellMissed:
+		return missedStyle.Render("□")
+	case CellFailed:
+		return failedStyle.Render("✗")
+	case CellSkipped:
+		return skippedStyle.Render("~")
+	case CellLevel1, CellLevel2, CellLevel3, CellLevel4:
+		return levelStyles[cell-CellLevel1].Render("■")
+	default:
+		return emptyStyle.Render("·")
+	}
+}
+
+// legend explains the glyphs.
+func legend() string {
+	var levels strings.Builder
+	for cell := CellLevel1; cell <= CellLevel4; cell++ {
+		levels.WriteString(renderCell(cell))
+	}
+	return labelStyle.Render("    less ") + levels.String() + labelStyle.Render(" more   ") +
+		renderCell(CellSkipped) + labelStyle.Render(" skipped   ") +
+		renderCell(CellFailed) + labelStyle.Render(" failed   ") +
+		renderCell(CellMissed) + labelStyle.Render(" missed") + "\n"
+}
+
+var dayLabels = [7]string{"Mon", "", "Wed", "", "Fri", "", "Sun"}
+
+// Styles for the heatmap.
+var (
+	titleStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("205")).
+			Bold(true)
+
+	labelStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("8"))
+
+	emptyStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("237")) // near black
+
+	missedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("88")) // dark red
+
+	failedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("88")) // dark red
+
+	skippedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240")) // dark grey
+
+	// Completion shades, lightest first
+	levelStyles = [4]lipgloss.Style{
+		lipgloss.NewStyle().Foreground(lipgloss.Color("22")),
+		lipgloss.NewStyle().Foreground(lipgloss.Color("28")),
+		lipgloss.NewStyle().Foreground(lipgloss.Color("34")),
+		lipgloss.NewStyle().Foreground(lipgloss.Color("40")),
+	}
+)
@@ -0,0 +1,158 @@
+package heatmap
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidlee/vice/internal/models"
+)
+
+func level(l models.AchievementLevel) *models.AchievementLevel {
+	return &l
+}
+
+func testEntryLog() *models.EntryLog {
+	return &models.EntryLog{
+		Version: "1.0.0",
+		Entries: []models.DayEntry{
+			{Date: "2025-07-14", Habits: []models.HabitEntry{
+				{HabitID: "walk", Status: models.EntryCompleted},
+				{HabitID: "run", Status: models.EntryCompleted, AchievementLevel: level(models.AchievementMini)},
+			}},
+			{Date: "2025-07-15", Habits: []models.HabitEntry{
+				{HabitID: "walk", Status: models.EntrySkipped},
+				{HabitID: "run", Status: models.EntrySkipped},
+			}},
+			// 2025-07-16 missing
+			{Date: "2025-07-17", Habits: []models.HabitEntry{
+				{HabitID: "walk", Status: models.EntryFailed},
+				{HabitID: "run", Status: models.EntryCompleted, AchievementLevel: level(models.AchievementMaxi)},
+			}},
+			{Date: "2025-07-18", Habits: []models.HabitEntry{
+				{HabitID: "walk", Status: models.EntryFailed},
+				{HabitID: "run", Status: models.EntryFailed, AchievementLevel: level(models.AchievementNone)},
+			}},
+		},
+	}
+}
+
+var (
+	walk = models.Habit{ID: "walk", Title: "Walk", HabitType: models.SimpleHabit}
+	run  = models.Habit{ID: "run", Title: "Run", HabitType: models.ElasticHabit}
+)
+
+func TestHeatmap_HabitCells(t *testing.T) {
+	heatmap := New(time.Date(2025, 7, 19, 12, 0, 0, 0, time.UTC), 4)
+
+	tests := []struct {
+		name     string
+		habit    models.Habit
+		expected map[string]Cell
+	}{
+		{
+			name:  "simple habit",
+			habit: walk,
+			expected: map[string]Cell{
+				"2025-07-14": CellLevel4,
+				"2025-07-15": CellSkipped,
+				"2025-07-16": CellMissed,
+				"2025-07-17": CellFailed,
+				"2025-07-19": CellEmpty, // today without an entry
+			},
+		},
+		{
+			name:  "elastic habit shaded by level",
+			habit: run,
+			expected: map[string]Cell{
+				"2025-07-14": CellLevel2,
+				"2025-07-15": CellSkipped,
+				"2025-07-17": CellLevel4,
+				"2025-07-18": CellFailed,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cells, err := heatmap.HabitCells(&tt.habit, testEntryLog())
+			if err != nil {
+				t.Fatalf("HabitCells() error = %v", err)
+			}
+			for date, expected := range tt.expected {
+				if cells[date] != expected {
+					t.Errorf("cell %s = %v, want %v", date, cells[date], expected)
+				}
+			}
+			if _, ok := cells["2025-07-13"]; ok {
+				t.Error("days before the first entry should not be shaded")
+			}
+		})
+	}
+}
+
+func TestHeatmap_AllCells(t *testing.T) {
+	heatmap := New(time.Date(2025, 7, 19, 12, 0, 0, 0, time.UTC), 4)
+
+	cells, err := heatmap.AllCells([]models.Habit{walk, run}, testEntryLog())
+	if err != nil {
+		t.Fatalf("AllCells() error = %v", err)
+	}
+
+	expected := map[string]Cell{
+		"2025-07-14": CellLevel4,  // 2/2
+		"2025-07-15": CellSkipped, // everything skipped
+		"2025-07-16": CellFailed,  // both missed
+		"2025-07-17": CellLevel2,  // 1/2
+		"2025-07-18": CellFailed,  // both failed
+	}
+	for date, want := range expected {
+		if cells[date] != want {
+			t.Errorf("cell %s = %v, want %v", date, cells[date], want)
+		}
+	}
+}
+
+func TestHeatmap_Render(t *testing.T) {
+	heatmap := New(time.Date(2025, 7, 19, 12, 0, 0, 0, time.UTC), 8)
+
+	output, err := heatmap.RenderHabit(&walk, testEntryLog())
+	if err != nil {
+		t.Fatalf("RenderHabit() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+	// title, months, 7 weekdays, legend
+	if len(lines) != 10 {
+		t.Fatalf("expected 10 lines, got %d:\n%s", len(lines), output)
+	}
+	if !strings.Contains(lines[0], "Walk - last 8 weeks") {
+		t.Errorf("missing title: %q", lines[0])
+	}
+	// The grid starts on Monday 26 May, too late in the month for a label
+	if strings.Contains(lines[1], "May") || !strings.Contains(lines[1], "Jun") || !strings.Contains(lines[1], "Jul") {
+		t.Errorf("unexpected month labels: %q", lines[1])
+	}
+	if !strings.HasPrefix(lines[2], "Mon") || !strings.HasPrefix(lines[8], "Sun") {
+		t.Errorf("unexpected weekday labels:\n%s", output)
+	}
+
+	// Last column: Mon 14th completed, Tue 15th skipped, Wed 16th missed, Thu 17th failed
+	mondayCells := strings.Fields(lines[2][4:])
+	if got := mondayCells[len(mondayCells)-1]; got != "■" {
+		t.Errorf("monday cell = %q, want completed", got)
+	}
+	tuesdayCells := strings.Fields(lines[3][4:])
+	if got := tuesdayCells[len(tuesdayCells)-1]; got != "~" {
+		t.Errorf("tuesday cell = %q, want skipped", got)
+	}
+
+	// Sunday the 20th is in the future and left blank
+	if sundayCells := strings.Fields(lines[8][4:]); len(sundayCells) != 7 {
+		t.Errorf("expected 7 past sundays, got %d", len(sundayCells))
+	}
+
+	if !strings.Contains(lines[9], "skipped") || !strings.Contains(lines[9], "missed") {
+		t.Errorf("missing legend: %q", lines[9])
+	}
+}
@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/huh"
+
+	"github.com/davidlee/vice/internal/models"
+	"github.com/davidlee/vice/internal/storage"
+)
+
+// ResolveMergeConflict asks which side of a merge conflict to keep, suggesting
+// the storage.KeepLatest choice. It is a storage.ConflictResolver.
+func ResolveMergeConflict(conflict *storage.MergeConflict) (string, error) {
+	choice, err := storage.KeepLatest(conflict)
+	if err != nil {
+		return "", err
+	}
+
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title(fmt.Sprintf("%s %s changed on both sides", conflict.Date, conflict.HabitID)).
+				Description("The most recent edit is selected").
+				Options(
+					huh.NewOption("Keep ours:   "+DescribeMergeEntry(conflict.Ours), storage.KeepOurs),
+					huh.NewOption("Keep theirs: "+DescribeMergeEntry(conflict.Theirs), storage.KeepTheirs),
+				).
+				Value(&choice),
+		),
+	)
+
+	if err := form.Run(); err != nil {
+		return "", fmt.Errorf("conflict resolution cancelled: %w", err)
+	}
+	return choice, nil
+}
+
+// DescribeMergeEntry summarizes an entry for merge reports and prompts: its
+// HabitEntry.Describe description and when it was last edited, e.g.
+// "completed 45m (notes: tired), edited 2025-07-16 20:00".
+func DescribeMergeEntry(entry *models.HabitEntry) string {
+	description := entry.Describe()
+	if entry == nil {
+		return description
+	}
+	return description + ", edited " + entry.GetLastModified().Local().Format("2006-01-02 15:04")
+}
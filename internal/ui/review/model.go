@@ -0,0 +1,265 @@
+// Package review provides the interactive flotsam SRS review session interface.
+// AIDEV-NOTE: review-ui; drives a flotsam.ReviewSessionManager - question → reveal → grade 0-6 → summary
+package review
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/davidlee/vice/internal/flotsam"
+)
+
+// state represents the current phase of the review interface.
+type state int
+
+const (
+	stateQuestion state = iota // showing the prompt only
+	stateAnswer                // answer revealed, waiting for a grade
+	stateSummary               // session finished, showing statistics
+)
+
+// KeyMap defines the keybindings for the review interface.
+type KeyMap struct {
+	Reveal key.Binding
+	Grade  key.Binding
+	Quit   key.Binding
+}
+
+// DefaultKeyMap returns the default keybindings for the review interface.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Reveal: key.NewBinding(
+			key.WithKeys(" ", "enter"),
+			key.WithHelp("space/enter", "show answer"),
+		),
+		Grade: key.NewBinding(
+			key.WithKeys("0", "1", "2", "3", "4", "5", "6"),
+			key.WithHelp("0-6", "grade"),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("q", "esc", "ctrl+c"),
+			key.WithHelp("q", "pause & quit"),
+		),
+	}
+}
+
+// Model is the bubbletea model for a flotsam review session.
+type Model struct {
+	manager flotsam.ReviewSessionManager
+	session *flotsam.ReviewSession
+	keys    KeyMap
+
+	state    state
+	card     *flotsam.FlotsamNote
+	question string
+	answer   string
+
+	stats  *flotsam.SessionStats
+	paused bool
+	err    error
+
+	width int
+}
+
+// NewModel creates a review model for an already-started session.
+func NewModel(manager flotsam.ReviewSessionManager, session *flotsam.ReviewSession) *Model {
+	m := &Model{
+		manager: manager,
+		session: session,
+		keys:    DefaultKeyMap(),
+	}
+	m.loadCurrentCard()
+	return m
+}
+
+// Init implements the tea.Model interface.
+func (m *Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements the tea.Model interface.
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.state == stateSummary || m.err != nil {
+			return m, tea.Quit
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			m.pause()
+			return m, tea.Quit
+		case m.state == stateQuestion && key.Matches(msg, m.keys.Reveal):
+			m.state = stateAnswer
+			return m, nil
+		case m.state == stateAnswer && key.Matches(msg, m.keys.Grade):
+			quality := flotsam.Quality(msg.Runes[0] - '0')
+			if err := m.manager.SubmitReview(m.session, quality); err != nil {
+				m.err = err
+				return m, nil
+			}
+			m.loadCurrentCard()
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
+
+// loadCurrentCard advances to the next card, finishing the session when none remain.
+func (m *Model) loadCurrentCard() {
+	card, err := m.manager.GetCurrentCard(m.session)
+	if errors.Is(err, flotsam.ErrSessionComplete) {
+		m.finish()
+		return
+	}
+	if err != nil {
+		m.err = err
+		return
+	}
+
+	m.card = card
+	m.question, m.answer = flotsam.SplitFlashcard(card)
+	m.state = stateQuestion
+}
+
+// finish completes the session and switches to the summary view.
+func (m *Model) finish() {
+	stats, err := m.manager.CompleteSession(m.session)
+	if err != nil {
+		m.err = err
+	}
+	m.stats = stats
+	m.card = nil
+	m.state = stateSummary
+}
+
+// pause stores the session for later if any cards remain.
+func (m *Model) pause() {
+	if m.state == stateSummary || m.session.CurrentIndex >= len(m.session.DueCards) {
+		return
+	}
+	if err := m.manager.PauseSession(m.session); err != nil {
+		m.err = err
+		return
+	}
+	m.paused = true
+}
+
+// View implements the tea.Model interface.
+func (m *Model) View() string {
+	if m.err != nil {
+		return errorStyle.Render(fmt.Sprintf("Error: %v", m.err)) + "\n\nPress any key to exit.\n"
+	}
+
+	if m.state == stateSummary {
+		return m.renderSummary()
+	}
+
+	var b strings.Builder
+
+	progress := fmt.Sprintf("Review %d/%d", m.session.CurrentIndex+1, m.session.TotalCards)
+	b.WriteString(titleStyle.Render(progress))
+	if m.card.Type != "" {
+		b.WriteString("  " + typeStyle.Render(m.card.Type))
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString(questionStyle.Render(m.question))
+	b.WriteString("\n\n")
+
+	if m.state == stateAnswer {
+		b.WriteString(separatorStyle.Render(strings.Repeat("─", m.separatorWidth())))
+		b.WriteString("\n\n")
+		b.WriteString(m.answer)
+		b.WriteString("\n\n")
+		b.WriteString(helpStyle.Render("0 no review · 1 blackout · 2 familiar · 3 easy miss · 4 hard · 5 effort · 6 easy"))
+	} else {
+		b.WriteString(helpStyle.Render("space/enter: show answer · q: pause & quit"))
+	}
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// renderSummary renders the end-of-session statistics.
+func (m *Model) renderSummary() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render("Review complete"))
+	b.WriteString("\n\n")
+
+	if m.stats == nil || m.stats.CardsReviewed == 0 {
+		b.WriteString("No cards reviewed.\n")
+	} else {
+		fmt.Fprintf(&b, "Cards reviewed: %d\n", m.stats.CardsReviewed)
+		fmt.Fprintf(&b, "Correct:        %d\n", m.stats.CorrectAnswers)
+		fmt.Fprintf(&b, "Incorrect:      %d\n", m.stats.IncorrectAnswers)
+		fmt.Fprintf(&b, "Success rate:   %.0f%%\n", m.stats.SuccessRate)
+		fmt.Fprintf(&b, "Time:           %s (avg %s/card)\n",
+			m.stats.Duration.Round(time.Second), m.stats.AverageTime.Round(time.Second))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("Press any key to exit."))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// separatorWidth returns the width of the question/answer divider.
+func (m *Model) separatorWidth() int {
+	if m.width > 0 && m.width < 60 {
+		return m.width
+	}
+	return 60
+}
+
+// Stats returns the session statistics once the session is complete.
+func (m *Model) Stats() *flotsam.SessionStats {
+	return m.stats
+}
+
+// Paused returns true if the session was paused before completion.
+func (m *Model) Paused() bool {
+	return m.paused
+}
+
+// Err returns any error encountered during the session.
+func (m *Model) Err() error {
+	return m.err
+}
+
+// Styles for the review interface.
+var (
+	titleStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("205")).
+			Background(lipgloss.Color("235")).
+			Padding(0, 1).
+			Bold(true)
+
+	typeStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240"))
+
+	questionStyle = lipgloss.NewStyle().
+			Bold(true)
+
+	separatorStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240"))
+
+	helpStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("8"))
+
+	errorStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("196"))
+)
@@ -0,0 +1,139 @@
+package review
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/davidlee/vice/internal/flotsam"
+)
+
+// fakeManager is an in-memory ReviewSessionManager for driving the model.
+type fakeManager struct {
+	grades    []flotsam.Quality
+	paused    bool
+	completed bool
+}
+
+func (f *fakeManager) StartSession(_ string, _ int) (*flotsam.ReviewSession, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeManager) GetCurrentCard(session *flotsam.ReviewSession) (*flotsam.FlotsamNote, error) {
+	if session.CurrentIndex >= len(session.DueCards) {
+		return nil, flotsam.ErrSessionComplete
+	}
+	return session.DueCards[session.CurrentIndex], nil
+}
+
+func (f *fakeManager) SubmitReview(session *flotsam.ReviewSession, quality flotsam.Quality) error {
+	f.grades = append(f.grades, quality)
+	session.CurrentIndex++
+	return nil
+}
+
+func (f *fakeManager) CompleteSession(_ *flotsam.ReviewSession) (*flotsam.SessionStats, error) {
+	f.completed = true
+	return &flotsam.SessionStats{CardsReviewed: len(f.grades)}, nil
+}
+
+func (f *fakeManager) PauseSession(_ *flotsam.ReviewSession) error {
+	f.paused = true
+	return nil
+}
+
+func (f *fakeManager) ResumeSession(_ string) (*flotsam.ReviewSession, error) {
+	return nil, flotsam.ErrSessionNotFound
+}
+
+func newTestSession() *flotsam.ReviewSession {
+	cards := []*flotsam.FlotsamNote{
+		{ID: "abc1", Title: "First", Type: "flashcard", Body: "## Question\n\nWhat is one?\n\n## Answer\n\nOne.\n"},
+		{ID: "abc2", Title: "Second idea", Type: "idea", Body: "Idea body"},
+	}
+	return &flotsam.ReviewSession{Context: "test", DueCards: cards, TotalCards: len(cards)}
+}
+
+func keyRunes(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestModelRevealAndGrade(t *testing.T) {
+	manager := &fakeManager{}
+	model := NewModel(manager, newTestSession())
+
+	view := model.View()
+	if !strings.Contains(view, "What is one?") {
+		t.Errorf("Expected question in view, got:\n%s", view)
+	}
+	if strings.Contains(view, "One.") {
+		t.Error("Answer should be hidden before reveal")
+	}
+
+	// Grading before reveal is ignored
+	model.Update(keyRunes("5"))
+	if len(manager.grades) != 0 {
+		t.Fatal("Grade should be ignored before the answer is shown")
+	}
+
+	model.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	if !strings.Contains(model.View(), "One.") {
+		t.Error("Expected answer after reveal")
+	}
+
+	model.Update(keyRunes("5"))
+	if len(manager.grades) != 1 || manager.grades[0] != flotsam.CorrectEffort {
+		t.Fatalf("Expected grade 5 to be submitted, got %v", manager.grades)
+	}
+	if !strings.Contains(model.View(), "Second idea") {
+		t.Error("Expected second card title as prompt")
+	}
+
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model.Update(keyRunes("2"))
+
+	if !manager.completed {
+		t.Fatal("Expected session to be completed after last card")
+	}
+	if model.Stats() == nil || model.Stats().CardsReviewed != 2 {
+		t.Errorf("Expected stats for 2 cards, got %+v", model.Stats())
+	}
+	if !strings.Contains(model.View(), "Review complete") {
+		t.Error("Expected summary view")
+	}
+
+	_, cmd := model.Update(keyRunes("x"))
+	if cmd == nil {
+		t.Error("Expected any key on summary to quit")
+	}
+}
+
+func TestModelQuitPausesSession(t *testing.T) {
+	manager := &fakeManager{}
+	model := NewModel(manager, newTestSession())
+
+	_, cmd := model.Update(keyRunes("q"))
+	if cmd == nil {
+		t.Error("Expected quit command")
+	}
+	if !manager.paused || !model.Paused() {
+		t.Error("Expected unfinished session to be paused on quit")
+	}
+	if manager.completed {
+		t.Error("Paused session should not be completed")
+	}
+}
+
+func TestModelEmptySession(t *testing.T) {
+	manager := &fakeManager{}
+	model := NewModel(manager, &flotsam.ReviewSession{Context: "test"})
+
+	if !manager.completed {
+		t.Error("Expected empty session to complete immediately")
+	}
+	if !strings.Contains(model.View(), "No cards reviewed") {
+		t.Errorf("Unexpected view for empty session:\n%s", model.View())
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/davidlee/vice/internal/config"
 	"github.com/davidlee/vice/internal/models"
 	"github.com/davidlee/vice/internal/parser"
+	"github.com/davidlee/vice/internal/scoring"
+	"github.com/davidlee/vice/internal/stats"
 	"github.com/davidlee/vice/internal/storage"
 )
 
@@ -42,44 +44,64 @@ func NewTodoDashboardLegacy(paths *config.Paths) *TodoDashboard {
 
 // HabitStatus represents the status of a single habit for today
 type HabitStatus struct {
-	Habit  models.Habit
-	Status models.EntryStatus
-	Value  interface{}
-	Notes  string
+	Habit    models.Habit
+	Status   models.EntryStatus
+	Value    interface{}
+	Notes    string
+	Schedule *scoring.ScheduleResult // Schedule position today (periodic habits)
 }
 
+// Display-only statuses for habits without an entry today (never persisted).
+// AIDEV-NOTE: habit-periodicity; off-schedule habits are neither pending nor counted in the total
+const (
+	statusNotDue    models.EntryStatus = "not_due"
+	statusTargetMet models.EntryStatus = "target_met"
+)
+
 // Display shows the todo dashboard with bubbles table (non-interactive)
 func (td *TodoDashboard) Display() error {
 	// Load today's habit statuses
-	statuses, err := td.loadTodayStatuses()
+	statuses, goals, err := td.loadTodayStatuses()
 	if err != nil {
 		return fmt.Errorf("failed to load habit statuses: %w", err)
 	}
 
-	return td.displayBubblesTable(statuses)
+	if err := td.displayBubblesTable(statuses); err != nil {
+		return err
+	}
+	td.displayGoals(goals)
+	return nil
 }
 
 // DisplayASCII shows a plain ASCII table
 func (td *TodoDashboard) DisplayASCII() error {
 	// Load today's habit statuses
-	statuses, err := td.loadTodayStatuses()
+	statuses, goals, err := td.loadTodayStatuses()
 	if err != nil {
 		return fmt.Errorf("failed to load habit statuses: %w", err)
 	}
 
-	return td.displaySimpleTable(statuses)
+	if err := td.displaySimpleTable(statuses); err != nil {
+		return err
+	}
+	td.displayGoals(goals)
+	return nil
 }
 
 // DisplayMarkdown shows the todo dashboard as markdown checklist
 func (td *TodoDashboard) DisplayMarkdown() error {
 	// Load today's habit statuses
-	statuses, err := td.loadTodayStatuses()
+	statuses, goals, err := td.loadTodayStatuses()
 	if err != nil {
 		return fmt.Errorf("failed to load habit statuses: %w", err)
 	}
 
 	// Output markdown format
-	return td.displayMarkdownList(statuses)
+	if err := td.displayMarkdownList(statuses); err != nil {
+		return err
+	}
+	td.displayMarkdownGoals(goals)
+	return nil
 }
 
 // displayBubblesTable shows a non-interactive bubbles table
@@ -99,7 +121,7 @@ func (td *TodoDashboard) displayBubblesTable(statuses []HabitStatus) error {
 
 		rows[i] = table.Row{
 			symbol,
-			td.truncateString(status.Habit.Title, 30),
+			td.truncateString(status.Habit.Title+td.scheduleSuffix(status), 30),
 			value,
 			notes,
 		}
@@ -155,7 +177,7 @@ func (td *TodoDashboard) displayMarkdownList(statuses []HabitStatus) error {
 
 	for _, status := range statuses {
 		checkbox := td.getMarkdownCheckbox(status.Status)
-		fmt.Printf("%s %s\n", checkbox, status.Habit.Title)
+		fmt.Printf("%s %s%s\n", checkbox, status.Habit.Title, td.scheduleSuffix(status))
 
 		// Add notes aligned with habit text (no bullet, indented to align with habit title)
 		if status.Notes != "" {
@@ -185,6 +207,10 @@ func (td *TodoDashboard) getMarkdownCheckbox(status models.EntryStatus) string {
 		return "- [-]"
 	case models.EntryFailed:
 		return "- [ ]" // Failed shown as unchecked
+	case statusTargetMet:
+		return "- [x]"
+	case statusNotDue:
+		return "- [-]"
 	case "pending":
 		return "- [ ]"
 	default:
@@ -200,7 +226,7 @@ func (td *TodoDashboard) displaySimpleTable(statuses []HabitStatus) error {
 
 	for _, status := range statuses {
 		symbol := td.getStatusSymbol(status.Status)
-		habit := td.truncateString(status.Habit.Title, 29)
+		habit := td.truncateString(status.Habit.Title+td.scheduleSuffix(status), 29)
 		value := td.truncateString(td.formatValue(status.Value), 19)
 		notes := td.truncateString(status.Notes, 30)
 
@@ -211,24 +237,42 @@ func (td *TodoDashboard) displaySimpleTable(statuses []HabitStatus) error {
 	return nil
 }
 
-// loadTodayStatuses loads all habits and today's entries to determine status
-func (td *TodoDashboard) loadTodayStatuses() ([]HabitStatus, error) {
+// loadTodayStatuses loads all habits and today's entries to determine status,
+// along with progress toward any goals
+func (td *TodoDashboard) loadTodayStatuses() ([]HabitStatus, []stats.GoalProgress, error) {
 	// Load habits
 	habitParser := parser.NewHabitParser()
 	schema, err := habitParser.LoadFromFile(td.env.GetHabitsFile())
 	if err != nil {
-		return nil, fmt.Errorf("failed to load habits: %w", err)
+		return nil, nil, fmt.Errorf("failed to load habits: %w", err)
 	}
 
 	// Load today's entries
 	entryStorage := storage.NewEntryStorage()
 	entryLog, err := entryStorage.LoadFromFile(td.env.GetEntriesFile())
 	if err != nil {
-		return nil, fmt.Errorf("failed to load entries: %w", err)
+		return nil, nil, fmt.Errorf("failed to load entries: %w", err)
 	}
 
 	// Get today's date
-	today := time.Now().Format("2006-01-02")
+	now := time.Now()
+	today := now.Format("2006-01-02")
+
+	// Evaluate schedules against history so periodic habits aren't shown as pending
+	schedules, err := scoring.NewEngine().EvaluateSchedules(schema.Habits, entryLog, now)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to evaluate habit schedules: %w", err)
+	}
+
+	// Goals are optional; goals.yml sits next to habits.yml
+	goals, err := parser.NewGoalParser().LoadFromFile(td.env.GetGoalsFile(), schema)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load goals: %w", err)
+	}
+	goalProgress, err := stats.NewCalculator(now).Goals(goals.Goals, schema.Habits, entryLog)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to compute goal progress: %w", err)
+	}
 
 	// Find today's entry
 	var todayEntry *models.DayEntry
@@ -243,8 +287,16 @@ func (td *TodoDashboard) loadTodayStatuses() ([]HabitStatus, error) {
 	var statuses []HabitStatus
 	for _, habit := range schema.Habits {
 		status := HabitStatus{
-			Habit:  habit,
-			Status: "pending", // Default to pending (no EntryPending constant)
+			Habit:    habit,
+			Status:   "pending", // Default to pending (no EntryPending constant)
+			Schedule: schedules[habit.ID],
+		}
+
+		switch status.Schedule.Status {
+		case models.ScheduleNotDue:
+			status.Status = statusNotDue
+		case models.ScheduleTargetMet:
+			status.Status = statusTargetMet
 		}
 
 		// Check if we have an entry for this habit today
@@ -262,7 +314,7 @@ func (td *TodoDashboard) loadTodayStatuses() ([]HabitStatus, error) {
 		statuses = append(statuses, status)
 	}
 
-	return statuses, nil
+	return statuses, goalProgress, nil
 }
 
 // displaySummary shows completion statistics
@@ -270,7 +322,8 @@ func (td *TodoDashboard) displaySummary(statuses []HabitStatus) {
 	completed := 0
 	skipped := 0
 	failed := 0
-	total := len(statuses)
+	notDue := 0
+	targetMet := 0
 
 	for _, status := range statuses {
 		switch status.Status {
@@ -280,9 +333,14 @@ func (td *TodoDashboard) displaySummary(statuses []HabitStatus) {
 			skipped++
 		case models.EntryFailed:
 			failed++
+		case statusNotDue:
+			notDue++
+		case statusTargetMet:
+			targetMet++
 		}
 	}
 
+	total := len(statuses) - notDue - targetMet
 	pending := total - completed - skipped - failed
 
 	fmt.Printf("\nSummary: %d/%d completed", completed, total)
@@ -295,9 +353,79 @@ func (td *TodoDashboard) displaySummary(statuses []HabitStatus) {
 	if pending > 0 {
 		fmt.Printf(", %d pending", pending)
 	}
+	if targetMet > 0 {
+		fmt.Printf(", %d target met", targetMet)
+	}
+	if notDue > 0 {
+		fmt.Printf(", %d not due", notDue)
+	}
 	fmt.Println()
 }
 
+// displayGoals lists progress toward goals below the habit table
+func (td *TodoDashboard) displayGoals(goals []stats.GoalProgress) {
+	if len(goals) == 0 {
+		return
+	}
+
+	fmt.Println("\nGoals:")
+	for _, goal := range goals {
+		fmt.Printf("  %s %s: %s\n", td.getGoalSymbol(goal.Status), goal.Title, goal.Summary())
+	}
+}
+
+// displayMarkdownGoals lists progress toward goals as a markdown checklist
+func (td *TodoDashboard) displayMarkdownGoals(goals []stats.GoalProgress) {
+	if len(goals) == 0 {
+		return
+	}
+
+	fmt.Println("\n## Goals")
+	fmt.Println()
+	for _, goal := range goals {
+		checkbox := "- [ ]"
+		if goal.Status == stats.GoalAchieved {
+			checkbox = "- [x]"
+		}
+		fmt.Printf("%s %s\n      %s\n", checkbox, goal.Title, goal.Summary())
+	}
+}
+
+// getGoalSymbol returns the Unicode symbol for a goal status
+func (td *TodoDashboard) getGoalSymbol(status stats.GoalStatus) string {
+	switch status {
+	case stats.GoalAchieved:
+		return "✓"
+	case stats.GoalOnTrack:
+		return "↗"
+	case stats.GoalBehind:
+		return "!"
+	case stats.GoalMissed:
+		return "✗"
+	default:
+		return "·"
+	}
+}
+
+// scheduleSuffix annotates periodic habits with their schedule and period progress
+func (td *TodoDashboard) scheduleSuffix(status HabitStatus) string {
+	if status.Habit.Schedule == nil || status.Schedule == nil {
+		return ""
+	}
+
+	switch status.Status {
+	case statusNotDue:
+		return fmt.Sprintf(" (not due - %s)", status.Habit.Schedule.Describe())
+	case statusTargetMet:
+		return fmt.Sprintf(" (target met - %s)", status.Habit.Schedule.Describe())
+	}
+
+	if status.Schedule.Target > 1 {
+		return fmt.Sprintf(" (%d/%d this week)", status.Schedule.Completed, status.Schedule.Target)
+	}
+	return ""
+}
+
 // formatValue converts a value to a display string
 func (td *TodoDashboard) formatValue(value interface{}) string {
 	if value == nil {
@@ -326,6 +454,10 @@ func (td *TodoDashboard) getStatusSymbol(status models.EntryStatus) string {
 		return "⤫"
 	case models.EntryFailed:
 		return "✗"
+	case statusTargetMet:
+		return "◎"
+	case statusNotDue:
+		return "·"
 	case "pending":
 		return "○"
 	default:
@@ -6,6 +6,7 @@ import (
 
 	"github.com/davidlee/vice/internal/config"
 	"github.com/davidlee/vice/internal/models"
+	"github.com/davidlee/vice/internal/stats"
 )
 
 func TestTodoDashboard(t *testing.T) {
@@ -38,6 +39,8 @@ func TestGetStatusSymbol(t *testing.T) {
 		{models.EntryCompleted, "✓"},
 		{models.EntrySkipped, "⤫"},
 		{models.EntryFailed, "✗"},
+		{statusTargetMet, "◎"},
+		{statusNotDue, "·"},
 		{"pending", "○"},
 		{"unknown", "?"},
 	}
@@ -117,3 +120,25 @@ func TestGetMarkdownCheckbox(t *testing.T) {
 		}
 	}
 }
+
+func TestGetGoalSymbol(t *testing.T) {
+	td := &TodoDashboard{}
+
+	tests := []struct {
+		status   stats.GoalStatus
+		expected string
+	}{
+		{stats.GoalAchieved, "✓"},
+		{stats.GoalOnTrack, "↗"},
+		{stats.GoalBehind, "!"},
+		{stats.GoalMissed, "✗"},
+		{stats.GoalUpcoming, "·"},
+	}
+
+	for _, test := range tests {
+		result := td.getGoalSymbol(test.status)
+		if result != test.expected {
+			t.Errorf("getGoalSymbol(%s) = %s, expected %s", test.status, result, test.expected)
+		}
+	}
+}